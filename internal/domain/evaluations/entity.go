@@ -23,6 +23,9 @@ type Evaluation struct {
 	Comments        string   `json:"comments,omitempty" gorm:"column:comments"`
 	GoogleDriveUrl  string   `json:"google_drive_url,omitempty" gorm:"column:google_drive_url"`
 
+	FinalizedAt *time.Time `json:"finalized_at,omitempty" gorm:"column:finalized_at"`
+	FinalizedBy *string    `json:"finalized_by,omitempty" gorm:"column:finalized_by"`
+
 	// Relationships
 	Event     domainevents.Event   `json:"event,omitempty" gorm:"foreignKey:EventID;references:Id"`
 	Vendor    domainvendors.Vendor `json:"vendor,omitempty" gorm:"foreignKey:VendorID;references:Id"`
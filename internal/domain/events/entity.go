@@ -3,8 +3,10 @@ package domainevents
 import (
 	"time"
 
+	domainuser "vendor-management-system/internal/domain/user"
 	domainvendors "vendor-management-system/internal/domain/vendors"
 
+	"github.com/shopspring/decimal"
 	"gorm.io/gorm"
 )
 
@@ -25,6 +27,51 @@ type Event struct {
 	WinnerVendorID *string               `json:"winner_vendor_id,omitempty" gorm:"column:winner_vendor_id"`
 	WinnerVendor   *domainvendors.Vendor `json:"winner_vendor,omitempty" gorm:"foreignKey:WinnerVendorID;references:Id"`
 
+	ParticipationMode     string   `json:"participation_mode" gorm:"column:participation_mode;default:open"` // open || invited
+	EligibilityEnabled    bool     `json:"eligibility_enabled" gorm:"column:eligibility_enabled"`
+	EligibleBusinessField string   `json:"eligible_business_field,omitempty" gorm:"column:eligible_business_field"`
+	EligibleProvinceId    string   `json:"eligible_province_id,omitempty" gorm:"column:eligible_province_id"`
+	EligibleActiveOnly    bool     `json:"eligible_active_only" gorm:"column:eligible_active_only"`
+	EligibleMinRating     *float64 `json:"eligible_min_rating,omitempty" gorm:"column:eligible_min_rating"`
+
+	SubmissionMaxFiles         *int   `json:"submission_max_files,omitempty" gorm:"column:submission_max_files"`
+	SubmissionMaxFileSizeMB    *int   `json:"submission_max_file_size_mb,omitempty" gorm:"column:submission_max_file_size_mb"`
+	SubmissionAllowedMimeTypes string `json:"submission_allowed_mime_types,omitempty" gorm:"column:submission_allowed_mime_types"` // comma separated
+
+	SealedBids       bool       `json:"sealed_bids" gorm:"column:sealed_bids"`
+	BidKey           string     `json:"-" gorm:"column:bid_key"` // per-event data key, wrapped with BID_ENCRYPTION_KEY
+	BidOpeningQuorum int        `json:"bid_opening_quorum" gorm:"column:bid_opening_quorum;default:2"`
+	BidsOpenedAt     *time.Time `json:"bids_opened_at,omitempty" gorm:"column:bids_opened_at"`
+
+	QACutoffHours *int `json:"qa_cutoff_hours,omitempty" gorm:"column:qa_cutoff_hours"` // hours before end_date the Q&A closes
+
+	EventType string `json:"event_type" gorm:"column:event_type;default:tender"` // tender || reverse_auction
+
+	// Reverse auction settings; AuctionEndAt moves later when a bid lands inside the extension window
+	AuctionStartAt             *time.Time       `json:"auction_start_at,omitempty" gorm:"column:auction_start_at"`
+	AuctionEndAt               *time.Time       `json:"auction_end_at,omitempty" gorm:"column:auction_end_at"`
+	AuctionStartPrice          *decimal.Decimal `json:"auction_start_price,omitempty" gorm:"column:auction_start_price;type:decimal(15,2)"`
+	AuctionMinDecrement        *decimal.Decimal `json:"auction_min_decrement,omitempty" gorm:"column:auction_min_decrement;type:decimal(15,2)"`
+	AuctionMinDecrementPercent *decimal.Decimal `json:"auction_min_decrement_percent,omitempty" gorm:"column:auction_min_decrement_percent;type:decimal(5,2)"`
+	AuctionExtensionWindow     int              `json:"auction_extension_window_minutes" gorm:"column:auction_extension_window_minutes"`
+	AuctionExtensionMinutes    int              `json:"auction_extension_minutes" gorm:"column:auction_extension_minutes"`
+	AuctionClosedAt            *time.Time       `json:"auction_closed_at,omitempty" gorm:"column:auction_closed_at"`
+
+	CancelledAt     *time.Time `json:"cancelled_at,omitempty" gorm:"column:cancelled_at"`
+	CancelledBy     *string    `json:"cancelled_by,omitempty" gorm:"column:cancelled_by"`
+	CancelReason    string     `json:"cancel_reason,omitempty" gorm:"column:cancel_reason"`
+	RetenderEventID *string    `json:"retender_event_id,omitempty" gorm:"column:retender_event_id"` // draft cloned when cancelled for a re-tender
+
+	// Confidential budget, only exposed through EventBudget to users allowed to manage it
+	OwnerEstimate        *decimal.Decimal `json:"-" gorm:"column:owner_estimate;type:decimal(15,2)"`
+	BudgetCeiling        *decimal.Decimal `json:"-" gorm:"column:budget_ceiling;type:decimal(15,2)"`
+	AbnormallyLowPercent *decimal.Decimal `json:"-" gorm:"column:abnormally_low_percent;type:decimal(5,2)"`
+
+	ContractValue *decimal.Decimal   `json:"contract_value,omitempty" gorm:"column:contract_value;type:decimal(15,2)"`
+	PaymentTerms  []EventPaymentTerm `json:"payment_terms,omitempty" gorm:"-"` // loaded on read
+
+	DeadlineExtensions []EventDeadlineExtension `json:"deadline_extensions,omitempty" gorm:"-"` // loaded on read
+
 	CreatedAt time.Time      `json:"created_at" gorm:"column:created_at"`
 	CreatedBy string         `json:"created_by" gorm:"column:created_by"`
 	UpdatedAt time.Time      `json:"updated_at" gorm:"column:updated_at"`
@@ -44,11 +91,174 @@ type EventFile struct {
 	FileUrl  string `json:"file_url" gorm:"column:file_url"` // image || document
 	Caption  string `json:"caption" gorm:"column:caption"`
 
+	AddendumId *string `json:"addendum_id,omitempty" gorm:"column:addendum_id"` // set when issued with an addendum
+
 	CreatedAt time.Time      `json:"created_at" gorm:"column:created_at"`
 	CreatedBy string         `json:"created_by" gorm:"column:created_by"`
 	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
 }
 
+func (EventAddendum) TableName() string {
+	return "event_addenda"
+}
+
+// EventAddendum is a numbered change to an open event. Vendors must acknowledge the latest
+// addendum before they can submit.
+type EventAddendum struct {
+	Id               string                         `json:"id" gorm:"column:id;primaryKey"`
+	EventID          string                         `json:"event_id" gorm:"column:event_id"`
+	AddendumNumber   int                            `json:"addendum_number" gorm:"column:addendum_number"`
+	Summary          string                         `json:"summary" gorm:"column:summary"`
+	PreviousEndDate  *time.Time                     `json:"previous_end_date,omitempty" gorm:"column:previous_end_date"`
+	NewEndDate       *time.Time                     `json:"new_end_date,omitempty" gorm:"column:new_end_date"`
+	File             []EventFile                    `json:"files,omitempty" gorm:"foreignKey:AddendumId"`
+	Acknowledgements []EventAddendumAcknowledgement `json:"acknowledgements,omitempty" gorm:"foreignKey:AddendumId"`
+
+	// AcknowledgedAt is set on vendor reads when the vendor has acknowledged the addendum
+	AcknowledgedAt *time.Time `json:"acknowledged_at,omitempty" gorm:"-"`
+
+	CreatedAt time.Time `json:"created_at" gorm:"column:created_at"`
+	CreatedBy string    `json:"created_by,omitempty" gorm:"column:created_by"`
+}
+
+func (EventTeamMember) TableName() string {
+	return "event_team_members"
+}
+
+// EventTeamMember is a user that manages the event together with its creator
+type EventTeamMember struct {
+	Id      string            `json:"id" gorm:"column:id;primaryKey"`
+	EventID string            `json:"event_id" gorm:"column:event_id"`
+	UserID  string            `json:"user_id" gorm:"column:user_id"`
+	User    *domainuser.Users `json:"user,omitempty" gorm:"foreignKey:UserID;references:Id"`
+
+	CreatedAt time.Time `json:"created_at" gorm:"column:created_at"`
+	CreatedBy string    `json:"created_by" gorm:"column:created_by"`
+}
+
+func (EventDeadlineExtension) TableName() string {
+	return "event_deadline_extensions"
+}
+
+// EventDeadlineExtension records one extension of an event's submission deadline
+type EventDeadlineExtension struct {
+	Id              string     `json:"id" gorm:"column:id;primaryKey"`
+	EventID         string     `json:"event_id" gorm:"column:event_id"`
+	PreviousEndDate *time.Time `json:"previous_end_date,omitempty" gorm:"column:previous_end_date"`
+	NewEndDate      time.Time  `json:"new_end_date" gorm:"column:new_end_date"`
+	Reason          string     `json:"reason" gorm:"column:reason"`
+
+	CreatedAt time.Time `json:"created_at" gorm:"column:created_at"`
+	CreatedBy string    `json:"created_by,omitempty" gorm:"column:created_by"`
+}
+
+func (EventAddendumAcknowledgement) TableName() string {
+	return "event_addendum_acknowledgements"
+}
+
+type EventAddendumAcknowledgement struct {
+	Id             string                `json:"id" gorm:"column:id;primaryKey"`
+	AddendumId     string                `json:"addendum_id" gorm:"column:addendum_id"`
+	VendorID       string                `json:"vendor_id" gorm:"column:vendor_id"`
+	Vendor         *domainvendors.Vendor `json:"vendor,omitempty" gorm:"foreignKey:VendorID;references:Id"`
+	AcknowledgedAt time.Time             `json:"acknowledged_at" gorm:"column:acknowledged_at"`
+	AcknowledgedBy string                `json:"acknowledged_by" gorm:"column:acknowledged_by"`
+}
+
+func (EventVendorView) TableName() string {
+	return "event_vendor_views"
+}
+
+// EventVendorView records that a vendor opened the event
+type EventVendorView struct {
+	Id            string    `json:"id" gorm:"column:id;primaryKey"`
+	EventID       string    `json:"event_id" gorm:"column:event_id"`
+	VendorID      string    `json:"vendor_id" gorm:"column:vendor_id"`
+	FirstViewedAt time.Time `json:"first_viewed_at" gorm:"column:first_viewed_at"`
+	LastViewedAt  time.Time `json:"last_viewed_at" gorm:"column:last_viewed_at"`
+}
+
+func (EventInvitation) TableName() string {
+	return "event_invitations"
+}
+
+type EventInvitation struct {
+	Id       string                `json:"id" gorm:"column:id;primaryKey"`
+	EventID  string                `json:"event_id" gorm:"column:event_id"`
+	VendorID string                `json:"vendor_id" gorm:"column:vendor_id"`
+	Vendor   *domainvendors.Vendor `json:"vendor,omitempty" gorm:"foreignKey:VendorID;references:Id"`
+
+	CreatedAt time.Time `json:"created_at" gorm:"column:created_at"`
+	CreatedBy string    `json:"created_by" gorm:"column:created_by"`
+}
+
+func (EventBidOpening) TableName() string {
+	return "event_bid_openings"
+}
+
+// EventBidOpening is one user's approval to open sealed bids before the deadline
+type EventBidOpening struct {
+	Id      string            `json:"id" gorm:"column:id;primaryKey"`
+	EventID string            `json:"event_id" gorm:"column:event_id"`
+	UserID  string            `json:"user_id" gorm:"column:user_id"`
+	User    *domainuser.Users `json:"user,omitempty" gorm:"foreignKey:UserID;references:Id"`
+	Note    string            `json:"note,omitempty" gorm:"column:note"`
+
+	CreatedAt time.Time `json:"created_at" gorm:"column:created_at"`
+}
+
+func (EventBoqItem) TableName() string {
+	return "event_boq_items"
+}
+
+// EventBoqItem is one line of the event's bill of quantities
+type EventBoqItem struct {
+	Id            string           `json:"id" gorm:"column:id;primaryKey"`
+	EventID       string           `json:"event_id" gorm:"column:event_id"`
+	ItemNo        int              `json:"item_no" gorm:"column:item_no"`
+	Description   string           `json:"description" gorm:"column:description"`
+	Unit          string           `json:"unit" gorm:"column:unit"`
+	Quantity      decimal.Decimal  `json:"quantity" gorm:"column:quantity;type:decimal(15,3)"`
+	OwnerEstimate *decimal.Decimal `json:"owner_estimate,omitempty" gorm:"column:owner_estimate;type:decimal(15,2)"` // per unit, hidden from vendors
+
+	CreatedAt time.Time      `json:"created_at" gorm:"column:created_at"`
+	CreatedBy string         `json:"created_by" gorm:"column:created_by"`
+	UpdatedAt time.Time      `json:"updated_at" gorm:"column:updated_at"`
+	UpdatedBy string         `json:"updated_by" gorm:"column:updated_by"`
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+	DeletedBy string         `json:"-"`
+}
+
+func (EventQuestion) TableName() string {
+	return "event_questions"
+}
+
+// EventQuestion is a vendor's clarification question on an event. A public answer is shown to
+// every vendor of the event without revealing who asked.
+type EventQuestion struct {
+	Id          string                `json:"id" gorm:"column:id;primaryKey"`
+	EventID     string                `json:"event_id" gorm:"column:event_id"`
+	VendorID    string                `json:"vendor_id,omitempty" gorm:"column:vendor_id"`
+	Vendor      *domainvendors.Vendor `json:"vendor,omitempty" gorm:"foreignKey:VendorID;references:Id"`
+	Question    string                `json:"question" gorm:"column:question"`
+	Answer      string                `json:"answer,omitempty" gorm:"column:answer"`
+	Status      string                `json:"status" gorm:"column:status;default:pending"`         // pending || answered
+	Visibility  string                `json:"visibility" gorm:"column:visibility;default:private"` // private || public
+	AnsweredAt  *time.Time            `json:"answered_at,omitempty" gorm:"column:answered_at"`
+	AnsweredBy  string                `json:"answered_by,omitempty" gorm:"column:answered_by"`
+	PublishedAt *time.Time            `json:"published_at,omitempty" gorm:"column:published_at"`
+
+	// Mine is set on vendor reads for the vendor's own questions
+	Mine bool `json:"mine" gorm:"-"`
+
+	CreatedAt time.Time      `json:"created_at" gorm:"column:created_at"`
+	CreatedBy string         `json:"created_by,omitempty" gorm:"column:created_by"`
+	UpdatedAt time.Time      `json:"updated_at" gorm:"column:updated_at"`
+	UpdatedBy string         `json:"updated_by,omitempty" gorm:"column:updated_by"`
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+	DeletedBy string         `json:"-"`
+}
+
 func (EventSubmission) TableName() string {
 	return "event_submissions"
 }
@@ -58,9 +268,10 @@ type EventSubmission struct {
 	EventID  string `json:"event_id" gorm:"column:event_id"`
 	VendorID string `json:"vendor_id" gorm:"column:vendor_id"`
 
-	Event  Event                 `json:"event,omitempty" gorm:"foreignKey:EventID;references:Id"`
-	Vendor domainvendors.Vendor  `json:"vendor,omitempty" gorm:"foreignKey:VendorID;references:Id"`
-	File   []EventSubmissionFile `json:"files,omitempty" gorm:"foreignKey:EventSubmissionId;constraint:OnDelete:CASCADE"`
+	Event  Event                  `json:"event,omitempty" gorm:"foreignKey:EventID;references:Id"`
+	Vendor domainvendors.Vendor   `json:"vendor,omitempty" gorm:"foreignKey:VendorID;references:Id"`
+	File   []EventSubmissionFile  `json:"files,omitempty" gorm:"foreignKey:EventSubmissionId;constraint:OnDelete:CASCADE"`
+	Prices []EventSubmissionPrice `json:"prices,omitempty" gorm:"foreignKey:EventSubmissionId;constraint:OnDelete:CASCADE"`
 
 	ProposalDetails     string   `json:"proposal_details,omitempty" gorm:"column:proposal_details"`
 	AdditionalMaterials string   `json:"additional_materials,omitempty" gorm:"column:additional_materials"`
@@ -69,6 +280,18 @@ type EventSubmission struct {
 	IsShortlisted       bool     `json:"is_shortlisted" gorm:"column:is_shortlisted"`
 	IsWinner            bool     `json:"is_winner" gorm:"column:is_winner"`
 
+	Status           string     `json:"status" gorm:"column:status;default:submitted"` // submitted || withdrawn
+	RevisionNumber   int        `json:"revision_number" gorm:"column:revision_number;default:1"`
+	WithdrawnAt      *time.Time `json:"withdrawn_at,omitempty" gorm:"column:withdrawn_at"`
+	WithdrawalReason string     `json:"withdrawal_reason,omitempty" gorm:"column:withdrawal_reason"`
+
+	// Sealed is set on read when the content is hidden until the bids are opened
+	Sealed bool `json:"sealed" gorm:"-"`
+	// QuotedTotal is computed on read from the unit prices and the bill of quantities
+	QuotedTotal *decimal.Decimal `json:"quoted_total,omitempty" gorm:"-"`
+	// PriceFlag is set on read for users allowed to see the budget when the quoted total fails the budget checks
+	PriceFlag string `json:"price_flag,omitempty" gorm:"-"` // above_ceiling || abnormally_low
+
 	CreatedAt time.Time      `json:"created_at" gorm:"column:created_at"`
 	CreatedBy string         `json:"created_by" gorm:"column:created_by"`
 	UpdatedAt time.Time      `json:"updated_at" gorm:"column:updated_at"`
@@ -84,14 +307,302 @@ func (EventSubmissionFile) TableName() string {
 type EventSubmissionFile struct {
 	ID                string `json:"id" gorm:"column:id;primaryKey"`
 	EventSubmissionId string `json:"event_submission_id" gorm:"column:event_submission_id"`
-	FileType          string `json:"file_type" gorm:"column:file_type"`
+	FileType          string `json:"file_type" gorm:"column:file_type"` // technical_proposal || price_proposal || company_profile || photo || document
 	FileUrl           string `json:"file_url" gorm:"column:file_url"`
+	FileName          string `json:"file_name,omitempty" gorm:"column:file_name"`
+	MimeType          string `json:"mime_type,omitempty" gorm:"column:mime_type"`
+	FileSize          int64  `json:"file_size,omitempty" gorm:"column:file_size"`
 	Caption           string `json:"caption" gorm:"column:caption"`
 	FileOrder         int    `json:"file_order" gorm:"column:file_order"`
+	IsEncrypted       bool   `json:"is_encrypted" gorm:"column:is_encrypted"`
+
+	CreatedAt time.Time      `json:"created_at" gorm:"column:created_at"`
+	CreatedBy string         `json:"created_by" gorm:"column:created_by"`
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+}
+
+func (EventSubmissionPrice) TableName() string {
+	return "event_submission_prices"
+}
+
+// EventSubmissionPrice is the vendor's unit price for one bill of quantities line.
+// While the event uses sealed bids the price is only stored in UnitPriceSealed.
+type EventSubmissionPrice struct {
+	Id                string           `json:"id" gorm:"column:id;primaryKey"`
+	EventSubmissionId string           `json:"event_submission_id" gorm:"column:event_submission_id"`
+	BoqItemId         string           `json:"boq_item_id" gorm:"column:boq_item_id"`
+	BoqItem           *EventBoqItem    `json:"boq_item,omitempty" gorm:"foreignKey:BoqItemId;references:Id"`
+	UnitPrice         *decimal.Decimal `json:"unit_price,omitempty" gorm:"column:unit_price;type:decimal(15,2)"`
+	UnitPriceSealed   string           `json:"-" gorm:"column:unit_price_sealed"`
+	LineTotal         *decimal.Decimal `json:"line_total,omitempty" gorm:"-"`
+
+	CreatedAt time.Time `json:"created_at" gorm:"column:created_at"`
+	CreatedBy string    `json:"created_by" gorm:"column:created_by"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"column:updated_at"`
+	UpdatedBy string    `json:"updated_by" gorm:"column:updated_by"`
+}
+
+func (EventSubmissionRevision) TableName() string {
+	return "event_submission_revisions"
+}
+
+// EventSubmissionRevision is an immutable snapshot of a submission, written on
+// every submit, amendment and withdrawal
+type EventSubmissionRevision struct {
+	Id                  string                         `json:"id" gorm:"column:id;primaryKey"`
+	EventSubmissionId   string                         `json:"event_submission_id" gorm:"column:event_submission_id"`
+	RevisionNumber      int                            `json:"revision_number" gorm:"column:revision_number"`
+	Action              string                         `json:"action" gorm:"column:action"` // submitted || amended || withdrawn
+	ProposalDetails     string                         `json:"proposal_details,omitempty" gorm:"column:proposal_details"`
+	AdditionalMaterials string                         `json:"additional_materials,omitempty" gorm:"column:additional_materials"`
+	Note                string                         `json:"note,omitempty" gorm:"column:note"`
+	File                []EventSubmissionRevisionFile  `json:"files,omitempty" gorm:"foreignKey:RevisionId;constraint:OnDelete:CASCADE"`
+	Prices              []EventSubmissionRevisionPrice `json:"prices,omitempty" gorm:"foreignKey:RevisionId;constraint:OnDelete:CASCADE"`
+
+	CreatedAt time.Time `json:"created_at" gorm:"column:created_at"`
+	CreatedBy string    `json:"created_by" gorm:"column:created_by"`
+}
+
+func (EventSubmissionRevisionFile) TableName() string {
+	return "event_submission_revision_files"
+}
+
+type EventSubmissionRevisionFile struct {
+	ID          string `json:"id" gorm:"column:id;primaryKey"`
+	RevisionId  string `json:"revision_id" gorm:"column:revision_id"`
+	FileType    string `json:"file_type" gorm:"column:file_type"`
+	FileUrl     string `json:"file_url" gorm:"column:file_url"`
+	FileName    string `json:"file_name,omitempty" gorm:"column:file_name"`
+	MimeType    string `json:"mime_type,omitempty" gorm:"column:mime_type"`
+	FileSize    int64  `json:"file_size,omitempty" gorm:"column:file_size"`
+	Caption     string `json:"caption" gorm:"column:caption"`
+	FileOrder   int    `json:"file_order" gorm:"column:file_order"`
+	IsEncrypted bool   `json:"is_encrypted" gorm:"column:is_encrypted"`
+
+	CreatedAt time.Time `json:"created_at" gorm:"column:created_at"`
+}
+
+func (EventSubmissionRevisionPrice) TableName() string {
+	return "event_submission_revision_prices"
+}
+
+type EventSubmissionRevisionPrice struct {
+	Id              string           `json:"id" gorm:"column:id;primaryKey"`
+	RevisionId      string           `json:"revision_id" gorm:"column:revision_id"`
+	BoqItemId       string           `json:"boq_item_id" gorm:"column:boq_item_id"`
+	UnitPrice       *decimal.Decimal `json:"unit_price,omitempty" gorm:"column:unit_price;type:decimal(15,2)"`
+	UnitPriceSealed string           `json:"-" gorm:"column:unit_price_sealed"`
+
+	CreatedAt time.Time `json:"created_at" gorm:"column:created_at"`
+}
+
+func (EventPaymentTerm) TableName() string {
+	return "event_payment_terms"
+}
+
+// EventPaymentTerm is one line of the event's payment term template, applied to every award
+type EventPaymentTerm struct {
+	Id           string          `json:"id" gorm:"column:id;primaryKey"`
+	EventID      string          `json:"event_id" gorm:"column:event_id"`
+	Sequence     int             `json:"sequence" gorm:"column:sequence"`
+	Label        string          `json:"label" gorm:"column:label"`
+	Percentage   decimal.Decimal `json:"percentage" gorm:"column:percentage;type:decimal(5,2)"`
+	Trigger      string          `json:"trigger" gorm:"column:release_trigger"` // on_award || on_completion || monthly
+	Installments int             `json:"installments" gorm:"column:installments;default:1"`
+
+	CreatedAt time.Time `json:"created_at" gorm:"column:created_at"`
+	CreatedBy string    `json:"created_by" gorm:"column:created_by"`
+}
+
+func (EventAuctionBid) TableName() string {
+	return "event_auction_bids"
+}
+
+// EventAuctionBid is one price offered by a vendor during a reverse auction
+type EventAuctionBid struct {
+	Id       string                `json:"id" gorm:"column:id;primaryKey"`
+	EventID  string                `json:"event_id" gorm:"column:event_id"`
+	VendorID string                `json:"vendor_id" gorm:"column:vendor_id"`
+	Vendor   *domainvendors.Vendor `json:"vendor,omitempty" gorm:"foreignKey:VendorID;references:Id"`
+	Amount   decimal.Decimal       `json:"amount" gorm:"column:amount;type:decimal(15,2)"`
+
+	CreatedAt time.Time `json:"created_at" gorm:"column:created_at"`
+	CreatedBy string    `json:"created_by" gorm:"column:created_by"`
+}
+
+// AuctionRank is a vendor's best bid and position in a reverse auction
+type AuctionRank struct {
+	Rank       int             `json:"rank"`
+	VendorID   string          `json:"vendor_id,omitempty"`
+	VendorName string          `json:"vendor_name,omitempty"`
+	BestBid    decimal.Decimal `json:"best_bid"`
+	BidCount   int             `json:"bid_count"`
+	BidAt      time.Time       `json:"bid_at"`
+}
+
+// AuctionStatus is the live state of a reverse auction. Vendors only get their own rank;
+// the leaderboard with vendor identities is for the event owner.
+type AuctionStatus struct {
+	EventID     string           `json:"event_id"`
+	Phase       string           `json:"phase"` // upcoming || live || ended || closed
+	StartAt     *time.Time       `json:"start_at,omitempty"`
+	EndAt       *time.Time       `json:"end_at,omitempty"`
+	ServerTime  time.Time        `json:"server_time"`
+	BidderCount int              `json:"bidder_count"`
+	LowestBid   *decimal.Decimal `json:"lowest_bid,omitempty"`
+
+	MyRank     *int             `json:"my_rank,omitempty"`
+	MyBestBid  *decimal.Decimal `json:"my_best_bid,omitempty"`
+	MaxNextBid *decimal.Decimal `json:"max_next_bid,omitempty"` // highest amount the vendor may bid next
+
+	Leaderboard []AuctionRank `json:"leaderboard,omitempty"`
+}
+
+func (EventTemplate) TableName() string {
+	return "event_templates"
+}
+
+// EventTemplate holds the reusable settings of an event. New events are created from it as drafts.
+type EventTemplate struct {
+	Id            string              `json:"id" gorm:"column:id;primaryKey"`
+	Name          string              `json:"name" gorm:"column:name"`
+	TitlePattern  string              `json:"title_pattern" gorm:"column:title_pattern"` // may contain {year}, {quarter} and {month}
+	Description   string              `json:"description,omitempty" gorm:"column:description"`
+	Category      string              `json:"category,omitempty" gorm:"column:category"`
+	SourceEventID *string             `json:"source_event_id,omitempty" gorm:"column:source_event_id"`
+	Files         []EventTemplateFile `json:"files,omitempty" gorm:"foreignKey:TemplateID;constraint:OnDelete:CASCADE"`
+
+	ParticipationMode     string   `json:"participation_mode" gorm:"column:participation_mode;default:open"`
+	EligibilityEnabled    bool     `json:"eligibility_enabled" gorm:"column:eligibility_enabled"`
+	EligibleBusinessField string   `json:"eligible_business_field,omitempty" gorm:"column:eligible_business_field"`
+	EligibleProvinceId    string   `json:"eligible_province_id,omitempty" gorm:"column:eligible_province_id"`
+	EligibleActiveOnly    bool     `json:"eligible_active_only" gorm:"column:eligible_active_only"`
+	EligibleMinRating     *float64 `json:"eligible_min_rating,omitempty" gorm:"column:eligible_min_rating"`
+
+	SubmissionMaxFiles         *int   `json:"submission_max_files,omitempty" gorm:"column:submission_max_files"`
+	SubmissionMaxFileSizeMB    *int   `json:"submission_max_file_size_mb,omitempty" gorm:"column:submission_max_file_size_mb"`
+	SubmissionAllowedMimeTypes string `json:"submission_allowed_mime_types,omitempty" gorm:"column:submission_allowed_mime_types"`
+
+	SealedBids       bool `json:"sealed_bids" gorm:"column:sealed_bids"`
+	BidOpeningQuorum int  `json:"bid_opening_quorum" gorm:"column:bid_opening_quorum;default:2"`
+	QACutoffHours    *int `json:"qa_cutoff_hours,omitempty" gorm:"column:qa_cutoff_hours"`
+
+	EventType string `json:"event_type" gorm:"column:event_type;default:tender"`
+
+	// Reverse auction settings; the window is relative to the start date of the new event
+	AuctionStartOffsetMinutes  *int             `json:"auction_start_offset_minutes,omitempty" gorm:"column:auction_start_offset_minutes"`
+	AuctionDurationMinutes     *int             `json:"auction_duration_minutes,omitempty" gorm:"column:auction_duration_minutes"`
+	AuctionStartPrice          *decimal.Decimal `json:"auction_start_price,omitempty" gorm:"column:auction_start_price;type:decimal(15,2)"`
+	AuctionMinDecrement        *decimal.Decimal `json:"auction_min_decrement,omitempty" gorm:"column:auction_min_decrement;type:decimal(15,2)"`
+	AuctionMinDecrementPercent *decimal.Decimal `json:"auction_min_decrement_percent,omitempty" gorm:"column:auction_min_decrement_percent;type:decimal(5,2)"`
+	AuctionExtensionWindow     int              `json:"auction_extension_window_minutes" gorm:"column:auction_extension_window_minutes"`
+	AuctionExtensionMinutes    int              `json:"auction_extension_minutes" gorm:"column:auction_extension_minutes"`
+
+	// Confidential budget, never exposed through the template endpoints
+	OwnerEstimate        *decimal.Decimal `json:"-" gorm:"column:owner_estimate;type:decimal(15,2)"`
+	BudgetCeiling        *decimal.Decimal `json:"-" gorm:"column:budget_ceiling;type:decimal(15,2)"`
+	AbnormallyLowPercent *decimal.Decimal `json:"-" gorm:"column:abnormally_low_percent;type:decimal(5,2)"`
+
+	ContractValue *decimal.Decimal `json:"contract_value,omitempty" gorm:"column:contract_value;type:decimal(15,2)"`
 
 	CreatedAt time.Time      `json:"created_at" gorm:"column:created_at"`
 	CreatedBy string         `json:"created_by" gorm:"column:created_by"`
+	UpdatedAt time.Time      `json:"updated_at" gorm:"column:updated_at"`
+	UpdatedBy string         `json:"updated_by" gorm:"column:updated_by"`
 	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+	DeletedBy string         `json:"-"`
+}
+
+func (EventTemplateFile) TableName() string {
+	return "event_template_files"
+}
+
+type EventTemplateFile struct {
+	ID         string `json:"id" gorm:"column:id;primaryKey"`
+	TemplateID string `json:"template_id" gorm:"column:template_id"`
+	FileType   string `json:"file_type" gorm:"column:file_type"`
+	FileUrl    string `json:"file_url" gorm:"column:file_url"`
+	Caption    string `json:"caption" gorm:"column:caption"`
+
+	CreatedAt time.Time `json:"created_at" gorm:"column:created_at"`
+	CreatedBy string    `json:"created_by" gorm:"column:created_by"`
+}
+
+func (EventAward) TableName() string {
+	return "event_awards"
+}
+
+// EventAward is one winning submission of an event, for a lot or a share of the work
+type EventAward struct {
+	Id            string          `json:"id" gorm:"column:id;primaryKey"`
+	EventID       string          `json:"event_id" gorm:"column:event_id"`
+	SubmissionID  string          `json:"submission_id" gorm:"column:submission_id"`
+	VendorID      string          `json:"vendor_id" gorm:"column:vendor_id"`
+	LotReference  string          `json:"lot_reference,omitempty" gorm:"column:lot_reference"`
+	AwardedAmount decimal.Decimal `json:"awarded_amount" gorm:"column:awarded_amount;type:decimal(15,2)"`
+	Notes         string          `json:"notes,omitempty" gorm:"column:notes"`
+
+	// A flagged price can only be awarded with a justification
+	PriceFlag          string `json:"price_flag,omitempty" gorm:"column:price_flag"` // above_ceiling || abnormally_low
+	PriceJustification string `json:"price_justification,omitempty" gorm:"column:price_justification"`
+
+	RevokedAt    *time.Time `json:"revoked_at,omitempty" gorm:"column:revoked_at"`
+	RevokedBy    *string    `json:"revoked_by,omitempty" gorm:"column:revoked_by"`
+	RevokeReason string     `json:"revoke_reason,omitempty" gorm:"column:revoke_reason"`
+
+	Vendor     *domainvendors.Vendor `json:"vendor,omitempty" gorm:"foreignKey:VendorID;references:Id"`
+	Submission *EventSubmission      `json:"submission,omitempty" gorm:"foreignKey:SubmissionID;references:Id"`
+
+	CreatedAt time.Time      `json:"created_at" gorm:"column:created_at"`
+	CreatedBy string         `json:"created_by" gorm:"column:created_by"`
+	UpdatedAt time.Time      `json:"updated_at" gorm:"column:updated_at"`
+	UpdatedBy string         `json:"updated_by" gorm:"column:updated_by"`
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+	DeletedBy string         `json:"-"`
+}
+
+func (EventHistory) TableName() string {
+	return "event_histories"
+}
+
+type EventHistory struct {
+	Id           string  `json:"id" gorm:"column:id;primaryKey"`
+	EventID      string  `json:"event_id" gorm:"column:event_id"`
+	Action       string  `json:"action" gorm:"column:action"` // award_granted || award_revoked || runner_up_awarded || event_reopened || event_cancelled
+	AwardID      *string `json:"award_id,omitempty" gorm:"column:award_id"`
+	SubmissionID *string `json:"submission_id,omitempty" gorm:"column:submission_id"`
+	VendorID     *string `json:"vendor_id,omitempty" gorm:"column:vendor_id"`
+	Description  string  `json:"description" gorm:"column:description"`
+	Reason       string  `json:"reason,omitempty" gorm:"column:reason"`
+
+	CreatedAt time.Time `json:"created_at" gorm:"column:created_at"`
+	CreatedBy string    `json:"created_by" gorm:"column:created_by"`
+}
+
+// EventBudget is the confidential owner estimate and budget ceiling of an event
+type EventBudget struct {
+	EventID                string           `json:"event_id"`
+	OwnerEstimate          *decimal.Decimal `json:"owner_estimate"`
+	BudgetCeiling          *decimal.Decimal `json:"budget_ceiling"`
+	AbnormallyLowPercent   decimal.Decimal  `json:"abnormally_low_percent"`             // the event's own or the default
+	AbnormallyLowThreshold *decimal.Decimal `json:"abnormally_low_threshold,omitempty"` // only with an owner estimate
+}
+
+func (CalendarToken) TableName() string {
+	return "calendar_tokens"
+}
+
+// CalendarToken authenticates a user's iCalendar feed; regenerating it invalidates the old feed URL
+type CalendarToken struct {
+	Id     string            `json:"id" gorm:"column:id;primaryKey"`
+	UserID string            `json:"user_id" gorm:"column:user_id"`
+	Token  string            `json:"token" gorm:"column:token"`
+	User   *domainuser.Users `json:"-" gorm:"foreignKey:UserID;references:Id"`
+
+	FeedURL string `json:"feed_url" gorm:"-"`
+
+	CreatedAt time.Time `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"column:updated_at"`
 }
 
 type EventSubmissionGroup struct {
@@ -110,3 +621,35 @@ type GroupedSubmissionsResponse struct {
 	EventsPerPage int                    `json:"events_per_page"`
 	TotalPages    int                    `json:"total_pages"`
 }
+
+// QuotationComparison puts every vendor's unit prices side by side per bill of quantities line
+type QuotationComparison struct {
+	Event         Event             `json:"event"`
+	Vendors       []QuotationVendor `json:"vendors"`
+	Lines         []QuotationLine   `json:"lines"`
+	EstimateTotal *decimal.Decimal  `json:"estimate_total,omitempty"` // only when every line has an owner estimate
+}
+
+type QuotationVendor struct {
+	SubmissionId     string           `json:"submission_id"`
+	VendorId         string           `json:"vendor_id"`
+	VendorName       string           `json:"vendor_name"`
+	QuotedTotal      *decimal.Decimal `json:"quoted_total,omitempty"`
+	IsLowest         bool             `json:"is_lowest"`
+	DeviationPercent *decimal.Decimal `json:"deviation_percent,omitempty"` // against the estimate total
+	PriceFlag        string           `json:"price_flag,omitempty"`        // above_ceiling || abnormally_low
+}
+
+type QuotationLine struct {
+	Item          EventBoqItem     `json:"item"`
+	EstimateTotal *decimal.Decimal `json:"estimate_total,omitempty"`
+	Quotes        []QuotationQuote `json:"quotes"` // in the same order as the vendors
+}
+
+type QuotationQuote struct {
+	SubmissionId     string           `json:"submission_id"`
+	UnitPrice        *decimal.Decimal `json:"unit_price,omitempty"`
+	LineTotal        *decimal.Decimal `json:"line_total,omitempty"`
+	IsLowest         bool             `json:"is_lowest"`
+	DeviationPercent *decimal.Decimal `json:"deviation_percent,omitempty"` // against the owner estimate
+}
@@ -3,6 +3,7 @@ package domainpayments
 import (
 	"time"
 
+	domainuser "vendor-management-system/internal/domain/user"
 	domainvendors "vendor-management-system/internal/domain/vendors"
 
 	"github.com/shopspring/decimal"
@@ -14,15 +15,58 @@ func (Payment) TableName() string {
 }
 
 type Payment struct {
-	Id            string                `json:"id" gorm:"column:id;primaryKey"`
-	InvoiceNumber string                `json:"invoice_number" gorm:"column:invoice_number"`
-	VendorID      string                `json:"vendor_id" gorm:"column:vendor_id"`
-	Amount        decimal.Decimal       `json:"amount" gorm:"column:amount;type:decimal(15,2)"`
-	Status        string                `json:"status" gorm:"column:status"`
-	PaymentDate   *time.Time            `json:"payment_date,omitempty" gorm:"column:payment_date"`
-	Description   string                `json:"description,omitempty" gorm:"column:description"`
-	File          []PaymentFile         `json:"files,omitempty" gorm:"foreignKey:PaymentId;constraint:OnDelete:CASCADE"`
-	Vendor        *domainvendors.Vendor `json:"vendor,omitempty" gorm:"foreignKey:VendorID;references:Id"`
+	Id            string          `json:"id" gorm:"column:id;primaryKey"`
+	InvoiceNumber string          `json:"invoice_number" gorm:"column:invoice_number"`
+	VendorID      string          `json:"vendor_id" gorm:"column:vendor_id"`
+	Amount        decimal.Decimal `json:"amount" gorm:"column:amount;type:decimal(15,2)"`
+	Status        string          `json:"status" gorm:"column:status"`
+	PaymentDate   *time.Time      `json:"payment_date,omitempty" gorm:"column:payment_date"`
+	Description   string          `json:"description,omitempty" gorm:"column:description"`
+
+	// Set on payments scheduled from an event award
+	EventID        *string    `json:"event_id,omitempty" gorm:"column:event_id"`
+	AwardID        *string    `json:"award_id,omitempty" gorm:"column:award_id"`
+	ReleaseTrigger string     `json:"release_trigger,omitempty" gorm:"column:release_trigger"` // on_award || on_completion || on_date
+	DueDate        *time.Time `json:"due_date,omitempty" gorm:"column:due_date"`
+
+	// Set on payments converted from a vendor invoice
+	InvoiceID *string `json:"invoice_id,omitempty" gorm:"column:invoice_id"`
+
+	// Set once the payment is included in a bulk transfer file
+	DisbursementBatchID *string `json:"disbursement_batch_id,omitempty" gorm:"column:disbursement_batch_id"`
+
+	// Tax breakdown of the gross Amount: TaxBase (DPP) plus VatAmount (PPN), less the withheld
+	// PPh, leaves NetAmount to transfer. Computed from the vendor's TaxRule unless overridden.
+	TaxBase           *decimal.Decimal `json:"tax_base,omitempty" gorm:"column:tax_base;type:decimal(15,2)"`
+	VatRate           *decimal.Decimal `json:"vat_rate,omitempty" gorm:"column:vat_rate;type:decimal(5,2)"`
+	VatAmount         *decimal.Decimal `json:"vat_amount,omitempty" gorm:"column:vat_amount;type:decimal(15,2)"`
+	WithholdingType   string           `json:"withholding_type,omitempty" gorm:"column:withholding_type"` // pph23 || pph21 || none
+	WithholdingRate   *decimal.Decimal `json:"withholding_rate,omitempty" gorm:"column:withholding_rate;type:decimal(5,2)"`
+	WithholdingAmount *decimal.Decimal `json:"withholding_amount,omitempty" gorm:"column:withholding_amount;type:decimal(15,2)"`
+	NetAmount         *decimal.Decimal `json:"net_amount,omitempty" gorm:"column:net_amount;type:decimal(15,2)"`
+	TaxOverriddenBy   *string          `json:"tax_overridden_by,omitempty" gorm:"column:tax_overridden_by"`
+	TaxOverrideReason string           `json:"tax_override_reason,omitempty" gorm:"column:tax_override_reason"`
+
+	// Sum of the settlements; the payment is partially paid until it covers the transfer amount
+	PaidAmount decimal.Decimal `json:"paid_amount" gorm:"column:paid_amount;type:decimal(15,2);default:0"`
+
+	// Restarted with a new round whenever the payment goes back to pending
+	ApprovalRound   int    `json:"approval_round" gorm:"column:approval_round;default:1"`
+	RejectionReason string `json:"rejection_reason,omitempty" gorm:"column:rejection_reason"`
+
+	File      []PaymentFile         `json:"files,omitempty" gorm:"foreignKey:PaymentId;constraint:OnDelete:CASCADE"`
+	Vendor    *domainvendors.Vendor `json:"vendor,omitempty" gorm:"foreignKey:VendorID;references:Id"`
+	Approvals []PaymentApproval     `json:"approvals,omitempty" gorm:"foreignKey:PaymentID"`
+
+	// Settlements are shown through BalanceHistory
+	Settlements []PaymentSettlement `json:"-" gorm:"foreignKey:PaymentID"`
+
+	// Approval levels the current round requires, with the approval that signed each of them
+	ApprovalChain []PaymentApprovalStep `json:"approval_chain,omitempty" gorm:"-"`
+
+	// Transfer amount still to be paid, and the settlements with the balance after each of them
+	OutstandingAmount *decimal.Decimal      `json:"outstanding_amount,omitempty" gorm:"-"`
+	BalanceHistory    []PaymentBalanceEntry `json:"balance_history,omitempty" gorm:"-"`
 
 	CreatedAt time.Time      `json:"created_at" gorm:"column:created_at"`
 	CreatedBy string         `json:"created_by" gorm:"column:created_by"`
@@ -47,3 +91,249 @@ type PaymentFile struct {
 	CreatedBy string         `json:"created_by" gorm:"column:created_by"`
 	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
 }
+
+func (PaymentSettlement) TableName() string {
+	return "payment_settlements"
+}
+
+// PaymentSettlement is one transfer towards a payment
+type PaymentSettlement struct {
+	Id              string          `json:"id" gorm:"column:id;primaryKey"`
+	PaymentID       string          `json:"payment_id" gorm:"column:payment_id"`
+	Amount          decimal.Decimal `json:"amount" gorm:"column:amount;type:decimal(15,2)"`
+	SettlementDate  time.Time       `json:"settlement_date" gorm:"column:settlement_date"`
+	Reference       string          `json:"reference,omitempty" gorm:"column:reference"`
+	FileUrl         string          `json:"file_url,omitempty" gorm:"column:file_url"`
+	StatementLineID *string         `json:"statement_line_id,omitempty" gorm:"column:statement_line_id"`
+	Note            string          `json:"note,omitempty" gorm:"column:note"`
+
+	CreatedAt time.Time `json:"created_at" gorm:"column:created_at"`
+	CreatedBy string    `json:"created_by" gorm:"column:created_by"`
+}
+
+// PaymentBalanceEntry is a settlement with the paid and outstanding amounts of the payment after it
+type PaymentBalanceEntry struct {
+	PaymentSettlement
+	PaidToDate        decimal.Decimal `json:"paid_to_date"`
+	OutstandingAmount decimal.Decimal `json:"outstanding_amount"`
+}
+
+func (PaymentApprovalLevel) TableName() string {
+	return "payment_approval_levels"
+}
+
+// PaymentApprovalLevel is required for payments whose amount exceeds MinAmount; its approvers
+// must hold the payment permission named by PermissionAction
+type PaymentApprovalLevel struct {
+	Id               string          `json:"id" gorm:"column:id;primaryKey"`
+	Level            int             `json:"level" gorm:"column:level"`
+	Name             string          `json:"name" gorm:"column:name"`
+	MinAmount        decimal.Decimal `json:"min_amount" gorm:"column:min_amount;type:decimal(15,2)"`
+	PermissionAction string          `json:"permission_action" gorm:"column:permission_action"`
+
+	CreatedAt time.Time `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"column:updated_at"`
+	UpdatedBy *string   `json:"updated_by,omitempty" gorm:"column:updated_by"`
+}
+
+func (PaymentApproval) TableName() string {
+	return "payment_approvals"
+}
+
+type PaymentApproval struct {
+	Id        string `json:"id" gorm:"column:id;primaryKey"`
+	PaymentID string `json:"payment_id" gorm:"column:payment_id"`
+	Round     int    `json:"round" gorm:"column:round"`
+	Level     int    `json:"level" gorm:"column:level"`
+	LevelName string `json:"level_name" gorm:"column:level_name"`
+	Decision  string `json:"decision" gorm:"column:decision"` // approved || rejected
+	Comment   string `json:"comment,omitempty" gorm:"column:comment"`
+
+	Approver *domainuser.Users `json:"approver,omitempty" gorm:"foreignKey:CreatedBy;references:Id"`
+
+	CreatedAt time.Time `json:"created_at" gorm:"column:created_at"`
+	CreatedBy string    `json:"created_by" gorm:"column:created_by"`
+}
+
+// PaymentApprovalStep is one required level of a payment's current approval round
+type PaymentApprovalStep struct {
+	Level     int              `json:"level"`
+	Name      string           `json:"name"`
+	MinAmount decimal.Decimal  `json:"min_amount"`
+	Approval  *PaymentApproval `json:"approval,omitempty"`
+}
+
+func (Invoice) TableName() string {
+	return "invoices"
+}
+
+// Invoice is submitted by a vendor and verified by finance before it becomes a Payment
+type Invoice struct {
+	Id            string     `json:"id" gorm:"column:id;primaryKey"`
+	InvoiceNumber string     `json:"invoice_number" gorm:"column:invoice_number"`
+	VendorID      string     `json:"vendor_id" gorm:"column:vendor_id"`
+	EventID       *string    `json:"event_id,omitempty" gorm:"column:event_id"`
+	AwardID       *string    `json:"award_id,omitempty" gorm:"column:award_id"`
+	InvoiceDate   time.Time  `json:"invoice_date" gorm:"column:invoice_date"`
+	DueDate       *time.Time `json:"due_date,omitempty" gorm:"column:due_date"`
+
+	// Amount is Subtotal (DPP) plus VatAmount (PPN)
+	Subtotal         decimal.Decimal `json:"subtotal" gorm:"column:subtotal;type:decimal(15,2)"`
+	VatAmount        decimal.Decimal `json:"vat_amount" gorm:"column:vat_amount;type:decimal(15,2)"`
+	Amount           decimal.Decimal `json:"amount" gorm:"column:amount;type:decimal(15,2)"`
+	TaxInvoiceNumber string          `json:"tax_invoice_number,omitempty" gorm:"column:tax_invoice_number"`
+	Description      string          `json:"description,omitempty" gorm:"column:description"`
+
+	Status          string     `json:"status" gorm:"column:status"` // submitted || verified || rejected || converted
+	RejectionReason string     `json:"rejection_reason,omitempty" gorm:"column:rejection_reason"`
+	VerifiedAt      *time.Time `json:"verified_at,omitempty" gorm:"column:verified_at"`
+	VerifiedBy      *string    `json:"verified_by,omitempty" gorm:"column:verified_by"`
+	PaymentID       *string    `json:"payment_id,omitempty" gorm:"column:payment_id"`
+
+	File   []InvoiceFile         `json:"files,omitempty" gorm:"foreignKey:InvoiceId;constraint:OnDelete:CASCADE"`
+	Vendor *domainvendors.Vendor `json:"vendor,omitempty" gorm:"foreignKey:VendorID;references:Id"`
+
+	CreatedAt time.Time      `json:"created_at" gorm:"column:created_at"`
+	CreatedBy string         `json:"created_by" gorm:"column:created_by"`
+	UpdatedAt time.Time      `json:"updated_at" gorm:"column:updated_at"`
+	UpdatedBy string         `json:"updated_by" gorm:"column:updated_by"`
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+	DeletedBy string         `json:"-"`
+}
+
+func (InvoiceFile) TableName() string {
+	return "invoice_files"
+}
+
+type InvoiceFile struct {
+	ID        string `json:"id" gorm:"column:id;primaryKey"`
+	InvoiceId string `json:"invoice_id" gorm:"column:invoice_id"`
+	FileType  string `json:"file_type" gorm:"column:file_type"` // invoice || tax_invoice || delivery_proof
+	FileUrl   string `json:"file_url" gorm:"column:file_url"`
+
+	CreatedAt time.Time      `json:"created_at" gorm:"column:created_at"`
+	CreatedBy string         `json:"created_by" gorm:"column:created_by"`
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+}
+
+func (TaxRule) TableName() string {
+	return "tax_rules"
+}
+
+// TaxRule holds the VAT and withholding rates, in percent, for one vendor type and tax status
+type TaxRule struct {
+	Id                    string          `json:"id" gorm:"column:id;primaryKey"`
+	VendorType            string          `json:"vendor_type" gorm:"column:vendor_type"` // company || individual
+	TaxStatus             string          `json:"tax_status" gorm:"column:tax_status"`   // pkp || non_pkp
+	VatRate               decimal.Decimal `json:"vat_rate" gorm:"column:vat_rate;type:decimal(5,2)"`
+	WithholdingType       string          `json:"withholding_type" gorm:"column:withholding_type"` // pph23 || pph21 || none
+	WithholdingRate       decimal.Decimal `json:"withholding_rate" gorm:"column:withholding_rate;type:decimal(5,2)"`
+	NoNpwpWithholdingRate decimal.Decimal `json:"no_npwp_withholding_rate" gorm:"column:no_npwp_withholding_rate;type:decimal(5,2)"`
+
+	CreatedAt time.Time `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"column:updated_at"`
+	UpdatedBy *string   `json:"updated_by,omitempty" gorm:"column:updated_by"`
+}
+
+// WithholdingSlip is the data of a bukti potong for one paid payment
+type WithholdingSlip struct {
+	PaymentID         string          `json:"payment_id"`
+	InvoiceNumber     string          `json:"invoice_number"`
+	VendorID          string          `json:"vendor_id"`
+	VendorName        string          `json:"vendor_name"`
+	NpwpNumber        string          `json:"npwp_number,omitempty"`
+	WithholdingType   string          `json:"withholding_type"`
+	TaxBase           decimal.Decimal `json:"tax_base"`
+	WithholdingRate   decimal.Decimal `json:"withholding_rate"`
+	WithholdingAmount decimal.Decimal `json:"withholding_amount"`
+	PaymentDate       time.Time       `json:"payment_date"`
+}
+
+func (DisbursementBatch) TableName() string {
+	return "disbursement_batches"
+}
+
+// DisbursementBatch is a bulk transfer file generated from approved payments; Checksum is the
+// hex SHA-256 of the file so a copy uploaded to the bank can be checked against it
+type DisbursementBatch struct {
+	Id           string          `json:"id" gorm:"column:id;primaryKey"`
+	BatchNumber  string          `json:"batch_number" gorm:"column:batch_number"`
+	Format       string          `json:"format" gorm:"column:format"` // csv || fixed_width
+	FileName     string          `json:"file_name" gorm:"column:file_name"`
+	FileUrl      string          `json:"file_url" gorm:"column:file_url"`
+	Checksum     string          `json:"checksum" gorm:"column:checksum"`
+	TotalAmount  decimal.Decimal `json:"total_amount" gorm:"column:total_amount;type:decimal(15,2)"`
+	PaymentCount int             `json:"payment_count" gorm:"column:payment_count"`
+
+	Payments []Payment `json:"payments,omitempty" gorm:"foreignKey:DisbursementBatchID"`
+
+	CreatedAt time.Time `json:"created_at" gorm:"column:created_at"`
+	CreatedBy string    `json:"created_by" gorm:"column:created_by"`
+}
+
+func (BankStatement) TableName() string {
+	return "bank_statements"
+}
+
+// BankStatement is an imported statement of the company's bank account
+type BankStatement struct {
+	Id         string     `json:"id" gorm:"column:id;primaryKey"`
+	FileName   string     `json:"file_name" gorm:"column:file_name"`
+	Format     string     `json:"format" gorm:"column:format"` // csv || mt940
+	FileUrl    string     `json:"file_url" gorm:"column:file_url"`
+	Checksum   string     `json:"checksum" gorm:"column:checksum"`
+	PeriodFrom *time.Time `json:"period_from,omitempty" gorm:"column:period_from"`
+	PeriodTo   *time.Time `json:"period_to,omitempty" gorm:"column:period_to"`
+	LineCount  int        `json:"line_count" gorm:"column:line_count"`
+
+	Lines []BankStatementLine `json:"lines,omitempty" gorm:"foreignKey:StatementID"`
+
+	CreatedAt time.Time `json:"created_at" gorm:"column:created_at"`
+	CreatedBy string    `json:"created_by" gorm:"column:created_by"`
+}
+
+func (BankStatementLine) TableName() string {
+	return "bank_statement_lines"
+}
+
+type BankStatementLine struct {
+	Id             string          `json:"id" gorm:"column:id;primaryKey"`
+	StatementID    string          `json:"statement_id" gorm:"column:statement_id"`
+	LineNo         int             `json:"line_no" gorm:"column:line_no"`
+	EntryDate      time.Time       `json:"entry_date" gorm:"column:entry_date"`
+	Direction      string          `json:"direction" gorm:"column:direction"` // debit || credit
+	Amount         decimal.Decimal `json:"amount" gorm:"column:amount;type:decimal(15,2)"`
+	Description    string          `json:"description,omitempty" gorm:"column:description"`
+	Reference      string          `json:"reference,omitempty" gorm:"column:reference"`
+	Status         string          `json:"status" gorm:"column:status"` // matched || review || unmatched || dismissed || ignored
+	PaymentID      *string         `json:"payment_id,omitempty" gorm:"column:payment_id"`
+	ResolvedAt     *time.Time      `json:"resolved_at,omitempty" gorm:"column:resolved_at"`
+	ResolvedBy     *string         `json:"resolved_by,omitempty" gorm:"column:resolved_by"` // nil when matched automatically
+	ResolutionNote string          `json:"resolution_note,omitempty" gorm:"column:resolution_note"`
+
+	Payment     *Payment                  `json:"payment,omitempty" gorm:"foreignKey:PaymentID;references:Id"`
+	Suggestions []BankStatementSuggestion `json:"suggestions,omitempty" gorm:"foreignKey:LineID"`
+}
+
+func (BankStatementSuggestion) TableName() string {
+	return "bank_statement_suggestions"
+}
+
+// BankStatementSuggestion is a payment a line under review may be for
+type BankStatementSuggestion struct {
+	Id        string `json:"id" gorm:"column:id;primaryKey"`
+	LineID    string `json:"line_id" gorm:"column:line_id"`
+	PaymentID string `json:"payment_id" gorm:"column:payment_id"`
+	Reason    string `json:"reason" gorm:"column:reason"` // invoice_number || vendor_account || amount_date
+
+	Payment *Payment `json:"payment,omitempty" gorm:"foreignKey:PaymentID;references:Id"`
+}
+
+// ReconciliationReport splits the debit lines of a statement by how they were reconciled
+type ReconciliationReport struct {
+	Statement BankStatement       `json:"statement"`
+	Matched   []BankStatementLine `json:"matched"`
+	Review    []BankStatementLine `json:"review"`
+	Unmatched []BankStatementLine `json:"unmatched"`
+	Dismissed []BankStatementLine `json:"dismissed"`
+}
@@ -3,6 +3,7 @@ package dto
 // CreateEvaluationRequest - Vendor creates evaluation after winning and completing event
 type CreateEvaluationRequest struct {
 	EventID  string `json:"event_id" binding:"required,uuid"`
+	VendorID string `json:"vendor_id" binding:"omitempty,uuid"` // required when the event has several awarded vendors
 	Comments string `json:"comments" binding:"omitempty,max=200"`
 }
 
@@ -1,5 +1,7 @@
 package dto
 
+import "mime/multipart"
+
 type CreateEventRequest struct {
 	Title         string `json:"title" binding:"required,min=3,max=100"`
 	Description   string `json:"description" binding:"omitempty,max=255"`
@@ -7,6 +9,36 @@ type CreateEventRequest struct {
 	StartDate     string `json:"start_date" binding:"omitempty"`
 	EndDate       string `json:"end_date" binding:"omitempty"`
 	TermsFilePath string `json:"terms_file_path" binding:"omitempty,max=500"` // Kept for backward compatibility, use EventFiles table for multi-file
+
+	ParticipationMode string                   `json:"participation_mode" binding:"omitempty,oneof=open invited"`
+	Eligibility       *EventEligibilityRequest `json:"eligibility" binding:"omitempty"`
+	InvitedVendorIDs  []string                 `json:"invited_vendor_ids" binding:"omitempty,dive,uuid"`
+
+	SubmissionFileRules *SubmissionFileRulesRequest `json:"submission_file_rules" binding:"omitempty"`
+
+	SealedBids       *bool `json:"sealed_bids"`
+	BidOpeningQuorum *int  `json:"bid_opening_quorum" binding:"omitempty,min=2,max=10"`
+
+	QACutoffHours *int `json:"qa_cutoff_hours" binding:"omitempty,min=0,max=720"`
+
+	ContractValue *float64             `json:"contract_value" binding:"omitempty,gt=0"`
+	PaymentTerms  []PaymentTermRequest `json:"payment_terms" binding:"omitempty,dive"`
+
+	EventType string                  `json:"event_type" binding:"omitempty,oneof=tender reverse_auction"`
+	Auction   *AuctionSettingsRequest `json:"auction" binding:"omitempty"`
+}
+
+// CloneEventRequest creates a draft event from an existing event or a saved template.
+// Title defaults to the source title, or the template's title pattern.
+type CloneEventRequest struct {
+	Title     string `json:"title" binding:"omitempty,min=3,max=100"`
+	StartDate string `json:"start_date" binding:"required"`
+	EndDate   string `json:"end_date" binding:"required"`
+}
+
+type SaveEventTemplateRequest struct {
+	Name         string `json:"name" binding:"required,min=3,max=100"`
+	TitlePattern string `json:"title_pattern" binding:"omitempty,min=3,max=100"` // defaults to the event title
 }
 
 type UpdateEventRequest struct {
@@ -17,6 +49,107 @@ type UpdateEventRequest struct {
 	EndDate       string `json:"end_date" binding:"omitempty"`
 	TermsFilePath string `json:"terms_file_path" binding:"omitempty,max=500"` // Kept for backward compatibility
 	Status        string `json:"status" binding:"omitempty,oneof=draft open closed completed cancelled"`
+
+	ParticipationMode string                   `json:"participation_mode" binding:"omitempty,oneof=open invited"`
+	Eligibility       *EventEligibilityRequest `json:"eligibility" binding:"omitempty"`
+
+	SubmissionFileRules *SubmissionFileRulesRequest `json:"submission_file_rules" binding:"omitempty"`
+
+	SealedBids       *bool `json:"sealed_bids"`
+	BidOpeningQuorum *int  `json:"bid_opening_quorum" binding:"omitempty,min=2,max=10"`
+
+	QACutoffHours *int `json:"qa_cutoff_hours" binding:"omitempty,min=0,max=720"`
+
+	ContractValue *float64             `json:"contract_value" binding:"omitempty,gt=0"`
+	PaymentTerms  []PaymentTermRequest `json:"payment_terms" binding:"omitempty,dive"`
+
+	Auction *AuctionSettingsRequest `json:"auction" binding:"omitempty"` // only before the auction starts
+}
+
+// AuctionSettingsRequest configures a reverse auction. Times are RFC 3339. A bid must be lower
+// than the vendor's previous bid by the larger of min_decrement and min_decrement_percent.
+type AuctionSettingsRequest struct {
+	StartAt                string   `json:"start_at" binding:"required"`
+	EndAt                  string   `json:"end_at" binding:"required"`
+	StartPrice             *float64 `json:"start_price" binding:"omitempty,gt=0"`
+	MinDecrement           *float64 `json:"min_decrement" binding:"omitempty,gt=0"`
+	MinDecrementPercent    *float64 `json:"min_decrement_percent" binding:"omitempty,gt=0,lte=50"`
+	ExtensionWindowMinutes int      `json:"extension_window_minutes" binding:"omitempty,min=0,max=60"`
+	ExtensionMinutes       int      `json:"extension_minutes" binding:"omitempty,min=0,max=60"`
+}
+
+type PlaceAuctionBidRequest struct {
+	Amount float64 `json:"amount" binding:"required,gt=0"`
+}
+
+// PaymentTermRequest is one line of the payment term template; the percentages must add up to 100
+type PaymentTermRequest struct {
+	Label        string  `json:"label" binding:"required,max=100"`
+	Percentage   float64 `json:"percentage" binding:"required,gt=0,lte=100"`
+	Trigger      string  `json:"trigger" binding:"required,oneof=on_award on_completion monthly"`
+	Installments int     `json:"installments" binding:"omitempty,min=1,max=60"` // number of monthly payments
+}
+
+// SubmissionFileRulesRequest overrides the global limits for files attached to submissions
+type SubmissionFileRulesRequest struct {
+	MaxFiles         *int     `json:"max_files" binding:"omitempty,min=1,max=50"`
+	MaxFileSizeMB    *int     `json:"max_file_size_mb" binding:"omitempty,min=1,max=100"`
+	AllowedMimeTypes []string `json:"allowed_mime_types" binding:"omitempty,dive,min=3,max=100"`
+}
+
+// EventEligibilityRequest describes which vendors are automatically invited to an invited-mode event
+type EventEligibilityRequest struct {
+	Enabled       bool     `json:"enabled"`
+	BusinessField string   `json:"business_field" binding:"omitempty,max=255"`
+	ProvinceId    string   `json:"province_id" binding:"omitempty,max=20"`
+	ActiveOnly    *bool    `json:"active_only"`
+	MinRating     *float64 `json:"min_rating" binding:"omitempty,min=0,max=5"`
+}
+
+type OpenBidsRequest struct {
+	Note string `json:"note" binding:"omitempty,max=500"`
+}
+
+type SetBoqItemsRequest struct {
+	Items []BoqItemRequest `json:"items" binding:"required,min=1,max=500,dive"`
+}
+
+type BoqItemRequest struct {
+	Description   string   `json:"description" binding:"required,max=500"`
+	Unit          string   `json:"unit" binding:"required,max=50"`
+	Quantity      float64  `json:"quantity" binding:"required,gt=0"`
+	OwnerEstimate *float64 `json:"owner_estimate" binding:"omitempty,gte=0"` // per unit
+}
+
+type AskQuestionRequest struct {
+	Question string `json:"question" binding:"required,min=5,max=2000"`
+}
+
+type AnswerQuestionRequest struct {
+	Answer  string `json:"answer" binding:"required,max=5000"`
+	Publish bool   `json:"publish"` // share the answer with every vendor of the event
+}
+
+// IssueAddendumRequest is sent as multipart form together with the addendum "files"
+type IssueAddendumRequest struct {
+	Summary  string   `form:"summary" binding:"required,min=5,max=5000"`
+	EndDate  string   `form:"end_date" binding:"omitempty"` // new deadline, YYYY-MM-DD
+	Captions []string `form:"captions" binding:"omitempty,dive,max=100"`
+}
+
+// ExtendDeadlineRequest moves the submission deadline of an event later
+type ExtendDeadlineRequest struct {
+	EndDate string `json:"end_date" binding:"required"` // YYYY-MM-DD
+	Reason  string `json:"reason" binding:"required,min=5,max=2000"`
+}
+
+// AddEventTeamMembersRequest lets staff users manage the event together with its creator
+type AddEventTeamMembersRequest struct {
+	UserIDs []string `json:"user_ids" binding:"required,min=1,dive,uuid"`
+}
+
+type InviteVendorsRequest struct {
+	VendorIDs []string `json:"vendor_ids" binding:"required,min=1,dive,uuid"`
 }
 
 // For file uploads - separate from event creation
@@ -26,13 +159,26 @@ type UploadEventFileRequest struct {
 }
 
 type SubmitPitchRequest struct {
-	ProposalDetails string `json:"proposal_details" binding:"omitempty"`
+	ProposalDetails string                   `json:"proposal_details" binding:"omitempty"`
+	Prices          []SubmissionPriceRequest `json:"prices" binding:"omitempty,dive"`
 }
 
-// For submission file uploads - separate from submission creation
-type UploadSubmissionFileRequest struct {
-	FileType string `json:"file_type" binding:"required,oneof=pitch proposal document"`
-	Caption  string `json:"caption" binding:"omitempty,max=100"`
+// SubmissionPriceRequest is the vendor's unit price for one bill of quantities line
+type SubmissionPriceRequest struct {
+	BoqItemId string  `json:"boq_item_id" binding:"required,uuid"`
+	UnitPrice float64 `json:"unit_price" binding:"gte=0"`
+}
+
+// SubmissionFileUpload is one file of a multipart pitch submission
+type SubmissionFileUpload struct {
+	File      *multipart.FileHeader
+	FileType  string
+	Caption   string
+	FileOrder int
+}
+
+type WithdrawSubmissionRequest struct {
+	Reason string `json:"reason" binding:"omitempty,max=500"`
 }
 
 type ScoreSubmissionRequest struct {
@@ -44,6 +190,38 @@ type ShortlistSubmissionRequest struct {
 	IsShortlisted bool `json:"is_shortlisted"`
 }
 
+// SelectWinnerRequest awards the event either to a single submission or split across several awards
 type SelectWinnerRequest struct {
-	SubmissionID string `json:"submission_id" binding:"required,uuid"`
+	SubmissionID  string         `json:"submission_id" binding:"omitempty,uuid"`
+	Justification string         `json:"justification" binding:"omitempty,max=2000"` // for submission_id, required when its price is flagged
+	Awards        []AwardRequest `json:"awards" binding:"omitempty,dive"`
+}
+
+// SetEventBudgetRequest sets the confidential owner estimate (HPS) and budget ceiling of an event
+type SetEventBudgetRequest struct {
+	OwnerEstimate        *float64 `json:"owner_estimate" binding:"omitempty,gt=0"`
+	BudgetCeiling        *float64 `json:"budget_ceiling" binding:"omitempty,gt=0"`
+	AbnormallyLowPercent *float64 `json:"abnormally_low_percent" binding:"omitempty,gt=0,lte=100"` // defaults to ABNORMALLY_LOW_PERCENT
+}
+
+// CancelEventRequest cancels an event; with retender set, the event is cloned into a new draft
+type CancelEventRequest struct {
+	Reason   string             `json:"reason" binding:"required,min=5,max=2000"`
+	Retender *CloneEventRequest `json:"retender,omitempty"`
+}
+
+// RevokeAwardRequest cancels an award and either hands it to the runner-up or re-opens the event
+type RevokeAwardRequest struct {
+	Reason        string `json:"reason" binding:"required,min=5,max=2000"`
+	Fallback      string `json:"fallback" binding:"omitempty,oneof=runner_up reopen"` // defaults to runner_up
+	EndDate       string `json:"end_date" binding:"omitempty"`                        // new deadline when re-opening, YYYY-MM-DD
+	Justification string `json:"justification" binding:"omitempty,max=2000"`          // required when the runner-up's price is flagged
+}
+
+type AwardRequest struct {
+	SubmissionID  string  `json:"submission_id" binding:"required,uuid"`
+	LotReference  string  `json:"lot_reference" binding:"omitempty,max=255"`
+	AwardedAmount float64 `json:"awarded_amount" binding:"gt=0"`
+	Notes         string  `json:"notes" binding:"omitempty,max=2000"`
+	Justification string  `json:"justification" binding:"omitempty,max=2000"` // required when the price is flagged
 }
@@ -1,5 +1,7 @@
 package dto
 
+import "mime/multipart"
+
 type CreatePaymentRequest struct {
 	InvoiceNumber string  `json:"invoice_number" binding:"required,max=100"`
 	VendorID      string  `json:"vendor_id" binding:"required,uuid"`
@@ -13,13 +15,13 @@ type CreatePaymentRequest struct {
 type UpdatePaymentRequest struct {
 	InvoiceNumber string  `json:"invoice_number" binding:"omitempty,max=100"`
 	Amount        float64 `json:"amount" binding:"omitempty,gt=0"`
-	Status        string  `json:"status" binding:"omitempty,oneof=pending paid cancelled"`
+	Status        string  `json:"status" binding:"omitempty,oneof=scheduled pending paid cancelled"`
 	PaymentDate   string  `json:"payment_date" binding:"omitempty"`
 	Description   string  `json:"description" binding:"omitempty"`
 }
 
 type UpdatePaymentStatusRequest struct {
-	Status      string `json:"status" binding:"required,oneof=pending paid cancelled"`
+	Status      string `json:"status" binding:"required,oneof=scheduled pending paid cancelled"`
 	PaymentDate string `json:"payment_date" binding:"omitempty"` // Required when marking as paid
 }
 
@@ -29,6 +31,14 @@ type UploadPaymentFileRequest struct {
 	Caption  string `json:"caption" binding:"omitempty,max=255"`
 }
 
+// CreatePaymentSettlementRequest is sent as a multipart form together with an optional proof_file
+type CreatePaymentSettlementRequest struct {
+	Amount         float64 `form:"amount" binding:"required,gt=0"`
+	SettlementDate string  `form:"settlement_date" binding:"required"` // YYYY-MM-DD
+	Reference      string  `form:"reference" binding:"omitempty,max=100"`
+	Note           string  `form:"note" binding:"omitempty,max=1000"`
+}
+
 type MarkAsPaidRequest struct {
 	PaymentDate string `json:"payment_date" binding:"omitempty"`
 }
@@ -38,3 +48,96 @@ type AddPaymentFile struct {
 	FileUrl  string `json:"file_url" binding:"required"`
 	Caption  string `json:"caption,omitempty"`
 }
+
+type ApprovePaymentRequest struct {
+	Comment string `json:"comment" binding:"omitempty,max=1000"`
+}
+
+type RejectPaymentRequest struct {
+	Reason string `json:"reason" binding:"required,max=1000"`
+}
+
+// SetPaymentApprovalLevelsRequest replaces the approval chain; levels are ordered by min_amount
+type SetPaymentApprovalLevelsRequest struct {
+	Levels []PaymentApprovalLevelRequest `json:"levels" binding:"required,min=1,dive"`
+}
+
+type PaymentApprovalLevelRequest struct {
+	Name             string  `json:"name" binding:"required,max=100"`
+	MinAmount        float64 `json:"min_amount" binding:"gte=0"`
+	PermissionAction string  `json:"permission_action" binding:"required,max=50"`
+}
+
+// SubmitInvoiceRequest is sent as a multipart form together with the invoice_file,
+// tax_invoice_file and delivery_proof_file uploads
+type SubmitInvoiceRequest struct {
+	InvoiceNumber    string  `form:"invoice_number" binding:"required,max=100"`
+	EventID          string  `form:"event_id" binding:"omitempty,uuid"`
+	AwardID          string  `form:"award_id" binding:"omitempty,uuid"`
+	InvoiceDate      string  `form:"invoice_date" binding:"required"` // YYYY-MM-DD
+	DueDate          string  `form:"due_date" binding:"omitempty"`
+	Subtotal         float64 `form:"subtotal" binding:"required,gt=0"`
+	VatAmount        float64 `form:"vat_amount" binding:"gte=0"`
+	TaxInvoiceNumber string  `form:"tax_invoice_number" binding:"omitempty,max=50"`
+	Description      string  `form:"description" binding:"omitempty,max=2000"`
+}
+
+// InvoiceFileUploads are the documents attached to a submitted invoice
+type InvoiceFileUploads struct {
+	Invoice       *multipart.FileHeader
+	TaxInvoice    *multipart.FileHeader
+	DeliveryProof *multipart.FileHeader
+}
+
+type RejectInvoiceRequest struct {
+	Reason string `json:"reason" binding:"required,max=1000"`
+}
+
+type ConvertInvoiceRequest struct {
+	PaymentDate string `json:"payment_date" binding:"omitempty"`
+	Description string `json:"description" binding:"omitempty"`
+}
+
+// OverridePaymentTaxRequest replaces the computed tax breakdown; tax_base plus vat_amount must equal the payment amount
+type OverridePaymentTaxRequest struct {
+	TaxBase         float64 `json:"tax_base" binding:"required,gt=0"`
+	VatAmount       float64 `json:"vat_amount" binding:"gte=0"`
+	WithholdingType string  `json:"withholding_type" binding:"required,oneof=pph23 pph21 none"`
+	WithholdingRate float64 `json:"withholding_rate" binding:"gte=0,lte=100"`
+	Reason          string  `json:"reason" binding:"required,max=1000"`
+}
+
+type SetTaxRulesRequest struct {
+	Rules []TaxRuleRequest `json:"rules" binding:"required,min=1,dive"`
+}
+
+type TaxRuleRequest struct {
+	VendorType            string  `json:"vendor_type" binding:"required,oneof=company individual"`
+	TaxStatus             string  `json:"tax_status" binding:"required,oneof=pkp non_pkp"`
+	VatRate               float64 `json:"vat_rate" binding:"gte=0,lte=100"`
+	WithholdingType       string  `json:"withholding_type" binding:"required,oneof=pph23 pph21 none"`
+	WithholdingRate       float64 `json:"withholding_rate" binding:"gte=0,lte=100"`
+	NoNpwpWithholdingRate float64 `json:"no_npwp_withholding_rate" binding:"gte=0,lte=100"`
+}
+
+// CreateDisbursementBatchRequest generates a bulk transfer file of approved payments in one of the
+// registered formats
+type CreateDisbursementBatchRequest struct {
+	PaymentIDs []string `json:"payment_ids" binding:"required,min=1,dive,uuid"`
+	Format     string   `json:"format" binding:"required"`
+}
+
+// ImportBankStatementRequest is sent as a multipart form together with the statement file, in
+// one of the registered statement formats
+type ImportBankStatementRequest struct {
+	Format string `form:"format" binding:"required"`
+}
+
+type MatchStatementLineRequest struct {
+	PaymentID string `json:"payment_id" binding:"required,uuid"`
+	Note      string `json:"note" binding:"omitempty,max=1000"`
+}
+
+type DismissStatementLineRequest struct {
+	Note string `json:"note" binding:"required,max=1000"`
+}
@@ -17,6 +17,7 @@ import (
 	"vendor-management-system/utils"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
@@ -32,6 +33,33 @@ func NewEvaluationHandler(s interfaceevaluations.ServiceEvaluationInterface, ven
 	}
 }
 
+// EvaluationOwners resolves the owners of the evaluation addressed by the :id parameter for OwnershipMiddleware
+func (h *HandlerEvaluation) EvaluationOwners(ctx *gin.Context) ([]string, error) {
+	id := ctx.Param("id")
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return h.Service.GetEvaluationOwners(id)
+}
+
+// EventOwners resolves the owners of the event addressed by the :id parameter for OwnershipMiddleware
+func (h *HandlerEvaluation) EventOwners(ctx *gin.Context) ([]string, error) {
+	id := ctx.Param("id")
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return h.Service.GetEventOwners(id)
+}
+
+// PhotoOwners resolves the owners of the evaluation of the photo addressed by the :id parameter
+func (h *HandlerEvaluation) PhotoOwners(ctx *gin.Context) ([]string, error) {
+	id := ctx.Param("id")
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return h.Service.GetPhotoOwners(id)
+}
+
 func (h *HandlerEvaluation) CreateEvaluation(ctx *gin.Context) {
 	var req dto.CreateEvaluationRequest
 	authData := utils.GetAuthData(ctx)
@@ -179,6 +207,35 @@ func (h *HandlerEvaluation) UpdateEvaluation(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, res)
 }
 
+// FinalizeEvaluation - Client closes the evaluation, releasing the vendor's completion-tied payments
+func (h *HandlerEvaluation) FinalizeEvaluation(ctx *gin.Context) {
+	authData := utils.GetAuthData(ctx)
+	userId := utils.InterfaceString(authData["user_id"])
+	logId := utils.GenerateLogId(ctx)
+	logPrefix := fmt.Sprintf("[%s][EvaluationHandler][FinalizeEvaluation]", logId)
+
+	id, err := utils.ValidateUUID(ctx, logId)
+	if err != nil {
+		return
+	}
+
+	data, err := h.Service.FinalizeEvaluation(userId, id)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.FinalizeEvaluation; ERROR: %s;", logPrefix, err))
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			res := response.Response(http.StatusNotFound, messages.MsgNotFound, logId, nil)
+			res.Error = response.Errors{Code: http.StatusNotFound, Message: "evaluation not found"}
+			ctx.JSON(http.StatusNotFound, res)
+			return
+		}
+		response.WriteError(ctx, logId, err, http.StatusBadRequest, "")
+		return
+	}
+
+	res := response.Response(http.StatusOK, "Evaluation finalized successfully", logId, data)
+	ctx.JSON(http.StatusOK, res)
+}
+
 // UpdateDriveUrl - Vendor updates Google Drive link for their evaluation
 func (h *HandlerEvaluation) UpdateDriveUrl(ctx *gin.Context) {
 	var req dto.UpdateDriveUrlRequest
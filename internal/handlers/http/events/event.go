@@ -1,11 +1,16 @@
 package handlerevents
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"mime/multipart"
 	"net/http"
 	"reflect"
 	"strconv"
+	"strings"
+	"time"
 
 	domainevents "vendor-management-system/internal/domain/events"
 	"vendor-management-system/internal/dto"
@@ -18,9 +23,14 @@ import (
 	"vendor-management-system/utils"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
+// auctionHeartbeat is how often an auction stream resends its view when nothing changes
+const auctionHeartbeat = 15 * time.Second
+
 type HandlerEvent struct {
 	Service    interfaceevents.ServiceEventInterface
 	VendorRepo interfacevendors.RepoVendorInterface
@@ -63,6 +73,7 @@ func (h *HandlerEvent) CreateEvent(ctx *gin.Context) {
 
 func (h *HandlerEvent) GetEventByID(ctx *gin.Context) {
 	authData := utils.GetAuthData(ctx)
+	userId := utils.InterfaceString(authData["user_id"])
 	userRole := utils.InterfaceString(authData["role"])
 	logId := utils.GenerateLogId(ctx)
 	logPrefix := fmt.Sprintf("[%s][EventHandler][GetEventByID]", logId)
@@ -85,12 +96,33 @@ func (h *HandlerEvent) GetEventByID(ctx *gin.Context) {
 		return
 	}
 
-	// Block draft events for vendors
-	if userRole == "vendor" && data.Status == "draft" {
-		res := response.Response(http.StatusNotFound, messages.MsgNotFound, logId, nil)
-		res.Error = response.Errors{Code: http.StatusNotFound, Message: "event not found"}
-		ctx.JSON(http.StatusNotFound, res)
-		return
+	// Block draft events and events the vendor is not invited to
+	if userRole == "vendor" {
+		visible := data.Status != utils.EventDraft
+		if visible {
+			var vendorId string
+			if vendor, err := h.VendorRepo.GetVendorByUserID(userId); err == nil {
+				vendorId = vendor.Id
+			}
+			if visible, err = h.Service.CanVendorAccessEvent(data, vendorId); err != nil {
+				logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.CanVendorAccessEvent; ERROR: %s;", logPrefix, err))
+				response.WriteError(ctx, logId, err, http.StatusInternalServerError, "")
+				return
+			}
+		}
+		if !visible {
+			res := response.Response(http.StatusNotFound, messages.MsgNotFound, logId, nil)
+			res.Error = response.Errors{Code: http.StatusNotFound, Message: "event not found"}
+			ctx.JSON(http.StatusNotFound, res)
+			return
+		}
+
+		// Vendors that opened the event are notified of later addenda
+		if vendor, err := h.VendorRepo.GetVendorByUserID(userId); err == nil {
+			if err := h.Service.RecordEventView(id, vendor.Id); err != nil {
+				logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.RecordEventView; ERROR: %s;", logPrefix, err))
+			}
+		}
 	}
 
 	res := response.Response(http.StatusOK, "success", logId, data)
@@ -99,6 +131,7 @@ func (h *HandlerEvent) GetEventByID(ctx *gin.Context) {
 
 func (h *HandlerEvent) GetAllEvents(ctx *gin.Context) {
 	authData := utils.GetAuthData(ctx)
+	userId := utils.InterfaceString(authData["user_id"])
 	userRole := utils.InterfaceString(authData["role"])
 	logId := utils.GenerateLogId(ctx)
 	logPrefix := fmt.Sprintf("[%s][EventHandler][GetAllEvents]", logId)
@@ -106,31 +139,36 @@ func (h *HandlerEvent) GetAllEvents(ctx *gin.Context) {
 	params, _ := filter.GetBaseParams(ctx, "created_at", "desc", 10)
 	params.Filters = filter.WhitelistFilter(params.Filters, []string{"status", "category"})
 
-	events, totalData, err := h.Service.GetAllEvents(params)
+	var (
+		events    []domainevents.Event
+		totalData int64
+		err       error
+	)
+
+	// Vendors only see non-draft open events and invited events they are eligible for
+	if userRole == utils.RoleVendor {
+		var vendorId string
+		if vendor, vErr := h.VendorRepo.GetVendorByUserID(userId); vErr == nil {
+			vendorId = vendor.Id
+		}
+		events, totalData, err = h.Service.GetAllEventsForVendor(vendorId, params)
+	} else {
+		events, totalData, err = h.Service.GetAllEvents(params)
+	}
 	if err != nil {
 		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; GetAllEvents; ERROR: %+v;", logPrefix, err))
 		response.WriteError(ctx, logId, err, http.StatusInternalServerError, "")
 		return
 	}
 
-	// Filter out draft events for vendors
-	if userRole == "vendor" {
-		var filteredEvents []domainevents.Event
-		for _, event := range events {
-			if event.Status != utils.EventDraft {
-				filteredEvents = append(filteredEvents, event)
-			}
-		}
-		events = filteredEvents
-		totalData = int64(len(filteredEvents))
-	}
-
 	res := response.PaginationResponse(http.StatusOK, int(totalData), params.Page, params.Limit, logId, events)
 	ctx.JSON(http.StatusOK, res)
 }
 
 func (h *HandlerEvent) UpdateEvent(ctx *gin.Context) {
 	var req dto.UpdateEventRequest
+	authData := utils.GetAuthData(ctx)
+	userId := utils.InterfaceString(authData["user_id"])
 	logId := utils.GenerateLogId(ctx)
 	logPrefix := fmt.Sprintf("[%s][EventHandler][UpdateEvent]", logId)
 
@@ -147,7 +185,7 @@ func (h *HandlerEvent) UpdateEvent(ctx *gin.Context) {
 		return
 	}
 
-	data, err := h.Service.UpdateEvent(id, req)
+	data, err := h.Service.UpdateEvent(id, userId, req)
 	if err != nil {
 		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.UpdateEvent; ERROR: %s;", logPrefix, err))
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -189,184 +227,88 @@ func (h *HandlerEvent) DeleteEvent(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, res)
 }
 
-func (h *HandlerEvent) SubmitPitch(ctx *gin.Context) {
+func (h *HandlerEvent) ExtendDeadline(ctx *gin.Context) {
+	var req dto.ExtendDeadlineRequest
 	authData := utils.GetAuthData(ctx)
 	userId := utils.InterfaceString(authData["user_id"])
 	logId := utils.GenerateLogId(ctx)
-	logPrefix := fmt.Sprintf("[%s][EventHandler][SubmitPitch]", logId)
+	logPrefix := fmt.Sprintf("[%s][EventHandler][ExtendDeadline]", logId)
 
-	eventId, err := utils.ValidateUUID(ctx, logId)
+	id, err := utils.ValidateUUID(ctx, logId)
 	if err != nil {
 		return
 	}
 
-	// Get proposal details from form
-	proposalDetails := ctx.PostForm("proposal_details")
-	if proposalDetails == "" {
-		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; proposal_details is required", logPrefix))
+	if err := ctx.BindJSON(&req); err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; BindJSON ERROR: %s;", logPrefix, err.Error()))
 		res := response.Response(http.StatusBadRequest, messages.InvalidRequest, logId, nil)
-		res.Error = "proposal_details is required"
-		ctx.JSON(http.StatusBadRequest, res)
-		return
-	}
-
-	// Get file from multipart form
-	file, err := ctx.FormFile("file")
-	if err != nil {
-		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; FormFile ERROR: %s;", logPrefix, err.Error()))
-		res := response.Response(http.StatusBadRequest, "File is required", logId, nil)
-		ctx.JSON(http.StatusBadRequest, res)
-		return
-	}
-
-	// Get file metadata from form
-	fileType := ctx.PostForm("file_type")
-	caption := ctx.PostForm("caption")
-
-	vendor, err := h.VendorRepo.GetVendorByUserID(userId)
-	if err != nil {
-		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; GetVendorByUserID; ERROR: %s;", logPrefix, err))
-		res := response.Response(http.StatusBadRequest, messages.MsgFail, logId, nil)
-		res.Error = "vendor profile not found"
+		res.Error = utils.ValidateError(err, reflect.TypeOf(req), "json")
 		ctx.JSON(http.StatusBadRequest, res)
 		return
 	}
 
-	// Validate vendor status is active
-	if vendor.Status != utils.VendorActive {
-		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Vendor status is not active: %s;", logPrefix, vendor.Status))
-		res := response.Response(http.StatusForbidden, messages.MsgFail, logId, nil)
-		res.Error = "only vendors with active status can submit pitches"
-		ctx.JSON(http.StatusForbidden, res)
-		return
-	}
-
-	// Create DTO request
-	req := dto.SubmitPitchRequest{
-		ProposalDetails: proposalDetails,
-	}
-
-	// Submit pitch with file
-	data, err := h.Service.SubmitPitchWithFile(ctx.Request.Context(), eventId, vendor.Id, req, file, fileType, caption)
+	data, err := h.Service.ExtendDeadline(id, userId, req)
 	if err != nil {
-		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.SubmitPitchWithFile; ERROR: %s;", logPrefix, err))
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.ExtendDeadline; ERROR: %s;", logPrefix, err))
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			res := response.Response(http.StatusNotFound, messages.MsgNotFound, logId, nil)
+			res.Error = response.Errors{Code: http.StatusNotFound, Message: "event not found"}
+			ctx.JSON(http.StatusNotFound, res)
+			return
+		}
 		response.WriteError(ctx, logId, err, http.StatusBadRequest, "")
 		return
 	}
 
-	res := response.Response(http.StatusOK, "Pitch submitted successfully", logId, data)
-	logger.WriteLog(logger.LogLevelDebug, fmt.Sprintf("%s; Response: %+v;", logPrefix, utils.JsonEncode(data)))
+	res := response.Response(http.StatusOK, "Event deadline extended successfully", logId, data)
 	ctx.JSON(http.StatusOK, res)
 }
 
-func (h *HandlerEvent) GetSubmissionsByEventID(ctx *gin.Context) {
+func (h *HandlerEvent) CancelEvent(ctx *gin.Context) {
+	var req dto.CancelEventRequest
+	authData := utils.GetAuthData(ctx)
+	userId := utils.InterfaceString(authData["user_id"])
 	logId := utils.GenerateLogId(ctx)
-	logPrefix := fmt.Sprintf("[%s][EventHandler][GetSubmissionsByEventID]", logId)
+	logPrefix := fmt.Sprintf("[%s][EventHandler][CancelEvent]", logId)
 
-	eventId, err := utils.ValidateUUID(ctx, logId)
+	id, err := utils.ValidateUUID(ctx, logId)
 	if err != nil {
 		return
 	}
 
-	params, _ := filter.GetBaseParams(ctx, "created_at", "desc", 10)
-	params.Filters = filter.WhitelistFilter(params.Filters, []string{"is_shortlisted", "is_winner"})
-	params.Filters["event_id"] = eventId
-
-	data, totalData, err := h.Service.GetSubmissionsByEventIDPaginated(eventId, params)
-	if err != nil {
-		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.GetSubmissionsByEventID; ERROR: %s;", logPrefix, err))
-		response.WriteError(ctx, logId, err, http.StatusInternalServerError, "")
+	if err := ctx.BindJSON(&req); err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; BindJSON ERROR: %s;", logPrefix, err.Error()))
+		res := response.Response(http.StatusBadRequest, messages.InvalidRequest, logId, nil)
+		res.Error = utils.ValidateError(err, reflect.TypeOf(req), "json")
+		ctx.JSON(http.StatusBadRequest, res)
 		return
 	}
 
-	res := response.PaginationResponse(http.StatusOK, int(totalData), params.Page, params.Limit, logId, data)
-	ctx.JSON(http.StatusOK, res)
-}
-
-func (h *HandlerEvent) GetAllSubmissions(ctx *gin.Context) {
-	logId := utils.GenerateLogId(ctx)
-	logPrefix := fmt.Sprintf("[%s][EventHandler][GetAllSubmissions]", logId)
-
-	params, _ := filter.GetBaseParams(ctx, "created_at", "desc", 10)
-	params.Filters = filter.WhitelistFilter(params.Filters, []string{"event_id", "vendor_id", "is_shortlisted", "is_winner"})
-
-	data, totalData, err := h.Service.GetAllSubmissions(params)
+	data, err := h.Service.CancelEvent(ctx.Request.Context(), id, userId, req)
 	if err != nil {
-		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.GetAllSubmissions; ERROR: %s;", logPrefix, err))
-		response.WriteError(ctx, logId, err, http.StatusInternalServerError, "")
-		return
-	}
-
-	res := response.PaginationResponse(http.StatusOK, int(totalData), params.Page, params.Limit, logId, data)
-	ctx.JSON(http.StatusOK, res)
-}
-
-func (h *HandlerEvent) GetGroupedSubmissions(ctx *gin.Context) {
-	logId := utils.GenerateLogId(ctx)
-	logPrefix := fmt.Sprintf("[%s][EventHandler][GetGroupedSubmissions]", logId)
-
-	params, _ := filter.GetBaseParams(ctx, "created_at", "desc", 10)
-
-	submissionPage := 1
-	if page := ctx.Query("submission_page"); page != "" {
-		if p, err := strconv.Atoi(page); err == nil && p > 0 {
-			submissionPage = p
-		}
-	}
-
-	submissionLimit := 10
-	if limit := ctx.Query("submission_limit"); limit != "" {
-		if l, err := strconv.Atoi(limit); err == nil && l > 0 {
-			submissionLimit = l
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.CancelEvent; ERROR: %s;", logPrefix, err))
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			res := response.Response(http.StatusNotFound, messages.MsgNotFound, logId, nil)
+			res.Error = response.Errors{Code: http.StatusNotFound, Message: "event not found"}
+			ctx.JSON(http.StatusNotFound, res)
+			return
 		}
-	}
-
-	data, err := h.Service.GetGroupedSubmissions(params, submissionPage, submissionLimit)
-	if err != nil {
-		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.GetGroupedSubmissions; ERROR: %s;", logPrefix, err))
-		response.WriteError(ctx, logId, err, http.StatusInternalServerError, "")
+		response.WriteError(ctx, logId, err, http.StatusBadRequest, "")
 		return
 	}
 
-	res := response.Response(http.StatusOK, messages.MsgSuccess, logId, data)
+	res := response.Response(http.StatusOK, "Event cancelled successfully", logId, data)
 	ctx.JSON(http.StatusOK, res)
 }
 
-func (h *HandlerEvent) GetMySubmissions(ctx *gin.Context) {
+func (h *HandlerEvent) CloneEvent(ctx *gin.Context) {
+	var req dto.CloneEventRequest
 	authData := utils.GetAuthData(ctx)
 	userId := utils.InterfaceString(authData["user_id"])
 	logId := utils.GenerateLogId(ctx)
-	logPrefix := fmt.Sprintf("[%s][EventHandler][GetMySubmissions]", logId)
-
-	params, _ := filter.GetBaseParams(ctx, "updated_at", "desc", 10)
-	params.Filters = filter.WhitelistFilter(params.Filters, []string{"event_id", "is_shortlisted", "is_winner"})
-
-	vendor, err := h.VendorRepo.GetVendorByUserID(userId)
-	if err != nil {
-		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; GetVendorByUserID; ERROR: %s;", logPrefix, err))
-		res := response.Response(http.StatusBadRequest, messages.MsgFail, logId, nil)
-		res.Error = "vendor profile not found"
-		ctx.JSON(http.StatusBadRequest, res)
-		return
-	}
-
-	data, totalData, err := h.Service.GetMySubmissions(vendor.Id, params)
-	if err != nil {
-		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.GetMySubmissions; ERROR: %s;", logPrefix, err))
-		response.WriteError(ctx, logId, err, http.StatusInternalServerError, "")
-		return
-	}
-
-	res := response.PaginationResponse(http.StatusOK, int(totalData), params.Page, params.Limit, logId, data)
-	ctx.JSON(http.StatusOK, res)
-}
-
-func (h *HandlerEvent) ScoreSubmission(ctx *gin.Context) {
-	var req dto.ScoreSubmissionRequest
-	logId := utils.GenerateLogId(ctx)
-	logPrefix := fmt.Sprintf("[%s][EventHandler][ScoreSubmission]", logId)
+	logPrefix := fmt.Sprintf("[%s][EventHandler][CloneEvent]", logId)
 
-	submissionId, err := utils.ValidateUUID(ctx, logId)
+	id, err := utils.ValidateUUID(ctx, logId)
 	if err != nil {
 		return
 	}
@@ -379,12 +321,12 @@ func (h *HandlerEvent) ScoreSubmission(ctx *gin.Context) {
 		return
 	}
 
-	data, err := h.Service.ScoreSubmission(submissionId, req)
+	data, err := h.Service.CloneEvent(ctx.Request.Context(), id, userId, req)
 	if err != nil {
-		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.ScoreSubmission; ERROR: %s;", logPrefix, err))
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.CloneEvent; ERROR: %s;", logPrefix, err))
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			res := response.Response(http.StatusNotFound, messages.MsgNotFound, logId, nil)
-			res.Error = response.Errors{Code: http.StatusNotFound, Message: "submission not found"}
+			res.Error = response.Errors{Code: http.StatusNotFound, Message: "event not found"}
 			ctx.JSON(http.StatusNotFound, res)
 			return
 		}
@@ -392,16 +334,18 @@ func (h *HandlerEvent) ScoreSubmission(ctx *gin.Context) {
 		return
 	}
 
-	res := response.Response(http.StatusOK, "Submission scored successfully", logId, data)
-	ctx.JSON(http.StatusOK, res)
+	res := response.Response(http.StatusCreated, "Event cloned successfully", logId, data)
+	ctx.JSON(http.StatusCreated, res)
 }
 
-func (h *HandlerEvent) ShortlistSubmission(ctx *gin.Context) {
-	var req dto.ShortlistSubmissionRequest
+func (h *HandlerEvent) SaveEventTemplate(ctx *gin.Context) {
+	var req dto.SaveEventTemplateRequest
+	authData := utils.GetAuthData(ctx)
+	userId := utils.InterfaceString(authData["user_id"])
 	logId := utils.GenerateLogId(ctx)
-	logPrefix := fmt.Sprintf("[%s][EventHandler][ShortlistSubmission]", logId)
+	logPrefix := fmt.Sprintf("[%s][EventHandler][SaveEventTemplate]", logId)
 
-	submissionId, err := utils.ValidateUUID(ctx, logId)
+	id, err := utils.ValidateUUID(ctx, logId)
 	if err != nil {
 		return
 	}
@@ -414,110 +358,120 @@ func (h *HandlerEvent) ShortlistSubmission(ctx *gin.Context) {
 		return
 	}
 
-	data, err := h.Service.ShortlistSubmission(submissionId, req.IsShortlisted)
+	data, err := h.Service.SaveEventTemplate(ctx.Request.Context(), id, userId, req)
 	if err != nil {
-		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.ShortlistSubmission; ERROR: %s;", logPrefix, err))
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.SaveEventTemplate; ERROR: %s;", logPrefix, err))
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			res := response.Response(http.StatusNotFound, messages.MsgNotFound, logId, nil)
-			res.Error = response.Errors{Code: http.StatusNotFound, Message: "submission not found"}
+			res.Error = response.Errors{Code: http.StatusNotFound, Message: "event not found"}
 			ctx.JSON(http.StatusNotFound, res)
 			return
 		}
-		response.WriteError(ctx, logId, err, http.StatusBadRequest, "")
+		response.WriteError(ctx, logId, err, http.StatusInternalServerError, "")
 		return
 	}
 
-	res := response.Response(http.StatusOK, "Submission shortlist updated successfully", logId, data)
-	ctx.JSON(http.StatusOK, res)
+	res := response.Response(http.StatusCreated, "Event template saved successfully", logId, data)
+	ctx.JSON(http.StatusCreated, res)
 }
 
-func (h *HandlerEvent) SelectWinner(ctx *gin.Context) {
-	var req dto.SelectWinnerRequest
+func (h *HandlerEvent) GetEventTemplates(ctx *gin.Context) {
 	logId := utils.GenerateLogId(ctx)
-	logPrefix := fmt.Sprintf("[%s][EventHandler][SelectWinner]", logId)
+	logPrefix := fmt.Sprintf("[%s][EventHandler][GetEventTemplates]", logId)
 
-	eventId, err := utils.ValidateUUID(ctx, logId)
+	params, _ := filter.GetBaseParams(ctx, "name", "asc", 10)
+	params.Filters = filter.WhitelistFilter(params.Filters, []string{"category", "participation_mode"})
+
+	data, totalData, err := h.Service.GetEventTemplates(params)
 	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.GetEventTemplates; ERROR: %s;", logPrefix, err))
+		response.WriteError(ctx, logId, err, http.StatusInternalServerError, "")
 		return
 	}
 
-	if err := ctx.BindJSON(&req); err != nil {
-		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; BindJSON ERROR: %s;", logPrefix, err.Error()))
-		res := response.Response(http.StatusBadRequest, messages.InvalidRequest, logId, nil)
-		res.Error = utils.ValidateError(err, reflect.TypeOf(req), "json")
-		ctx.JSON(http.StatusBadRequest, res)
+	res := response.PaginationResponse(http.StatusOK, int(totalData), params.Page, params.Limit, logId, data)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (h *HandlerEvent) GetEventTemplateByID(ctx *gin.Context) {
+	logId := utils.GenerateLogId(ctx)
+	logPrefix := fmt.Sprintf("[%s][EventHandler][GetEventTemplateByID]", logId)
+
+	id, err := utils.ValidateUUID(ctx, logId)
+	if err != nil {
 		return
 	}
 
-	data, err := h.Service.SelectWinner(eventId, req.SubmissionID)
+	data, err := h.Service.GetEventTemplateByID(id)
 	if err != nil {
-		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.SelectWinner; ERROR: %s;", logPrefix, err))
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.GetEventTemplateByID; ERROR: %s;", logPrefix, err))
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			res := response.Response(http.StatusNotFound, messages.MsgNotFound, logId, nil)
-			res.Error = response.Errors{Code: http.StatusNotFound, Message: "event or submission not found"}
+			res.Error = response.Errors{Code: http.StatusNotFound, Message: "template not found"}
 			ctx.JSON(http.StatusNotFound, res)
 			return
 		}
-		response.WriteError(ctx, logId, err, http.StatusBadRequest, "")
+		response.WriteError(ctx, logId, err, http.StatusInternalServerError, "")
 		return
 	}
 
-	res := response.Response(http.StatusOK, "Winner selected successfully", logId, data)
+	res := response.Response(http.StatusOK, messages.MsgSuccess, logId, data)
 	ctx.JSON(http.StatusOK, res)
 }
 
-func (h *HandlerEvent) GetEventResult(ctx *gin.Context) {
+func (h *HandlerEvent) CreateEventFromTemplate(ctx *gin.Context) {
+	var req dto.CloneEventRequest
 	authData := utils.GetAuthData(ctx)
 	userId := utils.InterfaceString(authData["user_id"])
 	logId := utils.GenerateLogId(ctx)
-	logPrefix := fmt.Sprintf("[%s][EventHandler][GetEventResult]", logId)
+	logPrefix := fmt.Sprintf("[%s][EventHandler][CreateEventFromTemplate]", logId)
 
-	eventId, err := utils.ValidateUUID(ctx, logId)
+	id, err := utils.ValidateUUID(ctx, logId)
 	if err != nil {
 		return
 	}
 
-	vendor, err := h.VendorRepo.GetVendorByUserID(userId)
-	if err != nil {
-		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; GetVendorByUserID; ERROR: %s;", logPrefix, err))
-		res := response.Response(http.StatusBadRequest, messages.MsgFail, logId, nil)
-		res.Error = "vendor profile not found"
+	if err := ctx.BindJSON(&req); err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; BindJSON ERROR: %s;", logPrefix, err.Error()))
+		res := response.Response(http.StatusBadRequest, messages.InvalidRequest, logId, nil)
+		res.Error = utils.ValidateError(err, reflect.TypeOf(req), "json")
 		ctx.JSON(http.StatusBadRequest, res)
 		return
 	}
 
-	data, err := h.Service.GetEventResult(eventId, vendor.Id)
+	data, err := h.Service.CreateEventFromTemplate(ctx.Request.Context(), id, userId, req)
 	if err != nil {
-		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.GetEventResult; ERROR: %s;", logPrefix, err))
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.CreateEventFromTemplate; ERROR: %s;", logPrefix, err))
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			res := response.Response(http.StatusNotFound, messages.MsgNotFound, logId, nil)
-			res.Error = response.Errors{Code: http.StatusNotFound, Message: "event not found"}
+			res.Error = response.Errors{Code: http.StatusNotFound, Message: "template not found"}
 			ctx.JSON(http.StatusNotFound, res)
 			return
 		}
-		response.WriteError(ctx, logId, err, http.StatusInternalServerError, "")
+		response.WriteError(ctx, logId, err, http.StatusBadRequest, "")
 		return
 	}
 
-	res := response.Response(http.StatusOK, "success", logId, data)
-	ctx.JSON(http.StatusOK, res)
+	res := response.Response(http.StatusCreated, "Event created successfully", logId, data)
+	ctx.JSON(http.StatusCreated, res)
 }
 
-func (h *HandlerEvent) GetEventResultForAdmin(ctx *gin.Context) {
+func (h *HandlerEvent) DeleteEventTemplate(ctx *gin.Context) {
+	authData := utils.GetAuthData(ctx)
+	userId := utils.InterfaceString(authData["user_id"])
 	logId := utils.GenerateLogId(ctx)
-	logPrefix := fmt.Sprintf("[%s][EventHandler][GetEventResultForAdmin]", logId)
+	logPrefix := fmt.Sprintf("[%s][EventHandler][DeleteEventTemplate]", logId)
 
-	eventId, err := utils.ValidateUUID(ctx, logId)
+	id, err := utils.ValidateUUID(ctx, logId)
 	if err != nil {
 		return
 	}
 
-	data, err := h.Service.GetEventResultForAdmin(eventId)
-	if err != nil {
-		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.GetEventResultForAdmin; ERROR: %s;", logPrefix, err))
+	if err := h.Service.DeleteEventTemplate(ctx.Request.Context(), id, userId); err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.DeleteEventTemplate; ERROR: %s;", logPrefix, err))
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			res := response.Response(http.StatusNotFound, messages.MsgNotFound, logId, nil)
-			res.Error = response.Errors{Code: http.StatusNotFound, Message: "event not found"}
+			res.Error = response.Errors{Code: http.StatusNotFound, Message: "template not found"}
 			ctx.JSON(http.StatusNotFound, res)
 			return
 		}
@@ -525,73 +479,320 @@ func (h *HandlerEvent) GetEventResultForAdmin(ctx *gin.Context) {
 		return
 	}
 
-	res := response.Response(http.StatusOK, "success", logId, data)
+	res := response.Response(http.StatusOK, "Event template deleted successfully", logId, nil)
 	ctx.JSON(http.StatusOK, res)
 }
 
-func (h *HandlerEvent) UploadEventFile(ctx *gin.Context) {
+func (h *HandlerEvent) SubmitPitch(ctx *gin.Context) {
 	authData := utils.GetAuthData(ctx)
 	userId := utils.InterfaceString(authData["user_id"])
 	logId := utils.GenerateLogId(ctx)
-	logPrefix := fmt.Sprintf("[%s][EventHandler][UploadEventFile]", logId)
+	logPrefix := fmt.Sprintf("[%s][EventHandler][SubmitPitch]", logId)
 
-	eventId := ctx.Param("id")
-	if eventId == "" {
+	eventId, err := utils.ValidateUUID(ctx, logId)
+	if err != nil {
+		return
+	}
+
+	// Get proposal details from form
+	proposalDetails := ctx.PostForm("proposal_details")
+	if proposalDetails == "" {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; proposal_details is required", logPrefix))
 		res := response.Response(http.StatusBadRequest, messages.InvalidRequest, logId, nil)
-		res.Error = "event id is required"
+		res.Error = "proposal_details is required"
 		ctx.JSON(http.StatusBadRequest, res)
 		return
 	}
 
-	file, err := ctx.FormFile("file")
+	// Get files and their metadata from multipart form
+	files, err := parseSubmissionFiles(ctx)
 	if err != nil {
-		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; FormFile ERROR: %s;", logPrefix, err.Error()))
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; parseSubmissionFiles ERROR: %s;", logPrefix, err.Error()))
 		res := response.Response(http.StatusBadRequest, messages.InvalidRequest, logId, nil)
-		res.Error = "file is required"
+		res.Error = err.Error()
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+	if len(files) == 0 {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; no file uploaded;", logPrefix))
+		res := response.Response(http.StatusBadRequest, "File is required", logId, nil)
 		ctx.JSON(http.StatusBadRequest, res)
 		return
 	}
 
-	fileType := ctx.PostForm("file_type")
-	if fileType == "" {
-		fileType = "document"
+	// Create DTO request
+	req, err := buildSubmitPitchRequest(ctx, proposalDetails)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; buildSubmitPitchRequest ERROR: %s;", logPrefix, err.Error()))
+		res := response.Response(http.StatusBadRequest, messages.InvalidRequest, logId, nil)
+		res.Error = utils.ValidateError(err, reflect.TypeOf(req), "json")
+		ctx.JSON(http.StatusBadRequest, res)
+		return
 	}
-	caption := ctx.PostForm("caption")
 
-	req := dto.UploadEventFileRequest{
-		FileType: fileType,
-		Caption:  caption,
+	vendor, err := h.VendorRepo.GetVendorByUserID(userId)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; GetVendorByUserID; ERROR: %s;", logPrefix, err))
+		res := response.Response(http.StatusBadRequest, messages.MsgFail, logId, nil)
+		res.Error = "vendor profile not found"
+		ctx.JSON(http.StatusBadRequest, res)
+		return
 	}
 
-	data, err := h.Service.UploadEventFile(ctx.Request.Context(), eventId, userId, file, req)
+	// Validate vendor status is active
+	if vendor.Status != utils.VendorActive {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Vendor status is not active: %s;", logPrefix, vendor.Status))
+		res := response.Response(http.StatusForbidden, messages.MsgFail, logId, nil)
+		res.Error = "only vendors with active status can submit pitches"
+		ctx.JSON(http.StatusForbidden, res)
+		return
+	}
+
+	// Submit pitch with files
+	data, err := h.Service.SubmitPitchWithFiles(ctx.Request.Context(), eventId, vendor.Id, req, files)
 	if err != nil {
-		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.UploadEventFile; ERROR: %s;", logPrefix, err))
-		response.WriteError(ctx, logId, err, http.StatusInternalServerError, "")
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.SubmitPitchWithFiles; ERROR: %s;", logPrefix, err))
+		response.WriteError(ctx, logId, err, http.StatusBadRequest, "")
 		return
 	}
 
-	res := response.Response(http.StatusOK, "file uploaded successfully", logId, data)
+	res := response.Response(http.StatusOK, "Pitch submitted successfully", logId, data)
+	logger.WriteLog(logger.LogLevelDebug, fmt.Sprintf("%s; Response: %+v;", logPrefix, utils.JsonEncode(data)))
 	ctx.JSON(http.StatusOK, res)
 }
 
-func (h *HandlerEvent) DeleteEventFile(ctx *gin.Context) {
+// buildSubmitPitchRequest reads the proposal together with the quotation from the "prices" form
+// field, a JSON array of {"boq_item_id", "unit_price"}. Without the field Prices stays nil.
+func buildSubmitPitchRequest(ctx *gin.Context, proposalDetails string) (dto.SubmitPitchRequest, error) {
+	req := dto.SubmitPitchRequest{
+		ProposalDetails: proposalDetails,
+	}
+
+	raw, ok := ctx.GetPostForm("prices")
+	if !ok {
+		return req, nil
+	}
+
+	req.Prices = []dto.SubmissionPriceRequest{}
+	if err := json.Unmarshal([]byte(raw), &req.Prices); err != nil {
+		return req, err
+	}
+
+	return req, binding.Validator.ValidateStruct(req)
+}
+
+// parseSubmissionFiles reads the "files" form field together with the parallel "file_types",
+// "captions" and "file_orders" fields. A single "file" with "file_type" and "caption" is still accepted.
+func parseSubmissionFiles(ctx *gin.Context) ([]dto.SubmissionFileUpload, error) {
+	form, err := ctx.MultipartForm()
+	if err != nil {
+		if errors.Is(err, http.ErrNotMultipart) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("invalid multipart form: %w", err)
+	}
+
+	headers := form.File["files"]
+	fileTypes := form.Value["file_types"]
+	captions := form.Value["captions"]
+	if len(headers) == 0 {
+		headers = form.File["file"]
+		fileTypes = form.Value["file_type"]
+		captions = form.Value["caption"]
+	}
+	orders := form.Value["file_orders"]
+
+	valueAt := func(values []string, i int) string {
+		if i < len(values) {
+			return values[i]
+		}
+		return ""
+	}
+
+	uploads := make([]dto.SubmissionFileUpload, 0, len(headers))
+	for i, header := range headers {
+		upload := dto.SubmissionFileUpload{
+			File:      header,
+			FileType:  valueAt(fileTypes, i),
+			Caption:   valueAt(captions, i),
+			FileOrder: i,
+		}
+		if upload.FileType == "" {
+			upload.FileType = utils.SubmissionFileDocument
+		}
+		if order := valueAt(orders, i); order != "" {
+			n, err := strconv.Atoi(order)
+			if err != nil {
+				return nil, fmt.Errorf("invalid file_orders value %q", order)
+			}
+			upload.FileOrder = n
+		}
+		uploads = append(uploads, upload)
+	}
+
+	return uploads, nil
+}
+
+func (h *HandlerEvent) AmendSubmission(ctx *gin.Context) {
+	authData := utils.GetAuthData(ctx)
+	userId := utils.InterfaceString(authData["user_id"])
 	logId := utils.GenerateLogId(ctx)
-	logPrefix := fmt.Sprintf("[%s][EventHandler][DeleteEventFile]", logId)
+	logPrefix := fmt.Sprintf("[%s][EventHandler][AmendSubmission]", logId)
 
-	fileId := ctx.Param("fileId")
-	if fileId == "" {
+	eventId, err := utils.ValidateUUID(ctx, logId)
+	if err != nil {
+		return
+	}
+
+	proposalDetails := ctx.PostForm("proposal_details")
+	if proposalDetails == "" {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; proposal_details is required", logPrefix))
 		res := response.Response(http.StatusBadRequest, messages.InvalidRequest, logId, nil)
-		res.Error = "file id is required"
+		res.Error = "proposal_details is required"
 		ctx.JSON(http.StatusBadRequest, res)
 		return
 	}
 
-	err := h.Service.DeleteEventFile(ctx.Request.Context(), fileId)
+	// Files are optional; when sent, current files not listed in keep_file_ids are replaced
+	files, err := parseSubmissionFiles(ctx)
 	if err != nil {
-		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.DeleteEventFile; ERROR: %s;", logPrefix, err))
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; parseSubmissionFiles ERROR: %s;", logPrefix, err.Error()))
+		res := response.Response(http.StatusBadRequest, messages.InvalidRequest, logId, nil)
+		res.Error = err.Error()
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	// Prices are optional too; when sent they replace the whole quotation
+	req, err := buildSubmitPitchRequest(ctx, proposalDetails)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; buildSubmitPitchRequest ERROR: %s;", logPrefix, err.Error()))
+		res := response.Response(http.StatusBadRequest, messages.InvalidRequest, logId, nil)
+		res.Error = utils.ValidateError(err, reflect.TypeOf(req), "json")
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	var keepFileIds []string
+	if form, err := ctx.MultipartForm(); err == nil {
+		if ids, ok := form.Value["keep_file_ids"]; ok {
+			keepFileIds = make([]string, 0, len(ids))
+			for _, id := range ids {
+				if id != "" {
+					keepFileIds = append(keepFileIds, id)
+				}
+			}
+		}
+	}
+
+	vendor, err := h.VendorRepo.GetVendorByUserID(userId)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; GetVendorByUserID; ERROR: %s;", logPrefix, err))
+		res := response.Response(http.StatusBadRequest, messages.MsgFail, logId, nil)
+		res.Error = "vendor profile not found"
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	if vendor.Status != utils.VendorActive {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Vendor status is not active: %s;", logPrefix, vendor.Status))
+		res := response.Response(http.StatusForbidden, messages.MsgFail, logId, nil)
+		res.Error = "only vendors with active status can amend submissions"
+		ctx.JSON(http.StatusForbidden, res)
+		return
+	}
+
+	data, err := h.Service.AmendSubmission(ctx.Request.Context(), eventId, vendor.Id, req, keepFileIds, files)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.AmendSubmission; ERROR: %s;", logPrefix, err))
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			res := response.Response(http.StatusNotFound, messages.MsgNotFound, logId, nil)
-			res.Error = "file not found"
+			res.Error = response.Errors{Code: http.StatusNotFound, Message: "submission not found"}
+			ctx.JSON(http.StatusNotFound, res)
+			return
+		}
+		response.WriteError(ctx, logId, err, http.StatusBadRequest, "")
+		return
+	}
+
+	res := response.Response(http.StatusOK, "Submission amended successfully", logId, data)
+	logger.WriteLog(logger.LogLevelDebug, fmt.Sprintf("%s; Response: %+v;", logPrefix, utils.JsonEncode(data)))
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (h *HandlerEvent) WithdrawSubmission(ctx *gin.Context) {
+	var req dto.WithdrawSubmissionRequest
+	authData := utils.GetAuthData(ctx)
+	userId := utils.InterfaceString(authData["user_id"])
+	logId := utils.GenerateLogId(ctx)
+	logPrefix := fmt.Sprintf("[%s][EventHandler][WithdrawSubmission]", logId)
+
+	eventId, err := utils.ValidateUUID(ctx, logId)
+	if err != nil {
+		return
+	}
+
+	// Body is optional; only the withdrawal reason can be sent
+	if err := ctx.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; BindJSON ERROR: %s;", logPrefix, err.Error()))
+		res := response.Response(http.StatusBadRequest, messages.InvalidRequest, logId, nil)
+		res.Error = utils.ValidateError(err, reflect.TypeOf(req), "json")
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	vendor, err := h.VendorRepo.GetVendorByUserID(userId)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; GetVendorByUserID; ERROR: %s;", logPrefix, err))
+		res := response.Response(http.StatusBadRequest, messages.MsgFail, logId, nil)
+		res.Error = "vendor profile not found"
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	data, err := h.Service.WithdrawSubmission(eventId, vendor.Id, req)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.WithdrawSubmission; ERROR: %s;", logPrefix, err))
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			res := response.Response(http.StatusNotFound, messages.MsgNotFound, logId, nil)
+			res.Error = response.Errors{Code: http.StatusNotFound, Message: "submission not found"}
+			ctx.JSON(http.StatusNotFound, res)
+			return
+		}
+		response.WriteError(ctx, logId, err, http.StatusBadRequest, "")
+		return
+	}
+
+	res := response.Response(http.StatusOK, "Submission withdrawn successfully", logId, data)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (h *HandlerEvent) GetMySubmissionRevisions(ctx *gin.Context) {
+	authData := utils.GetAuthData(ctx)
+	userId := utils.InterfaceString(authData["user_id"])
+	logId := utils.GenerateLogId(ctx)
+	logPrefix := fmt.Sprintf("[%s][EventHandler][GetMySubmissionRevisions]", logId)
+
+	eventId, err := utils.ValidateUUID(ctx, logId)
+	if err != nil {
+		return
+	}
+
+	vendor, err := h.VendorRepo.GetVendorByUserID(userId)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; GetVendorByUserID; ERROR: %s;", logPrefix, err))
+		res := response.Response(http.StatusBadRequest, messages.MsgFail, logId, nil)
+		res.Error = "vendor profile not found"
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	data, err := h.Service.GetMySubmissionRevisions(eventId, vendor.Id)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.GetMySubmissionRevisions; ERROR: %s;", logPrefix, err))
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			res := response.Response(http.StatusNotFound, messages.MsgNotFound, logId, nil)
+			res.Error = response.Errors{Code: http.StatusNotFound, Message: "submission not found"}
 			ctx.JSON(http.StatusNotFound, res)
 			return
 		}
@@ -599,6 +800,1600 @@ func (h *HandlerEvent) DeleteEventFile(ctx *gin.Context) {
 		return
 	}
 
-	res := response.Response(http.StatusOK, "file deleted successfully", logId, nil)
+	res := response.Response(http.StatusOK, "success", logId, data)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (h *HandlerEvent) GetSubmissionRevisions(ctx *gin.Context) {
+	logId := utils.GenerateLogId(ctx)
+	logPrefix := fmt.Sprintf("[%s][EventHandler][GetSubmissionRevisions]", logId)
+
+	submissionId, err := utils.ValidateUUID(ctx, logId)
+	if err != nil {
+		return
+	}
+
+	data, err := h.Service.GetSubmissionRevisions(submissionId)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.GetSubmissionRevisions; ERROR: %s;", logPrefix, err))
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			res := response.Response(http.StatusNotFound, messages.MsgNotFound, logId, nil)
+			res.Error = response.Errors{Code: http.StatusNotFound, Message: "submission not found"}
+			ctx.JSON(http.StatusNotFound, res)
+			return
+		}
+		response.WriteError(ctx, logId, err, http.StatusInternalServerError, "")
+		return
+	}
+
+	res := response.Response(http.StatusOK, "success", logId, data)
 	ctx.JSON(http.StatusOK, res)
 }
+
+func (h *HandlerEvent) GetSubmissionsByEventID(ctx *gin.Context) {
+	authData := utils.GetAuthData(ctx)
+	userId := utils.InterfaceString(authData["user_id"])
+	logId := utils.GenerateLogId(ctx)
+	logPrefix := fmt.Sprintf("[%s][EventHandler][GetSubmissionsByEventID]", logId)
+
+	eventId, err := utils.ValidateUUID(ctx, logId)
+	if err != nil {
+		return
+	}
+
+	params, _ := filter.GetBaseParams(ctx, "created_at", "desc", 10)
+	params.Filters = filter.WhitelistFilter(params.Filters, []string{"is_shortlisted", "is_winner", "status"})
+	params.Filters["event_id"] = eventId
+
+	data, totalData, err := h.Service.GetSubmissionsByEventIDPaginated(eventId, userId, params)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.GetSubmissionsByEventID; ERROR: %s;", logPrefix, err))
+		response.WriteError(ctx, logId, err, http.StatusInternalServerError, "")
+		return
+	}
+
+	res := response.PaginationResponse(http.StatusOK, int(totalData), params.Page, params.Limit, logId, data)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (h *HandlerEvent) GetAllSubmissions(ctx *gin.Context) {
+	authData := utils.GetAuthData(ctx)
+	userId := utils.InterfaceString(authData["user_id"])
+	logId := utils.GenerateLogId(ctx)
+	logPrefix := fmt.Sprintf("[%s][EventHandler][GetAllSubmissions]", logId)
+
+	params, _ := filter.GetBaseParams(ctx, "created_at", "desc", 10)
+	params.Filters = filter.WhitelistFilter(params.Filters, []string{"event_id", "vendor_id", "is_shortlisted", "is_winner", "status"})
+
+	data, totalData, err := h.Service.GetAllSubmissions(userId, params)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.GetAllSubmissions; ERROR: %s;", logPrefix, err))
+		response.WriteError(ctx, logId, err, http.StatusInternalServerError, "")
+		return
+	}
+
+	res := response.PaginationResponse(http.StatusOK, int(totalData), params.Page, params.Limit, logId, data)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (h *HandlerEvent) GetGroupedSubmissions(ctx *gin.Context) {
+	authData := utils.GetAuthData(ctx)
+	userId := utils.InterfaceString(authData["user_id"])
+	logId := utils.GenerateLogId(ctx)
+	logPrefix := fmt.Sprintf("[%s][EventHandler][GetGroupedSubmissions]", logId)
+
+	params, _ := filter.GetBaseParams(ctx, "created_at", "desc", 10)
+
+	submissionPage := 1
+	if page := ctx.Query("submission_page"); page != "" {
+		if p, err := strconv.Atoi(page); err == nil && p > 0 {
+			submissionPage = p
+		}
+	}
+
+	submissionLimit := 10
+	if limit := ctx.Query("submission_limit"); limit != "" {
+		if l, err := strconv.Atoi(limit); err == nil && l > 0 {
+			submissionLimit = l
+		}
+	}
+
+	data, err := h.Service.GetGroupedSubmissions(userId, params, submissionPage, submissionLimit)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.GetGroupedSubmissions; ERROR: %s;", logPrefix, err))
+		response.WriteError(ctx, logId, err, http.StatusInternalServerError, "")
+		return
+	}
+
+	res := response.Response(http.StatusOK, messages.MsgSuccess, logId, data)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (h *HandlerEvent) GetMySubmissions(ctx *gin.Context) {
+	authData := utils.GetAuthData(ctx)
+	userId := utils.InterfaceString(authData["user_id"])
+	logId := utils.GenerateLogId(ctx)
+	logPrefix := fmt.Sprintf("[%s][EventHandler][GetMySubmissions]", logId)
+
+	params, _ := filter.GetBaseParams(ctx, "updated_at", "desc", 10)
+	params.Filters = filter.WhitelistFilter(params.Filters, []string{"event_id", "is_shortlisted", "is_winner", "status"})
+
+	vendor, err := h.VendorRepo.GetVendorByUserID(userId)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; GetVendorByUserID; ERROR: %s;", logPrefix, err))
+		res := response.Response(http.StatusBadRequest, messages.MsgFail, logId, nil)
+		res.Error = "vendor profile not found"
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	data, totalData, err := h.Service.GetMySubmissions(vendor.Id, params)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.GetMySubmissions; ERROR: %s;", logPrefix, err))
+		response.WriteError(ctx, logId, err, http.StatusInternalServerError, "")
+		return
+	}
+
+	res := response.PaginationResponse(http.StatusOK, int(totalData), params.Page, params.Limit, logId, data)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (h *HandlerEvent) ScoreSubmission(ctx *gin.Context) {
+	var req dto.ScoreSubmissionRequest
+	authData := utils.GetAuthData(ctx)
+	userId := utils.InterfaceString(authData["user_id"])
+	logId := utils.GenerateLogId(ctx)
+	logPrefix := fmt.Sprintf("[%s][EventHandler][ScoreSubmission]", logId)
+
+	submissionId, err := utils.ValidateUUID(ctx, logId)
+	if err != nil {
+		return
+	}
+
+	if err := ctx.BindJSON(&req); err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; BindJSON ERROR: %s;", logPrefix, err.Error()))
+		res := response.Response(http.StatusBadRequest, messages.InvalidRequest, logId, nil)
+		res.Error = utils.ValidateError(err, reflect.TypeOf(req), "json")
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	data, err := h.Service.ScoreSubmission(submissionId, userId, req)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.ScoreSubmission; ERROR: %s;", logPrefix, err))
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			res := response.Response(http.StatusNotFound, messages.MsgNotFound, logId, nil)
+			res.Error = response.Errors{Code: http.StatusNotFound, Message: "submission not found"}
+			ctx.JSON(http.StatusNotFound, res)
+			return
+		}
+		response.WriteError(ctx, logId, err, http.StatusBadRequest, "")
+		return
+	}
+
+	res := response.Response(http.StatusOK, "Submission scored successfully", logId, data)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (h *HandlerEvent) ShortlistSubmission(ctx *gin.Context) {
+	var req dto.ShortlistSubmissionRequest
+	authData := utils.GetAuthData(ctx)
+	userId := utils.InterfaceString(authData["user_id"])
+	logId := utils.GenerateLogId(ctx)
+	logPrefix := fmt.Sprintf("[%s][EventHandler][ShortlistSubmission]", logId)
+
+	submissionId, err := utils.ValidateUUID(ctx, logId)
+	if err != nil {
+		return
+	}
+
+	if err := ctx.BindJSON(&req); err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; BindJSON ERROR: %s;", logPrefix, err.Error()))
+		res := response.Response(http.StatusBadRequest, messages.InvalidRequest, logId, nil)
+		res.Error = utils.ValidateError(err, reflect.TypeOf(req), "json")
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	data, err := h.Service.ShortlistSubmission(submissionId, userId, req.IsShortlisted)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.ShortlistSubmission; ERROR: %s;", logPrefix, err))
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			res := response.Response(http.StatusNotFound, messages.MsgNotFound, logId, nil)
+			res.Error = response.Errors{Code: http.StatusNotFound, Message: "submission not found"}
+			ctx.JSON(http.StatusNotFound, res)
+			return
+		}
+		response.WriteError(ctx, logId, err, http.StatusBadRequest, "")
+		return
+	}
+
+	res := response.Response(http.StatusOK, "Submission shortlist updated successfully", logId, data)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (h *HandlerEvent) SelectWinner(ctx *gin.Context) {
+	var req dto.SelectWinnerRequest
+	authData := utils.GetAuthData(ctx)
+	userId := utils.InterfaceString(authData["user_id"])
+	logId := utils.GenerateLogId(ctx)
+	logPrefix := fmt.Sprintf("[%s][EventHandler][SelectWinner]", logId)
+
+	eventId, err := utils.ValidateUUID(ctx, logId)
+	if err != nil {
+		return
+	}
+
+	if err := ctx.BindJSON(&req); err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; BindJSON ERROR: %s;", logPrefix, err.Error()))
+		res := response.Response(http.StatusBadRequest, messages.InvalidRequest, logId, nil)
+		res.Error = utils.ValidateError(err, reflect.TypeOf(req), "json")
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	data, err := h.Service.SelectWinner(eventId, userId, req)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.SelectWinner; ERROR: %s;", logPrefix, err))
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			res := response.Response(http.StatusNotFound, messages.MsgNotFound, logId, nil)
+			res.Error = response.Errors{Code: http.StatusNotFound, Message: "event or submission not found"}
+			ctx.JSON(http.StatusNotFound, res)
+			return
+		}
+		response.WriteError(ctx, logId, err, http.StatusBadRequest, "")
+		return
+	}
+
+	res := response.Response(http.StatusOK, "Winner selected successfully", logId, data)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (h *HandlerEvent) RevokeAward(ctx *gin.Context) {
+	var req dto.RevokeAwardRequest
+	authData := utils.GetAuthData(ctx)
+	userId := utils.InterfaceString(authData["user_id"])
+	logId := utils.GenerateLogId(ctx)
+	logPrefix := fmt.Sprintf("[%s][EventHandler][RevokeAward]", logId)
+
+	awardId, err := utils.ValidateUUID(ctx, logId)
+	if err != nil {
+		return
+	}
+
+	if err := ctx.BindJSON(&req); err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; BindJSON ERROR: %s;", logPrefix, err.Error()))
+		res := response.Response(http.StatusBadRequest, messages.InvalidRequest, logId, nil)
+		res.Error = utils.ValidateError(err, reflect.TypeOf(req), "json")
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	data, err := h.Service.RevokeAward(awardId, userId, req)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.RevokeAward; ERROR: %s;", logPrefix, err))
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			res := response.Response(http.StatusNotFound, messages.MsgNotFound, logId, nil)
+			res.Error = response.Errors{Code: http.StatusNotFound, Message: "award not found"}
+			ctx.JSON(http.StatusNotFound, res)
+			return
+		}
+		response.WriteError(ctx, logId, err, http.StatusBadRequest, "")
+		return
+	}
+
+	res := response.Response(http.StatusOK, "Award revoked successfully", logId, data)
+	logger.WriteLog(logger.LogLevelDebug, fmt.Sprintf("%s; Response: %+v;", logPrefix, utils.JsonEncode(data)))
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (h *HandlerEvent) GetEventHistory(ctx *gin.Context) {
+	logId := utils.GenerateLogId(ctx)
+	logPrefix := fmt.Sprintf("[%s][EventHandler][GetEventHistory]", logId)
+
+	eventId, err := utils.ValidateUUID(ctx, logId)
+	if err != nil {
+		return
+	}
+
+	data, err := h.Service.GetEventHistory(eventId)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.GetEventHistory; ERROR: %s;", logPrefix, err))
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			res := response.Response(http.StatusNotFound, messages.MsgNotFound, logId, nil)
+			res.Error = response.Errors{Code: http.StatusNotFound, Message: "event not found"}
+			ctx.JSON(http.StatusNotFound, res)
+			return
+		}
+		response.WriteError(ctx, logId, err, http.StatusInternalServerError, "")
+		return
+	}
+
+	res := response.Response(http.StatusOK, "success", logId, data)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (h *HandlerEvent) GetEventResult(ctx *gin.Context) {
+	authData := utils.GetAuthData(ctx)
+	userId := utils.InterfaceString(authData["user_id"])
+	logId := utils.GenerateLogId(ctx)
+	logPrefix := fmt.Sprintf("[%s][EventHandler][GetEventResult]", logId)
+
+	eventId, err := utils.ValidateUUID(ctx, logId)
+	if err != nil {
+		return
+	}
+
+	vendor, err := h.VendorRepo.GetVendorByUserID(userId)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; GetVendorByUserID; ERROR: %s;", logPrefix, err))
+		res := response.Response(http.StatusBadRequest, messages.MsgFail, logId, nil)
+		res.Error = "vendor profile not found"
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	data, err := h.Service.GetEventResult(eventId, vendor.Id)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.GetEventResult; ERROR: %s;", logPrefix, err))
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			res := response.Response(http.StatusNotFound, messages.MsgNotFound, logId, nil)
+			res.Error = response.Errors{Code: http.StatusNotFound, Message: "event not found"}
+			ctx.JSON(http.StatusNotFound, res)
+			return
+		}
+		response.WriteError(ctx, logId, err, http.StatusInternalServerError, "")
+		return
+	}
+
+	res := response.Response(http.StatusOK, "success", logId, data)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (h *HandlerEvent) GetEventResultForAdmin(ctx *gin.Context) {
+	authData := utils.GetAuthData(ctx)
+	userId := utils.InterfaceString(authData["user_id"])
+	logId := utils.GenerateLogId(ctx)
+	logPrefix := fmt.Sprintf("[%s][EventHandler][GetEventResultForAdmin]", logId)
+
+	eventId, err := utils.ValidateUUID(ctx, logId)
+	if err != nil {
+		return
+	}
+
+	data, err := h.Service.GetEventResultForAdmin(eventId, userId)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.GetEventResultForAdmin; ERROR: %s;", logPrefix, err))
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			res := response.Response(http.StatusNotFound, messages.MsgNotFound, logId, nil)
+			res.Error = response.Errors{Code: http.StatusNotFound, Message: "event not found"}
+			ctx.JSON(http.StatusNotFound, res)
+			return
+		}
+		response.WriteError(ctx, logId, err, http.StatusInternalServerError, "")
+		return
+	}
+
+	res := response.Response(http.StatusOK, "success", logId, data)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (h *HandlerEvent) UploadEventFile(ctx *gin.Context) {
+	authData := utils.GetAuthData(ctx)
+	userId := utils.InterfaceString(authData["user_id"])
+	logId := utils.GenerateLogId(ctx)
+	logPrefix := fmt.Sprintf("[%s][EventHandler][UploadEventFile]", logId)
+
+	eventId := ctx.Param("id")
+	if eventId == "" {
+		res := response.Response(http.StatusBadRequest, messages.InvalidRequest, logId, nil)
+		res.Error = "event id is required"
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	file, err := ctx.FormFile("file")
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; FormFile ERROR: %s;", logPrefix, err.Error()))
+		res := response.Response(http.StatusBadRequest, messages.InvalidRequest, logId, nil)
+		res.Error = "file is required"
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	fileType := ctx.PostForm("file_type")
+	if fileType == "" {
+		fileType = "document"
+	}
+	caption := ctx.PostForm("caption")
+
+	req := dto.UploadEventFileRequest{
+		FileType: fileType,
+		Caption:  caption,
+	}
+
+	data, err := h.Service.UploadEventFile(ctx.Request.Context(), eventId, userId, file, req)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.UploadEventFile; ERROR: %s;", logPrefix, err))
+		response.WriteError(ctx, logId, err, http.StatusInternalServerError, "")
+		return
+	}
+
+	res := response.Response(http.StatusOK, "file uploaded successfully", logId, data)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (h *HandlerEvent) DeleteEventFile(ctx *gin.Context) {
+	logId := utils.GenerateLogId(ctx)
+	logPrefix := fmt.Sprintf("[%s][EventHandler][DeleteEventFile]", logId)
+
+	fileId := ctx.Param("fileId")
+	if fileId == "" {
+		res := response.Response(http.StatusBadRequest, messages.InvalidRequest, logId, nil)
+		res.Error = "file id is required"
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	err := h.Service.DeleteEventFile(ctx.Request.Context(), fileId)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.DeleteEventFile; ERROR: %s;", logPrefix, err))
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			res := response.Response(http.StatusNotFound, messages.MsgNotFound, logId, nil)
+			res.Error = "file not found"
+			ctx.JSON(http.StatusNotFound, res)
+			return
+		}
+		response.WriteError(ctx, logId, err, http.StatusInternalServerError, "")
+		return
+	}
+
+	res := response.Response(http.StatusOK, "file deleted successfully", logId, nil)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (h *HandlerEvent) InviteVendors(ctx *gin.Context) {
+	var req dto.InviteVendorsRequest
+	authData := utils.GetAuthData(ctx)
+	userId := utils.InterfaceString(authData["user_id"])
+	logId := utils.GenerateLogId(ctx)
+	logPrefix := fmt.Sprintf("[%s][EventHandler][InviteVendors]", logId)
+
+	eventId, err := utils.ValidateUUID(ctx, logId)
+	if err != nil {
+		return
+	}
+
+	if err := ctx.BindJSON(&req); err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; BindJSON ERROR: %s;", logPrefix, err.Error()))
+		res := response.Response(http.StatusBadRequest, messages.InvalidRequest, logId, nil)
+		res.Error = utils.ValidateError(err, reflect.TypeOf(req), "json")
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+	logger.WriteLog(logger.LogLevelDebug, fmt.Sprintf("%s; Request: %+v;", logPrefix, utils.JsonEncode(req)))
+
+	data, err := h.Service.InviteVendors(eventId, userId, req)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.InviteVendors; ERROR: %s;", logPrefix, err))
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			res := response.Response(http.StatusNotFound, messages.MsgNotFound, logId, nil)
+			res.Error = response.Errors{Code: http.StatusNotFound, Message: "event not found"}
+			ctx.JSON(http.StatusNotFound, res)
+			return
+		}
+		response.WriteError(ctx, logId, err, http.StatusBadRequest, "")
+		return
+	}
+
+	res := response.Response(http.StatusOK, "Vendors invited successfully", logId, data)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (h *HandlerEvent) GetEventInvitations(ctx *gin.Context) {
+	logId := utils.GenerateLogId(ctx)
+	logPrefix := fmt.Sprintf("[%s][EventHandler][GetEventInvitations]", logId)
+
+	eventId, err := utils.ValidateUUID(ctx, logId)
+	if err != nil {
+		return
+	}
+
+	data, err := h.Service.GetEventInvitations(eventId)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.GetEventInvitations; ERROR: %s;", logPrefix, err))
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			res := response.Response(http.StatusNotFound, messages.MsgNotFound, logId, nil)
+			res.Error = response.Errors{Code: http.StatusNotFound, Message: "event not found"}
+			ctx.JSON(http.StatusNotFound, res)
+			return
+		}
+		response.WriteError(ctx, logId, err, http.StatusInternalServerError, "")
+		return
+	}
+
+	res := response.Response(http.StatusOK, "success", logId, data)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (h *HandlerEvent) RemoveInvitation(ctx *gin.Context) {
+	logId := utils.GenerateLogId(ctx)
+	logPrefix := fmt.Sprintf("[%s][EventHandler][RemoveInvitation]", logId)
+
+	eventId, err := utils.ValidateUUID(ctx, logId)
+	if err != nil {
+		return
+	}
+
+	vendorId := ctx.Param("vendorId")
+	if vendorId == "" {
+		res := response.Response(http.StatusBadRequest, messages.InvalidRequest, logId, nil)
+		res.Error = "vendor id is required"
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	if err := h.Service.RemoveInvitation(eventId, vendorId); err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.RemoveInvitation; ERROR: %s;", logPrefix, err))
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			res := response.Response(http.StatusNotFound, messages.MsgNotFound, logId, nil)
+			res.Error = response.Errors{Code: http.StatusNotFound, Message: "event not found"}
+			ctx.JSON(http.StatusNotFound, res)
+			return
+		}
+		response.WriteError(ctx, logId, err, http.StatusInternalServerError, "")
+		return
+	}
+
+	res := response.Response(http.StatusOK, "Invitation removed successfully", logId, nil)
+	ctx.JSON(http.StatusOK, res)
+}
+
+// EventOwners resolves the owners of the event addressed by the :id parameter for OwnershipMiddleware
+func (h *HandlerEvent) EventOwners(ctx *gin.Context) ([]string, error) {
+	id := ctx.Param("id")
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return h.Service.GetEventOwners(id)
+}
+
+// SubmissionOwners resolves the owners of the event of the submission addressed by the :id parameter
+func (h *HandlerEvent) SubmissionOwners(ctx *gin.Context) ([]string, error) {
+	id := ctx.Param("id")
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return h.Service.GetSubmissionOwners(id)
+}
+
+// SubmissionFileOwners resolves the owners of the event of the submission file addressed by the :id parameter
+func (h *HandlerEvent) SubmissionFileOwners(ctx *gin.Context) ([]string, error) {
+	id := ctx.Param("id")
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return h.Service.GetSubmissionFileOwners(id)
+}
+
+// AwardOwners resolves the owners of the event of the award addressed by the :id parameter
+func (h *HandlerEvent) AwardOwners(ctx *gin.Context) ([]string, error) {
+	id := ctx.Param("id")
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return h.Service.GetAwardOwners(id)
+}
+
+// QuestionOwners resolves the owners of the event of the question addressed by the :id parameter
+func (h *HandlerEvent) QuestionOwners(ctx *gin.Context) ([]string, error) {
+	id := ctx.Param("id")
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return h.Service.GetQuestionOwners(id)
+}
+
+func (h *HandlerEvent) GetEventTeam(ctx *gin.Context) {
+	logId := utils.GenerateLogId(ctx)
+	logPrefix := fmt.Sprintf("[%s][EventHandler][GetEventTeam]", logId)
+
+	eventId, err := utils.ValidateUUID(ctx, logId)
+	if err != nil {
+		return
+	}
+
+	data, err := h.Service.GetEventTeam(eventId)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.GetEventTeam; ERROR: %s;", logPrefix, err))
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			res := response.Response(http.StatusNotFound, messages.MsgNotFound, logId, nil)
+			res.Error = response.Errors{Code: http.StatusNotFound, Message: "event not found"}
+			ctx.JSON(http.StatusNotFound, res)
+			return
+		}
+		response.WriteError(ctx, logId, err, http.StatusInternalServerError, "")
+		return
+	}
+
+	res := response.Response(http.StatusOK, messages.MsgSuccess, logId, data)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (h *HandlerEvent) AddEventTeamMembers(ctx *gin.Context) {
+	var req dto.AddEventTeamMembersRequest
+	authData := utils.GetAuthData(ctx)
+	userId := utils.InterfaceString(authData["user_id"])
+	logId := utils.GenerateLogId(ctx)
+	logPrefix := fmt.Sprintf("[%s][EventHandler][AddEventTeamMembers]", logId)
+
+	eventId, err := utils.ValidateUUID(ctx, logId)
+	if err != nil {
+		return
+	}
+
+	if err := ctx.BindJSON(&req); err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; BindJSON ERROR: %s;", logPrefix, err.Error()))
+		res := response.Response(http.StatusBadRequest, messages.InvalidRequest, logId, nil)
+		res.Error = utils.ValidateError(err, reflect.TypeOf(req), "json")
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	data, err := h.Service.AddEventTeamMembers(eventId, userId, req)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.AddEventTeamMembers; ERROR: %s;", logPrefix, err))
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			res := response.Response(http.StatusNotFound, messages.MsgNotFound, logId, nil)
+			res.Error = response.Errors{Code: http.StatusNotFound, Message: "event not found"}
+			ctx.JSON(http.StatusNotFound, res)
+			return
+		}
+		response.WriteError(ctx, logId, err, http.StatusBadRequest, "")
+		return
+	}
+
+	res := response.Response(http.StatusOK, "Team members added successfully", logId, data)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (h *HandlerEvent) RemoveEventTeamMember(ctx *gin.Context) {
+	logId := utils.GenerateLogId(ctx)
+	logPrefix := fmt.Sprintf("[%s][EventHandler][RemoveEventTeamMember]", logId)
+
+	eventId, err := utils.ValidateUUID(ctx, logId)
+	if err != nil {
+		return
+	}
+
+	memberUserId := ctx.Param("userId")
+	if memberUserId == "" {
+		res := response.Response(http.StatusBadRequest, messages.InvalidRequest, logId, nil)
+		res.Error = "user id is required"
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	if err := h.Service.RemoveEventTeamMember(eventId, memberUserId); err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.RemoveEventTeamMember; ERROR: %s;", logPrefix, err))
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			res := response.Response(http.StatusNotFound, messages.MsgNotFound, logId, nil)
+			res.Error = response.Errors{Code: http.StatusNotFound, Message: "team member not found"}
+			ctx.JSON(http.StatusNotFound, res)
+			return
+		}
+		response.WriteError(ctx, logId, err, http.StatusInternalServerError, "")
+		return
+	}
+
+	res := response.Response(http.StatusOK, "Team member removed successfully", logId, nil)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (h *HandlerEvent) OpenBids(ctx *gin.Context) {
+	var req dto.OpenBidsRequest
+	authData := utils.GetAuthData(ctx)
+	userId := utils.InterfaceString(authData["user_id"])
+	logId := utils.GenerateLogId(ctx)
+	logPrefix := fmt.Sprintf("[%s][EventHandler][OpenBids]", logId)
+
+	eventId, err := utils.ValidateUUID(ctx, logId)
+	if err != nil {
+		return
+	}
+
+	// Body is optional; only a note can be sent
+	if err := ctx.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; BindJSON ERROR: %s;", logPrefix, err.Error()))
+		res := response.Response(http.StatusBadRequest, messages.InvalidRequest, logId, nil)
+		res.Error = utils.ValidateError(err, reflect.TypeOf(req), "json")
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	data, err := h.Service.OpenBids(eventId, userId, req)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.OpenBids; ERROR: %s;", logPrefix, err))
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			res := response.Response(http.StatusNotFound, messages.MsgNotFound, logId, nil)
+			res.Error = response.Errors{Code: http.StatusNotFound, Message: "event not found"}
+			ctx.JSON(http.StatusNotFound, res)
+			return
+		}
+		response.WriteError(ctx, logId, err, http.StatusBadRequest, "")
+		return
+	}
+
+	res := response.Response(http.StatusOK, "Bid opening approved", logId, data)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (h *HandlerEvent) GetBidOpeningStatus(ctx *gin.Context) {
+	logId := utils.GenerateLogId(ctx)
+	logPrefix := fmt.Sprintf("[%s][EventHandler][GetBidOpeningStatus]", logId)
+
+	eventId, err := utils.ValidateUUID(ctx, logId)
+	if err != nil {
+		return
+	}
+
+	data, err := h.Service.GetBidOpeningStatus(eventId)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.GetBidOpeningStatus; ERROR: %s;", logPrefix, err))
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			res := response.Response(http.StatusNotFound, messages.MsgNotFound, logId, nil)
+			res.Error = response.Errors{Code: http.StatusNotFound, Message: "event not found"}
+			ctx.JSON(http.StatusNotFound, res)
+			return
+		}
+		response.WriteError(ctx, logId, err, http.StatusInternalServerError, "")
+		return
+	}
+
+	res := response.Response(http.StatusOK, "success", logId, data)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (h *HandlerEvent) DownloadSubmissionFile(ctx *gin.Context) {
+	h.downloadSubmissionFile(ctx, false)
+}
+
+func (h *HandlerEvent) DownloadMySubmissionFile(ctx *gin.Context) {
+	h.downloadSubmissionFile(ctx, true)
+}
+
+// downloadSubmissionFile streams a submission file, decrypting sealed files on the way out.
+// Vendors can only download files of their own submission.
+func (h *HandlerEvent) downloadSubmissionFile(ctx *gin.Context, ownOnly bool) {
+	logId := utils.GenerateLogId(ctx)
+	logPrefix := fmt.Sprintf("[%s][EventHandler][DownloadSubmissionFile]", logId)
+
+	fileId, err := utils.ValidateUUID(ctx, logId)
+	if err != nil {
+		return
+	}
+
+	vendorId := ""
+	if ownOnly {
+		authData := utils.GetAuthData(ctx)
+		userId := utils.InterfaceString(authData["user_id"])
+		vendor, err := h.VendorRepo.GetVendorByUserID(userId)
+		if err != nil {
+			logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; GetVendorByUserID; ERROR: %s;", logPrefix, err))
+			res := response.Response(http.StatusBadRequest, messages.MsgFail, logId, nil)
+			res.Error = "vendor profile not found"
+			ctx.JSON(http.StatusBadRequest, res)
+			return
+		}
+		vendorId = vendor.Id
+	}
+
+	data, file, err := h.Service.DownloadSubmissionFile(ctx.Request.Context(), fileId, vendorId)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.DownloadSubmissionFile; ERROR: %s;", logPrefix, err))
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			res := response.Response(http.StatusNotFound, messages.MsgNotFound, logId, nil)
+			res.Error = response.Errors{Code: http.StatusNotFound, Message: "file not found"}
+			ctx.JSON(http.StatusNotFound, res)
+			return
+		}
+		response.WriteError(ctx, logId, err, http.StatusInternalServerError, "")
+		return
+	}
+
+	contentType := file.MimeType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	filename := strings.ReplaceAll(file.FileName, "\"", "")
+	if filename == "" {
+		filename = file.ID
+	}
+
+	ctx.Header("Content-Type", contentType)
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", filename))
+	ctx.Data(http.StatusOK, contentType, data)
+}
+
+func (h *HandlerEvent) GetEventBudget(ctx *gin.Context) {
+	logId := utils.GenerateLogId(ctx)
+	logPrefix := fmt.Sprintf("[%s][EventHandler][GetEventBudget]", logId)
+
+	eventId, err := utils.ValidateUUID(ctx, logId)
+	if err != nil {
+		return
+	}
+
+	data, err := h.Service.GetEventBudget(eventId)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.GetEventBudget; ERROR: %s;", logPrefix, err))
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			res := response.Response(http.StatusNotFound, messages.MsgNotFound, logId, nil)
+			res.Error = response.Errors{Code: http.StatusNotFound, Message: "event not found"}
+			ctx.JSON(http.StatusNotFound, res)
+			return
+		}
+		response.WriteError(ctx, logId, err, http.StatusInternalServerError, "")
+		return
+	}
+
+	res := response.Response(http.StatusOK, "success", logId, data)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (h *HandlerEvent) SetEventBudget(ctx *gin.Context) {
+	var req dto.SetEventBudgetRequest
+	authData := utils.GetAuthData(ctx)
+	userId := utils.InterfaceString(authData["user_id"])
+	logId := utils.GenerateLogId(ctx)
+	logPrefix := fmt.Sprintf("[%s][EventHandler][SetEventBudget]", logId)
+
+	eventId, err := utils.ValidateUUID(ctx, logId)
+	if err != nil {
+		return
+	}
+
+	if err := ctx.BindJSON(&req); err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; BindJSON ERROR: %s;", logPrefix, err.Error()))
+		res := response.Response(http.StatusBadRequest, messages.InvalidRequest, logId, nil)
+		res.Error = utils.ValidateError(err, reflect.TypeOf(req), "json")
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	data, err := h.Service.SetEventBudget(eventId, userId, req)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.SetEventBudget; ERROR: %s;", logPrefix, err))
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			res := response.Response(http.StatusNotFound, messages.MsgNotFound, logId, nil)
+			res.Error = response.Errors{Code: http.StatusNotFound, Message: "event not found"}
+			ctx.JSON(http.StatusNotFound, res)
+			return
+		}
+		response.WriteError(ctx, logId, err, http.StatusBadRequest, "")
+		return
+	}
+
+	res := response.Response(http.StatusOK, "Event budget updated successfully", logId, data)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (h *HandlerEvent) SetBoqItems(ctx *gin.Context) {
+	var req dto.SetBoqItemsRequest
+	authData := utils.GetAuthData(ctx)
+	userId := utils.InterfaceString(authData["user_id"])
+	logId := utils.GenerateLogId(ctx)
+	logPrefix := fmt.Sprintf("[%s][EventHandler][SetBoqItems]", logId)
+
+	eventId, err := utils.ValidateUUID(ctx, logId)
+	if err != nil {
+		return
+	}
+
+	if err := ctx.BindJSON(&req); err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; BindJSON ERROR: %s;", logPrefix, err.Error()))
+		res := response.Response(http.StatusBadRequest, messages.InvalidRequest, logId, nil)
+		res.Error = utils.ValidateError(err, reflect.TypeOf(req), "json")
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+	logger.WriteLog(logger.LogLevelDebug, fmt.Sprintf("%s; Request: %+v;", logPrefix, utils.JsonEncode(req)))
+
+	data, err := h.Service.SetBoqItems(eventId, userId, req)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.SetBoqItems; ERROR: %s;", logPrefix, err))
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			res := response.Response(http.StatusNotFound, messages.MsgNotFound, logId, nil)
+			res.Error = response.Errors{Code: http.StatusNotFound, Message: "event not found"}
+			ctx.JSON(http.StatusNotFound, res)
+			return
+		}
+		response.WriteError(ctx, logId, err, http.StatusBadRequest, "")
+		return
+	}
+
+	res := response.Response(http.StatusOK, "Bill of quantities saved successfully", logId, data)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (h *HandlerEvent) GetBoqItems(ctx *gin.Context) {
+	authData := utils.GetAuthData(ctx)
+	userId := utils.InterfaceString(authData["user_id"])
+	userRole := utils.InterfaceString(authData["role"])
+	logId := utils.GenerateLogId(ctx)
+	logPrefix := fmt.Sprintf("[%s][EventHandler][GetBoqItems]", logId)
+
+	eventId, err := utils.ValidateUUID(ctx, logId)
+	if err != nil {
+		return
+	}
+
+	// Vendors get their own view, which never has the owner estimates
+	vendorId := ""
+	if userRole == utils.RoleVendor {
+		vendor, err := h.VendorRepo.GetVendorByUserID(userId)
+		if err != nil {
+			logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; GetVendorByUserID; ERROR: %s;", logPrefix, err))
+			res := response.Response(http.StatusBadRequest, messages.MsgFail, logId, nil)
+			res.Error = "vendor profile not found"
+			ctx.JSON(http.StatusBadRequest, res)
+			return
+		}
+		vendorId = vendor.Id
+	}
+
+	data, err := h.Service.GetBoqItems(eventId, userId, vendorId)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.GetBoqItems; ERROR: %s;", logPrefix, err))
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			res := response.Response(http.StatusNotFound, messages.MsgNotFound, logId, nil)
+			res.Error = response.Errors{Code: http.StatusNotFound, Message: "event not found"}
+			ctx.JSON(http.StatusNotFound, res)
+			return
+		}
+		response.WriteError(ctx, logId, err, http.StatusInternalServerError, "")
+		return
+	}
+
+	res := response.Response(http.StatusOK, "success", logId, data)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (h *HandlerEvent) GetQuotationComparison(ctx *gin.Context) {
+	authData := utils.GetAuthData(ctx)
+	userId := utils.InterfaceString(authData["user_id"])
+	logId := utils.GenerateLogId(ctx)
+	logPrefix := fmt.Sprintf("[%s][EventHandler][GetQuotationComparison]", logId)
+
+	eventId, err := utils.ValidateUUID(ctx, logId)
+	if err != nil {
+		return
+	}
+
+	data, err := h.Service.GetQuotationComparison(eventId, userId)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.GetQuotationComparison; ERROR: %s;", logPrefix, err))
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			res := response.Response(http.StatusNotFound, messages.MsgNotFound, logId, nil)
+			res.Error = response.Errors{Code: http.StatusNotFound, Message: "event not found"}
+			ctx.JSON(http.StatusNotFound, res)
+			return
+		}
+		response.WriteError(ctx, logId, err, http.StatusInternalServerError, "")
+		return
+	}
+
+	res := response.Response(http.StatusOK, "success", logId, data)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (h *HandlerEvent) ExportQuotationComparison(ctx *gin.Context) {
+	authData := utils.GetAuthData(ctx)
+	userId := utils.InterfaceString(authData["user_id"])
+	logId := utils.GenerateLogId(ctx)
+	logPrefix := fmt.Sprintf("[%s][EventHandler][ExportQuotationComparison]", logId)
+
+	eventId, err := utils.ValidateUUID(ctx, logId)
+	if err != nil {
+		return
+	}
+
+	fileBytes, filename, err := h.Service.GenerateQuotationComparisonXLSX(eventId, userId)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.GenerateQuotationComparisonXLSX; ERROR: %s;", logPrefix, err))
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			res := response.Response(http.StatusNotFound, messages.MsgNotFound, logId, nil)
+			res.Error = response.Errors{Code: http.StatusNotFound, Message: "event not found"}
+			ctx.JSON(http.StatusNotFound, res)
+			return
+		}
+		response.WriteError(ctx, logId, err, http.StatusInternalServerError, "")
+		return
+	}
+
+	ctx.Header("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", filename))
+	ctx.Data(http.StatusOK, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", fileBytes)
+}
+
+func (h *HandlerEvent) ExportSubmissions(ctx *gin.Context) {
+	logId := utils.GenerateLogId(ctx)
+	logPrefix := fmt.Sprintf("[%s][EventHandler][ExportSubmissions]", logId)
+
+	eventId, err := utils.ValidateUUID(ctx, logId)
+	if err != nil {
+		return
+	}
+
+	fileBytes, filename, err := h.Service.GenerateSubmissionsXLSX(eventId)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.GenerateSubmissionsXLSX; ERROR: %s;", logPrefix, err))
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			res := response.Response(http.StatusNotFound, messages.MsgNotFound, logId, nil)
+			res.Error = response.Errors{Code: http.StatusNotFound, Message: "event not found"}
+			ctx.JSON(http.StatusNotFound, res)
+			return
+		}
+		response.WriteError(ctx, logId, err, http.StatusInternalServerError, "")
+		return
+	}
+
+	ctx.Header("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", filename))
+	ctx.Data(http.StatusOK, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", fileBytes)
+}
+
+func (h *HandlerEvent) AskQuestion(ctx *gin.Context) {
+	var req dto.AskQuestionRequest
+	authData := utils.GetAuthData(ctx)
+	userId := utils.InterfaceString(authData["user_id"])
+	logId := utils.GenerateLogId(ctx)
+	logPrefix := fmt.Sprintf("[%s][EventHandler][AskQuestion]", logId)
+
+	eventId, err := utils.ValidateUUID(ctx, logId)
+	if err != nil {
+		return
+	}
+
+	if err := ctx.BindJSON(&req); err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; BindJSON ERROR: %s;", logPrefix, err.Error()))
+		res := response.Response(http.StatusBadRequest, messages.InvalidRequest, logId, nil)
+		res.Error = utils.ValidateError(err, reflect.TypeOf(req), "json")
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	vendor, err := h.VendorRepo.GetVendorByUserID(userId)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; GetVendorByUserID; ERROR: %s;", logPrefix, err))
+		res := response.Response(http.StatusBadRequest, messages.MsgFail, logId, nil)
+		res.Error = "vendor profile not found"
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	data, err := h.Service.AskQuestion(eventId, vendor.Id, req)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.AskQuestion; ERROR: %s;", logPrefix, err))
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			res := response.Response(http.StatusNotFound, messages.MsgNotFound, logId, nil)
+			res.Error = response.Errors{Code: http.StatusNotFound, Message: "event not found"}
+			ctx.JSON(http.StatusNotFound, res)
+			return
+		}
+		response.WriteError(ctx, logId, err, http.StatusBadRequest, "")
+		return
+	}
+
+	res := response.Response(http.StatusCreated, "Question submitted successfully", logId, data)
+	ctx.JSON(http.StatusCreated, res)
+}
+
+func (h *HandlerEvent) GetVendorQuestions(ctx *gin.Context) {
+	authData := utils.GetAuthData(ctx)
+	userId := utils.InterfaceString(authData["user_id"])
+	logId := utils.GenerateLogId(ctx)
+	logPrefix := fmt.Sprintf("[%s][EventHandler][GetVendorQuestions]", logId)
+
+	eventId, err := utils.ValidateUUID(ctx, logId)
+	if err != nil {
+		return
+	}
+
+	vendor, err := h.VendorRepo.GetVendorByUserID(userId)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; GetVendorByUserID; ERROR: %s;", logPrefix, err))
+		res := response.Response(http.StatusBadRequest, messages.MsgFail, logId, nil)
+		res.Error = "vendor profile not found"
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	data, err := h.Service.GetVendorQuestions(eventId, vendor.Id)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.GetVendorQuestions; ERROR: %s;", logPrefix, err))
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			res := response.Response(http.StatusNotFound, messages.MsgNotFound, logId, nil)
+			res.Error = response.Errors{Code: http.StatusNotFound, Message: "event not found"}
+			ctx.JSON(http.StatusNotFound, res)
+			return
+		}
+		response.WriteError(ctx, logId, err, http.StatusInternalServerError, "")
+		return
+	}
+
+	res := response.Response(http.StatusOK, "success", logId, data)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (h *HandlerEvent) GetEventQuestions(ctx *gin.Context) {
+	logId := utils.GenerateLogId(ctx)
+	logPrefix := fmt.Sprintf("[%s][EventHandler][GetEventQuestions]", logId)
+
+	eventId, err := utils.ValidateUUID(ctx, logId)
+	if err != nil {
+		return
+	}
+
+	params, _ := filter.GetBaseParams(ctx, "created_at", "asc", 10)
+	params.Filters = filter.WhitelistFilter(params.Filters, []string{"status", "visibility", "vendor_id"})
+
+	data, totalData, err := h.Service.GetEventQuestions(eventId, params)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.GetEventQuestions; ERROR: %s;", logPrefix, err))
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			res := response.Response(http.StatusNotFound, messages.MsgNotFound, logId, nil)
+			res.Error = response.Errors{Code: http.StatusNotFound, Message: "event not found"}
+			ctx.JSON(http.StatusNotFound, res)
+			return
+		}
+		response.WriteError(ctx, logId, err, http.StatusInternalServerError, "")
+		return
+	}
+
+	res := response.PaginationResponse(http.StatusOK, int(totalData), params.Page, params.Limit, logId, data)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (h *HandlerEvent) AnswerQuestion(ctx *gin.Context) {
+	var req dto.AnswerQuestionRequest
+	authData := utils.GetAuthData(ctx)
+	userId := utils.InterfaceString(authData["user_id"])
+	logId := utils.GenerateLogId(ctx)
+	logPrefix := fmt.Sprintf("[%s][EventHandler][AnswerQuestion]", logId)
+
+	questionId, err := utils.ValidateUUID(ctx, logId)
+	if err != nil {
+		return
+	}
+
+	if err := ctx.BindJSON(&req); err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; BindJSON ERROR: %s;", logPrefix, err.Error()))
+		res := response.Response(http.StatusBadRequest, messages.InvalidRequest, logId, nil)
+		res.Error = utils.ValidateError(err, reflect.TypeOf(req), "json")
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+	logger.WriteLog(logger.LogLevelDebug, fmt.Sprintf("%s; Request: %+v;", logPrefix, utils.JsonEncode(req)))
+
+	data, err := h.Service.AnswerQuestion(questionId, userId, req)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.AnswerQuestion; ERROR: %s;", logPrefix, err))
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			res := response.Response(http.StatusNotFound, messages.MsgNotFound, logId, nil)
+			res.Error = response.Errors{Code: http.StatusNotFound, Message: "question not found"}
+			ctx.JSON(http.StatusNotFound, res)
+			return
+		}
+		response.WriteError(ctx, logId, err, http.StatusBadRequest, "")
+		return
+	}
+
+	res := response.Response(http.StatusOK, "Question answered successfully", logId, data)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (h *HandlerEvent) IssueAddendum(ctx *gin.Context) {
+	var req dto.IssueAddendumRequest
+	authData := utils.GetAuthData(ctx)
+	userId := utils.InterfaceString(authData["user_id"])
+	logId := utils.GenerateLogId(ctx)
+	logPrefix := fmt.Sprintf("[%s][EventHandler][IssueAddendum]", logId)
+
+	eventId, err := utils.ValidateUUID(ctx, logId)
+	if err != nil {
+		return
+	}
+
+	if err := ctx.ShouldBind(&req); err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; ShouldBind ERROR: %s;", logPrefix, err.Error()))
+		res := response.Response(http.StatusBadRequest, messages.InvalidRequest, logId, nil)
+		res.Error = utils.ValidateError(err, reflect.TypeOf(req), "form")
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	var files []*multipart.FileHeader
+	if form, err := ctx.MultipartForm(); err == nil {
+		files = form.File["files"]
+	}
+
+	data, err := h.Service.IssueAddendum(ctx.Request.Context(), eventId, userId, req, files)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.IssueAddendum; ERROR: %s;", logPrefix, err))
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			res := response.Response(http.StatusNotFound, messages.MsgNotFound, logId, nil)
+			res.Error = response.Errors{Code: http.StatusNotFound, Message: "event not found"}
+			ctx.JSON(http.StatusNotFound, res)
+			return
+		}
+		response.WriteError(ctx, logId, err, http.StatusBadRequest, "")
+		return
+	}
+
+	res := response.Response(http.StatusCreated, "Addendum issued successfully", logId, data)
+	logger.WriteLog(logger.LogLevelDebug, fmt.Sprintf("%s; Response: %+v;", logPrefix, utils.JsonEncode(data)))
+	ctx.JSON(http.StatusCreated, res)
+}
+
+func (h *HandlerEvent) GetAddenda(ctx *gin.Context) {
+	authData := utils.GetAuthData(ctx)
+	userId := utils.InterfaceString(authData["user_id"])
+	userRole := utils.InterfaceString(authData["role"])
+	logId := utils.GenerateLogId(ctx)
+	logPrefix := fmt.Sprintf("[%s][EventHandler][GetAddenda]", logId)
+
+	eventId, err := utils.ValidateUUID(ctx, logId)
+	if err != nil {
+		return
+	}
+
+	// Vendors only see their own acknowledgements
+	vendorId := ""
+	if userRole == utils.RoleVendor {
+		vendor, err := h.VendorRepo.GetVendorByUserID(userId)
+		if err != nil {
+			logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; GetVendorByUserID; ERROR: %s;", logPrefix, err))
+			res := response.Response(http.StatusBadRequest, messages.MsgFail, logId, nil)
+			res.Error = "vendor profile not found"
+			ctx.JSON(http.StatusBadRequest, res)
+			return
+		}
+		vendorId = vendor.Id
+	}
+
+	data, err := h.Service.GetAddenda(eventId, vendorId)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.GetAddenda; ERROR: %s;", logPrefix, err))
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			res := response.Response(http.StatusNotFound, messages.MsgNotFound, logId, nil)
+			res.Error = response.Errors{Code: http.StatusNotFound, Message: "event not found"}
+			ctx.JSON(http.StatusNotFound, res)
+			return
+		}
+		response.WriteError(ctx, logId, err, http.StatusInternalServerError, "")
+		return
+	}
+
+	res := response.Response(http.StatusOK, "success", logId, data)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (h *HandlerEvent) AcknowledgeAddendum(ctx *gin.Context) {
+	authData := utils.GetAuthData(ctx)
+	userId := utils.InterfaceString(authData["user_id"])
+	logId := utils.GenerateLogId(ctx)
+	logPrefix := fmt.Sprintf("[%s][EventHandler][AcknowledgeAddendum]", logId)
+
+	addendumId, err := utils.ValidateUUID(ctx, logId)
+	if err != nil {
+		return
+	}
+
+	vendor, err := h.VendorRepo.GetVendorByUserID(userId)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; GetVendorByUserID; ERROR: %s;", logPrefix, err))
+		res := response.Response(http.StatusBadRequest, messages.MsgFail, logId, nil)
+		res.Error = "vendor profile not found"
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	data, err := h.Service.AcknowledgeAddendum(addendumId, vendor.Id, userId)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.AcknowledgeAddendum; ERROR: %s;", logPrefix, err))
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			res := response.Response(http.StatusNotFound, messages.MsgNotFound, logId, nil)
+			res.Error = response.Errors{Code: http.StatusNotFound, Message: "addendum not found"}
+			ctx.JSON(http.StatusNotFound, res)
+			return
+		}
+		response.WriteError(ctx, logId, err, http.StatusInternalServerError, "")
+		return
+	}
+
+	res := response.Response(http.StatusOK, "Addendum acknowledged", logId, data)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (h *HandlerEvent) PlaceAuctionBid(ctx *gin.Context) {
+	var req dto.PlaceAuctionBidRequest
+	authData := utils.GetAuthData(ctx)
+	userId := utils.InterfaceString(authData["user_id"])
+	logId := utils.GenerateLogId(ctx)
+	logPrefix := fmt.Sprintf("[%s][EventHandler][PlaceAuctionBid]", logId)
+
+	eventId, err := utils.ValidateUUID(ctx, logId)
+	if err != nil {
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; BindJSON ERROR: %s;", logPrefix, err.Error()))
+		res := response.Response(http.StatusBadRequest, messages.InvalidRequest, logId, nil)
+		res.Error = utils.ValidateError(err, reflect.TypeOf(req), "json")
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	vendor, err := h.VendorRepo.GetVendorByUserID(userId)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; GetVendorByUserID; ERROR: %s;", logPrefix, err))
+		res := response.Response(http.StatusBadRequest, messages.MsgFail, logId, nil)
+		res.Error = "vendor profile not found"
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	data, err := h.Service.PlaceAuctionBid(eventId, vendor.Id, userId, req)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.PlaceAuctionBid; ERROR: %s;", logPrefix, err))
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			res := response.Response(http.StatusNotFound, messages.MsgNotFound, logId, nil)
+			res.Error = response.Errors{Code: http.StatusNotFound, Message: "event not found"}
+			ctx.JSON(http.StatusNotFound, res)
+			return
+		}
+		response.WriteError(ctx, logId, err, http.StatusBadRequest, "")
+		return
+	}
+
+	res := response.Response(http.StatusCreated, "Bid placed successfully", logId, data)
+	ctx.JSON(http.StatusCreated, res)
+}
+
+func (h *HandlerEvent) GetAuctionStatus(ctx *gin.Context) {
+	authData := utils.GetAuthData(ctx)
+	userId := utils.InterfaceString(authData["user_id"])
+	logId := utils.GenerateLogId(ctx)
+	logPrefix := fmt.Sprintf("[%s][EventHandler][GetAuctionStatus]", logId)
+
+	eventId, err := utils.ValidateUUID(ctx, logId)
+	if err != nil {
+		return
+	}
+
+	vendor, err := h.VendorRepo.GetVendorByUserID(userId)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; GetVendorByUserID; ERROR: %s;", logPrefix, err))
+		res := response.Response(http.StatusBadRequest, messages.MsgFail, logId, nil)
+		res.Error = "vendor profile not found"
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	data, err := h.Service.GetAuctionStatus(eventId, vendor.Id)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.GetAuctionStatus; ERROR: %s;", logPrefix, err))
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			res := response.Response(http.StatusNotFound, messages.MsgNotFound, logId, nil)
+			res.Error = response.Errors{Code: http.StatusNotFound, Message: "event not found"}
+			ctx.JSON(http.StatusNotFound, res)
+			return
+		}
+		response.WriteError(ctx, logId, err, http.StatusBadRequest, "")
+		return
+	}
+
+	res := response.Response(http.StatusOK, "success", logId, data)
+	ctx.JSON(http.StatusOK, res)
+}
+
+// StreamAuctionStatus pushes the vendor's auction view over server-sent events whenever a bid changes it
+func (h *HandlerEvent) StreamAuctionStatus(ctx *gin.Context) {
+	authData := utils.GetAuthData(ctx)
+	userId := utils.InterfaceString(authData["user_id"])
+	logId := utils.GenerateLogId(ctx)
+	logPrefix := fmt.Sprintf("[%s][EventHandler][StreamAuctionStatus]", logId)
+
+	eventId, err := utils.ValidateUUID(ctx, logId)
+	if err != nil {
+		return
+	}
+
+	vendor, err := h.VendorRepo.GetVendorByUserID(userId)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; GetVendorByUserID; ERROR: %s;", logPrefix, err))
+		res := response.Response(http.StatusBadRequest, messages.MsgFail, logId, nil)
+		res.Error = "vendor profile not found"
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	h.streamAuction(ctx, logId, logPrefix, eventId, func() (domainevents.AuctionStatus, error) {
+		return h.Service.GetAuctionStatus(eventId, vendor.Id)
+	})
+}
+
+func (h *HandlerEvent) GetAuctionLeaderboard(ctx *gin.Context) {
+	logId := utils.GenerateLogId(ctx)
+	logPrefix := fmt.Sprintf("[%s][EventHandler][GetAuctionLeaderboard]", logId)
+
+	eventId, err := utils.ValidateUUID(ctx, logId)
+	if err != nil {
+		return
+	}
+
+	data, err := h.Service.GetAuctionLeaderboard(eventId)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.GetAuctionLeaderboard; ERROR: %s;", logPrefix, err))
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			res := response.Response(http.StatusNotFound, messages.MsgNotFound, logId, nil)
+			res.Error = response.Errors{Code: http.StatusNotFound, Message: "event not found"}
+			ctx.JSON(http.StatusNotFound, res)
+			return
+		}
+		response.WriteError(ctx, logId, err, http.StatusBadRequest, "")
+		return
+	}
+
+	res := response.Response(http.StatusOK, "success", logId, data)
+	ctx.JSON(http.StatusOK, res)
+}
+
+// StreamAuctionLeaderboard pushes the full leaderboard to the event owner over server-sent events
+func (h *HandlerEvent) StreamAuctionLeaderboard(ctx *gin.Context) {
+	logId := utils.GenerateLogId(ctx)
+	logPrefix := fmt.Sprintf("[%s][EventHandler][StreamAuctionLeaderboard]", logId)
+
+	eventId, err := utils.ValidateUUID(ctx, logId)
+	if err != nil {
+		return
+	}
+
+	h.streamAuction(ctx, logId, logPrefix, eventId, func() (domainevents.AuctionStatus, error) {
+		return h.Service.GetAuctionLeaderboard(eventId)
+	})
+}
+
+// streamAuction sends a fresh view on connect, on every auction change and on a heartbeat,
+// so clients keep an accurate countdown even when nobody bids
+func (h *HandlerEvent) streamAuction(ctx *gin.Context, logId uuid.UUID, logPrefix, eventId string, view func() (domainevents.AuctionStatus, error)) {
+	status, err := view()
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; view; ERROR: %s;", logPrefix, err))
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			res := response.Response(http.StatusNotFound, messages.MsgNotFound, logId, nil)
+			res.Error = response.Errors{Code: http.StatusNotFound, Message: "event not found"}
+			ctx.JSON(http.StatusNotFound, res)
+			return
+		}
+		response.WriteError(ctx, logId, err, http.StatusBadRequest, "")
+		return
+	}
+
+	updates, unsubscribe := h.Service.SubscribeAuction(eventId)
+	defer unsubscribe()
+
+	ctx.Header("Content-Type", "text/event-stream")
+	ctx.Header("Cache-Control", "no-cache")
+	ctx.Header("Connection", "keep-alive")
+	ctx.Header("X-Accel-Buffering", "no")
+
+	heartbeat := time.NewTicker(auctionHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		ctx.SSEvent("auction", status)
+		ctx.Writer.Flush()
+
+		select {
+		case <-ctx.Request.Context().Done():
+			return
+		case <-updates:
+		case <-heartbeat.C:
+		}
+
+		if status, err = view(); err != nil {
+			logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; view; ERROR: %s;", logPrefix, err))
+			return
+		}
+	}
+}
+
+func (h *HandlerEvent) CloseAuction(ctx *gin.Context) {
+	authData := utils.GetAuthData(ctx)
+	userId := utils.InterfaceString(authData["user_id"])
+	logId := utils.GenerateLogId(ctx)
+	logPrefix := fmt.Sprintf("[%s][EventHandler][CloseAuction]", logId)
+
+	eventId, err := utils.ValidateUUID(ctx, logId)
+	if err != nil {
+		return
+	}
+
+	data, err := h.Service.CloseAuction(eventId, userId)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.CloseAuction; ERROR: %s;", logPrefix, err))
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			res := response.Response(http.StatusNotFound, messages.MsgNotFound, logId, nil)
+			res.Error = response.Errors{Code: http.StatusNotFound, Message: "event not found"}
+			ctx.JSON(http.StatusNotFound, res)
+			return
+		}
+		response.WriteError(ctx, logId, err, http.StatusBadRequest, "")
+		return
+	}
+
+	res := response.Response(http.StatusOK, "Auction closed successfully", logId, data)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (h *HandlerEvent) GetCalendarToken(ctx *gin.Context) {
+	authData := utils.GetAuthData(ctx)
+	userId := utils.InterfaceString(authData["user_id"])
+	logId := utils.GenerateLogId(ctx)
+	logPrefix := fmt.Sprintf("[%s][EventHandler][GetCalendarToken]", logId)
+
+	data, err := h.Service.GetCalendarToken(userId)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.GetCalendarToken; ERROR: %s;", logPrefix, err))
+		response.WriteError(ctx, logId, err, http.StatusInternalServerError, "")
+		return
+	}
+
+	res := response.Response(http.StatusOK, "success", logId, data)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (h *HandlerEvent) RegenerateCalendarToken(ctx *gin.Context) {
+	authData := utils.GetAuthData(ctx)
+	userId := utils.InterfaceString(authData["user_id"])
+	logId := utils.GenerateLogId(ctx)
+	logPrefix := fmt.Sprintf("[%s][EventHandler][RegenerateCalendarToken]", logId)
+
+	data, err := h.Service.RegenerateCalendarToken(userId)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.RegenerateCalendarToken; ERROR: %s;", logPrefix, err))
+		response.WriteError(ctx, logId, err, http.StatusInternalServerError, "")
+		return
+	}
+
+	res := response.Response(http.StatusOK, "Calendar feed token regenerated, the previous feed URL no longer works", logId, data)
+	ctx.JSON(http.StatusOK, res)
+}
+
+// GetCalendarFeed serves the iCalendar feed; calendar apps cannot log in, so the token in the path authenticates it
+func (h *HandlerEvent) GetCalendarFeed(ctx *gin.Context) {
+	logId := utils.GenerateLogId(ctx)
+	logPrefix := fmt.Sprintf("[%s][EventHandler][GetCalendarFeed]", logId)
+
+	token := strings.TrimSuffix(ctx.Param("token"), ".ics")
+
+	feed, err := h.Service.GetCalendarFeed(token)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.GetCalendarFeed; ERROR: %s;", logPrefix, err))
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			res := response.Response(http.StatusNotFound, messages.MsgNotFound, logId, nil)
+			res.Error = response.Errors{Code: http.StatusNotFound, Message: "calendar feed not found"}
+			ctx.JSON(http.StatusNotFound, res)
+			return
+		}
+		response.WriteError(ctx, logId, err, http.StatusInternalServerError, "")
+		return
+	}
+
+	ctx.Header("Content-Disposition", "inline; filename=\"events.ics\"")
+	ctx.Header("Cache-Control", "no-cache")
+	ctx.Data(http.StatusOK, "text/calendar; charset=utf-8", feed)
+}
@@ -3,12 +3,15 @@ package handlerpayments
 import (
 	"errors"
 	"fmt"
+	"io"
+	"mime/multipart"
 	"net/http"
 	"reflect"
 
 	"vendor-management-system/internal/dto"
 	interfacepayments "vendor-management-system/internal/interfaces/payments"
 	interfacevendors "vendor-management-system/internal/interfaces/vendors"
+	"vendor-management-system/pkg/disbursement"
 	"vendor-management-system/pkg/filter"
 	"vendor-management-system/pkg/logger"
 	"vendor-management-system/pkg/messages"
@@ -33,6 +36,8 @@ func NewPaymentHandler(s interfacepayments.ServicePaymentInterface, vendorRepo i
 
 func (h *HandlerPayment) CreatePayment(ctx *gin.Context) {
 	var req dto.CreatePaymentRequest
+	authData := utils.GetAuthData(ctx)
+	userId := utils.InterfaceString(authData["user_id"])
 	logId := utils.GenerateLogId(ctx)
 	logPrefix := fmt.Sprintf("[%s][PaymentHandler][CreatePayment]", logId)
 
@@ -45,7 +50,7 @@ func (h *HandlerPayment) CreatePayment(ctx *gin.Context) {
 	}
 	logger.WriteLog(logger.LogLevelDebug, fmt.Sprintf("%s; Request: %+v;", logPrefix, utils.JsonEncode(req)))
 
-	data, err := h.Service.CreatePayment(req)
+	data, err := h.Service.CreatePayment(userId, req)
 	if err != nil {
 		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.CreatePayment; ERROR: %s;", logPrefix, err))
 		response.WriteError(ctx, logId, err, http.StatusBadRequest, "")
@@ -118,11 +123,10 @@ func (h *HandlerPayment) GetMyPayments(ctx *gin.Context) {
 
 	params, _ := filter.GetBaseParams(ctx, "created_at", "desc", 10)
 	params.Filters = filter.WhitelistFilter(params.Filters, []string{"status"})
-	params.Filters["vendor_id"] = vendor.Id
 
-	data, totalData, err := h.Service.GetAllPayments(params)
+	data, totalData, err := h.Service.GetMyPayments(vendor.Id, params)
 	if err != nil {
-		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.GetAllPayments; ERROR: %s;", logPrefix, err))
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.GetMyPayments; ERROR: %s;", logPrefix, err))
 		response.WriteError(ctx, logId, err, http.StatusInternalServerError, "")
 		return
 	}
@@ -152,10 +156,10 @@ func (h *HandlerPayment) GetMyPaymentByID(ctx *gin.Context) {
 		return
 	}
 
-	// Get payment
-	data, err := h.Service.GetPaymentByID(id)
+	// Get payment, which must belong to this vendor
+	data, err := h.Service.GetMyPaymentByID(id, vendor.Id)
 	if err != nil {
-		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.GetPaymentByID; ERROR: %s;", logPrefix, err))
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.GetMyPaymentByID; ERROR: %s;", logPrefix, err))
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			res := response.Response(http.StatusNotFound, messages.MsgNotFound, logId, nil)
 			res.Error = response.Errors{Code: http.StatusNotFound, Message: "payment not found"}
@@ -166,20 +170,14 @@ func (h *HandlerPayment) GetMyPaymentByID(ctx *gin.Context) {
 		return
 	}
 
-	// Verify payment belongs to this vendor
-	if data.VendorID != vendor.Id {
-		res := response.Response(http.StatusForbidden, messages.MsgFail, logId, nil)
-		res.Error = "you don't have access to this payment"
-		ctx.JSON(http.StatusForbidden, res)
-		return
-	}
-
 	res := response.Response(http.StatusOK, "success", logId, data)
 	ctx.JSON(http.StatusOK, res)
 }
 
 func (h *HandlerPayment) UpdatePayment(ctx *gin.Context) {
 	var req dto.UpdatePaymentRequest
+	authData := utils.GetAuthData(ctx)
+	userId := utils.InterfaceString(authData["user_id"])
 	logId := utils.GenerateLogId(ctx)
 	logPrefix := fmt.Sprintf("[%s][PaymentHandler][UpdatePayment]", logId)
 
@@ -196,7 +194,7 @@ func (h *HandlerPayment) UpdatePayment(ctx *gin.Context) {
 		return
 	}
 
-	data, err := h.Service.UpdatePayment(id, req)
+	data, err := h.Service.UpdatePayment(id, userId, req)
 	if err != nil {
 		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.UpdatePayment; ERROR: %s;", logPrefix, err))
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -215,6 +213,8 @@ func (h *HandlerPayment) UpdatePayment(ctx *gin.Context) {
 
 func (h *HandlerPayment) UpdatePaymentStatus(ctx *gin.Context) {
 	var req dto.UpdatePaymentStatusRequest
+	authData := utils.GetAuthData(ctx)
+	userId := utils.InterfaceString(authData["user_id"])
 	logId := utils.GenerateLogId(ctx)
 	logPrefix := fmt.Sprintf("[%s][PaymentHandler][UpdatePaymentStatus]", logId)
 
@@ -231,7 +231,7 @@ func (h *HandlerPayment) UpdatePaymentStatus(ctx *gin.Context) {
 		return
 	}
 
-	data, err := h.Service.UpdatePaymentStatus(id, req)
+	data, err := h.Service.UpdatePaymentStatus(id, userId, req)
 	if err != nil {
 		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.UpdatePaymentStatus; ERROR: %s;", logPrefix, err))
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -248,96 +248,344 @@ func (h *HandlerPayment) UpdatePaymentStatus(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, res)
 }
 
-func (h *HandlerPayment) DeletePayment(ctx *gin.Context) {
+func (h *HandlerPayment) CreatePaymentSettlement(ctx *gin.Context) {
+	var req dto.CreatePaymentSettlementRequest
+	authData := utils.GetAuthData(ctx)
+	userId := utils.InterfaceString(authData["user_id"])
 	logId := utils.GenerateLogId(ctx)
-	logPrefix := fmt.Sprintf("[%s][PaymentHandler][DeletePayment]", logId)
+	logPrefix := fmt.Sprintf("[%s][PaymentHandler][CreatePaymentSettlement]", logId)
 
 	id, err := utils.ValidateUUID(ctx, logId)
 	if err != nil {
 		return
 	}
 
-	if err := h.Service.DeletePayment(id); err != nil {
-		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.DeletePayment; ERROR: %s;", logPrefix, err))
+	if err := ctx.ShouldBind(&req); err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; ShouldBind ERROR: %s;", logPrefix, err.Error()))
+		res := response.Response(http.StatusBadRequest, messages.InvalidRequest, logId, nil)
+		res.Error = utils.ValidateError(err, reflect.TypeOf(req), "form")
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	// The proof of transfer is optional
+	file, err := ctx.FormFile("proof_file")
+	if err != nil {
+		file = nil
+	}
+
+	data, err := h.Service.CreatePaymentSettlement(ctx.Request.Context(), id, userId, req, file)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.CreatePaymentSettlement; ERROR: %s;", logPrefix, err))
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			res := response.Response(http.StatusNotFound, messages.MsgNotFound, logId, nil)
+			res.Error = response.Errors{Code: http.StatusNotFound, Message: "payment not found"}
+			ctx.JSON(http.StatusNotFound, res)
+			return
+		}
+		response.WriteError(ctx, logId, err, http.StatusBadRequest, "")
+		return
+	}
+
+	res := response.Response(http.StatusCreated, "Payment settlement recorded successfully", logId, data)
+	ctx.JSON(http.StatusCreated, res)
+}
+
+func (h *HandlerPayment) ApprovePayment(ctx *gin.Context) {
+	var req dto.ApprovePaymentRequest
+	authData := utils.GetAuthData(ctx)
+	userId := utils.InterfaceString(authData["user_id"])
+	logId := utils.GenerateLogId(ctx)
+	logPrefix := fmt.Sprintf("[%s][PaymentHandler][ApprovePayment]", logId)
+
+	id, err := utils.ValidateUUID(ctx, logId)
+	if err != nil {
+		return
+	}
+
+	// Body is optional; only a comment can be sent
+	if err := ctx.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; BindJSON ERROR: %s;", logPrefix, err.Error()))
+		res := response.Response(http.StatusBadRequest, messages.InvalidRequest, logId, nil)
+		res.Error = utils.ValidateError(err, reflect.TypeOf(req), "json")
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	data, err := h.Service.ApprovePayment(id, userId, req)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.ApprovePayment; ERROR: %s;", logPrefix, err))
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			res := response.Response(http.StatusNotFound, messages.MsgNotFound, logId, nil)
+			res.Error = response.Errors{Code: http.StatusNotFound, Message: "payment not found"}
+			ctx.JSON(http.StatusNotFound, res)
+			return
+		}
+		response.WriteError(ctx, logId, err, http.StatusBadRequest, "")
+		return
+	}
+
+	res := response.Response(http.StatusOK, "Payment approved successfully", logId, data)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (h *HandlerPayment) RejectPayment(ctx *gin.Context) {
+	var req dto.RejectPaymentRequest
+	authData := utils.GetAuthData(ctx)
+	userId := utils.InterfaceString(authData["user_id"])
+	logId := utils.GenerateLogId(ctx)
+	logPrefix := fmt.Sprintf("[%s][PaymentHandler][RejectPayment]", logId)
+
+	id, err := utils.ValidateUUID(ctx, logId)
+	if err != nil {
+		return
+	}
+
+	if err := ctx.BindJSON(&req); err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; BindJSON ERROR: %s;", logPrefix, err.Error()))
+		res := response.Response(http.StatusBadRequest, messages.InvalidRequest, logId, nil)
+		res.Error = utils.ValidateError(err, reflect.TypeOf(req), "json")
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	data, err := h.Service.RejectPayment(id, userId, req)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.RejectPayment; ERROR: %s;", logPrefix, err))
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			res := response.Response(http.StatusNotFound, messages.MsgNotFound, logId, nil)
 			res.Error = response.Errors{Code: http.StatusNotFound, Message: "payment not found"}
 			ctx.JSON(http.StatusNotFound, res)
 			return
 		}
+		response.WriteError(ctx, logId, err, http.StatusBadRequest, "")
+		return
+	}
+
+	res := response.Response(http.StatusOK, "Payment rejected successfully", logId, data)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (h *HandlerPayment) GetApprovalLevels(ctx *gin.Context) {
+	logId := utils.GenerateLogId(ctx)
+	logPrefix := fmt.Sprintf("[%s][PaymentHandler][GetApprovalLevels]", logId)
+
+	data, err := h.Service.GetApprovalLevels()
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.GetApprovalLevels; ERROR: %s;", logPrefix, err))
 		response.WriteError(ctx, logId, err, http.StatusInternalServerError, "")
 		return
 	}
 
-	res := response.Response(http.StatusOK, "Payment deleted successfully", logId, nil)
+	res := response.Response(http.StatusOK, "success", logId, data)
 	ctx.JSON(http.StatusOK, res)
 }
 
-func (h *HandlerPayment) UploadPaymentFile(ctx *gin.Context) {
+func (h *HandlerPayment) SetApprovalLevels(ctx *gin.Context) {
+	var req dto.SetPaymentApprovalLevelsRequest
+	authData := utils.GetAuthData(ctx)
+	userId := utils.InterfaceString(authData["user_id"])
 	logId := utils.GenerateLogId(ctx)
-	logPrefix := fmt.Sprintf("[%s][PaymentHandler][UploadPaymentFile]", logId)
+	logPrefix := fmt.Sprintf("[%s][PaymentHandler][SetApprovalLevels]", logId)
 
-	paymentId := ctx.Param("id")
-	if paymentId == "" {
+	if err := ctx.BindJSON(&req); err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; BindJSON ERROR: %s;", logPrefix, err.Error()))
 		res := response.Response(http.StatusBadRequest, messages.InvalidRequest, logId, nil)
-		res.Error = "payment id is required"
+		res.Error = utils.ValidateError(err, reflect.TypeOf(req), "json")
 		ctx.JSON(http.StatusBadRequest, res)
 		return
 	}
 
+	data, err := h.Service.SetApprovalLevels(userId, req)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.SetApprovalLevels; ERROR: %s;", logPrefix, err))
+		response.WriteError(ctx, logId, err, http.StatusBadRequest, "")
+		return
+	}
+
+	res := response.Response(http.StatusOK, "Approval levels updated successfully", logId, data)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (h *HandlerPayment) OverridePaymentTax(ctx *gin.Context) {
+	var req dto.OverridePaymentTaxRequest
 	authData := utils.GetAuthData(ctx)
 	userId := utils.InterfaceString(authData["user_id"])
+	logId := utils.GenerateLogId(ctx)
+	logPrefix := fmt.Sprintf("[%s][PaymentHandler][OverridePaymentTax]", logId)
 
-	// Get file from form
-	file, err := ctx.FormFile("file")
+	id, err := utils.ValidateUUID(ctx, logId)
 	if err != nil {
-		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; FormFile ERROR: %s;", logPrefix, err.Error()))
+		return
+	}
+
+	if err := ctx.BindJSON(&req); err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; BindJSON ERROR: %s;", logPrefix, err.Error()))
 		res := response.Response(http.StatusBadRequest, messages.InvalidRequest, logId, nil)
-		res.Error = "file is required"
+		res.Error = utils.ValidateError(err, reflect.TypeOf(req), "json")
 		ctx.JSON(http.StatusBadRequest, res)
 		return
 	}
 
-	// Get file type and caption from form
-	fileType := ctx.PostForm("file_type")
-	if fileType == "" {
-		fileType = "proof" // default
+	data, err := h.Service.OverridePaymentTax(id, userId, req)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.OverridePaymentTax; ERROR: %s;", logPrefix, err))
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			res := response.Response(http.StatusNotFound, messages.MsgNotFound, logId, nil)
+			res.Error = response.Errors{Code: http.StatusNotFound, Message: "payment not found"}
+			ctx.JSON(http.StatusNotFound, res)
+			return
+		}
+		response.WriteError(ctx, logId, err, http.StatusBadRequest, "")
+		return
 	}
-	caption := ctx.PostForm("caption")
 
-	req := dto.UploadPaymentFileRequest{
-		FileType: fileType,
-		Caption:  caption,
+	res := response.Response(http.StatusOK, "Payment tax overridden successfully", logId, data)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (h *HandlerPayment) GetTaxRules(ctx *gin.Context) {
+	logId := utils.GenerateLogId(ctx)
+	logPrefix := fmt.Sprintf("[%s][PaymentHandler][GetTaxRules]", logId)
+
+	data, err := h.Service.GetTaxRules()
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.GetTaxRules; ERROR: %s;", logPrefix, err))
+		response.WriteError(ctx, logId, err, http.StatusInternalServerError, "")
+		return
 	}
 
-	data, err := h.Service.UploadPaymentFile(ctx.Request.Context(), paymentId, userId, file, req)
+	res := response.Response(http.StatusOK, "success", logId, data)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (h *HandlerPayment) SetTaxRules(ctx *gin.Context) {
+	var req dto.SetTaxRulesRequest
+	authData := utils.GetAuthData(ctx)
+	userId := utils.InterfaceString(authData["user_id"])
+	logId := utils.GenerateLogId(ctx)
+	logPrefix := fmt.Sprintf("[%s][PaymentHandler][SetTaxRules]", logId)
+
+	if err := ctx.BindJSON(&req); err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; BindJSON ERROR: %s;", logPrefix, err.Error()))
+		res := response.Response(http.StatusBadRequest, messages.InvalidRequest, logId, nil)
+		res.Error = utils.ValidateError(err, reflect.TypeOf(req), "json")
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	data, err := h.Service.SetTaxRules(userId, req)
 	if err != nil {
-		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.UploadPaymentFile; ERROR: %s;", logPrefix, err))
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.SetTaxRules; ERROR: %s;", logPrefix, err))
 		response.WriteError(ctx, logId, err, http.StatusBadRequest, "")
 		return
 	}
 
-	res := response.Response(http.StatusCreated, "File uploaded successfully", logId, data)
-	ctx.JSON(http.StatusCreated, res)
+	res := response.Response(http.StatusOK, "Tax rules updated successfully", logId, data)
+	ctx.JSON(http.StatusOK, res)
 }
 
-func (h *HandlerPayment) DeletePaymentFile(ctx *gin.Context) {
+func (h *HandlerPayment) GetWithholdingSlips(ctx *gin.Context) {
 	logId := utils.GenerateLogId(ctx)
-	logPrefix := fmt.Sprintf("[%s][PaymentHandler][DeletePaymentFile]", logId)
+	logPrefix := fmt.Sprintf("[%s][PaymentHandler][GetWithholdingSlips]", logId)
 
-	fileId := ctx.Param("file_id")
-	if fileId == "" {
+	data, err := h.Service.GetWithholdingSlips(ctx.Query("vendor_id"), ctx.Query("period"))
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.GetWithholdingSlips; ERROR: %s;", logPrefix, err))
+		response.WriteError(ctx, logId, err, http.StatusBadRequest, "")
+		return
+	}
+
+	res := response.Response(http.StatusOK, "success", logId, data)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (h *HandlerPayment) GetMyWithholdingSlips(ctx *gin.Context) {
+	authData := utils.GetAuthData(ctx)
+	userId := utils.InterfaceString(authData["user_id"])
+	logId := utils.GenerateLogId(ctx)
+	logPrefix := fmt.Sprintf("[%s][PaymentHandler][GetMyWithholdingSlips]", logId)
+
+	vendor, err := h.VendorRepo.GetVendorByUserID(userId)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; GetVendorByUserID; ERROR: %s;", logPrefix, err))
+		res := response.Response(http.StatusBadRequest, messages.MsgFail, logId, nil)
+		res.Error = "vendor profile not found"
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	data, err := h.Service.GetWithholdingSlips(vendor.Id, ctx.Query("period"))
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.GetWithholdingSlips; ERROR: %s;", logPrefix, err))
+		response.WriteError(ctx, logId, err, http.StatusBadRequest, "")
+		return
+	}
+
+	res := response.Response(http.StatusOK, "success", logId, data)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (h *HandlerPayment) CreateDisbursementBatch(ctx *gin.Context) {
+	var req dto.CreateDisbursementBatchRequest
+	authData := utils.GetAuthData(ctx)
+	userId := utils.InterfaceString(authData["user_id"])
+	logId := utils.GenerateLogId(ctx)
+	logPrefix := fmt.Sprintf("[%s][PaymentHandler][CreateDisbursementBatch]", logId)
+
+	if err := ctx.BindJSON(&req); err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; BindJSON ERROR: %s;", logPrefix, err.Error()))
 		res := response.Response(http.StatusBadRequest, messages.InvalidRequest, logId, nil)
-		res.Error = "file id is required"
+		res.Error = utils.ValidateError(err, reflect.TypeOf(req), "json")
 		ctx.JSON(http.StatusBadRequest, res)
 		return
 	}
+	logger.WriteLog(logger.LogLevelDebug, fmt.Sprintf("%s; Request: %+v;", logPrefix, utils.JsonEncode(req)))
 
-	if err := h.Service.DeletePaymentFile(ctx.Request.Context(), fileId); err != nil {
-		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.DeletePaymentFile; ERROR: %s;", logPrefix, err))
+	data, err := h.Service.CreateDisbursementBatch(ctx.Request.Context(), userId, req)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.CreateDisbursementBatch; ERROR: %s;", logPrefix, err))
+		response.WriteError(ctx, logId, err, http.StatusBadRequest, "")
+		return
+	}
+
+	res := response.Response(http.StatusCreated, "Disbursement batch created successfully", logId, data)
+	ctx.JSON(http.StatusCreated, res)
+}
+
+func (h *HandlerPayment) GetAllDisbursementBatches(ctx *gin.Context) {
+	logId := utils.GenerateLogId(ctx)
+	logPrefix := fmt.Sprintf("[%s][PaymentHandler][GetAllDisbursementBatches]", logId)
+
+	params, _ := filter.GetBaseParams(ctx, "created_at", "desc", 10)
+	params.Filters = filter.WhitelistFilter(params.Filters, []string{"format", "created_by"})
+
+	data, totalData, err := h.Service.GetAllDisbursementBatches(params)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.GetAllDisbursementBatches; ERROR: %+v;", logPrefix, err))
+		response.WriteError(ctx, logId, err, http.StatusInternalServerError, "")
+		return
+	}
+
+	res := response.PaginationResponse(http.StatusOK, int(totalData), params.Page, params.Limit, logId, data)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (h *HandlerPayment) GetDisbursementBatchByID(ctx *gin.Context) {
+	logId := utils.GenerateLogId(ctx)
+	logPrefix := fmt.Sprintf("[%s][PaymentHandler][GetDisbursementBatchByID]", logId)
+
+	id, err := utils.ValidateUUID(ctx, logId)
+	if err != nil {
+		return
+	}
+
+	data, err := h.Service.GetDisbursementBatchByID(id)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.GetDisbursementBatchByID; ERROR: %s;", logPrefix, err))
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			res := response.Response(http.StatusNotFound, messages.MsgNotFound, logId, nil)
-			res.Error = response.Errors{Code: http.StatusNotFound, Message: "file not found"}
+			res.Error = response.Errors{Code: http.StatusNotFound, Message: "disbursement batch not found"}
 			ctx.JSON(http.StatusNotFound, res)
 			return
 		}
@@ -345,6 +593,581 @@ func (h *HandlerPayment) DeletePaymentFile(ctx *gin.Context) {
 		return
 	}
 
-	res := response.Response(http.StatusOK, "File deleted successfully", logId, nil)
+	res := response.Response(http.StatusOK, "success", logId, data)
 	ctx.JSON(http.StatusOK, res)
 }
+
+func (h *HandlerPayment) DownloadDisbursementFile(ctx *gin.Context) {
+	logId := utils.GenerateLogId(ctx)
+	logPrefix := fmt.Sprintf("[%s][PaymentHandler][DownloadDisbursementFile]", logId)
+
+	id, err := utils.ValidateUUID(ctx, logId)
+	if err != nil {
+		return
+	}
+
+	data, batch, err := h.Service.DownloadDisbursementFile(ctx.Request.Context(), id)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.DownloadDisbursementFile; ERROR: %s;", logPrefix, err))
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			res := response.Response(http.StatusNotFound, messages.MsgNotFound, logId, nil)
+			res.Error = response.Errors{Code: http.StatusNotFound, Message: "disbursement batch not found"}
+			ctx.JSON(http.StatusNotFound, res)
+			return
+		}
+		response.WriteError(ctx, logId, err, http.StatusInternalServerError, "")
+		return
+	}
+
+	contentType := "application/octet-stream"
+	if format, ok := disbursement.Get(batch.Format); ok {
+		contentType = format.ContentType()
+	}
+
+	ctx.Header("Content-Type", contentType)
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", batch.FileName))
+	ctx.Header("X-Checksum-Sha256", batch.Checksum)
+	ctx.Data(http.StatusOK, contentType, data)
+}
+
+func (h *HandlerPayment) ImportBankStatement(ctx *gin.Context) {
+	var req dto.ImportBankStatementRequest
+	authData := utils.GetAuthData(ctx)
+	userId := utils.InterfaceString(authData["user_id"])
+	logId := utils.GenerateLogId(ctx)
+	logPrefix := fmt.Sprintf("[%s][PaymentHandler][ImportBankStatement]", logId)
+
+	if err := ctx.ShouldBind(&req); err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; ShouldBind ERROR: %s;", logPrefix, err.Error()))
+		res := response.Response(http.StatusBadRequest, messages.InvalidRequest, logId, nil)
+		res.Error = utils.ValidateError(err, reflect.TypeOf(req), "form")
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	file, err := ctx.FormFile("file")
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; FormFile ERROR: %s;", logPrefix, err.Error()))
+		res := response.Response(http.StatusBadRequest, messages.InvalidRequest, logId, nil)
+		res.Error = "file is required"
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	data, err := h.Service.ImportBankStatement(ctx.Request.Context(), userId, req, file)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.ImportBankStatement; ERROR: %s;", logPrefix, err))
+		response.WriteError(ctx, logId, err, http.StatusBadRequest, "")
+		return
+	}
+
+	res := response.Response(http.StatusCreated, "Bank statement imported successfully", logId, data)
+	ctx.JSON(http.StatusCreated, res)
+}
+
+func (h *HandlerPayment) GetAllBankStatements(ctx *gin.Context) {
+	logId := utils.GenerateLogId(ctx)
+	logPrefix := fmt.Sprintf("[%s][PaymentHandler][GetAllBankStatements]", logId)
+
+	params, _ := filter.GetBaseParams(ctx, "created_at", "desc", 10)
+	params.Filters = filter.WhitelistFilter(params.Filters, []string{"format", "created_by"})
+
+	data, totalData, err := h.Service.GetAllBankStatements(params)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.GetAllBankStatements; ERROR: %+v;", logPrefix, err))
+		response.WriteError(ctx, logId, err, http.StatusInternalServerError, "")
+		return
+	}
+
+	res := response.PaginationResponse(http.StatusOK, int(totalData), params.Page, params.Limit, logId, data)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (h *HandlerPayment) GetReconciliationReport(ctx *gin.Context) {
+	logId := utils.GenerateLogId(ctx)
+	logPrefix := fmt.Sprintf("[%s][PaymentHandler][GetReconciliationReport]", logId)
+
+	id, err := utils.ValidateUUID(ctx, logId)
+	if err != nil {
+		return
+	}
+
+	data, err := h.Service.GetReconciliationReport(id)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.GetReconciliationReport; ERROR: %s;", logPrefix, err))
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			res := response.Response(http.StatusNotFound, messages.MsgNotFound, logId, nil)
+			res.Error = response.Errors{Code: http.StatusNotFound, Message: "bank statement not found"}
+			ctx.JSON(http.StatusNotFound, res)
+			return
+		}
+		response.WriteError(ctx, logId, err, http.StatusInternalServerError, "")
+		return
+	}
+
+	res := response.Response(http.StatusOK, "success", logId, data)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (h *HandlerPayment) GetReconciliationQueue(ctx *gin.Context) {
+	logId := utils.GenerateLogId(ctx)
+	logPrefix := fmt.Sprintf("[%s][PaymentHandler][GetReconciliationQueue]", logId)
+
+	data, err := h.Service.GetReconciliationQueue(ctx.Query("status"))
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.GetReconciliationQueue; ERROR: %s;", logPrefix, err))
+		response.WriteError(ctx, logId, err, http.StatusBadRequest, "")
+		return
+	}
+
+	res := response.Response(http.StatusOK, "success", logId, data)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (h *HandlerPayment) MatchStatementLine(ctx *gin.Context) {
+	var req dto.MatchStatementLineRequest
+	authData := utils.GetAuthData(ctx)
+	userId := utils.InterfaceString(authData["user_id"])
+	logId := utils.GenerateLogId(ctx)
+	logPrefix := fmt.Sprintf("[%s][PaymentHandler][MatchStatementLine]", logId)
+
+	id, err := utils.ValidateUUID(ctx, logId)
+	if err != nil {
+		return
+	}
+
+	if err := ctx.BindJSON(&req); err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; BindJSON ERROR: %s;", logPrefix, err.Error()))
+		res := response.Response(http.StatusBadRequest, messages.InvalidRequest, logId, nil)
+		res.Error = utils.ValidateError(err, reflect.TypeOf(req), "json")
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	data, err := h.Service.MatchStatementLine(id, userId, req)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.MatchStatementLine; ERROR: %s;", logPrefix, err))
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			res := response.Response(http.StatusNotFound, messages.MsgNotFound, logId, nil)
+			res.Error = response.Errors{Code: http.StatusNotFound, Message: "statement line not found"}
+			ctx.JSON(http.StatusNotFound, res)
+			return
+		}
+		response.WriteError(ctx, logId, err, http.StatusBadRequest, "")
+		return
+	}
+
+	res := response.Response(http.StatusOK, "Statement line matched successfully", logId, data)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (h *HandlerPayment) DismissStatementLine(ctx *gin.Context) {
+	var req dto.DismissStatementLineRequest
+	authData := utils.GetAuthData(ctx)
+	userId := utils.InterfaceString(authData["user_id"])
+	logId := utils.GenerateLogId(ctx)
+	logPrefix := fmt.Sprintf("[%s][PaymentHandler][DismissStatementLine]", logId)
+
+	id, err := utils.ValidateUUID(ctx, logId)
+	if err != nil {
+		return
+	}
+
+	if err := ctx.BindJSON(&req); err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; BindJSON ERROR: %s;", logPrefix, err.Error()))
+		res := response.Response(http.StatusBadRequest, messages.InvalidRequest, logId, nil)
+		res.Error = utils.ValidateError(err, reflect.TypeOf(req), "json")
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	data, err := h.Service.DismissStatementLine(id, userId, req)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.DismissStatementLine; ERROR: %s;", logPrefix, err))
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			res := response.Response(http.StatusNotFound, messages.MsgNotFound, logId, nil)
+			res.Error = response.Errors{Code: http.StatusNotFound, Message: "statement line not found"}
+			ctx.JSON(http.StatusNotFound, res)
+			return
+		}
+		response.WriteError(ctx, logId, err, http.StatusBadRequest, "")
+		return
+	}
+
+	res := response.Response(http.StatusOK, "Statement line dismissed successfully", logId, data)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (h *HandlerPayment) DeletePayment(ctx *gin.Context) {
+	authData := utils.GetAuthData(ctx)
+	userId := utils.InterfaceString(authData["user_id"])
+	logId := utils.GenerateLogId(ctx)
+	logPrefix := fmt.Sprintf("[%s][PaymentHandler][DeletePayment]", logId)
+
+	id, err := utils.ValidateUUID(ctx, logId)
+	if err != nil {
+		return
+	}
+
+	if err := h.Service.DeletePayment(id, userId); err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.DeletePayment; ERROR: %s;", logPrefix, err))
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			res := response.Response(http.StatusNotFound, messages.MsgNotFound, logId, nil)
+			res.Error = response.Errors{Code: http.StatusNotFound, Message: "payment not found"}
+			ctx.JSON(http.StatusNotFound, res)
+			return
+		}
+		response.WriteError(ctx, logId, err, http.StatusInternalServerError, "")
+		return
+	}
+
+	res := response.Response(http.StatusOK, "Payment deleted successfully", logId, nil)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (h *HandlerPayment) UploadPaymentFile(ctx *gin.Context) {
+	logId := utils.GenerateLogId(ctx)
+	logPrefix := fmt.Sprintf("[%s][PaymentHandler][UploadPaymentFile]", logId)
+
+	paymentId := ctx.Param("id")
+	if paymentId == "" {
+		res := response.Response(http.StatusBadRequest, messages.InvalidRequest, logId, nil)
+		res.Error = "payment id is required"
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	authData := utils.GetAuthData(ctx)
+	userId := utils.InterfaceString(authData["user_id"])
+
+	// Get file from form
+	file, err := ctx.FormFile("file")
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; FormFile ERROR: %s;", logPrefix, err.Error()))
+		res := response.Response(http.StatusBadRequest, messages.InvalidRequest, logId, nil)
+		res.Error = "file is required"
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	// Get file type and caption from form
+	fileType := ctx.PostForm("file_type")
+	if fileType == "" {
+		fileType = "proof" // default
+	}
+	caption := ctx.PostForm("caption")
+
+	req := dto.UploadPaymentFileRequest{
+		FileType: fileType,
+		Caption:  caption,
+	}
+
+	data, err := h.Service.UploadPaymentFile(ctx.Request.Context(), paymentId, userId, file, req)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.UploadPaymentFile; ERROR: %s;", logPrefix, err))
+		response.WriteError(ctx, logId, err, http.StatusBadRequest, "")
+		return
+	}
+
+	res := response.Response(http.StatusCreated, "File uploaded successfully", logId, data)
+	ctx.JSON(http.StatusCreated, res)
+}
+
+func (h *HandlerPayment) DeletePaymentFile(ctx *gin.Context) {
+	logId := utils.GenerateLogId(ctx)
+	logPrefix := fmt.Sprintf("[%s][PaymentHandler][DeletePaymentFile]", logId)
+
+	fileId := ctx.Param("file_id")
+	if fileId == "" {
+		res := response.Response(http.StatusBadRequest, messages.InvalidRequest, logId, nil)
+		res.Error = "file id is required"
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	if err := h.Service.DeletePaymentFile(ctx.Request.Context(), fileId); err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.DeletePaymentFile; ERROR: %s;", logPrefix, err))
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			res := response.Response(http.StatusNotFound, messages.MsgNotFound, logId, nil)
+			res.Error = response.Errors{Code: http.StatusNotFound, Message: "file not found"}
+			ctx.JSON(http.StatusNotFound, res)
+			return
+		}
+		response.WriteError(ctx, logId, err, http.StatusInternalServerError, "")
+		return
+	}
+
+	res := response.Response(http.StatusOK, "File deleted successfully", logId, nil)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (h *HandlerPayment) SubmitInvoice(ctx *gin.Context) {
+	var req dto.SubmitInvoiceRequest
+	authData := utils.GetAuthData(ctx)
+	userId := utils.InterfaceString(authData["user_id"])
+	logId := utils.GenerateLogId(ctx)
+	logPrefix := fmt.Sprintf("[%s][PaymentHandler][SubmitInvoice]", logId)
+
+	if err := ctx.ShouldBind(&req); err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; ShouldBind ERROR: %s;", logPrefix, err.Error()))
+		res := response.Response(http.StatusBadRequest, messages.InvalidRequest, logId, nil)
+		res.Error = utils.ValidateError(err, reflect.TypeOf(req), "form")
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	vendor, err := h.VendorRepo.GetVendorByUserID(userId)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; GetVendorByUserID; ERROR: %s;", logPrefix, err))
+		res := response.Response(http.StatusBadRequest, messages.MsgFail, logId, nil)
+		res.Error = "vendor profile not found"
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	formFile := func(name string) *multipart.FileHeader {
+		file, err := ctx.FormFile(name)
+		if err != nil {
+			return nil
+		}
+		return file
+	}
+	files := dto.InvoiceFileUploads{
+		Invoice:       formFile("invoice_file"),
+		TaxInvoice:    formFile("tax_invoice_file"),
+		DeliveryProof: formFile("delivery_proof_file"),
+	}
+
+	data, err := h.Service.SubmitInvoice(ctx.Request.Context(), vendor.Id, userId, req, files)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.SubmitInvoice; ERROR: %s;", logPrefix, err))
+		response.WriteError(ctx, logId, err, http.StatusBadRequest, "")
+		return
+	}
+
+	res := response.Response(http.StatusCreated, "Invoice submitted successfully", logId, data)
+	logger.WriteLog(logger.LogLevelDebug, fmt.Sprintf("%s; Response: %+v;", logPrefix, utils.JsonEncode(data)))
+	ctx.JSON(http.StatusCreated, res)
+}
+
+func (h *HandlerPayment) GetMyInvoices(ctx *gin.Context) {
+	authData := utils.GetAuthData(ctx)
+	userId := utils.InterfaceString(authData["user_id"])
+	logId := utils.GenerateLogId(ctx)
+	logPrefix := fmt.Sprintf("[%s][PaymentHandler][GetMyInvoices]", logId)
+
+	vendor, err := h.VendorRepo.GetVendorByUserID(userId)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; GetVendorByUserID; ERROR: %s;", logPrefix, err))
+		res := response.Response(http.StatusBadRequest, messages.MsgFail, logId, nil)
+		res.Error = "vendor profile not found"
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	params, _ := filter.GetBaseParams(ctx, "created_at", "desc", 10)
+	params.Filters = filter.WhitelistFilter(params.Filters, []string{"status", "event_id"})
+	params.Filters["vendor_id"] = vendor.Id
+
+	data, totalData, err := h.Service.GetAllInvoices(params)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.GetAllInvoices; ERROR: %s;", logPrefix, err))
+		response.WriteError(ctx, logId, err, http.StatusInternalServerError, "")
+		return
+	}
+
+	res := response.PaginationResponse(http.StatusOK, int(totalData), params.Page, params.Limit, logId, data)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (h *HandlerPayment) GetMyInvoiceByID(ctx *gin.Context) {
+	authData := utils.GetAuthData(ctx)
+	userId := utils.InterfaceString(authData["user_id"])
+	logId := utils.GenerateLogId(ctx)
+	logPrefix := fmt.Sprintf("[%s][PaymentHandler][GetMyInvoiceByID]", logId)
+
+	id, err := utils.ValidateUUID(ctx, logId)
+	if err != nil {
+		return
+	}
+
+	vendor, err := h.VendorRepo.GetVendorByUserID(userId)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; GetVendorByUserID; ERROR: %s;", logPrefix, err))
+		res := response.Response(http.StatusBadRequest, messages.MsgFail, logId, nil)
+		res.Error = "vendor profile not found"
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	data, err := h.Service.GetInvoiceByID(id)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.GetInvoiceByID; ERROR: %s;", logPrefix, err))
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			res := response.Response(http.StatusNotFound, messages.MsgNotFound, logId, nil)
+			res.Error = response.Errors{Code: http.StatusNotFound, Message: "invoice not found"}
+			ctx.JSON(http.StatusNotFound, res)
+			return
+		}
+		response.WriteError(ctx, logId, err, http.StatusInternalServerError, "")
+		return
+	}
+
+	// Verify invoice belongs to this vendor
+	if data.VendorID != vendor.Id {
+		res := response.Response(http.StatusForbidden, messages.MsgFail, logId, nil)
+		res.Error = "you don't have access to this invoice"
+		ctx.JSON(http.StatusForbidden, res)
+		return
+	}
+
+	res := response.Response(http.StatusOK, "success", logId, data)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (h *HandlerPayment) GetAllInvoices(ctx *gin.Context) {
+	logId := utils.GenerateLogId(ctx)
+	logPrefix := fmt.Sprintf("[%s][PaymentHandler][GetAllInvoices]", logId)
+
+	params, _ := filter.GetBaseParams(ctx, "created_at", "desc", 10)
+	params.Filters = filter.WhitelistFilter(params.Filters, []string{"status", "vendor_id", "event_id"})
+
+	data, totalData, err := h.Service.GetAllInvoices(params)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.GetAllInvoices; ERROR: %+v;", logPrefix, err))
+		response.WriteError(ctx, logId, err, http.StatusInternalServerError, "")
+		return
+	}
+
+	res := response.PaginationResponse(http.StatusOK, int(totalData), params.Page, params.Limit, logId, data)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (h *HandlerPayment) GetInvoiceByID(ctx *gin.Context) {
+	logId := utils.GenerateLogId(ctx)
+	logPrefix := fmt.Sprintf("[%s][PaymentHandler][GetInvoiceByID]", logId)
+
+	id, err := utils.ValidateUUID(ctx, logId)
+	if err != nil {
+		return
+	}
+
+	data, err := h.Service.GetInvoiceByID(id)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.GetInvoiceByID; ERROR: %s;", logPrefix, err))
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			res := response.Response(http.StatusNotFound, messages.MsgNotFound, logId, nil)
+			res.Error = response.Errors{Code: http.StatusNotFound, Message: "invoice not found"}
+			ctx.JSON(http.StatusNotFound, res)
+			return
+		}
+		response.WriteError(ctx, logId, err, http.StatusInternalServerError, "")
+		return
+	}
+
+	res := response.Response(http.StatusOK, "success", logId, data)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (h *HandlerPayment) VerifyInvoice(ctx *gin.Context) {
+	authData := utils.GetAuthData(ctx)
+	userId := utils.InterfaceString(authData["user_id"])
+	logId := utils.GenerateLogId(ctx)
+	logPrefix := fmt.Sprintf("[%s][PaymentHandler][VerifyInvoice]", logId)
+
+	id, err := utils.ValidateUUID(ctx, logId)
+	if err != nil {
+		return
+	}
+
+	data, err := h.Service.VerifyInvoice(id, userId)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.VerifyInvoice; ERROR: %s;", logPrefix, err))
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			res := response.Response(http.StatusNotFound, messages.MsgNotFound, logId, nil)
+			res.Error = response.Errors{Code: http.StatusNotFound, Message: "invoice not found"}
+			ctx.JSON(http.StatusNotFound, res)
+			return
+		}
+		response.WriteError(ctx, logId, err, http.StatusBadRequest, "")
+		return
+	}
+
+	res := response.Response(http.StatusOK, "Invoice verified successfully", logId, data)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (h *HandlerPayment) RejectInvoice(ctx *gin.Context) {
+	var req dto.RejectInvoiceRequest
+	authData := utils.GetAuthData(ctx)
+	userId := utils.InterfaceString(authData["user_id"])
+	logId := utils.GenerateLogId(ctx)
+	logPrefix := fmt.Sprintf("[%s][PaymentHandler][RejectInvoice]", logId)
+
+	id, err := utils.ValidateUUID(ctx, logId)
+	if err != nil {
+		return
+	}
+
+	if err := ctx.BindJSON(&req); err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; BindJSON ERROR: %s;", logPrefix, err.Error()))
+		res := response.Response(http.StatusBadRequest, messages.InvalidRequest, logId, nil)
+		res.Error = utils.ValidateError(err, reflect.TypeOf(req), "json")
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	data, err := h.Service.RejectInvoice(id, userId, req)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.RejectInvoice; ERROR: %s;", logPrefix, err))
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			res := response.Response(http.StatusNotFound, messages.MsgNotFound, logId, nil)
+			res.Error = response.Errors{Code: http.StatusNotFound, Message: "invoice not found"}
+			ctx.JSON(http.StatusNotFound, res)
+			return
+		}
+		response.WriteError(ctx, logId, err, http.StatusBadRequest, "")
+		return
+	}
+
+	res := response.Response(http.StatusOK, "Invoice rejected successfully", logId, data)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (h *HandlerPayment) ConvertInvoice(ctx *gin.Context) {
+	var req dto.ConvertInvoiceRequest
+	authData := utils.GetAuthData(ctx)
+	userId := utils.InterfaceString(authData["user_id"])
+	logId := utils.GenerateLogId(ctx)
+	logPrefix := fmt.Sprintf("[%s][PaymentHandler][ConvertInvoice]", logId)
+
+	id, err := utils.ValidateUUID(ctx, logId)
+	if err != nil {
+		return
+	}
+
+	// Body is optional; only the payment date and description can be sent
+	if err := ctx.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; BindJSON ERROR: %s;", logPrefix, err.Error()))
+		res := response.Response(http.StatusBadRequest, messages.InvalidRequest, logId, nil)
+		res.Error = utils.ValidateError(err, reflect.TypeOf(req), "json")
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	data, err := h.Service.ConvertInvoice(id, userId, req)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Service.ConvertInvoice; ERROR: %s;", logPrefix, err))
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			res := response.Response(http.StatusNotFound, messages.MsgNotFound, logId, nil)
+			res.Error = response.Errors{Code: http.StatusNotFound, Message: "invoice not found"}
+			ctx.JSON(http.StatusNotFound, res)
+			return
+		}
+		response.WriteError(ctx, logId, err, http.StatusBadRequest, "")
+		return
+	}
+
+	res := response.Response(http.StatusCreated, "Invoice converted into a payment successfully", logId, data)
+	ctx.JSON(http.StatusCreated, res)
+}
@@ -13,11 +13,15 @@ type ServiceEvaluationInterface interface {
 	// Evaluation operations - Vendor creates after winning completed event
 	CreateEvaluation(vendorUserId string, req dto.CreateEvaluationRequest) (domainevaluations.Evaluation, error)
 	GetEvaluationByID(id string) (domainevaluations.Evaluation, error)
+	GetEvaluationOwners(id string) ([]string, error)
+	GetEventOwners(eventId string) ([]string, error)
+	GetPhotoOwners(photoId string) ([]string, error)
 	GetEvaluationsByEventID(eventId string) ([]domainevaluations.Evaluation, error)
 	GetEvaluationsByVendorID(vendorId string) ([]domainevaluations.Evaluation, error)
 	GetMyEvaluations(vendorUserId string, params filter.BaseParams) ([]domainevaluations.Evaluation, int64, error)
 	GetAllEvaluations(params filter.BaseParams) ([]domainevaluations.Evaluation, int64, error)
 	UpdateEvaluation(id string, req dto.UpdateEvaluationRequest) (domainevaluations.Evaluation, error)
+	FinalizeEvaluation(clientUserId string, id string) (domainevaluations.Evaluation, error)
 	UpdateDriveUrl(vendorUserId string, evaluationId string, req dto.UpdateDriveUrlRequest) (domainevaluations.Evaluation, error)
 	DeleteEvaluation(id string) error
 
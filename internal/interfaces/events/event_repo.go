@@ -1,7 +1,11 @@
 package interfaceevents
 
 import (
+	"time"
+
 	domainevents "vendor-management-system/internal/domain/events"
+	domainuser "vendor-management-system/internal/domain/user"
+	domainvendors "vendor-management-system/internal/domain/vendors"
 	"vendor-management-system/pkg/filter"
 )
 
@@ -12,6 +16,81 @@ type RepoEventInterface interface {
 	GetAllEvents(params filter.BaseParams) ([]domainevents.Event, int64, error)
 	UpdateEvent(m domainevents.Event) error
 	DeleteEvent(id string) error
+	GetAllEventsForVendor(vendorId string, params filter.BaseParams) ([]domainevents.Event, int64, error)
+	GetCalendarEvents(createdBy string, since time.Time) ([]domainevents.Event, error)
+	GetCalendarEventsForVendor(vendorId string, since time.Time) ([]domainevents.Event, error)
+
+	// Event invitation operations
+	CreateEventInvitations(m []domainevents.EventInvitation) error
+	GetEventInvitations(eventId string) ([]domainevents.EventInvitation, error)
+	DeleteEventInvitation(eventId, vendorId string) error
+	IsVendorEligibleForEvent(eventId, vendorId string) (bool, error)
+	GetEligibleVendors(eventId string) ([]domainvendors.Vendor, error)
+
+	// Bid opening operations
+	CreateBidOpening(m domainevents.EventBidOpening) error
+	GetBidOpenings(eventId string) ([]domainevents.EventBidOpening, error)
+
+	// Bill of quantities operations
+	GetBoqItems(eventId string) ([]domainevents.EventBoqItem, error)
+	ReplaceBoqItems(eventId, userId string, items []domainevents.EventBoqItem) error
+
+	// Question operations
+	CreateQuestion(m domainevents.EventQuestion) error
+	GetQuestionByID(id string) (domainevents.EventQuestion, error)
+	GetQuestionsByEventID(eventId string, params filter.BaseParams) ([]domainevents.EventQuestion, int64, error)
+	GetVisibleQuestions(eventId, vendorId string) ([]domainevents.EventQuestion, error)
+	UpdateQuestion(m domainevents.EventQuestion) error
+	GetEventParticipantVendors(eventId string) ([]domainvendors.Vendor, error)
+
+	// Addendum operations
+	CreateAddendum(m domainevents.EventAddendum, event domainevents.Event) error
+	GetAddendumByID(id string) (domainevents.EventAddendum, error)
+	GetAddendaByEventID(eventId string) ([]domainevents.EventAddendum, error)
+	GetLatestAddendum(eventId string) (domainevents.EventAddendum, error)
+	CreateAddendumAcknowledgement(m domainevents.EventAddendumAcknowledgement) error
+	GetAddendumAcknowledgement(addendumId, vendorId string) (domainevents.EventAddendumAcknowledgement, error)
+	RecordEventView(eventId, vendorId string) error
+	GetEventViewerVendors(eventId string) ([]domainvendors.Vendor, error)
+	GetEventInterestedVendors(eventId string) ([]domainvendors.Vendor, error)
+
+	// Team member operations
+	GetEventTeamMembers(eventId string) ([]domainevents.EventTeamMember, error)
+	AddEventTeamMembers(ms []domainevents.EventTeamMember) error
+	RemoveEventTeamMember(eventId, userId string) error
+	GetUsersByIDs(ids []string) ([]domainuser.Users, error)
+
+	// Deadline extension operations
+	CreateDeadlineExtension(m domainevents.EventDeadlineExtension, status string) error
+	GetDeadlineExtensions(eventId string) ([]domainevents.EventDeadlineExtension, error)
+
+	// Payment term operations
+	GetPaymentTerms(eventId string) ([]domainevents.EventPaymentTerm, error)
+	ReplacePaymentTerms(eventId string, terms []domainevents.EventPaymentTerm) error
+
+	// Auction operations
+	LockEventForUpdate(id string) (domainevents.Event, error)
+	CreateAuctionBid(m domainevents.EventAuctionBid) error
+	ExtendAuctionEnd(eventId string, endAt time.Time, userId string) error
+	GetAuctionBids(eventId string) ([]domainevents.EventAuctionBid, error)
+	GetLatestAuctionBid(eventId, vendorId string) (domainevents.EventAuctionBid, error)
+
+	// Template operations
+	CreateEventTemplate(m domainevents.EventTemplate) error
+	GetEventTemplateByID(id string) (domainevents.EventTemplate, error)
+	GetAllEventTemplates(params filter.BaseParams) ([]domainevents.EventTemplate, int64, error)
+	DeleteEventTemplate(id, userId string) error
+
+	// Award operations
+	GetAwardsByEventID(eventId string) ([]domainevents.EventAward, error)
+	GetActiveAwardsByEventIDs(eventIds []string) ([]domainevents.EventAward, error)
+	GetAwardsByEventAndVendor(eventId, vendorId string) ([]domainevents.EventAward, error)
+	GetAwardByID(id string) (domainevents.EventAward, error)
+	SaveAwards(event domainevents.Event, revoked []domainevents.EventAward, awards []domainevents.EventAward, history []domainevents.EventHistory) error
+
+	// History operations
+	CreateEventHistory(m domainevents.EventHistory) error
+	GetEventHistory(eventId string) ([]domainevents.EventHistory, error)
 
 	// Event file operations
 	CreateEventFile(m domainevents.EventFile) error
@@ -24,15 +103,32 @@ type RepoEventInterface interface {
 	GetSubmissionByEventAndVendor(eventId, vendorId string) (domainevents.EventSubmission, error)
 	GetSubmissionsByEventID(eventId string) ([]domainevents.EventSubmission, error)
 	GetSubmissionsByEventIDPaginated(eventId string, params filter.BaseParams) ([]domainevents.EventSubmission, int64, error)
-	GetAllSubmissions(params filter.BaseParams) ([]domainevents.EventSubmission, int64, error)
-	GetGroupedSubmissions(params filter.BaseParams, submissionPage int, submissionLimit int) (*domainevents.GroupedSubmissionsResponse, error)
+	GetAllSubmissions(ownerId string, params filter.BaseParams) ([]domainevents.EventSubmission, int64, error)
+	GetGroupedSubmissions(ownerId string, params filter.BaseParams, submissionPage int, submissionLimit int) (*domainevents.GroupedSubmissionsResponse, error)
 	GetSubmissionsByVendorID(vendorId string, params filter.BaseParams) ([]domainevents.EventSubmission, int64, error)
+	CountActiveSubmissionsByEventID(eventId string) (int64, error)
 	UpdateSubmission(m domainevents.EventSubmission) error
 	DeleteSubmission(id string) error
 
 	// Submission file operations
 	CreateSubmissionFile(m domainevents.EventSubmissionFile) error
 	GetSubmissionFileByID(id string) (domainevents.EventSubmissionFile, error)
+	GetSubmissionFilesBySubmissionID(submissionId string) ([]domainevents.EventSubmissionFile, error)
 	CountSubmissionFilesBySubmissionID(submissionId string) (int64, error)
 	DeleteSubmissionFile(id string) error
+	DeleteSubmissionFilesBySubmissionID(submissionId string) error
+	ReplaceSubmissionFiles(m domainevents.EventSubmission, keepFileIds []string, files []domainevents.EventSubmissionFile) error
+
+	// Submission price operations
+	GetSubmissionPrices(submissionId string) ([]domainevents.EventSubmissionPrice, error)
+	ReplaceSubmissionPrices(submissionId string, prices []domainevents.EventSubmissionPrice) error
+
+	// Submission revision operations
+	CreateSubmissionRevision(m domainevents.EventSubmissionRevision) error
+	GetSubmissionRevisions(submissionId string) ([]domainevents.EventSubmissionRevision, error)
+
+	// Calendar token operations
+	GetCalendarTokenByUserID(userId string) (domainevents.CalendarToken, error)
+	GetCalendarTokenByToken(token string) (domainevents.CalendarToken, error)
+	SaveCalendarToken(m domainevents.CalendarToken) error
 }
@@ -14,8 +14,61 @@ type ServiceEventInterface interface {
 	CreateEvent(userId string, req dto.CreateEventRequest) (domainevents.Event, error)
 	GetEventByID(id string) (domainevents.Event, error)
 	GetAllEvents(params filter.BaseParams) ([]domainevents.Event, int64, error)
-	UpdateEvent(id string, req dto.UpdateEventRequest) (domainevents.Event, error)
+	UpdateEvent(id, userId string, req dto.UpdateEventRequest) (domainevents.Event, error)
 	DeleteEvent(id string) error
+	GetAllEventsForVendor(vendorId string, params filter.BaseParams) ([]domainevents.Event, int64, error)
+	CanVendorAccessEvent(event domainevents.Event, vendorId string) (bool, error)
+
+	// Ownership and team operations
+	GetEventOwners(eventId string) ([]string, error)
+	GetSubmissionOwners(submissionId string) ([]string, error)
+	GetSubmissionFileOwners(fileId string) ([]string, error)
+	GetAwardOwners(awardId string) ([]string, error)
+	GetQuestionOwners(questionId string) ([]string, error)
+	GetEventTeam(eventId string) ([]domainevents.EventTeamMember, error)
+	AddEventTeamMembers(eventId, userId string, req dto.AddEventTeamMembersRequest) ([]domainevents.EventTeamMember, error)
+	RemoveEventTeamMember(eventId, memberUserId string) error
+
+	// Clone and template operations
+	GetEventBudget(eventId string) (domainevents.EventBudget, error)
+	SetEventBudget(eventId, userId string, req dto.SetEventBudgetRequest) (domainevents.EventBudget, error)
+	ExtendDeadline(eventId, userId string, req dto.ExtendDeadlineRequest) (domainevents.Event, error)
+	CancelEvent(ctx context.Context, eventId, userId string, req dto.CancelEventRequest) (map[string]interface{}, error)
+	CloneEvent(ctx context.Context, eventId, userId string, req dto.CloneEventRequest) (domainevents.Event, error)
+	SaveEventTemplate(ctx context.Context, eventId, userId string, req dto.SaveEventTemplateRequest) (domainevents.EventTemplate, error)
+	GetEventTemplates(params filter.BaseParams) ([]domainevents.EventTemplate, int64, error)
+	GetEventTemplateByID(id string) (domainevents.EventTemplate, error)
+	CreateEventFromTemplate(ctx context.Context, templateId, userId string, req dto.CloneEventRequest) (domainevents.Event, error)
+	DeleteEventTemplate(ctx context.Context, id, userId string) error
+
+	// Event invitation operations
+	InviteVendors(eventId, userId string, req dto.InviteVendorsRequest) ([]domainevents.EventInvitation, error)
+	GetEventInvitations(eventId string) (map[string]interface{}, error)
+	RemoveInvitation(eventId, vendorId string) error
+
+	// Sealed bid operations
+	OpenBids(eventId, userId string, req dto.OpenBidsRequest) (map[string]interface{}, error)
+	GetBidOpeningStatus(eventId string) (map[string]interface{}, error)
+	DownloadSubmissionFile(ctx context.Context, fileId, vendorId string) ([]byte, domainevents.EventSubmissionFile, error)
+
+	// Bill of quantities operations
+	SetBoqItems(eventId, userId string, req dto.SetBoqItemsRequest) ([]domainevents.EventBoqItem, error)
+	GetBoqItems(eventId, userId, vendorId string) ([]domainevents.EventBoqItem, error)
+	GetQuotationComparison(eventId, userId string) (domainevents.QuotationComparison, error)
+	GenerateQuotationComparisonXLSX(eventId, userId string) ([]byte, string, error)
+	GenerateSubmissionsXLSX(eventId string) ([]byte, string, error)
+
+	// Question operations
+	AskQuestion(eventId, vendorId string, req dto.AskQuestionRequest) (domainevents.EventQuestion, error)
+	GetEventQuestions(eventId string, params filter.BaseParams) ([]domainevents.EventQuestion, int64, error)
+	GetVendorQuestions(eventId, vendorId string) (map[string]interface{}, error)
+	AnswerQuestion(questionId, userId string, req dto.AnswerQuestionRequest) (domainevents.EventQuestion, error)
+
+	// Addendum operations
+	IssueAddendum(ctx context.Context, eventId, userId string, req dto.IssueAddendumRequest, fileHeaders []*multipart.FileHeader) (domainevents.EventAddendum, error)
+	GetAddenda(eventId, vendorId string) ([]domainevents.EventAddendum, error)
+	AcknowledgeAddendum(addendumId, vendorId, userId string) (domainevents.EventAddendumAcknowledgement, error)
+	RecordEventView(eventId, vendorId string) error
 
 	// Event file operations
 	UploadEventFile(ctx context.Context, eventId string, userId string, file *multipart.FileHeader, req dto.UploadEventFileRequest) (domainevents.EventFile, error)
@@ -23,19 +76,32 @@ type ServiceEventInterface interface {
 
 	// Submission operations
 	SubmitPitch(eventId, vendorId string, req dto.SubmitPitchRequest) (domainevents.EventSubmission, error)
-	SubmitPitchWithFile(ctx context.Context, eventId, vendorId string, req dto.SubmitPitchRequest, fileHeader *multipart.FileHeader, fileType, caption string) (domainevents.EventSubmission, error)
+	SubmitPitchWithFiles(ctx context.Context, eventId, vendorId string, req dto.SubmitPitchRequest, uploads []dto.SubmissionFileUpload) (domainevents.EventSubmission, error)
+	AmendSubmission(ctx context.Context, eventId, vendorId string, req dto.SubmitPitchRequest, keepFileIds []string, uploads []dto.SubmissionFileUpload) (domainevents.EventSubmission, error)
+	WithdrawSubmission(eventId, vendorId string, req dto.WithdrawSubmissionRequest) (domainevents.EventSubmission, error)
+	GetMySubmissionRevisions(eventId, vendorId string) ([]domainevents.EventSubmissionRevision, error)
+	GetSubmissionRevisions(submissionId string) ([]domainevents.EventSubmissionRevision, error)
 	GetSubmissionsByEventID(eventId string) ([]domainevents.EventSubmission, error)
-	GetSubmissionsByEventIDPaginated(eventId string, params filter.BaseParams) ([]domainevents.EventSubmission, int64, error)
-	GetAllSubmissions(params filter.BaseParams) ([]map[string]interface{}, int64, error)
-	GetGroupedSubmissions(params filter.BaseParams, submissionPage int, submissionLimit int) (*domainevents.GroupedSubmissionsResponse, error)
+	GetSubmissionsByEventIDPaginated(eventId, userId string, params filter.BaseParams) ([]domainevents.EventSubmission, int64, error)
+	GetAllSubmissions(userId string, params filter.BaseParams) ([]map[string]interface{}, int64, error)
+	GetGroupedSubmissions(userId string, params filter.BaseParams, submissionPage int, submissionLimit int) (*domainevents.GroupedSubmissionsResponse, error)
 	GetMySubmissions(vendorId string, params filter.BaseParams) ([]domainevents.EventSubmission, int64, error)
-	ScoreSubmission(submissionId string, req dto.ScoreSubmissionRequest) (domainevents.EventSubmission, error)
-	ShortlistSubmission(submissionId string, isShortlisted bool) (domainevents.EventSubmission, error)
-	SelectWinner(eventId, submissionId string) (domainevents.Event, error)
+	ScoreSubmission(submissionId, userId string, req dto.ScoreSubmissionRequest) (domainevents.EventSubmission, error)
+	ShortlistSubmission(submissionId, userId string, isShortlisted bool) (domainevents.EventSubmission, error)
+	SelectWinner(eventId, userId string, req dto.SelectWinnerRequest) (map[string]interface{}, error)
+	RevokeAward(awardId, userId string, req dto.RevokeAwardRequest) (map[string]interface{}, error)
+	GetEventHistory(eventId string) ([]domainevents.EventHistory, error)
+	PlaceAuctionBid(eventId, vendorId, userId string, req dto.PlaceAuctionBidRequest) (domainevents.AuctionStatus, error)
+	GetAuctionStatus(eventId, vendorId string) (domainevents.AuctionStatus, error)
+	GetAuctionLeaderboard(eventId string) (domainevents.AuctionStatus, error)
+	SubscribeAuction(eventId string) (<-chan struct{}, func())
+	CloseAuction(eventId, userId string) (map[string]interface{}, error)
+	GetCalendarToken(userId string) (domainevents.CalendarToken, error)
+	RegenerateCalendarToken(userId string) (domainevents.CalendarToken, error)
+	GetCalendarFeed(token string) ([]byte, error)
 	GetEventResult(eventId, vendorId string) (map[string]interface{}, error)
-	GetEventResultForAdmin(eventId string) (map[string]interface{}, error)
+	GetEventResultForAdmin(eventId, userId string) (map[string]interface{}, error)
 
 	// Submission file operations
-	UploadSubmissionFile(ctx context.Context, submissionId string, userId string, file *multipart.FileHeader, req dto.UploadSubmissionFileRequest) (domainevents.EventSubmissionFile, error)
 	DeleteSubmissionFile(ctx context.Context, fileId string) error
 }
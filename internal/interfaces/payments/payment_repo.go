@@ -1,8 +1,12 @@
 package interfacepayments
 
 import (
+	"time"
+
 	domainpayments "vendor-management-system/internal/domain/payments"
 	"vendor-management-system/pkg/filter"
+
+	"github.com/shopspring/decimal"
 )
 
 type RepoPaymentInterface interface {
@@ -11,7 +15,58 @@ type RepoPaymentInterface interface {
 	GetPaymentsByVendorID(vendorId string) ([]domainpayments.Payment, error)
 	GetAllPayments(params filter.BaseParams) ([]domainpayments.Payment, int64, error)
 	UpdatePayment(m domainpayments.Payment) error
-	DeletePayment(id string) error
+	DeletePayment(id, userId string) error
+	LockPaymentForUpdate(id string) (domainpayments.Payment, error)
+
+	// Scheduled payment operations
+	CreatePayments(m []domainpayments.Payment) error
+	CancelScheduledPayments(awardId, userId string) error
+	ReleaseScheduledPayments(eventId, vendorId, trigger, userId string) error
+	GetAwardPayments(awardId string) ([]domainpayments.Payment, error)
+
+	// Payment settlement operations
+	CreatePaymentSettlement(m domainpayments.PaymentSettlement) error
+	AddPaidAmount(id string, amount, transferAmount decimal.Decimal, settlementDate time.Time, userId string) (int64, error)
+
+	// Payment approval operations
+	GetApprovalLevels() ([]domainpayments.PaymentApprovalLevel, error)
+	ReplaceApprovalLevels(levels []domainpayments.PaymentApprovalLevel) error
+	CreatePaymentApproval(m domainpayments.PaymentApproval) error
+
+	// Tax operations
+	GetTaxRules() ([]domainpayments.TaxRule, error)
+	GetTaxRule(vendorType, taxStatus string) (domainpayments.TaxRule, error)
+	SaveTaxRules(rules []domainpayments.TaxRule) error
+	GetWithholdingSlips(vendorId string, from, to time.Time) ([]domainpayments.WithholdingSlip, error)
+
+	// Disbursement operations
+	GetPaymentsByIDs(ids []string) ([]domainpayments.Payment, error)
+	CreateDisbursementBatch(m domainpayments.DisbursementBatch) error
+	MarkPaymentsProcessing(ids []string, batchId, userId string) (int64, error)
+	GetDisbursementBatchByID(id string) (domainpayments.DisbursementBatch, error)
+	GetAllDisbursementBatches(params filter.BaseParams) ([]domainpayments.DisbursementBatch, int64, error)
+
+	// Reconciliation operations
+	GetOutstandingPayments() ([]domainpayments.Payment, error)
+	BankStatementExists(checksum string) (bool, error)
+	CreateBankStatement(m domainpayments.BankStatement) error
+	CreateBankStatementLines(m []domainpayments.BankStatementLine) error
+	CreateBankStatementSuggestions(m []domainpayments.BankStatementSuggestion) error
+	GetBankStatementByID(id string) (domainpayments.BankStatement, error)
+	GetAllBankStatements(params filter.BaseParams) ([]domainpayments.BankStatement, int64, error)
+	GetBankStatementLineByID(id string) (domainpayments.BankStatementLine, error)
+	GetBankStatementLinesByStatus(status string) ([]domainpayments.BankStatementLine, error)
+	ResolveBankStatementLine(m domainpayments.BankStatementLine) (int64, error)
+
+	// Invoice operations
+	CreateInvoice(m domainpayments.Invoice) error
+	GetInvoiceByID(id string) (domainpayments.Invoice, error)
+	InvoiceNumberExists(vendorId, invoiceNumber string) (bool, error)
+	GetAllInvoices(params filter.BaseParams) ([]domainpayments.Invoice, int64, error)
+	MarkInvoiceVerified(id, userId string, verifiedAt time.Time) (int64, error)
+	MarkInvoiceRejected(id, reason, userId string) (int64, error)
+	GetAwardInvoices(awardId string) ([]domainpayments.Invoice, error)
+	MarkInvoiceConverted(id, paymentId, userId string) (int64, error)
 
 	// Payment file operations
 	CreatePaymentFile(m domainpayments.PaymentFile) error
@@ -10,13 +10,52 @@ import (
 )
 
 type ServicePaymentInterface interface {
-	CreatePayment(req dto.CreatePaymentRequest) (domainpayments.Payment, error)
+	CreatePayment(userId string, req dto.CreatePaymentRequest) (domainpayments.Payment, error)
 	GetPaymentByID(id string) (domainpayments.Payment, error)
 	GetPaymentsByVendorID(vendorId string) ([]domainpayments.Payment, error)
 	GetAllPayments(params filter.BaseParams) ([]domainpayments.Payment, int64, error)
-	UpdatePayment(id string, req dto.UpdatePaymentRequest) (domainpayments.Payment, error)
-	UpdatePaymentStatus(id string, req dto.UpdatePaymentStatusRequest) (domainpayments.Payment, error)
-	DeletePayment(id string) error
+	GetMyPayments(vendorId string, params filter.BaseParams) ([]domainpayments.Payment, int64, error)
+	GetMyPaymentByID(id, vendorId string) (domainpayments.Payment, error)
+	UpdatePayment(id, userId string, req dto.UpdatePaymentRequest) (domainpayments.Payment, error)
+	UpdatePaymentStatus(id, userId string, req dto.UpdatePaymentStatusRequest) (domainpayments.Payment, error)
+	DeletePayment(id, userId string) error
+
+	// Payment settlement operations
+	CreatePaymentSettlement(ctx context.Context, id, userId string, req dto.CreatePaymentSettlementRequest, file *multipart.FileHeader) (domainpayments.Payment, error)
+
+	// Payment approval operations
+	ApprovePayment(id, userId string, req dto.ApprovePaymentRequest) (domainpayments.Payment, error)
+	RejectPayment(id, userId string, req dto.RejectPaymentRequest) (domainpayments.Payment, error)
+	GetApprovalLevels() ([]domainpayments.PaymentApprovalLevel, error)
+	SetApprovalLevels(userId string, req dto.SetPaymentApprovalLevelsRequest) ([]domainpayments.PaymentApprovalLevel, error)
+
+	// Tax operations
+	OverridePaymentTax(id, userId string, req dto.OverridePaymentTaxRequest) (domainpayments.Payment, error)
+	GetTaxRules() ([]domainpayments.TaxRule, error)
+	SetTaxRules(userId string, req dto.SetTaxRulesRequest) ([]domainpayments.TaxRule, error)
+	GetWithholdingSlips(vendorId, period string) ([]domainpayments.WithholdingSlip, error)
+
+	// Disbursement operations
+	CreateDisbursementBatch(ctx context.Context, userId string, req dto.CreateDisbursementBatchRequest) (domainpayments.DisbursementBatch, error)
+	GetDisbursementBatchByID(id string) (domainpayments.DisbursementBatch, error)
+	GetAllDisbursementBatches(params filter.BaseParams) ([]domainpayments.DisbursementBatch, int64, error)
+	DownloadDisbursementFile(ctx context.Context, id string) ([]byte, domainpayments.DisbursementBatch, error)
+
+	// Reconciliation operations
+	ImportBankStatement(ctx context.Context, userId string, req dto.ImportBankStatementRequest, file *multipart.FileHeader) (domainpayments.ReconciliationReport, error)
+	GetAllBankStatements(params filter.BaseParams) ([]domainpayments.BankStatement, int64, error)
+	GetReconciliationReport(id string) (domainpayments.ReconciliationReport, error)
+	GetReconciliationQueue(status string) ([]domainpayments.BankStatementLine, error)
+	MatchStatementLine(id, userId string, req dto.MatchStatementLineRequest) (domainpayments.BankStatementLine, error)
+	DismissStatementLine(id, userId string, req dto.DismissStatementLineRequest) (domainpayments.BankStatementLine, error)
+
+	// Invoice operations
+	SubmitInvoice(ctx context.Context, vendorId, userId string, req dto.SubmitInvoiceRequest, files dto.InvoiceFileUploads) (domainpayments.Invoice, error)
+	GetInvoiceByID(id string) (domainpayments.Invoice, error)
+	GetAllInvoices(params filter.BaseParams) ([]domainpayments.Invoice, int64, error)
+	VerifyInvoice(id, userId string) (domainpayments.Invoice, error)
+	RejectInvoice(id, userId string, req dto.RejectInvoiceRequest) (domainpayments.Invoice, error)
+	ConvertInvoice(id, userId string, req dto.ConvertInvoiceRequest) (domainpayments.Payment, error)
 
 	// Payment file operations
 	UploadPaymentFile(ctx context.Context, paymentId string, userId string, file *multipart.FileHeader, req dto.UploadPaymentFileRequest) (domainpayments.PaymentFile, error)
@@ -0,0 +1,22 @@
+package interfaceuow
+
+import (
+	interfaceevaluations "vendor-management-system/internal/interfaces/evaluations"
+	interfaceevents "vendor-management-system/internal/interfaces/events"
+	interfacepayments "vendor-management-system/internal/interfaces/payments"
+	interfacevendors "vendor-management-system/internal/interfaces/vendors"
+)
+
+// Repositories are the repositories bound to a single unit of work
+type Repositories struct {
+	Event      interfaceevents.RepoEventInterface
+	Vendor     interfacevendors.RepoVendorInterface
+	Payment    interfacepayments.RepoPaymentInterface
+	Evaluation interfaceevaluations.RepoEvaluationInterface
+}
+
+type UnitOfWorkInterface interface {
+	// Do runs fn inside one database transaction. Every write made through the given
+	// repositories is committed when fn returns nil and rolled back otherwise.
+	Do(fn func(repos Repositories) error) error
+}
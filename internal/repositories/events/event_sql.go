@@ -3,14 +3,35 @@ package repositoryevents
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	domainevents "vendor-management-system/internal/domain/events"
+	domainuser "vendor-management-system/internal/domain/user"
+	domainvendors "vendor-management-system/internal/domain/vendors"
 	interfaceevents "vendor-management-system/internal/interfaces/events"
 	"vendor-management-system/pkg/filter"
+	"vendor-management-system/utils"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// vendorEligibleCondition matches a row of `vendors` (joined with `vendor_profiles`) against a row of `events`.
+// A vendor is eligible for an invited event when it is on the invitee list or matches the event's eligibility rule.
+const vendorEligibleCondition = `(
+	EXISTS (SELECT 1 FROM event_invitations ei WHERE ei.event_id = events.id AND ei.vendor_id = vendors.id)
+	OR (
+		events.eligibility_enabled = TRUE
+		AND (COALESCE(events.eligible_business_field, '') = '' OR vendor_profiles.business_field = events.eligible_business_field)
+		AND (COALESCE(events.eligible_province_id, '') = '' OR vendor_profiles.province_id = events.eligible_province_id)
+		AND (events.eligible_active_only = FALSE OR vendors.status = 'active')
+		AND (events.eligible_min_rating IS NULL OR COALESCE((
+			SELECT AVG(ev.overall_rating) FROM evaluations ev
+			WHERE ev.vendor_id = vendors.id AND ev.overall_rating IS NOT NULL AND ev.deleted_at IS NULL
+		), 0) >= events.eligible_min_rating)
+	)
+)`
+
 type repo struct {
 	DB *gorm.DB
 }
@@ -32,8 +53,51 @@ func (r *repo) GetEventByID(id string) (ret domainevents.Event, err error) {
 }
 
 func (r *repo) GetAllEvents(params filter.BaseParams) (ret []domainevents.Event, totalData int64, err error) {
-	query := r.DB.Model(&domainevents.Event{})
+	return r.findEvents(r.DB.Model(&domainevents.Event{}), params)
+}
+
+func (r *repo) GetAllEventsForVendor(vendorId string, params filter.BaseParams) (ret []domainevents.Event, totalData int64, err error) {
+	query := r.DB.Model(&domainevents.Event{}).
+		Where("events.status <> ?", "draft").
+		Where(`(events.participation_mode = 'open' OR EXISTS (
+			SELECT 1 FROM vendors
+			LEFT JOIN vendor_profiles ON vendor_profiles.vendor_id = vendors.id AND vendor_profiles.deleted_at IS NULL
+			WHERE vendors.id = ? AND vendors.deleted_at IS NULL AND `+vendorEligibleCondition+`))`, vendorId)
+
+	return r.findEvents(query, params)
+}
+
+// GetCalendarEvents lists the events for a staff calendar feed that have not ended before since;
+// createdBy limits them to the events one user created or is on the team of
+func (r *repo) GetCalendarEvents(createdBy string, since time.Time) (ret []domainevents.Event, err error) {
+	query := r.DB.Where("events.end_date IS NULL OR events.end_date >= ?", since)
+	if createdBy != "" {
+		query = query.Where(`events.created_by = ? OR EXISTS (
+			SELECT 1 FROM event_team_members etm WHERE etm.event_id = events.id AND etm.user_id = ?)`, createdBy, createdBy)
+	}
+	if err = query.Order("events.start_date ASC").Find(&ret).Error; err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
 
+// GetCalendarEventsForVendor lists the events the vendor can see or has submitted to that have not ended before since
+func (r *repo) GetCalendarEventsForVendor(vendorId string, since time.Time) (ret []domainevents.Event, err error) {
+	if err = r.DB.
+		Where("events.end_date IS NULL OR events.end_date >= ?", since).
+		Where(`EXISTS (SELECT 1 FROM event_submissions es WHERE es.event_id = events.id AND es.vendor_id = ? AND es.deleted_at IS NULL)
+		OR (events.status <> 'draft' AND (events.participation_mode = 'open' OR EXISTS (
+			SELECT 1 FROM vendors
+			LEFT JOIN vendor_profiles ON vendor_profiles.vendor_id = vendors.id AND vendor_profiles.deleted_at IS NULL
+			WHERE vendors.id = ? AND vendors.deleted_at IS NULL AND `+vendorEligibleCondition+`)))`, vendorId, vendorId).
+		Order("events.start_date ASC").
+		Find(&ret).Error; err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+func (r *repo) findEvents(query *gorm.DB, params filter.BaseParams) (ret []domainevents.Event, totalData int64, err error) {
 	if params.Search != "" {
 		searchPattern := "%" + params.Search + "%"
 		query = query.Where("LOWER(title) LIKE LOWER(?) OR LOWER(description) LIKE LOWER(?)", searchPattern, searchPattern)
@@ -93,6 +157,531 @@ func (r *repo) DeleteEvent(id string) error {
 	return r.DB.Where("id = ?", id).Delete(&domainevents.Event{}).Error
 }
 
+// Event invitation operations
+func (r *repo) CreateEventInvitations(m []domainevents.EventInvitation) error {
+	if len(m) == 0 {
+		return nil
+	}
+	return r.DB.Clauses(clause.OnConflict{DoNothing: true}).Create(&m).Error
+}
+
+func (r *repo) GetEventInvitations(eventId string) (ret []domainevents.EventInvitation, err error) {
+	if err = r.DB.Preload("Vendor").Preload("Vendor.Profile").Where("event_id = ?", eventId).Order("created_at ASC").Find(&ret).Error; err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+func (r *repo) DeleteEventInvitation(eventId, vendorId string) error {
+	return r.DB.Where("event_id = ? AND vendor_id = ?", eventId, vendorId).Delete(&domainevents.EventInvitation{}).Error
+}
+
+func (r *repo) IsVendorEligibleForEvent(eventId, vendorId string) (bool, error) {
+	var count int64
+	err := r.DB.Table("vendors").
+		Joins("LEFT JOIN vendor_profiles ON vendor_profiles.vendor_id = vendors.id AND vendor_profiles.deleted_at IS NULL").
+		Joins("JOIN events ON events.id = ?", eventId).
+		Where("vendors.id = ? AND vendors.deleted_at IS NULL", vendorId).
+		Where(vendorEligibleCondition).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *repo) GetEligibleVendors(eventId string) (ret []domainvendors.Vendor, err error) {
+	if err = r.DB.Model(&domainvendors.Vendor{}).
+		Select("vendors.*").
+		Joins("LEFT JOIN vendor_profiles ON vendor_profiles.vendor_id = vendors.id AND vendor_profiles.deleted_at IS NULL").
+		Joins("JOIN events ON events.id = ?", eventId).
+		Where(vendorEligibleCondition).
+		Find(&ret).Error; err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// Bid opening operations
+func (r *repo) CreateBidOpening(m domainevents.EventBidOpening) error {
+	return r.DB.Create(&m).Error
+}
+
+func (r *repo) GetBidOpenings(eventId string) (ret []domainevents.EventBidOpening, err error) {
+	if err = r.DB.Preload("User").Where("event_id = ?", eventId).Order("created_at ASC").Find(&ret).Error; err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// Bill of quantities operations
+func (r *repo) GetBoqItems(eventId string) (ret []domainevents.EventBoqItem, err error) {
+	if err = r.DB.Where("event_id = ?", eventId).Order("item_no ASC").Find(&ret).Error; err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// ReplaceBoqItems soft-deletes the event's current lines and inserts the new ones in one transaction
+func (r *repo) ReplaceBoqItems(eventId, userId string, items []domainevents.EventBoqItem) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&domainevents.EventBoqItem{}).
+			Where("event_id = ?", eventId).
+			Update("deleted_by", userId).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("event_id = ?", eventId).Delete(&domainevents.EventBoqItem{}).Error; err != nil {
+			return err
+		}
+
+		if len(items) == 0 {
+			return nil
+		}
+		return tx.Create(&items).Error
+	})
+}
+
+// Question operations
+func (r *repo) CreateQuestion(m domainevents.EventQuestion) error {
+	return r.DB.Create(&m).Error
+}
+
+func (r *repo) GetQuestionByID(id string) (ret domainevents.EventQuestion, err error) {
+	if err = r.DB.Where("id = ?", id).First(&ret).Error; err != nil {
+		return domainevents.EventQuestion{}, err
+	}
+	return ret, nil
+}
+
+func (r *repo) GetQuestionsByEventID(eventId string, params filter.BaseParams) (ret []domainevents.EventQuestion, totalData int64, err error) {
+	query := r.DB.Model(&domainevents.EventQuestion{}).Where("event_id = ?", eventId)
+
+	if params.Search != "" {
+		query = query.Where("LOWER(question) LIKE LOWER(?)", "%"+params.Search+"%")
+	}
+
+	for key, value := range params.Filters {
+		switch key {
+		case "status", "visibility", "vendor_id":
+			query = query.Where(fmt.Sprintf("event_questions.%s = ?", key), value)
+		}
+	}
+
+	if err = query.Count(&totalData).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err = query.Preload("Vendor").Preload("Vendor.Profile").
+		Order("created_at ASC").
+		Offset(params.Offset).Limit(params.Limit).
+		Find(&ret).Error; err != nil {
+		return nil, 0, err
+	}
+	return ret, totalData, nil
+}
+
+// GetVisibleQuestions returns the vendor's own questions together with every published one
+func (r *repo) GetVisibleQuestions(eventId, vendorId string) (ret []domainevents.EventQuestion, err error) {
+	if err = r.DB.Where("event_id = ?", eventId).
+		Where("vendor_id = ? OR visibility = ?", vendorId, utils.QuestionPublic).
+		Order("created_at ASC").
+		Find(&ret).Error; err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+func (r *repo) UpdateQuestion(m domainevents.EventQuestion) error {
+	return r.DB.Omit(clause.Associations).Save(&m).Error
+}
+
+// GetEventParticipantVendors returns every vendor taking part in the event: vendors that
+// submitted or asked a question, plus the eligible vendors of an invited event
+func (r *repo) GetEventParticipantVendors(eventId string) (ret []domainvendors.Vendor, err error) {
+	if err = r.DB.Model(&domainvendors.Vendor{}).
+		Joins("LEFT JOIN vendor_profiles ON vendor_profiles.vendor_id = vendors.id AND vendor_profiles.deleted_at IS NULL").
+		Joins("JOIN events ON events.id = ?", eventId).
+		Where(`EXISTS (SELECT 1 FROM event_submissions es WHERE es.event_id = events.id AND es.vendor_id = vendors.id AND es.deleted_at IS NULL)
+			OR EXISTS (SELECT 1 FROM event_questions eq WHERE eq.event_id = events.id AND eq.vendor_id = vendors.id AND eq.deleted_at IS NULL)
+			OR (events.participation_mode = ? AND `+vendorEligibleCondition+`)`, utils.EventModeInvited).
+		Find(&ret).Error; err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// Addendum operations
+
+// CreateAddendum stores the addendum with its files and the event's new deadline in one transaction
+func (r *repo) CreateAddendum(m domainevents.EventAddendum, event domainevents.Event) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&m).Error; err != nil {
+			return err
+		}
+		return tx.Omit(clause.Associations).Save(&event).Error
+	})
+}
+
+func (r *repo) GetAddendumByID(id string) (ret domainevents.EventAddendum, err error) {
+	if err = r.DB.Where("id = ?", id).First(&ret).Error; err != nil {
+		return domainevents.EventAddendum{}, err
+	}
+	return ret, nil
+}
+
+func (r *repo) GetAddendaByEventID(eventId string) (ret []domainevents.EventAddendum, err error) {
+	if err = r.DB.Preload("File").
+		Preload("Acknowledgements", func(db *gorm.DB) *gorm.DB {
+			return db.Order("acknowledged_at ASC")
+		}).
+		Preload("Acknowledgements.Vendor").Preload("Acknowledgements.Vendor.Profile").
+		Where("event_id = ?", eventId).
+		Order("addendum_number ASC").
+		Find(&ret).Error; err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+func (r *repo) GetLatestAddendum(eventId string) (ret domainevents.EventAddendum, err error) {
+	if err = r.DB.Where("event_id = ?", eventId).Order("addendum_number DESC").First(&ret).Error; err != nil {
+		return domainevents.EventAddendum{}, err
+	}
+	return ret, nil
+}
+
+func (r *repo) CreateAddendumAcknowledgement(m domainevents.EventAddendumAcknowledgement) error {
+	return r.DB.Clauses(clause.OnConflict{DoNothing: true}).Create(&m).Error
+}
+
+func (r *repo) GetAddendumAcknowledgement(addendumId, vendorId string) (ret domainevents.EventAddendumAcknowledgement, err error) {
+	if err = r.DB.Where("addendum_id = ? AND vendor_id = ?", addendumId, vendorId).First(&ret).Error; err != nil {
+		return domainevents.EventAddendumAcknowledgement{}, err
+	}
+	return ret, nil
+}
+
+// Team member operations
+func (r *repo) GetEventTeamMembers(eventId string) (ret []domainevents.EventTeamMember, err error) {
+	if err = r.DB.Preload("User").Where("event_id = ?", eventId).Order("created_at ASC").Find(&ret).Error; err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+func (r *repo) AddEventTeamMembers(ms []domainevents.EventTeamMember) error {
+	if len(ms) == 0 {
+		return nil
+	}
+	return r.DB.Clauses(clause.OnConflict{DoNothing: true}).Create(&ms).Error
+}
+
+func (r *repo) RemoveEventTeamMember(eventId, userId string) error {
+	result := r.DB.Where("event_id = ? AND user_id = ?", eventId, userId).Delete(&domainevents.EventTeamMember{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *repo) GetUsersByIDs(ids []string) (ret []domainuser.Users, err error) {
+	if err = r.DB.Where("id IN ?", ids).Find(&ret).Error; err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// Deadline extension operations
+// CreateDeadlineExtension records the extension and moves the event's deadline. Only the deadline
+// and status are written, so a concurrent event update cannot be undone by it.
+func (r *repo) CreateDeadlineExtension(m domainevents.EventDeadlineExtension, status string) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&m).Error; err != nil {
+			return err
+		}
+		return tx.Model(&domainevents.Event{}).Where("id = ?", m.EventID).
+			Updates(map[string]interface{}{"end_date": m.NewEndDate, "status": status, "updated_at": m.CreatedAt, "updated_by": m.CreatedBy}).Error
+	})
+}
+
+func (r *repo) GetDeadlineExtensions(eventId string) (ret []domainevents.EventDeadlineExtension, err error) {
+	if err = r.DB.Where("event_id = ?", eventId).Order("created_at ASC").Find(&ret).Error; err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+func (r *repo) RecordEventView(eventId, vendorId string) error {
+	now := time.Now()
+	view := domainevents.EventVendorView{
+		Id:            utils.CreateUUID(),
+		EventID:       eventId,
+		VendorID:      vendorId,
+		FirstViewedAt: now,
+		LastViewedAt:  now,
+	}
+	return r.DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "event_id"}, {Name: "vendor_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"last_viewed_at"}),
+	}).Create(&view).Error
+}
+
+// GetEventViewerVendors returns the vendors that opened or submitted to the event
+func (r *repo) GetEventViewerVendors(eventId string) (ret []domainvendors.Vendor, err error) {
+	if err = r.DB.Model(&domainvendors.Vendor{}).
+		Where(`EXISTS (SELECT 1 FROM event_vendor_views ev WHERE ev.event_id = ? AND ev.vendor_id = vendors.id)
+			OR EXISTS (SELECT 1 FROM event_submissions es WHERE es.event_id = ? AND es.vendor_id = vendors.id AND es.deleted_at IS NULL)`, eventId, eventId).
+		Find(&ret).Error; err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// GetEventInterestedVendors returns the vendors that opened, were invited to or submitted to the event
+func (r *repo) GetEventInterestedVendors(eventId string) (ret []domainvendors.Vendor, err error) {
+	if err = r.DB.Model(&domainvendors.Vendor{}).
+		Where(`EXISTS (SELECT 1 FROM event_vendor_views ev WHERE ev.event_id = ? AND ev.vendor_id = vendors.id)
+			OR EXISTS (SELECT 1 FROM event_invitations ei WHERE ei.event_id = ? AND ei.vendor_id = vendors.id)
+			OR EXISTS (SELECT 1 FROM event_submissions es WHERE es.event_id = ? AND es.vendor_id = vendors.id AND es.deleted_at IS NULL)`, eventId, eventId, eventId).
+		Find(&ret).Error; err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// Payment term operations
+func (r *repo) GetPaymentTerms(eventId string) (ret []domainevents.EventPaymentTerm, err error) {
+	if err = r.DB.Where("event_id = ?", eventId).Order("sequence ASC").Find(&ret).Error; err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+func (r *repo) ReplacePaymentTerms(eventId string, terms []domainevents.EventPaymentTerm) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("event_id = ?", eventId).Delete(&domainevents.EventPaymentTerm{}).Error; err != nil {
+			return err
+		}
+		if len(terms) == 0 {
+			return nil
+		}
+		return tx.Create(&terms).Error
+	})
+}
+
+// Auction operations
+
+// LockEventForUpdate reads the event with a row lock; only meaningful inside a transaction
+func (r *repo) LockEventForUpdate(id string) (ret domainevents.Event, err error) {
+	if err = r.DB.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&ret).Error; err != nil {
+		return domainevents.Event{}, err
+	}
+	return ret, nil
+}
+
+func (r *repo) CreateAuctionBid(m domainevents.EventAuctionBid) error {
+	return r.DB.Omit(clause.Associations).Create(&m).Error
+}
+
+// ExtendAuctionEnd only touches the end time, so a concurrent event update cannot undo it
+func (r *repo) ExtendAuctionEnd(eventId string, endAt time.Time, userId string) error {
+	return r.DB.Model(&domainevents.Event{}).Where("id = ?", eventId).
+		Updates(map[string]interface{}{"auction_end_at": endAt, "updated_at": time.Now(), "updated_by": userId}).Error
+}
+
+func (r *repo) GetAuctionBids(eventId string) (ret []domainevents.EventAuctionBid, err error) {
+	if err = r.DB.Preload("Vendor").Preload("Vendor.Profile").
+		Where("event_id = ?", eventId).
+		Order("created_at ASC").
+		Find(&ret).Error; err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+func (r *repo) GetLatestAuctionBid(eventId, vendorId string) (ret domainevents.EventAuctionBid, err error) {
+	if err = r.DB.Where("event_id = ? AND vendor_id = ?", eventId, vendorId).
+		Order("created_at DESC").
+		First(&ret).Error; err != nil {
+		return domainevents.EventAuctionBid{}, err
+	}
+	return ret, nil
+}
+
+// Template operations
+func (r *repo) CreateEventTemplate(m domainevents.EventTemplate) error {
+	return r.DB.Create(&m).Error
+}
+
+func (r *repo) GetEventTemplateByID(id string) (ret domainevents.EventTemplate, err error) {
+	if err = r.DB.Preload("Files").Where("id = ?", id).First(&ret).Error; err != nil {
+		return domainevents.EventTemplate{}, err
+	}
+	return ret, nil
+}
+
+func (r *repo) GetAllEventTemplates(params filter.BaseParams) (ret []domainevents.EventTemplate, totalData int64, err error) {
+	query := r.DB.Model(&domainevents.EventTemplate{})
+
+	if params.Search != "" {
+		searchPattern := "%" + params.Search + "%"
+		query = query.Where("LOWER(name) LIKE LOWER(?) OR LOWER(title_pattern) LIKE LOWER(?)", searchPattern, searchPattern)
+	}
+
+	for key, value := range params.Filters {
+		switch key {
+		case "category", "participation_mode":
+			query = query.Where(fmt.Sprintf("event_templates.%s = ?", key), value)
+		}
+	}
+
+	if err = query.Count(&totalData).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if params.OrderBy != "" && params.OrderDirection != "" {
+		validColumns := map[string]bool{
+			"name":       true,
+			"category":   true,
+			"created_at": true,
+			"updated_at": true,
+		}
+
+		if _, ok := validColumns[params.OrderBy]; !ok {
+			return nil, 0, fmt.Errorf("invalid orderBy column: %s", params.OrderBy)
+		}
+
+		query = query.Order(fmt.Sprintf("%s %s", params.OrderBy, params.OrderDirection))
+	}
+
+	if err = query.Preload("Files").Offset(params.Offset).Limit(params.Limit).Find(&ret).Error; err != nil {
+		return nil, 0, err
+	}
+	return ret, totalData, nil
+}
+
+func (r *repo) DeleteEventTemplate(id, userId string) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&domainevents.EventTemplate{}).Where("id = ?", id).Update("deleted_by", userId).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", id).Delete(&domainevents.EventTemplate{}).Error
+	})
+}
+
+// Award operations
+func (r *repo) GetAwardsByEventID(eventId string) (ret []domainevents.EventAward, err error) {
+	if err = r.DB.Preload("Vendor").Preload("Vendor.Profile").
+		Where("event_id = ?", eventId).
+		Order("created_at ASC").
+		Find(&ret).Error; err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// GetActiveAwardsByEventIDs returns the awards of the given events that were not revoked
+func (r *repo) GetActiveAwardsByEventIDs(eventIds []string) (ret []domainevents.EventAward, err error) {
+	if len(eventIds) == 0 {
+		return nil, nil
+	}
+	if err = r.DB.Where("event_id IN ? AND revoked_at IS NULL", eventIds).
+		Order("created_at ASC").
+		Find(&ret).Error; err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+func (r *repo) GetAwardsByEventAndVendor(eventId, vendorId string) (ret []domainevents.EventAward, err error) {
+	if err = r.DB.Where("event_id = ? AND vendor_id = ?", eventId, vendorId).
+		Order("created_at ASC").
+		Find(&ret).Error; err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+func (r *repo) GetAwardByID(id string) (ret domainevents.EventAward, err error) {
+	if err = r.DB.Preload("Vendor").Where("id = ?", id).First(&ret).Error; err != nil {
+		return domainevents.EventAward{}, err
+	}
+	return ret, nil
+}
+
+// SaveAwards revokes the given awards, stores the new ones, syncs the submissions'
+// winner flags, saves the event and appends its history in one transaction
+func (r *repo) SaveAwards(event domainevents.Event, revoked []domainevents.EventAward, awards []domainevents.EventAward, history []domainevents.EventHistory) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		for _, award := range revoked {
+			result := tx.Model(&domainevents.EventAward{}).Where("id = ? AND deleted_at IS NULL", award.Id).
+				Updates(map[string]interface{}{
+					"revoked_at":    award.RevokedAt,
+					"revoked_by":    award.RevokedBy,
+					"revoke_reason": award.RevokeReason,
+					"deleted_at":    now,
+					"deleted_by":    award.DeletedBy,
+				})
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return fmt.Errorf("award %s has already been revoked", award.Id)
+			}
+		}
+		if len(awards) > 0 {
+			if err := tx.Omit(clause.Associations).Create(&awards).Error; err != nil {
+				return err
+			}
+		}
+
+		// A submission is a winner while it holds at least one active award
+		submissionIds := make([]string, 0, len(revoked)+len(awards))
+		for _, award := range revoked {
+			submissionIds = append(submissionIds, award.SubmissionID)
+		}
+		for _, award := range awards {
+			submissionIds = append(submissionIds, award.SubmissionID)
+		}
+		if len(submissionIds) > 0 {
+			if err := tx.Model(&domainevents.EventSubmission{}).Where("id IN ?", submissionIds).
+				Updates(map[string]interface{}{
+					"is_winner":  gorm.Expr("EXISTS (SELECT 1 FROM event_awards a WHERE a.submission_id = event_submissions.id AND a.deleted_at IS NULL)"),
+					"updated_at": now,
+				}).Error; err != nil {
+				return err
+			}
+			if err := tx.Model(&domainevents.EventSubmission{}).
+				Where("id IN ? AND is_winner = ?", submissionIds, true).
+				Update("is_shortlisted", true).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Omit(clause.Associations).Save(&event).Error; err != nil {
+			return err
+		}
+
+		if len(history) > 0 {
+			return tx.Create(&history).Error
+		}
+		return nil
+	})
+}
+
+// History operations
+func (r *repo) CreateEventHistory(m domainevents.EventHistory) error {
+	return r.DB.Create(&m).Error
+}
+
+func (r *repo) GetEventHistory(eventId string) (ret []domainevents.EventHistory, err error) {
+	if err = r.DB.Where("event_id = ?", eventId).Order("created_at ASC").Find(&ret).Error; err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
 // Event file operations
 func (r *repo) CreateEventFile(m domainevents.EventFile) error {
 	return r.DB.Create(&m).Error
@@ -129,7 +718,7 @@ func (r *repo) GetSubmissionByEventAndVendor(eventId, vendorId string) (ret doma
 }
 
 func (r *repo) GetSubmissionsByEventID(eventId string) (ret []domainevents.EventSubmission, err error) {
-	if err = r.DB.Preload("Event").Preload("Vendor").Preload("Vendor.Profile").Preload("File").Where("event_id = ?", eventId).Find(&ret).Error; err != nil {
+	if err = r.DB.Preload("Event").Preload("Vendor").Preload("Vendor.Profile").Preload("File").Preload("Prices.BoqItem").Where("event_id = ?", eventId).Find(&ret).Error; err != nil {
 		return nil, err
 	}
 	return ret, nil
@@ -147,7 +736,7 @@ func (r *repo) GetSubmissionsByEventIDPaginated(eventId string, params filter.Ba
 
 	for key, value := range params.Filters {
 		switch key {
-		case "is_shortlisted", "is_winner":
+		case "is_shortlisted", "is_winner", "status":
 			query = query.Where(fmt.Sprintf("event_submissions.%s = ?", key), value)
 		}
 	}
@@ -162,7 +751,7 @@ func (r *repo) GetSubmissionsByEventIDPaginated(eventId string, params filter.Ba
 		query = query.Order("event_submissions.created_at DESC")
 	}
 
-	if err = query.Preload("Event").Preload("Vendor").Preload("Vendor.Profile").Preload("File").
+	if err = query.Preload("Event").Preload("Vendor").Preload("Vendor.Profile").Preload("File").Preload("Prices.BoqItem").
 		Offset(params.Offset).Limit(params.Limit).
 		Find(&ret).Error; err != nil {
 		return nil, 0, err
@@ -171,10 +760,14 @@ func (r *repo) GetSubmissionsByEventIDPaginated(eventId string, params filter.Ba
 	return ret, totalData, nil
 }
 
-func (r *repo) GetAllSubmissions(params filter.BaseParams) (ret []domainevents.EventSubmission, totalData int64, err error) {
+func (r *repo) GetAllSubmissions(ownerId string, params filter.BaseParams) (ret []domainevents.EventSubmission, totalData int64, err error) {
 	query := r.DB.Model(&domainevents.EventSubmission{}).
 		Joins("LEFT JOIN events ON event_submissions.event_id = events.id AND events.deleted_at IS NULL")
 
+	if ownerId != "" {
+		query = query.Where(ownedEventCondition, ownerId, ownerId)
+	}
+
 	if params.Search != "" {
 		searchPattern := "%" + params.Search + "%"
 		query = query.Where("LOWER(events.title) LIKE LOWER(?)", searchPattern)
@@ -206,7 +799,7 @@ func (r *repo) GetAllSubmissions(params filter.BaseParams) (ret []domainevents.E
 		query = query.Order(fmt.Sprintf("event_submissions.%s %s", params.OrderBy, params.OrderDirection))
 	}
 
-	if err := query.Preload("Event").Preload("Vendor").Preload("Vendor.Profile").Preload("File").
+	if err := query.Preload("Event").Preload("Vendor").Preload("Vendor.Profile").Preload("File").Preload("Prices.BoqItem").
 		Offset(params.Offset).Limit(params.Limit).Find(&ret).Error; err != nil {
 		return nil, 0, err
 	}
@@ -229,7 +822,7 @@ func (r *repo) GetSubmissionsByVendorID(vendorId string, params filter.BaseParam
 		switch key {
 		case "event_id":
 			query = query.Where("event_submissions.event_id = ?", value)
-		case "is_shortlisted", "is_winner":
+		case "is_shortlisted", "is_winner", "status":
 			query = query.Where(fmt.Sprintf("event_submissions.%s = ?", key), value)
 		}
 	}
@@ -253,7 +846,7 @@ func (r *repo) GetSubmissionsByVendorID(vendorId string, params filter.BaseParam
 		orderDirection = "desc"
 	}
 
-	if err = query.Preload("Event").Preload("File").
+	if err = query.Preload("Event").Preload("File").Preload("Prices.BoqItem").
 		Order(fmt.Sprintf("%s %s", orderBy, orderDirection)).
 		Offset(params.Offset).Limit(params.Limit).
 		Find(&ret).Error; err != nil {
@@ -262,12 +855,19 @@ func (r *repo) GetSubmissionsByVendorID(vendorId string, params filter.BaseParam
 	return ret, totalData, nil
 }
 
-func (r *repo) GetGroupedSubmissions(params filter.BaseParams, submissionPage int, submissionLimit int) (*domainevents.GroupedSubmissionsResponse, error) {
+// ownedEventCondition keeps the events a user created or is on the team of
+const ownedEventCondition = "(events.created_by = ? OR EXISTS (SELECT 1 FROM event_team_members WHERE event_team_members.event_id = events.id AND event_team_members.user_id = ?))"
+
+func (r *repo) GetGroupedSubmissions(ownerId string, params filter.BaseParams, submissionPage int, submissionLimit int) (*domainevents.GroupedSubmissionsResponse, error) {
 	// Build base query
 	baseQuery := r.DB.Table("events").
 		Joins("INNER JOIN event_submissions ON event_submissions.event_id = events.id").
 		Where("events.deleted_at IS NULL")
 
+	if ownerId != "" {
+		baseQuery = baseQuery.Where(ownedEventCondition, ownerId, ownerId)
+	}
+
 	if params.Search != "" {
 		searchPattern := "%" + params.Search + "%"
 		baseQuery = baseQuery.Where("LOWER(events.title) LIKE LOWER(?)", searchPattern)
@@ -294,6 +894,11 @@ func (r *repo) GetGroupedSubmissions(params filter.BaseParams, submissionPage in
 		args = append(args, "%"+params.Search+"%")
 	}
 
+	if ownerId != "" {
+		query += " AND " + ownedEventCondition
+		args = append(args, ownerId, ownerId)
+	}
+
 	query += " ORDER BY events.id DESC LIMIT ? OFFSET ?"
 	args = append(args, params.Limit, params.Offset)
 
@@ -324,7 +929,7 @@ func (r *repo) GetGroupedSubmissions(params filter.BaseParams, submissionPage in
 		// Get paginated submissions
 		var submissions []domainevents.EventSubmission
 		submissionOffset := (submissionPage - 1) * submissionLimit
-		r.DB.Preload("Vendor").Preload("Vendor.Profile").Preload("File").
+		r.DB.Preload("Vendor").Preload("Vendor.Profile").Preload("File").Preload("Prices.BoqItem").
 			Where("event_id = ?", eventID).
 			Order("created_at DESC").
 			Offset(submissionOffset).Limit(submissionLimit).
@@ -345,6 +950,14 @@ func (r *repo) GetGroupedSubmissions(params filter.BaseParams, submissionPage in
 	return response, nil
 }
 
+func (r *repo) CountActiveSubmissionsByEventID(eventId string) (int64, error) {
+	var count int64
+	err := r.DB.Model(&domainevents.EventSubmission{}).
+		Where("event_id = ? AND status <> ?", eventId, utils.SubmissionWithdrawn).
+		Count(&count).Error
+	return count, err
+}
+
 func (r *repo) UpdateSubmission(m domainevents.EventSubmission) error {
 	return r.DB.Save(&m).Error
 }
@@ -365,6 +978,13 @@ func (r *repo) GetSubmissionFileByID(id string) (ret domainevents.EventSubmissio
 	return ret, nil
 }
 
+func (r *repo) GetSubmissionFilesBySubmissionID(submissionId string) (ret []domainevents.EventSubmissionFile, err error) {
+	if err = r.DB.Where("event_submission_id = ?", submissionId).Order("file_order ASC, created_at ASC").Find(&ret).Error; err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
 func (r *repo) CountSubmissionFilesBySubmissionID(submissionId string) (int64, error) {
 	var count int64
 	err := r.DB.Model(&domainevents.EventSubmissionFile{}).Where("event_submission_id = ?", submissionId).Count(&count).Error
@@ -374,3 +994,88 @@ func (r *repo) CountSubmissionFilesBySubmissionID(submissionId string) (int64, e
 func (r *repo) DeleteSubmissionFile(id string) error {
 	return r.DB.Where("id = ?", id).Delete(&domainevents.EventSubmissionFile{}).Error
 }
+
+func (r *repo) DeleteSubmissionFilesBySubmissionID(submissionId string) error {
+	return r.DB.Where("event_submission_id = ?", submissionId).Delete(&domainevents.EventSubmissionFile{}).Error
+}
+
+// ReplaceSubmissionFiles saves the submission and swaps its files in one transaction.
+// Current files not listed in keepFileIds are soft-deleted.
+func (r *repo) ReplaceSubmissionFiles(m domainevents.EventSubmission, keepFileIds []string, files []domainevents.EventSubmissionFile) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Save(&m).Error; err != nil {
+			return err
+		}
+
+		query := tx.Where("event_submission_id = ?", m.Id)
+		if len(keepFileIds) > 0 {
+			query = query.Where("id NOT IN ?", keepFileIds)
+		}
+		if err := query.Delete(&domainevents.EventSubmissionFile{}).Error; err != nil {
+			return err
+		}
+
+		if len(files) == 0 {
+			return nil
+		}
+		return tx.Create(&files).Error
+	})
+}
+
+// Submission price operations
+func (r *repo) GetSubmissionPrices(submissionId string) (ret []domainevents.EventSubmissionPrice, err error) {
+	if err = r.DB.Where("event_submission_id = ?", submissionId).Find(&ret).Error; err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// ReplaceSubmissionPrices swaps the submission's whole quotation in one transaction
+func (r *repo) ReplaceSubmissionPrices(submissionId string, prices []domainevents.EventSubmissionPrice) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("event_submission_id = ?", submissionId).Delete(&domainevents.EventSubmissionPrice{}).Error; err != nil {
+			return err
+		}
+
+		if len(prices) == 0 {
+			return nil
+		}
+		return tx.Create(&prices).Error
+	})
+}
+
+// Submission revision operations
+// Revisions are append-only; there is deliberately no update or delete.
+func (r *repo) CreateSubmissionRevision(m domainevents.EventSubmissionRevision) error {
+	return r.DB.Create(&m).Error
+}
+
+func (r *repo) GetSubmissionRevisions(submissionId string) (ret []domainevents.EventSubmissionRevision, err error) {
+	if err = r.DB.Preload("File", func(db *gorm.DB) *gorm.DB {
+		return db.Order("file_order ASC")
+	}).Preload("Prices").Where("event_submission_id = ?", submissionId).
+		Order("revision_number ASC").
+		Find(&ret).Error; err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// Calendar token operations
+func (r *repo) GetCalendarTokenByUserID(userId string) (ret domainevents.CalendarToken, err error) {
+	if err = r.DB.Where("user_id = ?", userId).First(&ret).Error; err != nil {
+		return domainevents.CalendarToken{}, err
+	}
+	return ret, nil
+}
+
+func (r *repo) GetCalendarTokenByToken(token string) (ret domainevents.CalendarToken, err error) {
+	if err = r.DB.Preload("User").Where("token = ?", token).First(&ret).Error; err != nil {
+		return domainevents.CalendarToken{}, err
+	}
+	return ret, nil
+}
+
+func (r *repo) SaveCalendarToken(m domainevents.CalendarToken) error {
+	return r.DB.Save(&m).Error
+}
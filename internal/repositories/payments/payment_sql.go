@@ -2,12 +2,16 @@ package repositorypayments
 
 import (
 	"fmt"
+	"time"
 
 	domainpayments "vendor-management-system/internal/domain/payments"
 	interfacepayments "vendor-management-system/internal/interfaces/payments"
 	"vendor-management-system/pkg/filter"
+	"vendor-management-system/utils"
 
+	"github.com/shopspring/decimal"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type repo struct {
@@ -27,6 +31,13 @@ func (r *repo) GetPaymentByID(id string) (ret domainpayments.Payment, err error)
 		Preload("File").
 		Preload("Vendor").
 		Preload("Vendor.Profile").
+		Preload("Approvals", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at ASC")
+		}).
+		Preload("Approvals.Approver").
+		Preload("Settlements", func(db *gorm.DB) *gorm.DB {
+			return db.Order("settlement_date ASC, created_at ASC")
+		}).
 		Where("id = ?", id).First(&ret).Error; err != nil {
 		return domainpayments.Payment{}, err
 	}
@@ -38,6 +49,9 @@ func (r *repo) GetPaymentsByVendorID(vendorId string) (ret []domainpayments.Paym
 		Preload("File").
 		Preload("Vendor").
 		Preload("Vendor.Profile").
+		Preload("Settlements", func(db *gorm.DB) *gorm.DB {
+			return db.Order("settlement_date ASC, created_at ASC")
+		}).
 		Where("vendor_id = ?", vendorId).Order("created_at DESC").Find(&ret).Error; err != nil {
 		return nil, err
 	}
@@ -110,6 +124,7 @@ func (r *repo) GetAllPayments(params filter.BaseParams) (ret []domainpayments.Pa
 			"amount":         true,
 			"status":         true,
 			"payment_date":   true,
+			"due_date":       true,
 			"created_at":     true,
 			"updated_at":     true,
 		}
@@ -124,6 +139,9 @@ func (r *repo) GetAllPayments(params filter.BaseParams) (ret []domainpayments.Pa
 	if err := query.Offset(params.Offset).Limit(params.Limit).
 		Preload("Vendor").
 		Preload("Vendor.Profile").
+		Preload("Settlements", func(db *gorm.DB) *gorm.DB {
+			return db.Order("settlement_date ASC, created_at ASC")
+		}).
 		Find(&ret).Error; err != nil {
 		return nil, 0, err
 	}
@@ -131,15 +149,106 @@ func (r *repo) GetAllPayments(params filter.BaseParams) (ret []domainpayments.Pa
 	return ret, totalData, nil
 }
 
+// UpdatePayment saves the payment except its paid amount, which only settlements change through AddPaidAmount
 func (r *repo) UpdatePayment(m domainpayments.Payment) error {
-	return r.DB.Save(&m).Error
+	return r.DB.Omit(clause.Associations, "paid_amount").Save(&m).Error
 }
 
-func (r *repo) DeletePayment(id string) error {
-	return r.DB.Where("id = ?", id).Delete(&domainpayments.Payment{}).Error
+func (r *repo) DeletePayment(id, userId string) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&domainpayments.Payment{}).Where("id = ?", id).Update("deleted_by", userId).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", id).Delete(&domainpayments.Payment{}).Error
+	})
+}
+
+// LockPaymentForUpdate reads the payment with a row lock; only meaningful inside a transaction
+func (r *repo) LockPaymentForUpdate(id string) (ret domainpayments.Payment, err error) {
+	if err = r.DB.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&ret).Error; err != nil {
+		return domainpayments.Payment{}, err
+	}
+	return ret, nil
 }
 
 // Payment file operations
+// Scheduled payment operations
+func (r *repo) CreatePayments(m []domainpayments.Payment) error {
+	if len(m) == 0 {
+		return nil
+	}
+	return r.DB.Omit(clause.Associations).Create(&m).Error
+}
+
+// CancelScheduledPayments cancels the payments of an award that have not been paid yet
+func (r *repo) CancelScheduledPayments(awardId, userId string) error {
+	return r.DB.Model(&domainpayments.Payment{}).
+		Where("award_id = ? AND status IN ?", awardId, []string{utils.PaymentScheduled, utils.PaymentPending, utils.PaymentApproved, utils.PaymentRejected}).
+		Updates(map[string]interface{}{"status": utils.PaymentCancelled, "updated_at": time.Now(), "updated_by": userId}).Error
+}
+
+// ReleaseScheduledPayments makes the vendor's scheduled payments with the given trigger payable
+func (r *repo) ReleaseScheduledPayments(eventId, vendorId, trigger, userId string) error {
+	return r.DB.Model(&domainpayments.Payment{}).
+		Where("event_id = ? AND vendor_id = ? AND release_trigger = ? AND status = ?", eventId, vendorId, trigger, utils.PaymentScheduled).
+		Updates(map[string]interface{}{"status": utils.PaymentPending, "updated_at": time.Now(), "updated_by": userId}).Error
+}
+
+// GetAwardPayments returns the payments of an award that were not cancelled, the earliest due first
+func (r *repo) GetAwardPayments(awardId string) (ret []domainpayments.Payment, err error) {
+	if err = r.DB.
+		Where("award_id = ? AND status <> ?", awardId, utils.PaymentCancelled).
+		Order("due_date ASC NULLS LAST, created_at ASC, invoice_number ASC").
+		Find(&ret).Error; err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// Payment settlement operations
+func (r *repo) CreatePaymentSettlement(m domainpayments.PaymentSettlement) error {
+	return r.DB.Create(&m).Error
+}
+
+// AddPaidAmount adds a settlement to the paid amount of a payment that is being paid, making it
+// partially paid or paid, and returns how many payments were updated: none when the settlement
+// would pay more than the transfer amount
+func (r *repo) AddPaidAmount(id string, amount, transferAmount decimal.Decimal, settlementDate time.Time, userId string) (int64, error) {
+	res := r.DB.Model(&domainpayments.Payment{}).
+		Where("id = ? AND status IN ? AND paid_amount + ? <= ?", id,
+			[]string{utils.PaymentApproved, utils.PaymentProcessing, utils.PaymentPartiallyPaid}, amount, transferAmount).
+		Updates(map[string]interface{}{
+			"paid_amount": gorm.Expr("paid_amount + ?", amount),
+			"status": gorm.Expr("CASE WHEN paid_amount + ? >= ? THEN CAST(? AS payment_status) ELSE CAST(? AS payment_status) END",
+				amount, transferAmount, utils.PaymentPaid, utils.PaymentPartiallyPaid),
+			"payment_date": settlementDate,
+			"updated_at":   time.Now(),
+			"updated_by":   userId,
+		})
+	return res.RowsAffected, res.Error
+}
+
+// Payment approval operations
+func (r *repo) GetApprovalLevels() (ret []domainpayments.PaymentApprovalLevel, err error) {
+	if err = r.DB.Order("level ASC").Find(&ret).Error; err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+func (r *repo) ReplaceApprovalLevels(levels []domainpayments.PaymentApprovalLevel) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("1 = 1").Delete(&domainpayments.PaymentApprovalLevel{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(&levels).Error
+	})
+}
+
+func (r *repo) CreatePaymentApproval(m domainpayments.PaymentApproval) error {
+	return r.DB.Omit(clause.Associations).Create(&m).Error
+}
+
 func (r *repo) CreatePaymentFile(m domainpayments.PaymentFile) error {
 	return r.DB.Create(&m).Error
 }
@@ -154,3 +263,429 @@ func (r *repo) GetPaymentFileByID(id string) (ret domainpayments.PaymentFile, er
 func (r *repo) DeletePaymentFile(id string) error {
 	return r.DB.Where("id = ?", id).Delete(&domainpayments.PaymentFile{}).Error
 }
+
+// Tax operations
+func (r *repo) GetTaxRules() (ret []domainpayments.TaxRule, err error) {
+	if err = r.DB.Order("vendor_type ASC, tax_status ASC").Find(&ret).Error; err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+func (r *repo) GetTaxRule(vendorType, taxStatus string) (ret domainpayments.TaxRule, err error) {
+	if err = r.DB.Where("vendor_type = ? AND tax_status = ?", vendorType, taxStatus).First(&ret).Error; err != nil {
+		return domainpayments.TaxRule{}, err
+	}
+	return ret, nil
+}
+
+// SaveTaxRules upserts the rules by vendor type and tax status
+func (r *repo) SaveTaxRules(rules []domainpayments.TaxRule) error {
+	return r.DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "vendor_type"}, {Name: "tax_status"}},
+		DoUpdates: clause.AssignmentColumns([]string{"vat_rate", "withholding_type", "withholding_rate", "no_npwp_withholding_rate", "updated_at", "updated_by"}),
+	}).Create(&rules).Error
+}
+
+// GetWithholdingSlips lists the paid payments with withheld tax whose payment date falls in [from, to)
+func (r *repo) GetWithholdingSlips(vendorId string, from, to time.Time) (ret []domainpayments.WithholdingSlip, err error) {
+	query := r.DB.Table("payments").
+		Select(`payments.id AS payment_id, payments.invoice_number, payments.vendor_id,
+			vendor_profiles.vendor_name, vendor_profiles.npwp_number, payments.withholding_type,
+			payments.tax_base, payments.withholding_rate, payments.withholding_amount, payments.payment_date`).
+		Joins("LEFT JOIN vendor_profiles ON payments.vendor_id = vendor_profiles.vendor_id AND vendor_profiles.deleted_at IS NULL").
+		Where("payments.deleted_at IS NULL AND payments.status = ? AND payments.withholding_amount > 0", utils.PaymentPaid).
+		Where("payments.payment_date >= ? AND payments.payment_date < ?", from, to)
+
+	if vendorId != "" {
+		query = query.Where("payments.vendor_id = ?", vendorId)
+	}
+
+	if err = query.Order("vendor_profiles.vendor_name ASC, payments.payment_date ASC").Scan(&ret).Error; err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// Disbursement operations
+func (r *repo) GetPaymentsByIDs(ids []string) (ret []domainpayments.Payment, err error) {
+	if err = r.DB.
+		Preload("Vendor").
+		Preload("Vendor.Profile").
+		Where("id IN ?", ids).Order("created_at ASC").Find(&ret).Error; err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+func (r *repo) CreateDisbursementBatch(m domainpayments.DisbursementBatch) error {
+	return r.DB.Omit(clause.Associations).Create(&m).Error
+}
+
+// MarkPaymentsProcessing moves the approved payments into the batch and returns how many were moved;
+// payments batched by a concurrent request are left alone
+func (r *repo) MarkPaymentsProcessing(ids []string, batchId, userId string) (int64, error) {
+	res := r.DB.Model(&domainpayments.Payment{}).
+		Where("id IN ? AND status = ? AND disbursement_batch_id IS NULL", ids, utils.PaymentApproved).
+		Updates(map[string]interface{}{
+			"status":                utils.PaymentProcessing,
+			"disbursement_batch_id": batchId,
+			"updated_at":            time.Now(),
+			"updated_by":            userId,
+		})
+	return res.RowsAffected, res.Error
+}
+
+func (r *repo) GetDisbursementBatchByID(id string) (ret domainpayments.DisbursementBatch, err error) {
+	if err = r.DB.
+		Preload("Payments", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at ASC")
+		}).
+		Preload("Payments.Vendor").
+		Preload("Payments.Vendor.Profile").
+		Where("id = ?", id).First(&ret).Error; err != nil {
+		return domainpayments.DisbursementBatch{}, err
+	}
+	return ret, nil
+}
+
+func (r *repo) GetAllDisbursementBatches(params filter.BaseParams) (ret []domainpayments.DisbursementBatch, totalData int64, err error) {
+	query := r.DB.Model(&domainpayments.DisbursementBatch{})
+
+	if params.Search != "" {
+		query = query.Where("LOWER(batch_number) LIKE LOWER(?)", "%"+params.Search+"%")
+	}
+
+	for key, value := range params.Filters {
+		if value == nil {
+			continue
+		}
+
+		switch v := value.(type) {
+		case string:
+			if v == "" {
+				continue
+			}
+			query = query.Where(fmt.Sprintf("%s = ?", key), v)
+		case []string, []int:
+			query = query.Where(fmt.Sprintf("%s IN ?", key), v)
+		default:
+			query = query.Where(fmt.Sprintf("%s = ?", key), v)
+		}
+	}
+
+	if err := query.Count(&totalData).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if params.OrderBy != "" && params.OrderDirection != "" {
+		validColumns := map[string]bool{
+			"batch_number":  true,
+			"total_amount":  true,
+			"payment_count": true,
+			"created_at":    true,
+		}
+
+		if _, ok := validColumns[params.OrderBy]; !ok {
+			return nil, 0, fmt.Errorf("invalid orderBy column: %s", params.OrderBy)
+		}
+
+		query = query.Order(fmt.Sprintf("%s %s", params.OrderBy, params.OrderDirection))
+	}
+
+	if err := query.Offset(params.Offset).Limit(params.Limit).Find(&ret).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return ret, totalData, nil
+}
+
+// Reconciliation operations
+
+// GetOutstandingPayments lists the payments whose transfer can still appear on a bank statement
+func (r *repo) GetOutstandingPayments() (ret []domainpayments.Payment, err error) {
+	if err = r.DB.
+		Preload("Vendor").
+		Preload("Vendor.Profile").
+		Where("status IN ?", []string{utils.PaymentApproved, utils.PaymentProcessing, utils.PaymentPartiallyPaid}).
+		Order("created_at ASC").Find(&ret).Error; err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+func (r *repo) BankStatementExists(checksum string) (bool, error) {
+	var count int64
+	if err := r.DB.Model(&domainpayments.BankStatement{}).Where("checksum = ?", checksum).Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *repo) CreateBankStatement(m domainpayments.BankStatement) error {
+	return r.DB.Omit(clause.Associations).Create(&m).Error
+}
+
+func (r *repo) CreateBankStatementLines(m []domainpayments.BankStatementLine) error {
+	if len(m) == 0 {
+		return nil
+	}
+	return r.DB.Omit(clause.Associations).Create(&m).Error
+}
+
+func (r *repo) CreateBankStatementSuggestions(m []domainpayments.BankStatementSuggestion) error {
+	if len(m) == 0 {
+		return nil
+	}
+	return r.DB.Omit(clause.Associations).Create(&m).Error
+}
+
+func (r *repo) GetBankStatementByID(id string) (ret domainpayments.BankStatement, err error) {
+	if err = r.DB.
+		Preload("Lines", func(db *gorm.DB) *gorm.DB {
+			return db.Order("line_no ASC")
+		}).
+		Preload("Lines.Payment").
+		Preload("Lines.Payment.Vendor").
+		Preload("Lines.Payment.Vendor.Profile").
+		Preload("Lines.Suggestions").
+		Preload("Lines.Suggestions.Payment").
+		Preload("Lines.Suggestions.Payment.Vendor").
+		Preload("Lines.Suggestions.Payment.Vendor.Profile").
+		Where("id = ?", id).First(&ret).Error; err != nil {
+		return domainpayments.BankStatement{}, err
+	}
+	return ret, nil
+}
+
+func (r *repo) GetAllBankStatements(params filter.BaseParams) (ret []domainpayments.BankStatement, totalData int64, err error) {
+	query := r.DB.Model(&domainpayments.BankStatement{})
+
+	if params.Search != "" {
+		query = query.Where("LOWER(file_name) LIKE LOWER(?)", "%"+params.Search+"%")
+	}
+
+	for key, value := range params.Filters {
+		if value == nil {
+			continue
+		}
+
+		switch v := value.(type) {
+		case string:
+			if v == "" {
+				continue
+			}
+			query = query.Where(fmt.Sprintf("%s = ?", key), v)
+		case []string, []int:
+			query = query.Where(fmt.Sprintf("%s IN ?", key), v)
+		default:
+			query = query.Where(fmt.Sprintf("%s = ?", key), v)
+		}
+	}
+
+	if err := query.Count(&totalData).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if params.OrderBy != "" && params.OrderDirection != "" {
+		validColumns := map[string]bool{
+			"file_name":   true,
+			"period_from": true,
+			"period_to":   true,
+			"created_at":  true,
+		}
+
+		if _, ok := validColumns[params.OrderBy]; !ok {
+			return nil, 0, fmt.Errorf("invalid orderBy column: %s", params.OrderBy)
+		}
+
+		query = query.Order(fmt.Sprintf("%s %s", params.OrderBy, params.OrderDirection))
+	}
+
+	if err := query.Offset(params.Offset).Limit(params.Limit).Find(&ret).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return ret, totalData, nil
+}
+
+func (r *repo) GetBankStatementLineByID(id string) (ret domainpayments.BankStatementLine, err error) {
+	if err = r.DB.
+		Preload("Payment").
+		Preload("Suggestions").
+		Preload("Suggestions.Payment").
+		Where("id = ?", id).First(&ret).Error; err != nil {
+		return domainpayments.BankStatementLine{}, err
+	}
+	return ret, nil
+}
+
+func (r *repo) GetBankStatementLinesByStatus(status string) (ret []domainpayments.BankStatementLine, err error) {
+	if err = r.DB.
+		Preload("Suggestions").
+		Preload("Suggestions.Payment").
+		Preload("Suggestions.Payment.Vendor").
+		Preload("Suggestions.Payment.Vendor.Profile").
+		Where("status = ?", status).
+		Order("entry_date ASC, line_no ASC").Find(&ret).Error; err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// ResolveBankStatementLine records how a line under review or unmatched was resolved and returns how
+// many lines were updated: none when the line was resolved meanwhile
+func (r *repo) ResolveBankStatementLine(m domainpayments.BankStatementLine) (int64, error) {
+	res := r.DB.Model(&domainpayments.BankStatementLine{}).
+		Where("id = ? AND status IN ?", m.Id, []string{utils.StatementLineReview, utils.StatementLineUnmatched}).
+		Updates(map[string]interface{}{
+			"status":          m.Status,
+			"payment_id":      m.PaymentID,
+			"resolved_at":     m.ResolvedAt,
+			"resolved_by":     m.ResolvedBy,
+			"resolution_note": m.ResolutionNote,
+		})
+	return res.RowsAffected, res.Error
+}
+
+// Invoice operations
+func (r *repo) CreateInvoice(m domainpayments.Invoice) error {
+	return r.DB.Omit("Vendor").Create(&m).Error
+}
+
+func (r *repo) GetInvoiceByID(id string) (ret domainpayments.Invoice, err error) {
+	if err = r.DB.
+		Preload("File").
+		Preload("Vendor").
+		Preload("Vendor.Profile").
+		Where("id = ?", id).First(&ret).Error; err != nil {
+		return domainpayments.Invoice{}, err
+	}
+	return ret, nil
+}
+
+// InvoiceNumberExists reports whether the vendor already has an invoice with the number that was not rejected
+func (r *repo) InvoiceNumberExists(vendorId, invoiceNumber string) (bool, error) {
+	var count int64
+	if err := r.DB.Model(&domainpayments.Invoice{}).
+		Where("vendor_id = ? AND invoice_number = ? AND status <> ?", vendorId, invoiceNumber, utils.InvoiceRejected).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *repo) GetAllInvoices(params filter.BaseParams) (ret []domainpayments.Invoice, totalData int64, err error) {
+	query := r.DB.Model(&domainpayments.Invoice{}).
+		Joins("LEFT JOIN vendor_profiles ON invoices.vendor_id = vendor_profiles.vendor_id AND vendor_profiles.deleted_at IS NULL")
+
+	if params.Search != "" {
+		searchPattern := "%" + params.Search + "%"
+		query = query.Where("LOWER(invoices.invoice_number) LIKE LOWER(?) OR LOWER(vendor_profiles.vendor_name) LIKE LOWER(?)", searchPattern, searchPattern)
+	}
+
+	for key, value := range params.Filters {
+		if value == nil {
+			continue
+		}
+
+		switch v := value.(type) {
+		case string:
+			if v == "" {
+				continue
+			}
+			query = query.Where(fmt.Sprintf("invoices.%s = ?", key), v)
+		case []string, []int:
+			query = query.Where(fmt.Sprintf("invoices.%s IN ?", key), v)
+		default:
+			query = query.Where(fmt.Sprintf("invoices.%s = ?", key), v)
+		}
+	}
+
+	if err := query.Count(&totalData).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Add Select after Count to specify columns for final query
+	query = query.Select("invoices.*")
+
+	if params.OrderBy != "" && params.OrderDirection != "" {
+		validColumns := map[string]bool{
+			"invoice_number": true,
+			"invoice_date":   true,
+			"due_date":       true,
+			"amount":         true,
+			"status":         true,
+			"created_at":     true,
+			"updated_at":     true,
+		}
+
+		if _, ok := validColumns[params.OrderBy]; !ok {
+			return nil, 0, fmt.Errorf("invalid orderBy column: %s", params.OrderBy)
+		}
+
+		query = query.Order(fmt.Sprintf("invoices.%s %s", params.OrderBy, params.OrderDirection))
+	}
+
+	if err := query.
+		Preload("Vendor").
+		Preload("Vendor.Profile").
+		Offset(params.Offset).Limit(params.Limit).Find(&ret).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return ret, totalData, nil
+}
+
+// MarkInvoiceVerified verifies a submitted invoice and returns how many invoices were updated:
+// none when the invoice is no longer submitted
+func (r *repo) MarkInvoiceVerified(id, userId string, verifiedAt time.Time) (int64, error) {
+	res := r.DB.Model(&domainpayments.Invoice{}).
+		Where("id = ? AND status = ?", id, utils.InvoiceSubmitted).
+		Updates(map[string]interface{}{
+			"status":      utils.InvoiceVerified,
+			"verified_at": verifiedAt,
+			"verified_by": userId,
+			"updated_at":  verifiedAt,
+			"updated_by":  userId,
+		})
+	return res.RowsAffected, res.Error
+}
+
+// MarkInvoiceRejected rejects a submitted or verified invoice and returns how many invoices were
+// updated: none when the invoice was converted or rejected meanwhile
+func (r *repo) MarkInvoiceRejected(id, reason, userId string) (int64, error) {
+	res := r.DB.Model(&domainpayments.Invoice{}).
+		Where("id = ? AND status IN ?", id, []string{utils.InvoiceSubmitted, utils.InvoiceVerified}).
+		Updates(map[string]interface{}{
+			"status":           utils.InvoiceRejected,
+			"rejection_reason": reason,
+			"updated_at":       time.Now(),
+			"updated_by":       userId,
+		})
+	return res.RowsAffected, res.Error
+}
+
+// GetAwardInvoices returns the invoices of an award that are submitted, verified or converted
+func (r *repo) GetAwardInvoices(awardId string) (ret []domainpayments.Invoice, err error) {
+	if err = r.DB.
+		Where("award_id = ? AND status IN ?", awardId, []string{utils.InvoiceSubmitted, utils.InvoiceVerified, utils.InvoiceConverted}).
+		Find(&ret).Error; err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// MarkInvoiceConverted links a verified invoice to its payment and returns how many invoices were
+// updated: none when the invoice is no longer verified
+func (r *repo) MarkInvoiceConverted(id, paymentId, userId string) (int64, error) {
+	res := r.DB.Model(&domainpayments.Invoice{}).
+		Where("id = ? AND status = ?", id, utils.InvoiceVerified).
+		Updates(map[string]interface{}{
+			"status":     utils.InvoiceConverted,
+			"payment_id": paymentId,
+			"updated_at": time.Now(),
+			"updated_by": userId,
+		})
+	return res.RowsAffected, res.Error
+}
@@ -0,0 +1,30 @@
+package repositoryuow
+
+import (
+	interfaceuow "vendor-management-system/internal/interfaces/uow"
+	repositoryevaluations "vendor-management-system/internal/repositories/evaluations"
+	repositoryevents "vendor-management-system/internal/repositories/events"
+	repositorypayments "vendor-management-system/internal/repositories/payments"
+	repositoryvendors "vendor-management-system/internal/repositories/vendors"
+
+	"gorm.io/gorm"
+)
+
+type unitOfWork struct {
+	DB *gorm.DB
+}
+
+func NewUnitOfWork(db *gorm.DB) interfaceuow.UnitOfWorkInterface {
+	return &unitOfWork{DB: db}
+}
+
+func (u *unitOfWork) Do(fn func(repos interfaceuow.Repositories) error) error {
+	return u.DB.Transaction(func(tx *gorm.DB) error {
+		return fn(interfaceuow.Repositories{
+			Event:      repositoryevents.NewEventRepo(tx),
+			Vendor:     repositoryvendors.NewVendorRepo(tx),
+			Payment:    repositorypayments.NewPaymentRepo(tx),
+			Evaluation: repositoryevaluations.NewEvaluationRepo(tx),
+		})
+	})
+}
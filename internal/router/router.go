@@ -29,6 +29,7 @@ import (
 	permissionRepo "vendor-management-system/internal/repositories/permission"
 	roleRepo "vendor-management-system/internal/repositories/role"
 	sessionRepo "vendor-management-system/internal/repositories/session"
+	uowRepo "vendor-management-system/internal/repositories/uow"
 	userRepo "vendor-management-system/internal/repositories/user"
 	vendorRepo "vendor-management-system/internal/repositories/vendors"
 	evaluationSvc "vendor-management-system/internal/services/evaluations"
@@ -43,6 +44,7 @@ import (
 	userSvc "vendor-management-system/internal/services/user"
 	vendorSvc "vendor-management-system/internal/services/vendors"
 	"vendor-management-system/middlewares"
+	"vendor-management-system/pkg/broadcast"
 	"vendor-management-system/pkg/logger"
 	"vendor-management-system/pkg/security"
 	"vendor-management-system/utils"
@@ -262,7 +264,7 @@ func (r *Routes) VendorRoutes() {
 	}
 
 	repo := vendorRepo.NewVendorRepo(r.DB)
-	svc := vendorSvc.NewVendorService(repo, storageProvider)
+	svc := vendorSvc.NewVendorService(repo, storageProvider, uowRepo.NewUnitOfWork(r.DB))
 	h := vendorHandler.NewVendorHandler(svc)
 	pRepo := permissionRepo.NewPermissionRepo(r.DB)
 	mdw := middlewares.NewMiddleware(authRepo.NewBlacklistRepo(r.DB), pRepo)
@@ -298,38 +300,93 @@ func (r *Routes) EventRoutes() {
 	eRepo := eventRepo.NewEventRepo(r.DB)
 	nRepo := notificationRepo.NewNotificationRepo(r.DB)
 	nSvc := notificationSvc.NewNotificationService(nRepo)
-	svc := eventSvc.NewEventService(eRepo, vRepo, nSvc, storageProvider)
-	h := eventHandler.NewEventHandler(svc, vRepo)
+	// Sealed bids stay unavailable unless an encryption key is configured
+	bidCipher, err := security.NewBidCipher(utils.GetEnv("BID_ENCRYPTION_KEY", "").(string))
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, "Sealed bids disabled: "+err.Error())
+	}
+
 	pRepo := permissionRepo.NewPermissionRepo(r.DB)
+	svc := eventSvc.NewEventService(eRepo, vRepo, nSvc, pRepo, storageProvider, bidCipher, uowRepo.NewUnitOfWork(r.DB), broadcast.NewBroker())
+	h := eventHandler.NewEventHandler(svc, vRepo)
 	mdw := middlewares.NewMiddleware(authRepo.NewBlacklistRepo(r.DB), pRepo)
 
 	// Public event list (for vendors to see open events)
 	r.App.GET("/api/events", mdw.AuthMiddleware(), mdw.PermissionMiddleware("event", "list"), h.GetAllEvents)
 	r.App.GET("/api/event/:id", mdw.AuthMiddleware(), mdw.PermissionMiddleware("event", "view"), h.GetEventByID)
+	r.App.GET("/api/event/:id/boq", mdw.AuthMiddleware(), mdw.PermissionMiddleware("event", "view"), h.GetBoqItems)
+	r.App.GET("/api/event/:id/addenda", mdw.AuthMiddleware(), mdw.PermissionMiddleware("event", "view"), h.GetAddenda)
+
+	// Calendar feed of event deadlines; the feed itself is authenticated by its token
+	r.App.GET("/api/calendar/token", mdw.AuthMiddleware(), mdw.PermissionMiddleware("calendar", "manage"), h.GetCalendarToken)
+	r.App.POST("/api/calendar/token", mdw.AuthMiddleware(), mdw.PermissionMiddleware("calendar", "manage"), h.RegenerateCalendarToken)
+	r.App.GET("/api/calendar/:token", h.GetCalendarFeed)
 
 	// Client/Admin event management
 	eventAdmin := r.App.Group("/api/event").Use(mdw.AuthMiddleware())
 	{
 		eventAdmin.POST("", mdw.PermissionMiddleware("event", "create"), h.CreateEvent)
-		eventAdmin.PUT("/:id", mdw.PermissionMiddleware("event", "update"), h.UpdateEvent)
-		eventAdmin.DELETE("/:id", mdw.PermissionMiddleware("event", "delete"), h.DeleteEvent)
-		eventAdmin.POST("/:id/files", mdw.PermissionMiddleware("event", "update"), h.UploadEventFile)
-		eventAdmin.DELETE("/:id/files/:fileId", mdw.PermissionMiddleware("event", "update"), h.DeleteEventFile)
+		eventAdmin.PUT("/:id", mdw.PermissionMiddleware("event", "update"), mdw.OwnershipMiddleware("event", h.EventOwners), h.UpdateEvent)
+		eventAdmin.DELETE("/:id", mdw.PermissionMiddleware("event", "delete"), mdw.OwnershipMiddleware("event", h.EventOwners), h.DeleteEvent)
+		eventAdmin.GET("/:id/team", mdw.PermissionMiddleware("event", "update"), mdw.OwnershipMiddleware("event", h.EventOwners), h.GetEventTeam)
+		eventAdmin.POST("/:id/team", mdw.PermissionMiddleware("event", "update"), mdw.OwnershipMiddleware("event", h.EventOwners), h.AddEventTeamMembers)
+		eventAdmin.DELETE("/:id/team/:userId", mdw.PermissionMiddleware("event", "update"), mdw.OwnershipMiddleware("event", h.EventOwners), h.RemoveEventTeamMember)
+		eventAdmin.POST("/:id/deadline/extend", mdw.PermissionMiddleware("event", "extend_deadline"), mdw.OwnershipMiddleware("event", h.EventOwners), h.ExtendDeadline)
+		eventAdmin.POST("/:id/cancel", mdw.PermissionMiddleware("event", "cancel"), mdw.OwnershipMiddleware("event", h.EventOwners), h.CancelEvent)
+		eventAdmin.POST("/:id/clone", mdw.PermissionMiddleware("event", "create"), mdw.OwnershipMiddleware("event", h.EventOwners), h.CloneEvent)
+		eventAdmin.POST("/:id/template", mdw.PermissionMiddleware("event", "manage_template"), mdw.OwnershipMiddleware("event", h.EventOwners), h.SaveEventTemplate)
+		eventAdmin.GET("/templates", mdw.PermissionMiddleware("event", "create"), h.GetEventTemplates)
+		eventAdmin.GET("/template/:id", mdw.PermissionMiddleware("event", "create"), h.GetEventTemplateByID)
+		eventAdmin.POST("/template/:id/event", mdw.PermissionMiddleware("event", "create"), h.CreateEventFromTemplate)
+		eventAdmin.DELETE("/template/:id", mdw.PermissionMiddleware("event", "manage_template"), h.DeleteEventTemplate)
+		eventAdmin.POST("/:id/files", mdw.PermissionMiddleware("event", "update"), mdw.OwnershipMiddleware("event", h.EventOwners), h.UploadEventFile)
+		eventAdmin.DELETE("/:id/files/:fileId", mdw.PermissionMiddleware("event", "update"), mdw.OwnershipMiddleware("event", h.EventOwners), h.DeleteEventFile)
+		eventAdmin.POST("/:id/addenda", mdw.PermissionMiddleware("event", "issue_addendum"), mdw.OwnershipMiddleware("event", h.EventOwners), h.IssueAddendum)
+		eventAdmin.GET("/:id/budget", mdw.PermissionMiddleware("event", "manage_budget"), mdw.OwnershipMiddleware("event", h.EventOwners), h.GetEventBudget)
+		eventAdmin.PUT("/:id/budget", mdw.PermissionMiddleware("event", "manage_budget"), mdw.OwnershipMiddleware("event", h.EventOwners), h.SetEventBudget)
+		eventAdmin.PUT("/:id/boq", mdw.PermissionMiddleware("event", "update"), mdw.OwnershipMiddleware("event", h.EventOwners), h.SetBoqItems)
+		eventAdmin.GET("/:id/boq/comparison", mdw.PermissionMiddleware("event", "view_submissions"), mdw.OwnershipMiddleware("event", h.EventOwners), h.GetQuotationComparison)
+		eventAdmin.GET("/:id/boq/comparison/export", mdw.PermissionMiddleware("event", "view_submissions"), mdw.OwnershipMiddleware("event", h.EventOwners), h.ExportQuotationComparison)
+		eventAdmin.GET("/:id/invitations", mdw.PermissionMiddleware("event", "manage_invitations"), mdw.OwnershipMiddleware("event", h.EventOwners), h.GetEventInvitations)
+		eventAdmin.POST("/:id/invitations", mdw.PermissionMiddleware("event", "manage_invitations"), mdw.OwnershipMiddleware("event", h.EventOwners), h.InviteVendors)
+		eventAdmin.DELETE("/:id/invitations/:vendorId", mdw.PermissionMiddleware("event", "manage_invitations"), mdw.OwnershipMiddleware("event", h.EventOwners), h.RemoveInvitation)
+		eventAdmin.GET("/:id/bids/opening", mdw.PermissionMiddleware("event", "open_bids"), mdw.OwnershipMiddleware("event", h.EventOwners), h.GetBidOpeningStatus)
+		eventAdmin.POST("/:id/bids/open", mdw.PermissionMiddleware("event", "open_bids"), mdw.OwnershipMiddleware("event", h.EventOwners), h.OpenBids)
+		eventAdmin.GET("/:id/questions", mdw.PermissionMiddleware("event", "answer_question"), mdw.OwnershipMiddleware("event", h.EventOwners), h.GetEventQuestions)
+		eventAdmin.PUT("/question/:id/answer", mdw.PermissionMiddleware("event", "answer_question"), mdw.OwnershipMiddleware("event", h.QuestionOwners), h.AnswerQuestion)
 		eventAdmin.GET("/submissions", mdw.PermissionMiddleware("event", "list_submissions"), h.GetAllSubmissions)
 		eventAdmin.GET("/submissions/grouped", mdw.PermissionMiddleware("event", "list_submissions"), h.GetGroupedSubmissions)
-		eventAdmin.GET("/:id/submissions", mdw.PermissionMiddleware("event", "view_submissions"), h.GetSubmissionsByEventID)
-		eventAdmin.PUT("/submission/:id/score", mdw.PermissionMiddleware("event", "score"), h.ScoreSubmission)
-		eventAdmin.PUT("/submission/:id/shortlist", mdw.PermissionMiddleware("event", "score"), h.ShortlistSubmission)
-		eventAdmin.POST("/:id/winner", mdw.PermissionMiddleware("event", "select_winner"), h.SelectWinner)
-		eventAdmin.GET("/:id/result", mdw.PermissionMiddleware("event", "view_submissions"), h.GetEventResultForAdmin)
+		eventAdmin.GET("/:id/submissions", mdw.PermissionMiddleware("event", "view_submissions"), mdw.OwnershipMiddleware("event", h.EventOwners), h.GetSubmissionsByEventID)
+		eventAdmin.GET("/:id/submissions/export", mdw.PermissionMiddleware("event", "view_submissions"), mdw.OwnershipMiddleware("event", h.EventOwners), h.ExportSubmissions)
+		eventAdmin.GET("/submission/:id/revisions", mdw.PermissionMiddleware("event", "view_submissions"), mdw.OwnershipMiddleware("event", h.SubmissionOwners), h.GetSubmissionRevisions)
+		eventAdmin.GET("/submission/file/:id", mdw.PermissionMiddleware("event", "view_submissions"), mdw.OwnershipMiddleware("event", h.SubmissionFileOwners), h.DownloadSubmissionFile)
+		eventAdmin.PUT("/submission/:id/score", mdw.PermissionMiddleware("event", "score"), mdw.OwnershipMiddleware("event", h.SubmissionOwners), h.ScoreSubmission)
+		eventAdmin.PUT("/submission/:id/shortlist", mdw.PermissionMiddleware("event", "score"), mdw.OwnershipMiddleware("event", h.SubmissionOwners), h.ShortlistSubmission)
+		eventAdmin.POST("/:id/winner", mdw.PermissionMiddleware("event", "select_winner"), mdw.OwnershipMiddleware("event", h.EventOwners), h.SelectWinner)
+		eventAdmin.POST("/award/:id/revoke", mdw.PermissionMiddleware("event", "revoke_award"), mdw.OwnershipMiddleware("event", h.AwardOwners), h.RevokeAward)
+		eventAdmin.GET("/:id/result", mdw.PermissionMiddleware("event", "view_submissions"), mdw.OwnershipMiddleware("event", h.EventOwners), h.GetEventResultForAdmin)
+		eventAdmin.GET("/:id/history", mdw.PermissionMiddleware("event", "view_submissions"), mdw.OwnershipMiddleware("event", h.EventOwners), h.GetEventHistory)
+		eventAdmin.GET("/:id/auction", mdw.PermissionMiddleware("event", "view_submissions"), mdw.OwnershipMiddleware("event", h.EventOwners), h.GetAuctionLeaderboard)
+		eventAdmin.GET("/:id/auction/stream", mdw.PermissionMiddleware("event", "view_submissions"), mdw.OwnershipMiddleware("event", h.EventOwners), h.StreamAuctionLeaderboard)
+		eventAdmin.POST("/:id/auction/close", mdw.PermissionMiddleware("event", "close_auction"), mdw.OwnershipMiddleware("event", h.EventOwners), h.CloseAuction)
 	}
 
 	// Vendor submission routes
 	vendorEvent := r.App.Group("/api/vendor/event").Use(mdw.AuthMiddleware())
 	{
 		vendorEvent.POST("/:id/submit", mdw.PermissionMiddleware("event", "submit_pitch"), h.SubmitPitch)
+		vendorEvent.PUT("/:id/submission", mdw.PermissionMiddleware("event", "submit_pitch"), h.AmendSubmission)
+		vendorEvent.POST("/:id/withdraw", mdw.PermissionMiddleware("event", "submit_pitch"), h.WithdrawSubmission)
+		vendorEvent.GET("/:id/submission/revisions", mdw.PermissionMiddleware("event", "view_my_submissions"), h.GetMySubmissionRevisions)
+		vendorEvent.POST("/:id/questions", mdw.PermissionMiddleware("event", "ask_question"), h.AskQuestion)
+		vendorEvent.POST("/addendum/:id/acknowledge", mdw.PermissionMiddleware("event", "submit_pitch"), h.AcknowledgeAddendum)
+		vendorEvent.GET("/:id/questions", mdw.PermissionMiddleware("event", "view"), h.GetVendorQuestions)
 		vendorEvent.GET("/submissions", mdw.PermissionMiddleware("event", "view_my_submissions"), h.GetMySubmissions)
+		vendorEvent.GET("/submission/file/:id", mdw.PermissionMiddleware("event", "view_my_submissions"), h.DownloadMySubmissionFile)
 		vendorEvent.GET("/:id/result", mdw.PermissionMiddleware("event", "view"), h.GetEventResult)
+		vendorEvent.POST("/:id/auction/bid", mdw.PermissionMiddleware("event", "bid"), h.PlaceAuctionBid)
+		vendorEvent.GET("/:id/auction", mdw.PermissionMiddleware("event", "view"), h.GetAuctionStatus)
+		vendorEvent.GET("/:id/auction/stream", mdw.PermissionMiddleware("event", "view"), h.StreamAuctionStatus)
 	}
 }
 
@@ -343,14 +400,16 @@ func (r *Routes) PaymentRoutes() {
 
 	vRepo := vendorRepo.NewVendorRepo(r.DB)
 	pRepo := paymentRepo.NewPaymentRepo(r.DB)
-	svc := paymentSvc.NewPaymentService(pRepo, vRepo, storageProvider)
-	h := paymentHandler.NewPaymentHandler(svc, vRepo)
+	evRepo := evaluationRepo.NewEvaluationRepo(r.DB)
 	permRepo := permissionRepo.NewPermissionRepo(r.DB)
+	svc := paymentSvc.NewPaymentService(pRepo, vRepo, evRepo, eventRepo.NewEventRepo(r.DB), permRepo, storageProvider, uowRepo.NewUnitOfWork(r.DB))
+	h := paymentHandler.NewPaymentHandler(svc, vRepo)
 	mdw := middlewares.NewMiddleware(authRepo.NewBlacklistRepo(r.DB), permRepo)
 
 	// Vendor can only view their payments
 	r.App.GET("/api/vendor/payments", mdw.AuthMiddleware(), mdw.PermissionMiddleware("payment", "view"), h.GetMyPayments)
 	r.App.GET("/api/vendor/payment/:id", mdw.AuthMiddleware(), mdw.PermissionMiddleware("payment", "view"), h.GetMyPaymentByID)
+	r.App.GET("/api/vendor/withholding-slips", mdw.AuthMiddleware(), mdw.PermissionMiddleware("payment", "view"), h.GetMyWithholdingSlips)
 
 	// Admin payment management
 	paymentAdmin := r.App.Group("/api/payment").Use(mdw.AuthMiddleware())
@@ -359,12 +418,50 @@ func (r *Routes) PaymentRoutes() {
 		paymentAdmin.GET("/:id", mdw.PermissionMiddleware("payment", "view"), h.GetPaymentByID)
 		paymentAdmin.PUT("/:id", mdw.PermissionMiddleware("payment", "update"), h.UpdatePayment)
 		paymentAdmin.PUT("/:id/status", mdw.PermissionMiddleware("payment", "update"), h.UpdatePaymentStatus)
+		paymentAdmin.POST("/:id/settlements", mdw.PermissionMiddleware("payment", "settle"), h.CreatePaymentSettlement)
+		paymentAdmin.POST("/:id/approve", mdw.PermissionMiddleware("payment", "approve"), h.ApprovePayment)
+		paymentAdmin.POST("/:id/reject", mdw.PermissionMiddleware("payment", "approve"), h.RejectPayment)
+		paymentAdmin.GET("/approval-levels", mdw.PermissionMiddleware("payment", "list"), h.GetApprovalLevels)
+		paymentAdmin.PUT("/approval-levels", mdw.PermissionMiddleware("payment", "manage_approval_levels"), h.SetApprovalLevels)
+		paymentAdmin.PUT("/:id/tax", mdw.PermissionMiddleware("payment", "override_tax"), h.OverridePaymentTax)
+		paymentAdmin.GET("/tax-rules", mdw.PermissionMiddleware("payment", "list"), h.GetTaxRules)
+		paymentAdmin.PUT("/tax-rules", mdw.PermissionMiddleware("payment", "manage_tax_rules"), h.SetTaxRules)
 		paymentAdmin.DELETE("/:id", mdw.PermissionMiddleware("payment", "delete"), h.DeletePayment)
 		paymentAdmin.POST("/:id/files", mdw.PermissionMiddleware("payment", "update"), h.UploadPaymentFile)
 		paymentAdmin.DELETE("/:id/files/:file_id", mdw.PermissionMiddleware("payment", "update"), h.DeletePaymentFile)
 	}
 
 	r.App.GET("/api/payments", mdw.AuthMiddleware(), mdw.PermissionMiddleware("payment", "list"), h.GetAllPayments)
+	r.App.GET("/api/payments/withholding-slips", mdw.AuthMiddleware(), mdw.PermissionMiddleware("payment", "list"), h.GetWithholdingSlips)
+
+	// Bulk transfer files of approved payments
+	r.App.POST("/api/payments/disbursement-batch", mdw.AuthMiddleware(), mdw.PermissionMiddleware("payment", "disburse"), h.CreateDisbursementBatch)
+	r.App.GET("/api/payments/disbursement-batches", mdw.AuthMiddleware(), mdw.PermissionMiddleware("payment", "list"), h.GetAllDisbursementBatches)
+	r.App.GET("/api/payments/disbursement-batch/:id", mdw.AuthMiddleware(), mdw.PermissionMiddleware("payment", "list"), h.GetDisbursementBatchByID)
+	r.App.GET("/api/payments/disbursement-batch/:id/file", mdw.AuthMiddleware(), mdw.PermissionMiddleware("payment", "disburse"), h.DownloadDisbursementFile)
+
+	// Bank statement reconciliation
+	r.App.POST("/api/payments/bank-statement", mdw.AuthMiddleware(), mdw.PermissionMiddleware("payment", "reconcile"), h.ImportBankStatement)
+	r.App.GET("/api/payments/bank-statements", mdw.AuthMiddleware(), mdw.PermissionMiddleware("payment", "list"), h.GetAllBankStatements)
+	r.App.GET("/api/payments/bank-statement/:id", mdw.AuthMiddleware(), mdw.PermissionMiddleware("payment", "list"), h.GetReconciliationReport)
+	r.App.GET("/api/payments/reconciliation-queue", mdw.AuthMiddleware(), mdw.PermissionMiddleware("payment", "reconcile"), h.GetReconciliationQueue)
+	r.App.PUT("/api/payments/statement-line/:id/match", mdw.AuthMiddleware(), mdw.PermissionMiddleware("payment", "reconcile"), h.MatchStatementLine)
+	r.App.PUT("/api/payments/statement-line/:id/dismiss", mdw.AuthMiddleware(), mdw.PermissionMiddleware("payment", "reconcile"), h.DismissStatementLine)
+
+	// Vendor invoices
+	r.App.POST("/api/vendor/invoices", mdw.AuthMiddleware(), mdw.PermissionMiddleware("invoice", "submit"), h.SubmitInvoice)
+	r.App.GET("/api/vendor/invoices", mdw.AuthMiddleware(), mdw.PermissionMiddleware("invoice", "view"), h.GetMyInvoices)
+	r.App.GET("/api/vendor/invoice/:id", mdw.AuthMiddleware(), mdw.PermissionMiddleware("invoice", "view"), h.GetMyInvoiceByID)
+
+	// Admin invoice verification
+	r.App.GET("/api/invoices", mdw.AuthMiddleware(), mdw.PermissionMiddleware("invoice", "list"), h.GetAllInvoices)
+	invoiceAdmin := r.App.Group("/api/invoice").Use(mdw.AuthMiddleware())
+	{
+		invoiceAdmin.GET("/:id", mdw.PermissionMiddleware("invoice", "list"), h.GetInvoiceByID)
+		invoiceAdmin.PUT("/:id/verify", mdw.PermissionMiddleware("invoice", "verify"), h.VerifyInvoice)
+		invoiceAdmin.PUT("/:id/reject", mdw.PermissionMiddleware("invoice", "verify"), h.RejectInvoice)
+		invoiceAdmin.POST("/:id/payment", mdw.PermissionMiddleware("payment", "create"), h.ConvertInvoice)
+	}
 }
 
 func (r *Routes) EvaluationRoutes() {
@@ -378,15 +475,15 @@ func (r *Routes) EvaluationRoutes() {
 	vRepo := vendorRepo.NewVendorRepo(r.DB)
 	eRepo := eventRepo.NewEventRepo(r.DB)
 	evRepo := evaluationRepo.NewEvaluationRepo(r.DB)
-	svc := evaluationSvc.NewEvaluationService(evRepo, eRepo, vRepo, storageProvider)
-	h := evaluationHandler.NewEvaluationHandler(svc, vRepo)
 	pRepo := permissionRepo.NewPermissionRepo(r.DB)
+	svc := evaluationSvc.NewEvaluationService(evRepo, eRepo, vRepo, pRepo, storageProvider, uowRepo.NewUnitOfWork(r.DB))
+	h := evaluationHandler.NewEvaluationHandler(svc, vRepo)
 	mdw := middlewares.NewMiddleware(authRepo.NewBlacklistRepo(r.DB), pRepo)
 
 	// View evaluations (shared access)
 	r.App.GET("/api/evaluations", mdw.AuthMiddleware(), mdw.PermissionMiddleware("evaluation", "list"), h.GetAllEvaluations)
 	r.App.GET("/api/evaluation/:id", mdw.AuthMiddleware(), mdw.PermissionMiddleware("evaluation", "view"), h.GetEvaluationByID)
-	r.App.GET("/api/event/:id/evaluations", mdw.AuthMiddleware(), mdw.PermissionMiddleware("evaluation", "view"), h.GetEvaluationsByEventID)
+	r.App.GET("/api/event/:id/evaluations", mdw.AuthMiddleware(), mdw.PermissionMiddleware("evaluation", "view"), mdw.OwnershipMiddleware("evaluation", h.EventOwners), h.GetEvaluationsByEventID)
 
 	// Vendor can view their own evaluations
 	r.App.GET("/api/vendor/evaluations", mdw.AuthMiddleware(), mdw.PermissionMiddleware("evaluation", "view"), h.GetMyEvaluations)
@@ -401,8 +498,9 @@ func (r *Routes) EvaluationRoutes() {
 	evalClient := r.App.Group("/api/evaluation").Use(mdw.AuthMiddleware())
 	{
 		evalClient.POST("", mdw.PermissionMiddleware("evaluation", "create"), h.CreateEvaluation)
-		evalClient.PUT("/:id", mdw.PermissionMiddleware("evaluation", "update"), h.UpdateEvaluation)
-		evalClient.PUT("/photo/:id/review", mdw.PermissionMiddleware("evaluation", "review_photo"), h.ReviewPhoto)
+		evalClient.PUT("/:id", mdw.PermissionMiddleware("evaluation", "update"), mdw.OwnershipMiddleware("evaluation", h.EvaluationOwners), h.UpdateEvaluation)
+		evalClient.POST("/:id/finalize", mdw.PermissionMiddleware("evaluation", "finalize"), mdw.OwnershipMiddleware("evaluation", h.EvaluationOwners), h.FinalizeEvaluation)
+		evalClient.PUT("/photo/:id/review", mdw.PermissionMiddleware("evaluation", "review_photo"), mdw.OwnershipMiddleware("evaluation", h.PhotoOwners), h.ReviewPhoto)
 	}
 
 	// Admin can delete evaluation and photos
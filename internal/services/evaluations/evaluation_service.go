@@ -12,6 +12,8 @@ import (
 	"vendor-management-system/internal/dto"
 	interfaceevaluations "vendor-management-system/internal/interfaces/evaluations"
 	interfaceevents "vendor-management-system/internal/interfaces/events"
+	interfacepermission "vendor-management-system/internal/interfaces/permission"
+	interfaceuow "vendor-management-system/internal/interfaces/uow"
 	interfacevendors "vendor-management-system/internal/interfaces/vendors"
 	"vendor-management-system/pkg/filter"
 	"vendor-management-system/utils"
@@ -21,24 +23,30 @@ type ServiceEvaluation struct {
 	EvaluationRepo  interfaceevaluations.RepoEvaluationInterface
 	EventRepo       interfaceevents.RepoEventInterface
 	VendorRepo      interfacevendors.RepoVendorInterface
+	PermissionRepo  interfacepermission.RepoPermissionInterface
 	StorageProvider storage.StorageProvider
+	UoW             interfaceuow.UnitOfWorkInterface
 }
 
 func NewEvaluationService(
 	evaluationRepo interfaceevaluations.RepoEvaluationInterface,
 	eventRepo interfaceevents.RepoEventInterface,
 	vendorRepo interfacevendors.RepoVendorInterface,
+	permissionRepo interfacepermission.RepoPermissionInterface,
 	storageProvider storage.StorageProvider,
+	uow interfaceuow.UnitOfWorkInterface,
 ) *ServiceEvaluation {
 	return &ServiceEvaluation{
 		EvaluationRepo:  evaluationRepo,
 		EventRepo:       eventRepo,
 		VendorRepo:      vendorRepo,
+		PermissionRepo:  permissionRepo,
 		StorageProvider: storageProvider,
+		UoW:             uow,
 	}
 }
 
-// CreateEvaluation - Client creates evaluation for winner vendor after event is completed
+// CreateEvaluation - Client creates evaluation for an awarded vendor after event is completed
 func (s *ServiceEvaluation) CreateEvaluation(clientUserId string, req dto.CreateEvaluationRequest) (domainevaluations.Evaluation, error) {
 	// Get event
 	event, err := s.EventRepo.GetEventByID(req.EventID)
@@ -51,23 +59,53 @@ func (s *ServiceEvaluation) CreateEvaluation(clientUserId string, req dto.Create
 		return domainevaluations.Evaluation{}, errors.New("can only create evaluation for completed events")
 	}
 
-	// Event must have a winner
-	if event.WinnerVendorID == nil || *event.WinnerVendorID == "" {
+	evaluatorId, err := s.evaluatorFor(event.Id, event.CreatedBy, clientUserId)
+	if err != nil {
+		return domainevaluations.Evaluation{}, err
+	}
+
+	// Event must have at least one award
+	awards, err := s.EventRepo.GetAwardsByEventID(req.EventID)
+	if err != nil {
+		return domainevaluations.Evaluation{}, err
+	}
+	if len(awards) == 0 {
 		return domainevaluations.Evaluation{}, errors.New("event must have a winner to create evaluation")
 	}
 
+	// One evaluation per awarded vendor
+	vendorId := req.VendorID
+	if vendorId == "" {
+		vendorId = awards[0].VendorID
+		for _, award := range awards {
+			if award.VendorID != vendorId {
+				return domainevaluations.Evaluation{}, errors.New("vendor_id is required when the event has several awarded vendors")
+			}
+		}
+	}
+	awarded := false
+	for _, award := range awards {
+		if award.VendorID == vendorId {
+			awarded = true
+			break
+		}
+	}
+	if !awarded {
+		return domainevaluations.Evaluation{}, errors.New("vendor was not awarded in this event")
+	}
+
 	// Check if evaluation already exists
-	existing, _ := s.EvaluationRepo.GetEvaluationByEventAndVendor(req.EventID, *event.WinnerVendorID)
+	existing, _ := s.EvaluationRepo.GetEvaluationByEventAndVendor(req.EventID, vendorId)
 	if existing.Id != "" {
-		return domainevaluations.Evaluation{}, errors.New("evaluation already exists for this event")
+		return domainevaluations.Evaluation{}, errors.New("evaluation already exists for this vendor in this event")
 	}
 
 	now := time.Now()
 	evaluation := domainevaluations.Evaluation{
 		Id:              utils.CreateUUID(),
 		EventID:         req.EventID,
-		VendorID:        *event.WinnerVendorID,
-		EvaluatorUserID: clientUserId,
+		VendorID:        vendorId,
+		EvaluatorUserID: evaluatorId,
 		Comments:        req.Comments,
 		CreatedAt:       now,
 		CreatedBy:       clientUserId,
@@ -82,6 +120,35 @@ func (s *ServiceEvaluation) CreateEvaluation(clientUserId string, req dto.Create
 	return s.EvaluationRepo.GetEvaluationWithPhotos(evaluation.Id)
 }
 
+// evaluatorFor returns who evaluates the vendors of an event when the user creates an evaluation.
+// The event's creator and team members evaluate themselves; a user with evaluation:manage_all
+// may create it on behalf of the event's creator, who stays in charge of it.
+func (s *ServiceEvaluation) evaluatorFor(eventId, eventCreatorId, userId string) (string, error) {
+	if userId == eventCreatorId {
+		return userId, nil
+	}
+	members, err := s.EventRepo.GetEventTeamMembers(eventId)
+	if err != nil {
+		return "", err
+	}
+	for _, m := range members {
+		if m.UserID == userId {
+			return userId, nil
+		}
+	}
+
+	permissions, err := s.PermissionRepo.GetUserPermissions(userId)
+	if err != nil {
+		return "", err
+	}
+	for _, p := range permissions {
+		if p.Resource == "evaluation" && p.Action == "manage_all" {
+			return eventCreatorId, nil
+		}
+	}
+	return "", errors.New("access denied: only the owners of the event can evaluate its vendors")
+}
+
 func (s *ServiceEvaluation) GetEvaluationByID(id string) (domainevaluations.Evaluation, error) {
 	return s.EvaluationRepo.GetEvaluationWithPhotos(id)
 }
@@ -126,6 +193,72 @@ func (s *ServiceEvaluation) UpdateEvaluation(id string, req dto.UpdateEvaluation
 	return s.EvaluationRepo.GetEvaluationWithPhotos(id)
 }
 
+// GetEvaluationOwners returns the users allowed to manage the evaluation: its evaluator
+func (s *ServiceEvaluation) GetEvaluationOwners(id string) ([]string, error) {
+	evaluation, err := s.EvaluationRepo.GetEvaluationByID(id)
+	if err != nil {
+		return nil, err
+	}
+	return []string{evaluation.EvaluatorUserID}, nil
+}
+
+// GetEventOwners returns the users allowed to see the evaluations of an event: its creator and its team
+func (s *ServiceEvaluation) GetEventOwners(eventId string) ([]string, error) {
+	event, err := s.EventRepo.GetEventByID(eventId)
+	if err != nil {
+		return nil, err
+	}
+	members, err := s.EventRepo.GetEventTeamMembers(eventId)
+	if err != nil {
+		return nil, err
+	}
+
+	owners := []string{event.CreatedBy}
+	for _, m := range members {
+		owners = append(owners, m.UserID)
+	}
+	return owners, nil
+}
+
+// GetPhotoOwners returns the owners of the photo's evaluation
+func (s *ServiceEvaluation) GetPhotoOwners(photoId string) ([]string, error) {
+	photo, err := s.EvaluationRepo.GetPhotoByID(photoId)
+	if err != nil {
+		return nil, err
+	}
+	return s.GetEvaluationOwners(photo.EvaluationID)
+}
+
+// FinalizeEvaluation - Client closes the evaluation, releasing the vendor's completion-tied payments
+func (s *ServiceEvaluation) FinalizeEvaluation(clientUserId string, id string) (domainevaluations.Evaluation, error) {
+	evaluation, err := s.EvaluationRepo.GetEvaluationByID(id)
+	if err != nil {
+		return domainevaluations.Evaluation{}, err
+	}
+
+	if evaluation.FinalizedAt != nil {
+		return domainevaluations.Evaluation{}, errors.New("evaluation has already been finalized")
+	}
+
+	now := time.Now()
+	evaluation.FinalizedAt = &now
+	evaluation.FinalizedBy = &clientUserId
+	evaluation.UpdatedAt = now
+	evaluation.UpdatedBy = clientUserId
+
+	err = s.UoW.Do(func(repos interfaceuow.Repositories) error {
+		if err := repos.Evaluation.UpdateEvaluation(evaluation); err != nil {
+			return err
+		}
+		return repos.Payment.ReleaseScheduledPayments(evaluation.EventID, evaluation.VendorID, utils.PaymentTermOnCompletion, clientUserId)
+	})
+	if err != nil {
+		return domainevaluations.Evaluation{}, err
+	}
+
+	return s.EvaluationRepo.GetEvaluationWithPhotos(id)
+}
+
 // UpdateDriveUrl - Vendor updates Google Drive link for their evaluation
 func (s *ServiceEvaluation) UpdateDriveUrl(vendorUserId string, evaluationId string, req dto.UpdateDriveUrlRequest) (domainevaluations.Evaluation, error) {
 	// Get evaluation
@@ -245,11 +378,6 @@ func (s *ServiceEvaluation) ReviewPhoto(clientUserId string, photoId string, req
 		return domainevaluations.EvaluationPhoto{}, errors.New("evaluation not found")
 	}
 
-	// Verify client is the evaluator (event creator)
-	if evaluation.EvaluatorUserID != clientUserId {
-		return domainevaluations.EvaluationPhoto{}, errors.New("only the event creator can review this evaluation")
-	}
-
 	// Validate rating (1-5 stars)
 	if req.Rating < 1 || req.Rating > 5 {
 		return domainevaluations.EvaluationPhoto{}, errors.New("rating must be between 1 and 5")
@@ -264,13 +392,17 @@ func (s *ServiceEvaluation) ReviewPhoto(clientUserId string, photoId string, req
 	photo.ReviewedAt = &now
 	photo.UpdatedAt = &now
 
-	if err := s.EvaluationRepo.UpdatePhoto(photo); err != nil {
-		return domainevaluations.EvaluationPhoto{}, err
-	}
+	// The photo review and the recalculated overall rating are saved together
+	err = s.UoW.Do(func(repos interfaceuow.Repositories) error {
+		if err := repos.Evaluation.UpdatePhoto(photo); err != nil {
+			return err
+		}
 
-	// Calculate overall rating from all photos
-	photos, err := s.EvaluationRepo.GetPhotosByEvaluationID(evaluation.Id)
-	if err == nil {
+		// Calculate overall rating from all photos
+		photos, err := repos.Evaluation.GetPhotosByEvaluationID(evaluation.Id)
+		if err != nil {
+			return err
+		}
 		totalRating := 0.0
 		ratedCount := 0
 		for _, p := range photos {
@@ -279,12 +411,16 @@ func (s *ServiceEvaluation) ReviewPhoto(clientUserId string, photoId string, req
 				ratedCount++
 			}
 		}
-		if ratedCount > 0 {
-			overallRating := totalRating / float64(ratedCount)
-			evaluation.OverallRating = &overallRating
-			evaluation.UpdatedAt = time.Now()
-			_ = s.EvaluationRepo.UpdateEvaluation(evaluation)
+		if ratedCount == 0 {
+			return nil
 		}
+		overallRating := totalRating / float64(ratedCount)
+		evaluation.OverallRating = &overallRating
+		evaluation.UpdatedAt = time.Now()
+		return repos.Evaluation.UpdateEvaluation(evaluation)
+	})
+	if err != nil {
+		return domainevaluations.EvaluationPhoto{}, err
 	}
 
 	return photo, nil
@@ -1,22 +1,38 @@
 package serviceevents
 
 import (
+	"bytes"
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"io"
+	"math"
 	"mime/multipart"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
+	"vendor-management-system/pkg/broadcast"
+	"vendor-management-system/pkg/ical"
 	"vendor-management-system/pkg/storage"
 
 	domainevents "vendor-management-system/internal/domain/events"
+	domainpayments "vendor-management-system/internal/domain/payments"
 	"vendor-management-system/internal/dto"
 	interfaceevents "vendor-management-system/internal/interfaces/events"
 	interfacenotification "vendor-management-system/internal/interfaces/notification"
+	interfacepermission "vendor-management-system/internal/interfaces/permission"
+	interfaceuow "vendor-management-system/internal/interfaces/uow"
 	interfacevendors "vendor-management-system/internal/interfaces/vendors"
 	"vendor-management-system/pkg/filter"
 	"vendor-management-system/pkg/logger"
+	"vendor-management-system/pkg/security"
 	"vendor-management-system/utils"
 
+	"github.com/shopspring/decimal"
+	"github.com/xuri/excelize/v2"
 	"gorm.io/gorm"
 )
 
@@ -24,15 +40,23 @@ type ServiceEvent struct {
 	EventRepo       interfaceevents.RepoEventInterface
 	VendorRepo      interfacevendors.RepoVendorInterface
 	NotificationSvc interfacenotification.ServiceNotificationInterface
+	PermissionRepo  interfacepermission.RepoPermissionInterface
 	StorageProvider storage.StorageProvider
+	BidCipher       *security.BidCipher // nil when BID_ENCRYPTION_KEY is not configured
+	UoW             interfaceuow.UnitOfWorkInterface
+	AuctionBroker   *broadcast.Broker // signals live auction changes to the SSE streams
 }
 
-func NewEventService(eventRepo interfaceevents.RepoEventInterface, vendorRepo interfacevendors.RepoVendorInterface, notificationSvc interfacenotification.ServiceNotificationInterface, storageProvider storage.StorageProvider) *ServiceEvent {
+func NewEventService(eventRepo interfaceevents.RepoEventInterface, vendorRepo interfacevendors.RepoVendorInterface, notificationSvc interfacenotification.ServiceNotificationInterface, permissionRepo interfacepermission.RepoPermissionInterface, storageProvider storage.StorageProvider, bidCipher *security.BidCipher, uow interfaceuow.UnitOfWorkInterface, auctionBroker *broadcast.Broker) *ServiceEvent {
 	return &ServiceEvent{
 		EventRepo:       eventRepo,
 		VendorRepo:      vendorRepo,
 		NotificationSvc: notificationSvc,
+		PermissionRepo:  permissionRepo,
 		StorageProvider: storageProvider,
+		BidCipher:       bidCipher,
+		UoW:             uow,
+		AuctionBroker:   auctionBroker,
 	}
 }
 
@@ -55,49 +79,366 @@ func (s *ServiceEvent) CreateEvent(userId string, req dto.CreateEventRequest) (d
 		endDate = &t
 	}
 
+	participationMode := req.ParticipationMode
+	if participationMode == "" {
+		participationMode = utils.EventModeOpen
+	}
+
 	now := time.Now()
 	event := domainevents.Event{
-		Id:          utils.CreateUUID(),
-		Title:       req.Title,
-		Description: req.Description,
-		Category:    req.Category,
-		StartDate:   startDate,
-		EndDate:     endDate,
-		Status:      utils.EventDraft,
-		CreatedAt:   now,
-		CreatedBy:   userId,
-		UpdatedAt:   now,
-		UpdatedBy:   userId,
-	}
-
-	if err := s.EventRepo.CreateEvent(event); err != nil {
+		Id:                 utils.CreateUUID(),
+		Title:              req.Title,
+		Description:        req.Description,
+		Category:           req.Category,
+		StartDate:          startDate,
+		EndDate:            endDate,
+		Status:             utils.EventDraft,
+		ParticipationMode:  participationMode,
+		EligibleActiveOnly: true,
+		BidOpeningQuorum:   2,
+		CreatedAt:          now,
+		CreatedBy:          userId,
+		UpdatedAt:          now,
+		UpdatedBy:          userId,
+	}
+	applyEligibility(&event, req.Eligibility)
+	applySubmissionFileRules(&event, req.SubmissionFileRules)
+
+	if req.BidOpeningQuorum != nil {
+		event.BidOpeningQuorum = *req.BidOpeningQuorum
+	}
+	event.QACutoffHours = req.QACutoffHours
+	if req.ContractValue != nil {
+		contractValue := decimal.NewFromFloat(*req.ContractValue).Round(2)
+		event.ContractValue = &contractValue
+	}
+	terms, err := buildPaymentTerms(event.Id, userId, req.PaymentTerms)
+	if err != nil {
+		return domainevents.Event{}, err
+	}
+	if req.SealedBids != nil && *req.SealedBids {
+		if err := s.enableSealedBids(&event); err != nil {
+			return domainevents.Event{}, err
+		}
+	}
+	event.EventType = utils.EventTypeTender
+	if req.EventType == utils.EventTypeReverseAuction {
+		event.EventType = utils.EventTypeReverseAuction
+		if req.Auction == nil {
+			return domainevents.Event{}, errors.New("auction settings are required for a reverse auction")
+		}
+		if err := applyAuctionSettings(&event, req.Auction); err != nil {
+			return domainevents.Event{}, err
+		}
+	}
+
+	invitations, err := s.buildInvitations(event.Id, userId, req.InvitedVendorIDs)
+	if err != nil {
+		return domainevents.Event{}, err
+	}
+
+	err = s.UoW.Do(func(repos interfaceuow.Repositories) error {
+		if err := repos.Event.CreateEvent(event); err != nil {
+			return err
+		}
+		if err := repos.Event.CreateEventInvitations(invitations); err != nil {
+			return err
+		}
+		return repos.Event.ReplacePaymentTerms(event.Id, terms)
+	})
+	if err != nil {
 		return domainevents.Event{}, err
 	}
 
+	event.PaymentTerms = terms
 	return event, nil
 }
 
+// buildPaymentTerms validates the payment term template; its percentages must add up to 100
+func buildPaymentTerms(eventId, userId string, reqs []dto.PaymentTermRequest) ([]domainevents.EventPaymentTerm, error) {
+	if len(reqs) == 0 {
+		return nil, nil
+	}
+
+	now := time.Now()
+	total := decimal.Zero
+	terms := make([]domainevents.EventPaymentTerm, 0, len(reqs))
+	for i, r := range reqs {
+		installments := 1
+		if r.Trigger == utils.PaymentTermMonthly && r.Installments > 0 {
+			installments = r.Installments
+		}
+		percentage := decimal.NewFromFloat(r.Percentage).Round(2)
+		total = total.Add(percentage)
+		terms = append(terms, domainevents.EventPaymentTerm{
+			Id:           utils.CreateUUID(),
+			EventID:      eventId,
+			Sequence:     i + 1,
+			Label:        strings.TrimSpace(r.Label),
+			Percentage:   percentage,
+			Trigger:      r.Trigger,
+			Installments: installments,
+			CreatedAt:    now,
+			CreatedBy:    userId,
+		})
+	}
+
+	if !total.Equal(decimal.NewFromInt(100)) {
+		return nil, fmt.Errorf("invalid payment_terms: percentages must add up to 100, got %s", total.String())
+	}
+	return terms, nil
+}
+
+// schedulePayments splits each award over the event's payment terms. An award without an
+// amount falls back to the event's contract value. Rounding differences go to the last payment.
+func schedulePayments(event domainevents.Event, terms []domainevents.EventPaymentTerm, awards []domainevents.EventAward, userId string, now time.Time) []domainpayments.Payment {
+	if len(terms) == 0 {
+		return nil
+	}
+
+	var payments []domainpayments.Payment
+	for i := range awards {
+		award := awards[i]
+		base := award.AwardedAmount
+		if !base.IsPositive() && event.ContractValue != nil {
+			base = *event.ContractValue
+		}
+		if !base.IsPositive() {
+			logger.WriteLog(logger.LogLevelInfo, fmt.Sprintf("Award %s has no amount, no payments scheduled", award.Id))
+			continue
+		}
+
+		// Every installment of every term, in order
+		type slice struct {
+			label   string
+			trigger string
+			due     *time.Time
+			amount  decimal.Decimal
+		}
+		var slices []slice
+		for _, term := range terms {
+			termAmount := base.Mul(term.Percentage).Div(decimal.NewFromInt(100)).Round(2)
+			switch term.Trigger {
+			case utils.PaymentTermMonthly:
+				installment := termAmount.Div(decimal.NewFromInt(int64(term.Installments))).Round(2)
+				for n := 1; n <= term.Installments; n++ {
+					amount := installment
+					if n == term.Installments {
+						amount = termAmount.Sub(installment.Mul(decimal.NewFromInt(int64(term.Installments - 1))))
+					}
+					due := now.AddDate(0, n, 0)
+					slices = append(slices, slice{
+						label:   fmt.Sprintf("%s %d/%d", term.Label, n, term.Installments),
+						trigger: utils.PaymentReleaseOnDate,
+						due:     &due,
+						amount:  amount,
+					})
+				}
+			case utils.PaymentTermOnAward:
+				due := now
+				slices = append(slices, slice{label: term.Label, trigger: term.Trigger, due: &due, amount: termAmount})
+			default:
+				slices = append(slices, slice{label: term.Label, trigger: term.Trigger, amount: termAmount})
+			}
+		}
+
+		scheduled := decimal.Zero
+		for n, sl := range slices {
+			amount := sl.amount
+			if n == len(slices)-1 {
+				amount = base.Sub(scheduled)
+			}
+			scheduled = scheduled.Add(amount)
+
+			description := fmt.Sprintf("%s - %s", event.Title, sl.label)
+			if award.LotReference != "" {
+				description = fmt.Sprintf("%s (lot %s) - %s", event.Title, award.LotReference, sl.label)
+			}
+			eventId, awardId := event.Id, award.Id
+			payments = append(payments, domainpayments.Payment{
+				Id:             utils.CreateUUID(),
+				InvoiceNumber:  fmt.Sprintf("SCH-%s-%02d", strings.ToUpper(strings.ReplaceAll(award.Id, "-", "")[:10]), n+1),
+				VendorID:       award.VendorID,
+				Amount:         amount,
+				Status:         utils.PaymentScheduled,
+				Description:    description,
+				EventID:        &eventId,
+				AwardID:        &awardId,
+				ReleaseTrigger: sl.trigger,
+				DueDate:        sl.due,
+				CreatedAt:      now,
+				CreatedBy:      userId,
+				UpdatedAt:      now,
+				UpdatedBy:      userId,
+			})
+		}
+	}
+	return payments
+}
+
+// applyEligibility copies the eligibility rule from the request onto the event
+func applyEligibility(event *domainevents.Event, req *dto.EventEligibilityRequest) {
+	if req == nil {
+		return
+	}
+
+	event.EligibilityEnabled = req.Enabled
+	event.EligibleBusinessField = strings.TrimSpace(req.BusinessField)
+	event.EligibleProvinceId = strings.TrimSpace(req.ProvinceId)
+	event.EligibleActiveOnly = req.ActiveOnly == nil || *req.ActiveOnly
+	event.EligibleMinRating = req.MinRating
+}
+
+// applySubmissionFileRules copies the submission file limits from the request onto the event
+func applySubmissionFileRules(event *domainevents.Event, req *dto.SubmissionFileRulesRequest) {
+	if req == nil {
+		return
+	}
+
+	event.SubmissionMaxFiles = req.MaxFiles
+	event.SubmissionMaxFileSizeMB = req.MaxFileSizeMB
+
+	mimeTypes := make([]string, 0, len(req.AllowedMimeTypes))
+	seen := make(map[string]struct{}, len(req.AllowedMimeTypes))
+	for _, m := range req.AllowedMimeTypes {
+		m = strings.ToLower(strings.TrimSpace(m))
+		if _, ok := seen[m]; ok || m == "" {
+			continue
+		}
+		seen[m] = struct{}{}
+		mimeTypes = append(mimeTypes, m)
+	}
+	event.SubmissionAllowedMimeTypes = strings.Join(mimeTypes, ",")
+}
+
+// applyAuctionSettings copies the reverse auction window and bidding rules onto the event
+func applyAuctionSettings(event *domainevents.Event, req *dto.AuctionSettingsRequest) error {
+	startAt, err := time.Parse(time.RFC3339, req.StartAt)
+	if err != nil {
+		return errors.New("invalid auction start_at format, use RFC 3339")
+	}
+	endAt, err := time.Parse(time.RFC3339, req.EndAt)
+	if err != nil {
+		return errors.New("invalid auction end_at format, use RFC 3339")
+	}
+	if !endAt.After(startAt) {
+		return errors.New("auction end_at must be after start_at")
+	}
+	if !startAt.After(time.Now()) {
+		return errors.New("auction start_at must be in the future")
+	}
+
+	event.AuctionStartAt = &startAt
+	event.AuctionEndAt = &endAt
+	event.AuctionStartPrice = nil
+	if req.StartPrice != nil {
+		startPrice := decimal.NewFromFloat(*req.StartPrice).Round(2)
+		event.AuctionStartPrice = &startPrice
+	}
+	event.AuctionMinDecrement = nil
+	if req.MinDecrement != nil {
+		minDecrement := decimal.NewFromFloat(*req.MinDecrement).Round(2)
+		event.AuctionMinDecrement = &minDecrement
+	}
+	event.AuctionMinDecrementPercent = nil
+	if req.MinDecrementPercent != nil {
+		minDecrementPercent := decimal.NewFromFloat(*req.MinDecrementPercent).Round(2)
+		event.AuctionMinDecrementPercent = &minDecrementPercent
+	}
+
+	// A bid inside the extension window pushes the end back; the extension defaults to the window
+	event.AuctionExtensionWindow = req.ExtensionWindowMinutes
+	event.AuctionExtensionMinutes = req.ExtensionMinutes
+	if event.AuctionExtensionWindow > 0 && event.AuctionExtensionMinutes == 0 {
+		event.AuctionExtensionMinutes = event.AuctionExtensionWindow
+	}
+
+	return validateAuctionEvent(*event)
+}
+
+// validateAuctionEvent checks the settings a reverse auction cannot run without
+func validateAuctionEvent(event domainevents.Event) error {
+	if event.ParticipationMode != utils.EventModeInvited {
+		return errors.New("reverse auctions are only open to invited vendors, set participation_mode to invited")
+	}
+	if event.SealedBids {
+		return errors.New("reverse auctions cannot use sealed bids")
+	}
+	if event.AuctionStartAt == nil || event.AuctionEndAt == nil {
+		return errors.New("auction settings are required for a reverse auction")
+	}
+	return nil
+}
+
+// enableSealedBids turns on sealed bids and makes sure the event has its own data key
+func (s *ServiceEvent) enableSealedBids(event *domainevents.Event) error {
+	if s.BidCipher == nil {
+		return errSealedBidsUnavailable
+	}
+
+	if event.BidKey == "" {
+		key, err := s.BidCipher.NewDataKey()
+		if err != nil {
+			return err
+		}
+		event.BidKey = key
+	}
+	event.SealedBids = true
+
+	return nil
+}
+
+func (s *ServiceEvent) buildInvitations(eventId, userId string, vendorIds []string) ([]domainevents.EventInvitation, error) {
+	now := time.Now()
+	seen := make(map[string]struct{}, len(vendorIds))
+	invitations := make([]domainevents.EventInvitation, 0, len(vendorIds))
+	for _, vendorId := range vendorIds {
+		if _, ok := seen[vendorId]; ok {
+			continue
+		}
+		seen[vendorId] = struct{}{}
+
+		if _, err := s.VendorRepo.GetVendorByID(vendorId); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, fmt.Errorf("vendor %s not found", vendorId)
+			}
+			return nil, err
+		}
+
+		invitations = append(invitations, domainevents.EventInvitation{
+			Id:        utils.CreateUUID(),
+			EventID:   eventId,
+			VendorID:  vendorId,
+			CreatedAt: now,
+			CreatedBy: userId,
+		})
+	}
+
+	return invitations, nil
+}
+
 func (s *ServiceEvent) GetAllEvents(params filter.BaseParams) ([]domainevents.Event, int64, error) {
 	events, total, err := s.EventRepo.GetAllEvents(params)
 	if err != nil {
 		return nil, 0, err
 	}
 
-	// Auto-update status for expired events
-	now := time.Now()
 	for i := range events {
-		// If end_date passed and status is still open/pending, change to closed
-		if events[i].EndDate.Before(now) && (events[i].Status == utils.EventOpen || events[i].Status == utils.EventPending) {
-			events[i].Status = utils.EventClosed
-			events[i].UpdatedAt = now
-			_ = s.EventRepo.UpdateEvent(events[i])
-		}
-		// If it has winner, change to completed
-		if events[i].WinnerVendorID != nil && *events[i].WinnerVendorID != "" && events[i].Status != utils.EventCompleted && events[i].Status != utils.EventCancelled {
-			events[i].Status = utils.EventCompleted
-			events[i].UpdatedAt = now
-			_ = s.EventRepo.UpdateEvent(events[i])
-		}
+		s.refreshEventStatus(&events[i])
+	}
+
+	return events, total, nil
+}
+
+// GetAllEventsForVendor lists non-draft events the vendor is allowed to see (open events and invited events it is eligible for)
+func (s *ServiceEvent) GetAllEventsForVendor(vendorId string, params filter.BaseParams) ([]domainevents.Event, int64, error) {
+	events, total, err := s.EventRepo.GetAllEventsForVendor(vendorId, params)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	for i := range events {
+		s.refreshEventStatus(&events[i])
 	}
 
 	return events, total, nil
@@ -109,30 +450,67 @@ func (s *ServiceEvent) GetEventByID(id string) (domainevents.Event, error) {
 		return domainevents.Event{}, err
 	}
 
-	// Auto-update status
+	s.refreshEventStatus(&event)
+
+	if event.PaymentTerms, err = s.EventRepo.GetPaymentTerms(id); err != nil {
+		return domainevents.Event{}, err
+	}
+	if event.DeadlineExtensions, err = s.EventRepo.GetDeadlineExtensions(id); err != nil {
+		return domainevents.Event{}, err
+	}
+
+	return event, nil
+}
+
+// refreshEventStatus auto-updates the status of expired or already awarded events
+func (s *ServiceEvent) refreshEventStatus(event *domainevents.Event) {
 	now := time.Now()
-	// If end_date passed and status is still open/pending, change to closed
-	if event.EndDate.Before(now) && (event.Status == utils.EventOpen || event.Status == utils.EventPending) {
-		event.Status = utils.EventClosed
+	status := derivedEventStatus(*event, now)
+	if status != event.Status {
+		event.Status = status
 		event.UpdatedAt = now
-		_ = s.EventRepo.UpdateEvent(event)
+		_ = s.EventRepo.UpdateEvent(*event)
 	}
-	// If has winner, change to completed
-	if event.WinnerVendorID != nil && *event.WinnerVendorID != "" && event.Status != utils.EventCompleted && event.Status != utils.EventCancelled {
-		event.Status = utils.EventCompleted
-		event.UpdatedAt = now
-		_ = s.EventRepo.UpdateEvent(event)
+}
+
+// derivedEventStatus returns the status the event has at now, given its deadline and winner
+func derivedEventStatus(event domainevents.Event, now time.Time) string {
+	status := event.Status
+	// If end_date passed and status is still open/pending, change to closed.
+	// Reverse auctions are closed by CloseAuction, since anti-sniping can move their end.
+	if event.EventType != utils.EventTypeReverseAuction && event.EndDate != nil && event.EndDate.Before(now) && (status == utils.EventOpen || status == utils.EventPending) {
+		status = utils.EventClosed
 	}
+	// If it has winner, change to completed
+	if event.WinnerVendorID != nil && *event.WinnerVendorID != "" && status != utils.EventCompleted && status != utils.EventCancelled {
+		status = utils.EventCompleted
+	}
+	return status
+}
 
-	return event, nil
+// CanVendorAccessEvent reports whether the vendor may see and submit to the event
+func (s *ServiceEvent) CanVendorAccessEvent(event domainevents.Event, vendorId string) (bool, error) {
+	if event.ParticipationMode != utils.EventModeInvited {
+		return true, nil
+	}
+	if vendorId == "" {
+		return false, nil
+	}
+	return s.EventRepo.IsVendorEligibleForEvent(event.Id, vendorId)
 }
 
-func (s *ServiceEvent) UpdateEvent(id string, req dto.UpdateEventRequest) (domainevents.Event, error) {
+func (s *ServiceEvent) UpdateEvent(id, userId string, req dto.UpdateEventRequest) (domainevents.Event, error) {
 	event, err := s.EventRepo.GetEventByID(id)
 	if err != nil {
 		return domainevents.Event{}, err
 	}
 	prevStatus := event.Status
+	if prevStatus == utils.EventCancelled {
+		return domainevents.Event{}, errEventCancelled
+	}
+	if req.Status == utils.EventCancelled {
+		return domainevents.Event{}, errors.New("use the cancel action to cancel an event, it requires a reason")
+	}
 
 	if req.Title != "" {
 		event.Title = req.Title
@@ -155,15 +533,97 @@ func (s *ServiceEvent) UpdateEvent(id string, req dto.UpdateEventRequest) (domai
 		if err != nil {
 			return domainevents.Event{}, errors.New("invalid end_date format, use YYYY-MM-DD")
 		}
+		// Once vendors can see the event, deadline changes go through ExtendDeadline so they are recorded and announced
+		if prevStatus != utils.EventDraft && (event.EndDate == nil || !event.EndDate.Equal(t)) {
+			return domainevents.Event{}, errors.New("end_date of a published event can only be changed with a deadline extension")
+		}
 		event.EndDate = &t
 	}
 	if req.Status != "" {
 		event.Status = req.Status
 	}
+	if req.ParticipationMode != "" {
+		event.ParticipationMode = req.ParticipationMode
+	}
+	applyEligibility(&event, req.Eligibility)
+	applySubmissionFileRules(&event, req.SubmissionFileRules)
+
+	if req.SealedBids != nil && *req.SealedBids != event.SealedBids {
+		// Switching after vendors could submit would leave a mix of sealed and plain bids
+		if prevStatus != utils.EventDraft {
+			return domainevents.Event{}, errors.New("sealed_bids can only be changed while the event is a draft")
+		}
+		if *req.SealedBids {
+			if err := s.enableSealedBids(&event); err != nil {
+				return domainevents.Event{}, err
+			}
+		} else {
+			event.SealedBids = false
+		}
+	}
+	if req.BidOpeningQuorum != nil {
+		if event.BidsOpenedAt != nil {
+			return domainevents.Event{}, errors.New("bid_opening_quorum cannot be changed after the bids have been opened")
+		}
+		event.BidOpeningQuorum = *req.BidOpeningQuorum
+	}
+	if req.QACutoffHours != nil {
+		event.QACutoffHours = req.QACutoffHours
+	}
+	if req.ContractValue != nil {
+		contractValue := decimal.NewFromFloat(*req.ContractValue).Round(2)
+		event.ContractValue = &contractValue
+	}
+	if req.Auction != nil {
+		if event.EventType != utils.EventTypeReverseAuction {
+			return domainevents.Event{}, errors.New("auction settings only apply to reverse auction events")
+		}
+		if event.AuctionStartAt != nil && !time.Now().Before(*event.AuctionStartAt) {
+			return domainevents.Event{}, errors.New("auction settings cannot be changed after the auction has started")
+		}
+		if err := applyAuctionSettings(&event, req.Auction); err != nil {
+			return domainevents.Event{}, err
+		}
+	} else if event.EventType == utils.EventTypeReverseAuction {
+		if err := validateAuctionEvent(event); err != nil {
+			return domainevents.Event{}, err
+		}
+	}
+
+	// Payment terms are only replaced when sent, and not once payments have been scheduled from them
+	var terms []domainevents.EventPaymentTerm
+	if req.PaymentTerms != nil {
+		if event.WinnerVendorID != nil {
+			return domainevents.Event{}, errors.New("payment_terms cannot be changed after the event has been awarded")
+		}
+		if terms, err = buildPaymentTerms(event.Id, userId, req.PaymentTerms); err != nil {
+			return domainevents.Event{}, err
+		}
+	}
+
+	if prevStatus != utils.EventOpen && event.Status == utils.EventOpen && event.ParticipationMode == utils.EventModeInvited && !event.EligibilityEnabled {
+		invitations, err := s.EventRepo.GetEventInvitations(event.Id)
+		if err != nil {
+			return domainevents.Event{}, err
+		}
+		if len(invitations) == 0 {
+			return domainevents.Event{}, errors.New("invited event must have at least one invited vendor or an eligibility rule before it is opened")
+		}
+	}
 
 	event.UpdatedAt = time.Now()
+	event.UpdatedBy = userId
 
-	if err := s.EventRepo.UpdateEvent(event); err != nil {
+	err = s.UoW.Do(func(repos interfaceuow.Repositories) error {
+		if err := repos.Event.UpdateEvent(event); err != nil {
+			return err
+		}
+		if req.PaymentTerms != nil {
+			return repos.Event.ReplacePaymentTerms(event.Id, terms)
+		}
+		return nil
+	})
+	if err != nil {
 		return domainevents.Event{}, err
 	}
 
@@ -174,504 +634,4711 @@ func (s *ServiceEvent) UpdateEvent(id string, req dto.UpdateEventRequest) (domai
 	return event, nil
 }
 
-func (s *ServiceEvent) DeleteEvent(id string) error {
-	_, err := s.EventRepo.GetEventByID(id)
-	if err != nil {
-		return err
-	}
-
-	return s.EventRepo.DeleteEvent(id)
-}
-
-func (s *ServiceEvent) SubmitPitch(eventId, vendorId string, req dto.SubmitPitchRequest) (domainevents.EventSubmission, error) {
+// CancelEvent cancels an event with a reason and tells every participant. The event stays readable,
+// while ensureSubmissionWindow and the award checks keep it from changing further. Awards must be
+// revoked first. With a re-tender the event is first cloned into a new draft, which is linked
+// from the cancelled event and keeps its invited vendors.
+func (s *ServiceEvent) CancelEvent(ctx context.Context, eventId, userId string, req dto.CancelEventRequest) (map[string]interface{}, error) {
 	event, err := s.EventRepo.GetEventByID(eventId)
 	if err != nil {
-		return domainevents.EventSubmission{}, err
+		return nil, err
 	}
-
-	if event.Status != utils.EventOpen {
-		return domainevents.EventSubmission{}, errors.New("event is not open for submissions")
+	if err := checkCancellable(s.EventRepo, event); err != nil {
+		return nil, err
 	}
 
-	existing, err := s.EventRepo.GetSubmissionByEventAndVendor(eventId, vendorId)
-	if err == nil && existing.Id != "" {
-		return domainevents.EventSubmission{}, errors.New("you have already submitted a pitch for this event")
+	var retender *domainevents.Event
+	if req.Retender != nil {
+		clone, err := s.CloneEvent(ctx, eventId, userId, *req.Retender)
+		if err != nil {
+			return nil, err
+		}
+		retender = &clone
 	}
 
 	now := time.Now()
-	submission := domainevents.EventSubmission{
-		Id:              utils.CreateUUID(),
-		EventID:         eventId,
-		VendorID:        vendorId,
-		ProposalDetails: req.ProposalDetails,
-		IsShortlisted:   false,
-		IsWinner:        false,
-		CreatedAt:       now,
-		CreatedBy:       vendorId,
-		UpdatedAt:       now,
-		UpdatedBy:       vendorId,
+	reason := strings.TrimSpace(req.Reason)
+	description := "Event cancelled"
+	if retender != nil {
+		description = fmt.Sprintf("Event cancelled and re-tendered as \"%s\"", retender.Title)
 	}
 
-	if err := s.EventRepo.CreateSubmission(submission); err != nil {
-		return domainevents.EventSubmission{}, err
+	// The event row lock keeps awards and other event changes out while the event is cancelled
+	err = s.UoW.Do(func(repos interfaceuow.Repositories) error {
+		locked, err := repos.Event.LockEventForUpdate(eventId)
+		if err != nil {
+			return err
+		}
+		if err := checkCancellable(repos.Event, locked); err != nil {
+			return err
+		}
+
+		event = locked
+		if retender != nil {
+			event.RetenderEventID = &retender.Id
+		}
+		event.Status = utils.EventCancelled
+		event.CancelledAt = &now
+		event.CancelledBy = &userId
+		event.CancelReason = reason
+		event.UpdatedAt = now
+		event.UpdatedBy = userId
+		if err := repos.Event.UpdateEvent(event); err != nil {
+			return err
+		}
+		if retender != nil {
+			if err := copyInvitations(repos.Event, eventId, retender.Id, userId, now); err != nil {
+				return err
+			}
+		}
+		return repos.Event.CreateEventHistory(domainevents.EventHistory{
+			Id:          utils.CreateUUID(),
+			EventID:     event.Id,
+			Action:      utils.HistoryEventCancelled,
+			Description: description,
+			Reason:      reason,
+			CreatedAt:   now,
+			CreatedBy:   userId,
+		})
+	})
+	if err != nil {
+		// Do not leave a re-tender draft behind for an event that was not cancelled
+		if retender != nil {
+			if delErr := s.EventRepo.DeleteEvent(retender.Id); delErr != nil {
+				logger.WriteLog(logger.LogLevelError, fmt.Sprintf("Failed to delete re-tender draft %s: %s", retender.Id, delErr))
+			} else {
+				s.cleanupEventFiles(ctx, retender.File)
+			}
+		}
+		return nil, err
 	}
 
-	return submission, nil
+	if event.EventType == utils.EventTypeReverseAuction {
+		s.publishAuction(event.Id)
+	}
+	s.notifyEventCancelled(event)
+
+	return map[string]interface{}{
+		"event":          event,
+		"retender_event": retender,
+	}, nil
 }
 
-func (s *ServiceEvent) notifyEventOpen(event domainevents.Event) error {
-	if s.NotificationSvc == nil {
-		return nil
+// checkCancellable refuses to cancel an event that is already cancelled or still has active awards
+func checkCancellable(repo interfaceevents.RepoEventInterface, event domainevents.Event) error {
+	if event.Status == utils.EventCancelled {
+		return errEventCancelled
 	}
-	title := "Event dibuka"
-	message := fmt.Sprintf("Event \"%s\" telah dibuka untuk submission.", event.Title)
-	return s.NotificationSvc.CreateForAll(title, message, utils.NotifEventOpen, "event", event.Id)
+	if event.WinnerVendorID != nil && *event.WinnerVendorID != "" {
+		return errors.New("an awarded event cannot be cancelled, revoke the award first")
+	}
+	awards, err := repo.GetAwardsByEventID(event.Id)
+	if err != nil {
+		return err
+	}
+	if len(awards) > 0 {
+		return errors.New("an awarded event cannot be cancelled, revoke its awards first")
+	}
+	return nil
 }
 
-func (s *ServiceEvent) notifyWinnerAndLosers(event domainevents.Event, winnerSubmission domainevents.EventSubmission) {
+// copyInvitations invites the vendors invited to one event to another, so a re-tender reaches them again
+func copyInvitations(repo interfaceevents.RepoEventInterface, fromEventId, toEventId, userId string, now time.Time) error {
+	invitations, err := repo.GetEventInvitations(fromEventId)
+	if err != nil {
+		return err
+	}
+
+	copies := make([]domainevents.EventInvitation, 0, len(invitations))
+	for _, invitation := range invitations {
+		copies = append(copies, domainevents.EventInvitation{
+			Id:        utils.CreateUUID(),
+			EventID:   toEventId,
+			VendorID:  invitation.VendorID,
+			CreatedAt: now,
+			CreatedBy: userId,
+		})
+	}
+	return repo.CreateEventInvitations(copies)
+}
+
+// notifyEventCancelled tells every vendor with an active submission and every invited vendor about the cancellation
+func (s *ServiceEvent) notifyEventCancelled(event domainevents.Event) {
 	if s.NotificationSvc == nil {
 		return
 	}
 
-	// Notify winner
-	if winnerSubmission.VendorID != "" {
-		if winnerVendor, err := s.VendorRepo.GetVendorByID(winnerSubmission.VendorID); err == nil && winnerVendor.UserId != "" {
-			_ = s.NotificationSvc.CreateForUser(
-				winnerVendor.UserId,
-				"Selamat! Anda menang",
-				fmt.Sprintf("Vendor Anda terpilih sebagai pemenang untuk event \"%s\".", event.Title),
-				utils.NotifEventWinner,
-				"event",
-				event.Id,
-			)
-		} else if err != nil {
-			logger.WriteLog(logger.LogLevelError, fmt.Sprintf("Failed to load winner vendor for notification: %s", err))
+	userIds := []string{}
+	seen := map[string]bool{}
+	addUser := func(userId string) {
+		if userId == "" || seen[userId] {
+			return
 		}
+		seen[userId] = true
+		userIds = append(userIds, userId)
 	}
 
-	// Notify non-winners
 	submissions, err := s.EventRepo.GetSubmissionsByEventID(event.Id)
 	if err != nil {
-		return
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("Failed to load submissions for cancellation notification: %s", err))
 	}
 	for _, sub := range submissions {
-		if sub.VendorID == winnerSubmission.VendorID {
-			continue
-		}
-		vendor, err := s.VendorRepo.GetVendorByID(sub.VendorID)
-		if err != nil {
-			logger.WriteLog(logger.LogLevelError, fmt.Sprintf("Failed to load vendor for loser notification: %s", err))
-			continue
+		if sub.Status != utils.SubmissionWithdrawn {
+			addUser(sub.Vendor.UserId)
 		}
-		if vendor.UserId == "" {
-			continue
-		}
-		_ = s.NotificationSvc.CreateForUser(
-			vendor.UserId,
-			"Terima kasih sudah berpartisipasi",
-			fmt.Sprintf("Vendor Anda belum terpilih pada event \"%s\". Tetap semangat dan coba lagi!", event.Title),
-			utils.NotifEventLoser,
-			"event",
-			event.Id,
-		)
 	}
-}
-
-func (s *ServiceEvent) SubmitPitchWithFile(ctx context.Context, eventId, vendorId string, req dto.SubmitPitchRequest, fileHeader *multipart.FileHeader, fileType, caption string) (domainevents.EventSubmission, error) {
-	// First, create or update the submission
-	submission, err := s.SubmitPitch(eventId, vendorId, req)
+	invitations, err := s.EventRepo.GetEventInvitations(event.Id)
 	if err != nil {
-		return domainevents.EventSubmission{}, err
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("Failed to load invitations for cancellation notification: %s", err))
+	}
+	for _, invitation := range invitations {
+		if invitation.Vendor != nil {
+			addUser(invitation.Vendor.UserId)
+		}
 	}
 
-	// Validate file size
-	maxFileSize := utils.GetEnv("MAX_FILE_SIZE", 20).(int)
-	if err := utils.ValidateFileSize(fileHeader, maxFileSize); err != nil {
-		return domainevents.EventSubmission{}, err
+	message := fmt.Sprintf("Event \"%s\" dibatalkan. Alasan: %s", event.Title, event.CancelReason)
+	for _, userId := range userIds {
+		_ = s.NotificationSvc.CreateForUser(userId, "Event dibatalkan", message, utils.NotifEventCancelled, "event", event.Id)
 	}
+}
 
-	// Open file
-	file, err := fileHeader.Open()
+// ExtendDeadline moves the submission deadline of an event later, records the change with its
+// reason and notifies the vendors following the event. A closed event re-opens, but only
+// within the grace period after its previous deadline.
+func (s *ServiceEvent) ExtendDeadline(eventId, userId string, req dto.ExtendDeadlineRequest) (domainevents.Event, error) {
+	now := time.Now()
+	newEndDate, err := time.Parse("2006-01-02", req.EndDate)
 	if err != nil {
-		return domainevents.EventSubmission{}, fmt.Errorf("failed to open file %s: %w", fileHeader.Filename, err)
+		return domainevents.Event{}, errors.New("invalid end_date format, use YYYY-MM-DD")
 	}
-	defer file.Close()
 
-	// Upload to storage provider (MinIO or R2)
-	fileUrl, err := s.StorageProvider.UploadFile(ctx, file, fileHeader, "event-submission-files")
-	if err != nil {
-		return domainevents.EventSubmission{}, fmt.Errorf("failed to upload file %s to storage: %w", fileHeader.Filename, err)
+	extension := domainevents.EventDeadlineExtension{
+		Id:         utils.CreateUUID(),
+		EventID:    eventId,
+		NewEndDate: newEndDate,
+		Reason:     strings.TrimSpace(req.Reason),
+		CreatedAt:  now,
+		CreatedBy:  userId,
+	}
+
+	// The event row lock keeps addenda, awards and cancellation out while the deadline moves
+	var event domainevents.Event
+	err = s.UoW.Do(func(repos interfaceuow.Repositories) error {
+		locked, err := repos.Event.LockEventForUpdate(eventId)
+		if err != nil {
+			return err
+		}
+		locked.Status = derivedEventStatus(locked, now)
+		if err := checkDeadlineExtension(locked, newEndDate, now); err != nil {
+			return err
+		}
+
+		extension.PreviousEndDate = locked.EndDate
+		if locked.Status == utils.EventClosed {
+			locked.Status = utils.EventOpen
+		}
+		locked.EndDate = &newEndDate
+		locked.UpdatedAt = now
+		locked.UpdatedBy = userId
+		event = locked
+		return repos.Event.CreateDeadlineExtension(extension, locked.Status)
+	})
+	if err != nil {
+		return domainevents.Event{}, err
+	}
+
+	logger.WriteLog(logger.LogLevelInfo, fmt.Sprintf("Deadline of event %s extended to %s by user %s", eventId, req.EndDate, userId))
+	s.notifyDeadlineExtended(event, extension)
+
+	return s.GetEventByID(eventId)
+}
+
+// checkDeadlineExtension applies the rules for moving the deadline of an event to newEndDate,
+// for deadline extensions and for addenda alike. Sealed bids are readable once the deadline has
+// passed, so a sealed-bid event cannot be extended from then on.
+func checkDeadlineExtension(event domainevents.Event, newEndDate, now time.Time) error {
+	switch event.Status {
+	case utils.EventOpen, utils.EventPending, utils.EventClosed:
+	case utils.EventDraft:
+		return errors.New("the deadline of a draft event can be changed directly")
+	default:
+		return fmt.Errorf("the deadline of a %s event cannot be extended", event.Status)
+	}
+	if event.EventType == utils.EventTypeReverseAuction {
+		return errors.New("reverse auctions are rescheduled through their auction settings")
+	}
+	if event.SealedBids && bidsRevealed(event) {
+		return errors.New("sealed bids are open or past their deadline, the deadline can no longer be extended")
+	}
+
+	if !newEndDate.After(now) {
+		return errors.New("invalid end_date: the new deadline must be in the future")
+	}
+	if event.EndDate != nil {
+		if !newEndDate.After(*event.EndDate) {
+			return errors.New("invalid end_date: the new deadline must be later than the current one")
+		}
+		graceEnd := event.EndDate.Add(time.Duration(utils.DeadlineExtensionGraceHours) * time.Hour)
+		if now.After(graceEnd) {
+			return fmt.Errorf("the event closed more than %d hours ago and can no longer be extended", utils.DeadlineExtensionGraceHours)
+		}
+	}
+	return nil
+}
+
+// notifyDeadlineExtended tells every vendor that opened, was invited to or submitted to the event about the new deadline
+func (s *ServiceEvent) notifyDeadlineExtended(event domainevents.Event, extension domainevents.EventDeadlineExtension) {
+	if s.NotificationSvc == nil {
+		return
+	}
+
+	vendors, err := s.EventRepo.GetEventInterestedVendors(event.Id)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("Failed to load vendors of event %s for deadline extension notification: %s", event.Id, err))
+		return
+	}
+
+	message := fmt.Sprintf("Batas waktu event \"%s\" diperpanjang hingga %s. Alasan: %s", event.Title, extension.NewEndDate.Format("2006-01-02"), extension.Reason)
+	for _, vendor := range vendors {
+		if vendor.UserId == "" {
+			continue
+		}
+		_ = s.NotificationSvc.CreateForUser(vendor.UserId, "Batas waktu diperpanjang", message, utils.NotifEventExtended, "event", event.Id)
+	}
+}
+
+// GetEventOwners returns the users allowed to manage the event: its creator and its team
+func (s *ServiceEvent) GetEventOwners(eventId string) ([]string, error) {
+	event, err := s.EventRepo.GetEventByID(eventId)
+	if err != nil {
+		return nil, err
+	}
+	members, err := s.EventRepo.GetEventTeamMembers(eventId)
+	if err != nil {
+		return nil, err
+	}
+
+	owners := []string{event.CreatedBy}
+	for _, m := range members {
+		owners = append(owners, m.UserID)
+	}
+	return owners, nil
+}
+
+// GetSubmissionOwners returns the owners of the submission's event
+func (s *ServiceEvent) GetSubmissionOwners(submissionId string) ([]string, error) {
+	submission, err := s.EventRepo.GetSubmissionByID(submissionId)
+	if err != nil {
+		return nil, err
+	}
+	return s.GetEventOwners(submission.EventID)
+}
+
+// GetSubmissionFileOwners returns the owners of the event the submission file was sent to
+func (s *ServiceEvent) GetSubmissionFileOwners(fileId string) ([]string, error) {
+	submissionFile, err := s.EventRepo.GetSubmissionFileByID(fileId)
+	if err != nil {
+		return nil, err
+	}
+	return s.GetSubmissionOwners(submissionFile.EventSubmissionId)
+}
+
+// ownedEventsScope returns the user whose events a cross-event list is limited to,
+// or an empty string when the user has event:manage_all and sees every event
+func (s *ServiceEvent) ownedEventsScope(userId string) (string, error) {
+	permissions, err := s.PermissionRepo.GetUserPermissions(userId)
+	if err != nil {
+		return "", err
+	}
+	for _, p := range permissions {
+		if p.Resource == "event" && p.Action == "manage_all" {
+			return "", nil
+		}
+	}
+	return userId, nil
+}
+
+// GetAwardOwners returns the owners of the award's event
+func (s *ServiceEvent) GetAwardOwners(awardId string) ([]string, error) {
+	award, err := s.EventRepo.GetAwardByID(awardId)
+	if err != nil {
+		return nil, err
+	}
+	return s.GetEventOwners(award.EventID)
+}
+
+// GetQuestionOwners returns the owners of the question's event
+func (s *ServiceEvent) GetQuestionOwners(questionId string) ([]string, error) {
+	question, err := s.EventRepo.GetQuestionByID(questionId)
+	if err != nil {
+		return nil, err
+	}
+	return s.GetEventOwners(question.EventID)
+}
+
+// GetEventTeam lists the users assigned to manage the event besides its creator
+func (s *ServiceEvent) GetEventTeam(eventId string) ([]domainevents.EventTeamMember, error) {
+	if _, err := s.EventRepo.GetEventByID(eventId); err != nil {
+		return nil, err
+	}
+	return s.EventRepo.GetEventTeamMembers(eventId)
+}
+
+// AddEventTeamMembers lets staff users manage the event; vendor accounts cannot join a team
+func (s *ServiceEvent) AddEventTeamMembers(eventId, userId string, req dto.AddEventTeamMembersRequest) ([]domainevents.EventTeamMember, error) {
+	event, err := s.EventRepo.GetEventByID(eventId)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]struct{}, len(req.UserIDs))
+	userIds := make([]string, 0, len(req.UserIDs))
+	for _, id := range req.UserIDs {
+		if _, ok := seen[id]; !ok {
+			seen[id] = struct{}{}
+			userIds = append(userIds, id)
+		}
+	}
+	users, err := s.EventRepo.GetUsersByIDs(userIds)
+	if err != nil {
+		return nil, err
+	}
+	if len(users) != len(userIds) {
+		return nil, errors.New("one or more users do not exist")
 	}
 
-	// Create submission file record
 	now := time.Now()
-	submissionFile := domainevents.EventSubmissionFile{
-		ID:                utils.CreateUUID(),
-		EventSubmissionId: submission.Id,
-		FileType:          fileType,
-		FileUrl:           fileUrl,
-		Caption:           caption,
-		CreatedAt:         now,
-		CreatedBy:         vendorId,
+	members := make([]domainevents.EventTeamMember, 0, len(users))
+	for _, user := range users {
+		if user.Role == utils.RoleVendor {
+			return nil, fmt.Errorf("user %s is a vendor and cannot join an event team", user.Id)
+		}
+		if user.Id == event.CreatedBy {
+			continue
+		}
+		members = append(members, domainevents.EventTeamMember{
+			Id:        utils.CreateUUID(),
+			EventID:   eventId,
+			UserID:    user.Id,
+			CreatedAt: now,
+			CreatedBy: userId,
+		})
 	}
 
-	if err := s.EventRepo.CreateSubmissionFile(submissionFile); err != nil {
-		// Cleanup uploaded file if database save fails
-		_ = s.StorageProvider.DeleteFile(ctx, fileUrl)
-		return domainevents.EventSubmission{}, err
+	if err := s.EventRepo.AddEventTeamMembers(members); err != nil {
+		return nil, err
 	}
 
-	return submission, nil
+	return s.EventRepo.GetEventTeamMembers(eventId)
 }
 
-func (s *ServiceEvent) GetSubmissionsByEventID(eventId string) ([]domainevents.EventSubmission, error) {
-	return s.EventRepo.GetSubmissionsByEventID(eventId)
+// RemoveEventTeamMember takes a user off the event's team; the creator always keeps access
+func (s *ServiceEvent) RemoveEventTeamMember(eventId, memberUserId string) error {
+	if _, err := s.EventRepo.GetEventByID(eventId); err != nil {
+		return err
+	}
+	return s.EventRepo.RemoveEventTeamMember(eventId, memberUserId)
 }
 
-func (s *ServiceEvent) GetSubmissionsByEventIDPaginated(eventId string, params filter.BaseParams) ([]domainevents.EventSubmission, int64, error) {
-	return s.EventRepo.GetSubmissionsByEventIDPaginated(eventId, params)
+func (s *ServiceEvent) DeleteEvent(id string) error {
+	_, err := s.EventRepo.GetEventByID(id)
+	if err != nil {
+		return err
+	}
+
+	return s.EventRepo.DeleteEvent(id)
 }
 
-func (s *ServiceEvent) GetAllSubmissions(params filter.BaseParams) ([]map[string]interface{}, int64, error) {
-	submissions, total, err := s.EventRepo.GetAllSubmissions(params)
+// CloneEvent creates a draft copy of an event with new dates. Files are copied in storage;
+// the BOQ, payment terms, budget and auction settings come along, submissions and invitations do not.
+func (s *ServiceEvent) CloneEvent(ctx context.Context, eventId, userId string, req dto.CloneEventRequest) (domainevents.Event, error) {
+	source, err := s.EventRepo.GetEventByID(eventId)
 	if err != nil {
-		return nil, 0, err
+		return domainevents.Event{}, err
 	}
 
-	result := make([]map[string]interface{}, len(submissions))
-	for i, sub := range submissions {
-		result[i] = map[string]interface{}{
-			"id":                   sub.Id,
-			"event_id":             sub.EventID,
-			"event":                sub.Event,
-			"vendor_id":            sub.VendorID,
-			"vendor":               sub.Vendor,
-			"proposal_details":     sub.ProposalDetails,
-			"additional_materials": sub.AdditionalMaterials,
-			"score":                sub.Score,
-			"comments":             sub.Comments,
-			"is_shortlisted":       sub.IsShortlisted,
-			"is_winner":            sub.IsWinner,
-			"files":                sub.File,
-			"created_at":           sub.CreatedAt,
-			"updated_at":           sub.UpdatedAt,
+	template := templateFromEvent(source)
+	template.TitlePattern = source.Title
+
+	event, err := s.newEventFromTemplate(template, userId, req)
+	if err != nil {
+		return domainevents.Event{}, err
+	}
+
+	boqItems, err := s.EventRepo.GetBoqItems(eventId)
+	if err != nil {
+		return domainevents.Event{}, err
+	}
+	terms, err := s.EventRepo.GetPaymentTerms(eventId)
+	if err != nil {
+		return domainevents.Event{}, err
+	}
+
+	return s.createEventCopy(ctx, event, template.Files, boqItems, terms)
+}
+
+// SaveEventTemplate stores the settings and files of an event as a reusable template
+func (s *ServiceEvent) SaveEventTemplate(ctx context.Context, eventId, userId string, req dto.SaveEventTemplateRequest) (domainevents.EventTemplate, error) {
+	event, err := s.EventRepo.GetEventByID(eventId)
+	if err != nil {
+		return domainevents.EventTemplate{}, err
+	}
+
+	now := time.Now()
+	template := templateFromEvent(event)
+	template.Id = utils.CreateUUID()
+	template.Name = strings.TrimSpace(req.Name)
+	template.TitlePattern = strings.TrimSpace(req.TitlePattern)
+	if template.TitlePattern == "" {
+		template.TitlePattern = event.Title
+	}
+	template.SourceEventID = &event.Id
+	template.CreatedAt = now
+	template.CreatedBy = userId
+	template.UpdatedAt = now
+	template.UpdatedBy = userId
+
+	files := make([]domainevents.EventTemplateFile, 0, len(template.Files))
+	for _, f := range template.Files {
+		fileUrl, err := s.StorageProvider.CopyFile(ctx, f.FileUrl, "event-templates")
+		if err != nil {
+			s.cleanupTemplateFiles(ctx, files)
+			return domainevents.EventTemplate{}, fmt.Errorf("failed to copy file %s: %w", f.FileUrl, err)
 		}
+		files = append(files, domainevents.EventTemplateFile{
+			ID:         utils.CreateUUID(),
+			TemplateID: template.Id,
+			FileType:   f.FileType,
+			FileUrl:    fileUrl,
+			Caption:    f.Caption,
+			CreatedAt:  now,
+			CreatedBy:  userId,
+		})
 	}
+	template.Files = files
 
-	return result, total, nil
+	if err := s.EventRepo.CreateEventTemplate(template); err != nil {
+		s.cleanupTemplateFiles(ctx, files)
+		return domainevents.EventTemplate{}, err
+	}
+
+	return template, nil
 }
 
-func (s *ServiceEvent) GetGroupedSubmissions(params filter.BaseParams, submissionPage int, submissionLimit int) (*domainevents.GroupedSubmissionsResponse, error) {
-	return s.EventRepo.GetGroupedSubmissions(params, submissionPage, submissionLimit)
+func (s *ServiceEvent) GetEventTemplates(params filter.BaseParams) ([]domainevents.EventTemplate, int64, error) {
+	return s.EventRepo.GetAllEventTemplates(params)
 }
 
-func (s *ServiceEvent) GetMySubmissions(vendorId string, params filter.BaseParams) ([]domainevents.EventSubmission, int64, error) {
-	return s.EventRepo.GetSubmissionsByVendorID(vendorId, params)
+func (s *ServiceEvent) GetEventTemplateByID(id string) (domainevents.EventTemplate, error) {
+	return s.EventRepo.GetEventTemplateByID(id)
 }
 
-func (s *ServiceEvent) ScoreSubmission(submissionId string, req dto.ScoreSubmissionRequest) (domainevents.EventSubmission, error) {
-	submission, err := s.EventRepo.GetSubmissionByID(submissionId)
+// CreateEventFromTemplate creates a draft event from a saved template
+func (s *ServiceEvent) CreateEventFromTemplate(ctx context.Context, templateId, userId string, req dto.CloneEventRequest) (domainevents.Event, error) {
+	template, err := s.EventRepo.GetEventTemplateByID(templateId)
 	if err != nil {
-		return domainevents.EventSubmission{}, err
+		return domainevents.Event{}, err
 	}
 
-	submission.Score = &req.Score
-	submission.Comments = req.Comments
-	submission.UpdatedAt = time.Now()
+	event, err := s.newEventFromTemplate(template, userId, req)
+	if err != nil {
+		return domainevents.Event{}, err
+	}
 
-	if err := s.EventRepo.UpdateSubmission(submission); err != nil {
+	return s.createEventCopy(ctx, event, template.Files, nil, nil)
+}
+
+func (s *ServiceEvent) DeleteEventTemplate(ctx context.Context, id, userId string) error {
+	template, err := s.EventRepo.GetEventTemplateByID(id)
+	if err != nil {
+		return err
+	}
+	if err := s.EventRepo.DeleteEventTemplate(id, userId); err != nil {
+		return err
+	}
+
+	// The template owns copies of its files, nothing else points at them
+	s.cleanupTemplateFiles(ctx, template.Files)
+	return nil
+}
+
+// templateFromEvent captures the reusable settings of an event. Files issued with an addendum are left out.
+func templateFromEvent(event domainevents.Event) domainevents.EventTemplate {
+	template := domainevents.EventTemplate{
+		Description:                event.Description,
+		Category:                   event.Category,
+		ParticipationMode:          event.ParticipationMode,
+		EligibilityEnabled:         event.EligibilityEnabled,
+		EligibleBusinessField:      event.EligibleBusinessField,
+		EligibleProvinceId:         event.EligibleProvinceId,
+		EligibleActiveOnly:         event.EligibleActiveOnly,
+		EligibleMinRating:          event.EligibleMinRating,
+		SubmissionMaxFiles:         event.SubmissionMaxFiles,
+		SubmissionMaxFileSizeMB:    event.SubmissionMaxFileSizeMB,
+		SubmissionAllowedMimeTypes: event.SubmissionAllowedMimeTypes,
+		SealedBids:                 event.SealedBids,
+		BidOpeningQuorum:           event.BidOpeningQuorum,
+		QACutoffHours:              event.QACutoffHours,
+		EventType:                  event.EventType,
+		AuctionStartPrice:          event.AuctionStartPrice,
+		AuctionMinDecrement:        event.AuctionMinDecrement,
+		AuctionMinDecrementPercent: event.AuctionMinDecrementPercent,
+		AuctionExtensionWindow:     event.AuctionExtensionWindow,
+		AuctionExtensionMinutes:    event.AuctionExtensionMinutes,
+		OwnerEstimate:              event.OwnerEstimate,
+		BudgetCeiling:              event.BudgetCeiling,
+		AbnormallyLowPercent:       event.AbnormallyLowPercent,
+		ContractValue:              event.ContractValue,
+	}
+
+	// The auction window is kept relative to the start date; without one it starts at midnight of it
+	if event.AuctionStartAt != nil && event.AuctionEndAt != nil {
+		offset := 0
+		if event.StartDate != nil {
+			offset = int(event.AuctionStartAt.Sub(*event.StartDate).Minutes())
+		}
+		duration := int(event.AuctionEndAt.Sub(*event.AuctionStartAt).Minutes())
+		template.AuctionStartOffsetMinutes = &offset
+		template.AuctionDurationMinutes = &duration
+	}
+
+	for _, f := range event.File {
+		if f.AddendumId != nil {
+			continue
+		}
+		template.Files = append(template.Files, domainevents.EventTemplateFile{
+			FileType: f.FileType,
+			FileUrl:  f.FileUrl,
+			Caption:  f.Caption,
+		})
+	}
+
+	return template
+}
+
+// expandTitlePattern fills {year}, {quarter} and {month} from the event's start date
+func expandTitlePattern(pattern string, start time.Time) string {
+	return strings.NewReplacer(
+		"{year}", strconv.Itoa(start.Year()),
+		"{quarter}", fmt.Sprintf("Q%d", (int(start.Month())-1)/3+1),
+		"{month}", start.Format("January"),
+	).Replace(pattern)
+}
+
+// newEventFromTemplate builds a draft event from the template settings and the requested dates
+func (s *ServiceEvent) newEventFromTemplate(template domainevents.EventTemplate, userId string, req dto.CloneEventRequest) (domainevents.Event, error) {
+	startDate, err := time.Parse("2006-01-02", req.StartDate)
+	if err != nil {
+		return domainevents.Event{}, errors.New("invalid start_date format, use YYYY-MM-DD")
+	}
+	endDate, err := time.Parse("2006-01-02", req.EndDate)
+	if err != nil {
+		return domainevents.Event{}, errors.New("invalid end_date format, use YYYY-MM-DD")
+	}
+	if endDate.Before(startDate) {
+		return domainevents.Event{}, errors.New("end_date must not be before start_date")
+	}
+
+	title := strings.TrimSpace(req.Title)
+	if title == "" {
+		title = expandTitlePattern(template.TitlePattern, startDate)
+	}
+
+	participationMode := template.ParticipationMode
+	if participationMode == "" {
+		participationMode = utils.EventModeOpen
+	}
+	quorum := template.BidOpeningQuorum
+	if quorum < 2 {
+		quorum = 2
+	}
+
+	now := time.Now()
+	event := domainevents.Event{
+		Id:                         utils.CreateUUID(),
+		Title:                      title,
+		Description:                template.Description,
+		Category:                   template.Category,
+		StartDate:                  &startDate,
+		EndDate:                    &endDate,
+		Status:                     utils.EventDraft,
+		ParticipationMode:          participationMode,
+		EligibilityEnabled:         template.EligibilityEnabled,
+		EligibleBusinessField:      template.EligibleBusinessField,
+		EligibleProvinceId:         template.EligibleProvinceId,
+		EligibleActiveOnly:         template.EligibleActiveOnly,
+		EligibleMinRating:          template.EligibleMinRating,
+		SubmissionMaxFiles:         template.SubmissionMaxFiles,
+		SubmissionMaxFileSizeMB:    template.SubmissionMaxFileSizeMB,
+		SubmissionAllowedMimeTypes: template.SubmissionAllowedMimeTypes,
+		BidOpeningQuorum:           quorum,
+		QACutoffHours:              template.QACutoffHours,
+		EventType:                  utils.EventTypeTender,
+		OwnerEstimate:              template.OwnerEstimate,
+		BudgetCeiling:              template.BudgetCeiling,
+		AbnormallyLowPercent:       template.AbnormallyLowPercent,
+		ContractValue:              template.ContractValue,
+		CreatedAt:                  now,
+		CreatedBy:                  userId,
+		UpdatedAt:                  now,
+		UpdatedBy:                  userId,
+	}
+
+	// The copy gets its own bid key
+	if template.SealedBids {
+		if err := s.enableSealedBids(&event); err != nil {
+			return domainevents.Event{}, err
+		}
+	}
+
+	if template.EventType == utils.EventTypeReverseAuction {
+		if err := auctionFromTemplate(&event, template, startDate, now); err != nil {
+			return domainevents.Event{}, err
+		}
+	}
+
+	return event, nil
+}
+
+// auctionFromTemplate turns the event into a reverse auction with the template's settings,
+// placing the auction window at the same distance from the new start date
+func auctionFromTemplate(event *domainevents.Event, template domainevents.EventTemplate, startDate, now time.Time) error {
+	event.EventType = utils.EventTypeReverseAuction
+	event.AuctionStartPrice = template.AuctionStartPrice
+	event.AuctionMinDecrement = template.AuctionMinDecrement
+	event.AuctionMinDecrementPercent = template.AuctionMinDecrementPercent
+	event.AuctionExtensionWindow = template.AuctionExtensionWindow
+	event.AuctionExtensionMinutes = template.AuctionExtensionMinutes
+
+	if template.AuctionStartOffsetMinutes != nil && template.AuctionDurationMinutes != nil {
+		startAt := startDate.Add(time.Duration(*template.AuctionStartOffsetMinutes) * time.Minute)
+		endAt := startAt.Add(time.Duration(*template.AuctionDurationMinutes) * time.Minute)
+		if !startAt.After(now) {
+			return errors.New("the auction of the copy would start in the past, choose a later start_date")
+		}
+		event.AuctionStartAt = &startAt
+		event.AuctionEndAt = &endAt
+	}
+
+	return validateAuctionEvent(*event)
+}
+
+// createEventCopy copies the files in storage and saves the new event with its BOQ and payment terms
+func (s *ServiceEvent) createEventCopy(ctx context.Context, event domainevents.Event, sourceFiles []domainevents.EventTemplateFile, boqItems []domainevents.EventBoqItem, terms []domainevents.EventPaymentTerm) (domainevents.Event, error) {
+	userId := event.CreatedBy
+	now := event.CreatedAt
+
+	files := make([]domainevents.EventFile, 0, len(sourceFiles))
+	for _, f := range sourceFiles {
+		fileUrl, err := s.StorageProvider.CopyFile(ctx, f.FileUrl, "event-files")
+		if err != nil {
+			s.cleanupEventFiles(ctx, files)
+			return domainevents.Event{}, fmt.Errorf("failed to copy file %s: %w", f.FileUrl, err)
+		}
+		files = append(files, domainevents.EventFile{
+			ID:        utils.CreateUUID(),
+			EventId:   event.Id,
+			FileType:  f.FileType,
+			FileUrl:   fileUrl,
+			Caption:   f.Caption,
+			CreatedAt: now,
+			CreatedBy: userId,
+		})
+	}
+
+	items := make([]domainevents.EventBoqItem, 0, len(boqItems))
+	for _, item := range boqItems {
+		items = append(items, domainevents.EventBoqItem{
+			Id:            utils.CreateUUID(),
+			EventID:       event.Id,
+			ItemNo:        item.ItemNo,
+			Description:   item.Description,
+			Unit:          item.Unit,
+			Quantity:      item.Quantity,
+			OwnerEstimate: item.OwnerEstimate,
+			CreatedAt:     now,
+			CreatedBy:     userId,
+			UpdatedAt:     now,
+			UpdatedBy:     userId,
+		})
+	}
+
+	paymentTerms := make([]domainevents.EventPaymentTerm, 0, len(terms))
+	for _, term := range terms {
+		term.Id = utils.CreateUUID()
+		term.EventID = event.Id
+		term.CreatedAt = now
+		term.CreatedBy = userId
+		paymentTerms = append(paymentTerms, term)
+	}
+
+	err := s.UoW.Do(func(repos interfaceuow.Repositories) error {
+		if err := repos.Event.CreateEvent(event); err != nil {
+			return err
+		}
+		for _, f := range files {
+			if err := repos.Event.CreateEventFile(f); err != nil {
+				return err
+			}
+		}
+		if err := repos.Event.ReplaceBoqItems(event.Id, userId, items); err != nil {
+			return err
+		}
+		return repos.Event.ReplacePaymentTerms(event.Id, paymentTerms)
+	})
+	if err != nil {
+		s.cleanupEventFiles(ctx, files)
+		return domainevents.Event{}, err
+	}
+
+	event.File = files
+	event.PaymentTerms = paymentTerms
+	return event, nil
+}
+
+func (s *ServiceEvent) cleanupTemplateFiles(ctx context.Context, files []domainevents.EventTemplateFile) {
+	for _, f := range files {
+		if err := s.StorageProvider.DeleteFile(ctx, f.FileUrl); err != nil {
+			logger.WriteLog(logger.LogLevelError, fmt.Sprintf("Failed to clean up template file %s: %s", f.FileUrl, err))
+		}
+	}
+}
+
+func (s *ServiceEvent) SubmitPitch(eventId, vendorId string, req dto.SubmitPitchRequest) (domainevents.EventSubmission, error) {
+	submission, err := s.createSubmission(eventId, vendorId, req, nil)
+	if err != nil {
 		return domainevents.EventSubmission{}, err
 	}
 
+	// The stored copy may be sealed; the vendor gets back what they sent
+	s.revealSubmission(submission.Event, &submission)
 	return submission, nil
 }
 
-func (s *ServiceEvent) ShortlistSubmission(submissionId string, isShortlisted bool) (domainevents.EventSubmission, error) {
-	submission, err := s.EventRepo.GetSubmissionByID(submissionId)
+// ensureSubmissionWindow rejects any change to a submission once the event is no
+// longer open or its deadline has passed
+func ensureSubmissionWindow(event domainevents.Event) error {
+	if event.EventType == utils.EventTypeReverseAuction {
+		return errors.New("reverse auction events take bids through the auction, not submissions")
+	}
+	if event.Status != utils.EventOpen {
+		return errors.New("event is not open for submissions")
+	}
+	if event.EndDate != nil && !time.Now().Before(*event.EndDate) {
+		return errors.New("submission deadline has passed, submissions are frozen")
+	}
+	if event.SealedBids && event.BidsOpenedAt != nil {
+		return errors.New("bids have been opened, submissions are frozen")
+	}
+	return nil
+}
+
+// lockSubmissionWindow takes the event row lock for a submission change and checks the submission
+// window and the vendor's access again on the locked row, so no change lands after the event was
+// closed, cancelled or had its bids opened meanwhile
+func lockSubmissionWindow(repo interfaceevents.RepoEventInterface, eventId, vendorId string) error {
+	locked, err := repo.LockEventForUpdate(eventId)
+	if err != nil {
+		return err
+	}
+	if err := ensureSubmissionWindow(locked); err != nil {
+		return err
+	}
+	if locked.ParticipationMode == utils.EventModeInvited {
+		allowed, err := repo.IsVendorEligibleForEvent(eventId, vendorId)
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return errors.New("access denied: your vendor is not invited to this event")
+		}
+	}
+	return nil
+}
+
+// createSubmission stores a new submission together with its already uploaded files, its
+// quotation and its first revision, or re-activates a previously withdrawn one
+func (s *ServiceEvent) createSubmission(eventId, vendorId string, req dto.SubmitPitchRequest, files []domainevents.EventSubmissionFile) (domainevents.EventSubmission, error) {
+	event, err := s.EventRepo.GetEventByID(eventId)
+	if err != nil {
+		return domainevents.EventSubmission{}, err
+	}
+
+	if err := ensureSubmissionWindow(event); err != nil {
+		return domainevents.EventSubmission{}, err
+	}
+
+	allowed, err := s.CanVendorAccessEvent(event, vendorId)
+	if err != nil {
+		return domainevents.EventSubmission{}, err
+	}
+	if !allowed {
+		return domainevents.EventSubmission{}, errors.New("access denied: your vendor is not invited to this event")
+	}
+
+	if err := s.ensureAddendaAcknowledged(eventId, vendorId); err != nil {
+		return domainevents.EventSubmission{}, err
+	}
+
+	proposalDetails, err := s.sealText(event, req.ProposalDetails)
+	if err != nil {
+		return domainevents.EventSubmission{}, err
+	}
+
+	prices, err := s.buildSubmissionPrices(event, vendorId, req.Prices)
+	if err != nil {
+		return domainevents.EventSubmission{}, err
+	}
+
+	now := time.Now()
+	var submission domainevents.EventSubmission
+	// The event row lock keeps a concurrent submit of the same vendor from creating a second submission
+	err = s.UoW.Do(func(repos interfaceuow.Repositories) error {
+		if err := lockSubmissionWindow(repos.Event, eventId, vendorId); err != nil {
+			return err
+		}
+		existing, err := repos.Event.GetSubmissionByEventAndVendor(eventId, vendorId)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		if existing.Id != "" {
+			if existing.Status != utils.SubmissionWithdrawn {
+				return errors.New("you have already submitted a pitch for this event, amend it instead")
+			}
+
+			// Re-submitting after a withdrawal continues the same revision history
+			existing.Status = utils.SubmissionSubmitted
+			existing.ProposalDetails = proposalDetails
+			existing.RevisionNumber++
+			existing.WithdrawnAt = nil
+			existing.WithdrawalReason = ""
+			existing.Score = nil
+			existing.Comments = ""
+			existing.IsShortlisted = false
+			existing.UpdatedAt = now
+			existing.UpdatedBy = vendorId
+
+			if len(files) == 0 {
+				if err := repos.Event.UpdateSubmission(existing); err != nil {
+					return err
+				}
+			} else {
+				for i := range files {
+					files[i].EventSubmissionId = existing.Id
+				}
+				if err := repos.Event.ReplaceSubmissionFiles(existing, nil, files); err != nil {
+					return err
+				}
+				existing.File = files
+			}
+
+			// The quotation sent before the withdrawal no longer stands
+			for i := range prices {
+				prices[i].EventSubmissionId = existing.Id
+			}
+			if err := repos.Event.ReplaceSubmissionPrices(existing.Id, prices); err != nil {
+				return err
+			}
+
+			submission = existing
+			return s.recordRevision(repos.Event, submission, utils.RevisionSubmitted, "", vendorId)
+		}
+
+		submission = domainevents.EventSubmission{
+			Id:              utils.CreateUUID(),
+			EventID:         eventId,
+			VendorID:        vendorId,
+			ProposalDetails: proposalDetails,
+			IsShortlisted:   false,
+			IsWinner:        false,
+			Status:          utils.SubmissionSubmitted,
+			RevisionNumber:  1,
+			CreatedAt:       now,
+			CreatedBy:       vendorId,
+			UpdatedAt:       now,
+			UpdatedBy:       vendorId,
+		}
+		for i := range files {
+			files[i].EventSubmissionId = submission.Id
+		}
+		submission.File = files
+		for i := range prices {
+			prices[i].EventSubmissionId = submission.Id
+		}
+		submission.Prices = prices
+
+		// GORM inserts the files and prices together with the submission
+		if err := repos.Event.CreateSubmission(submission); err != nil {
+			return err
+		}
+		return s.recordRevision(repos.Event, submission, utils.RevisionSubmitted, "", vendorId)
+	})
+	if err != nil {
+		return domainevents.EventSubmission{}, err
+	}
+
+	if err := s.loadVendorQuotation(&submission); err != nil {
+		return domainevents.EventSubmission{}, err
+	}
+	submission.Event = event
+	return submission, nil
+}
+
+// recordRevision snapshots the submission with its current files and prices as an immutable revision,
+// within the caller's unit of work
+func (s *ServiceEvent) recordRevision(repo interfaceevents.RepoEventInterface, submission domainevents.EventSubmission, action, note, userId string) error {
+	files, err := repo.GetSubmissionFilesBySubmissionID(submission.Id)
+	if err != nil {
+		return err
+	}
+
+	prices, err := repo.GetSubmissionPrices(submission.Id)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	revision := domainevents.EventSubmissionRevision{
+		Id:                  utils.CreateUUID(),
+		EventSubmissionId:   submission.Id,
+		RevisionNumber:      submission.RevisionNumber,
+		Action:              action,
+		ProposalDetails:     submission.ProposalDetails,
+		AdditionalMaterials: submission.AdditionalMaterials,
+		Note:                note,
+		CreatedAt:           now,
+		CreatedBy:           userId,
+	}
+	for _, f := range files {
+		revision.File = append(revision.File, domainevents.EventSubmissionRevisionFile{
+			ID:         utils.CreateUUID(),
+			RevisionId: revision.Id,
+			FileType:   f.FileType,
+			FileUrl:    f.FileUrl,
+			FileName:   f.FileName,
+			MimeType:   f.MimeType,
+			FileSize:   f.FileSize,
+			Caption:    f.Caption,
+			FileOrder:  f.FileOrder,
+			CreatedAt:  now,
+		})
+	}
+	for _, p := range prices {
+		revision.Prices = append(revision.Prices, domainevents.EventSubmissionRevisionPrice{
+			Id:              utils.CreateUUID(),
+			RevisionId:      revision.Id,
+			BoqItemId:       p.BoqItemId,
+			UnitPrice:       p.UnitPrice,
+			UnitPriceSealed: p.UnitPriceSealed,
+			CreatedAt:       now,
+		})
+	}
+
+	return repo.CreateSubmissionRevision(revision)
+}
+
+func (s *ServiceEvent) notifyEventOpen(event domainevents.Event) error {
+	if s.NotificationSvc == nil {
+		return nil
+	}
+	title := "Event dibuka"
+	message := fmt.Sprintf("Event \"%s\" telah dibuka untuk submission.", event.Title)
+	if event.ParticipationMode != utils.EventModeInvited {
+		return s.NotificationSvc.CreateForAll(title, message, utils.NotifEventOpen, "event", event.Id)
+	}
+
+	// Invited events are only announced to eligible vendors
+	vendors, err := s.EventRepo.GetEligibleVendors(event.Id)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("Failed to load eligible vendors for event %s: %s", event.Id, err))
+		return err
+	}
+	for _, vendor := range vendors {
+		if vendor.UserId == "" {
+			continue
+		}
+		_ = s.NotificationSvc.CreateForUser(vendor.UserId, title, message, utils.NotifEventOpen, "event", event.Id)
+	}
+	return nil
+}
+
+func (s *ServiceEvent) notifyInvitedVendors(event domainevents.Event, vendorIds []string) {
+	if s.NotificationSvc == nil {
+		return
+	}
+
+	for _, vendorId := range vendorIds {
+		vendor, err := s.VendorRepo.GetVendorByID(vendorId)
+		if err != nil {
+			logger.WriteLog(logger.LogLevelError, fmt.Sprintf("Failed to load vendor for invitation notification: %s", err))
+			continue
+		}
+		if vendor.UserId == "" {
+			continue
+		}
+		_ = s.NotificationSvc.CreateForUser(
+			vendor.UserId,
+			"Undangan event",
+			fmt.Sprintf("Vendor Anda diundang untuk mengikuti event \"%s\".", event.Title),
+			utils.NotifEventInvite,
+			"event",
+			event.Id,
+		)
+	}
+}
+
+// notifyWinnerAndLosers tells every new award holder about their award and, on the first
+// award of the event, tells the remaining participants they were not selected
+func (s *ServiceEvent) notifyWinnerAndLosers(event domainevents.Event, awards []domainevents.EventAward, notifyLosers bool) {
+	if s.NotificationSvc == nil {
+		return
+	}
+
+	awarded := map[string]bool{}
+	for _, award := range awards {
+		awarded[award.VendorID] = true
+		winnerVendor, err := s.VendorRepo.GetVendorByID(award.VendorID)
+		if err != nil {
+			logger.WriteLog(logger.LogLevelError, fmt.Sprintf("Failed to load winner vendor for notification: %s", err))
+			continue
+		}
+		if winnerVendor.UserId == "" {
+			continue
+		}
+		msg := fmt.Sprintf("Vendor Anda terpilih sebagai pemenang untuk event \"%s\"", event.Title)
+		if award.LotReference != "" {
+			msg += fmt.Sprintf(" pada lot \"%s\"", award.LotReference)
+		}
+		if award.AwardedAmount.IsPositive() {
+			msg += fmt.Sprintf(" dengan nilai Rp %s", award.AwardedAmount.StringFixed(2))
+		}
+		_ = s.NotificationSvc.CreateForUser(
+			winnerVendor.UserId,
+			"Selamat! Anda menang",
+			msg+".",
+			utils.NotifEventWinner,
+			"event",
+			event.Id,
+		)
+	}
+
+	if !notifyLosers {
+		return
+	}
+
+	// Notify non-winners
+	submissions, err := s.EventRepo.GetSubmissionsByEventID(event.Id)
+	if err != nil {
+		return
+	}
+	for _, sub := range submissions {
+		if awarded[sub.VendorID] || sub.Status == utils.SubmissionWithdrawn {
+			continue
+		}
+		vendor, err := s.VendorRepo.GetVendorByID(sub.VendorID)
+		if err != nil {
+			logger.WriteLog(logger.LogLevelError, fmt.Sprintf("Failed to load vendor for loser notification: %s", err))
+			continue
+		}
+		if vendor.UserId == "" {
+			continue
+		}
+		_ = s.NotificationSvc.CreateForUser(
+			vendor.UserId,
+			"Terima kasih sudah berpartisipasi",
+			fmt.Sprintf("Vendor Anda belum terpilih pada event \"%s\". Tetap semangat dan coba lagi!", event.Title),
+			utils.NotifEventLoser,
+			"event",
+			event.Id,
+		)
+	}
+}
+
+func (s *ServiceEvent) SubmitPitchWithFiles(ctx context.Context, eventId, vendorId string, req dto.SubmitPitchRequest, uploads []dto.SubmissionFileUpload) (domainevents.EventSubmission, error) {
+	event, err := s.EventRepo.GetEventByID(eventId)
+	if err != nil {
+		return domainevents.EventSubmission{}, err
+	}
+
+	// Fail fast before anything is uploaded
+	if err := ensureSubmissionWindow(event); err != nil {
+		return domainevents.EventSubmission{}, err
+	}
+
+	if len(uploads) == 0 {
+		return domainevents.EventSubmission{}, errors.New("at least one file is required")
+	}
+
+	mimeTypes, err := validateSubmissionFiles(event, uploads, 0)
+	if err != nil {
+		return domainevents.EventSubmission{}, err
+	}
+
+	files, err := s.uploadSubmissionFiles(ctx, event, vendorId, uploads, mimeTypes, 0)
+	if err != nil {
+		return domainevents.EventSubmission{}, err
+	}
+
+	submission, err := s.createSubmission(eventId, vendorId, req, files)
+	if err != nil {
+		s.cleanupSubmissionFiles(ctx, files)
+		return domainevents.EventSubmission{}, err
+	}
+
+	// The stored copy may be sealed; the vendor gets back what they sent
+	s.revealSubmission(submission.Event, &submission)
+	return submission, nil
+}
+
+// submissionFileRules resolves the event's submission file limits, falling back to the global defaults
+func submissionFileRules(event domainevents.Event) (maxFiles int, maxFileSizeMB int, allowedMimeTypes []string) {
+	maxFiles = utils.MaxSubmissionFiles
+	if event.SubmissionMaxFiles != nil {
+		maxFiles = *event.SubmissionMaxFiles
+	}
+
+	maxFileSizeMB = utils.GetEnv("MAX_FILE_SIZE", 20).(int)
+	if event.SubmissionMaxFileSizeMB != nil {
+		maxFileSizeMB = *event.SubmissionMaxFileSizeMB
+	}
+
+	mimeTypes := event.SubmissionAllowedMimeTypes
+	if mimeTypes == "" {
+		mimeTypes = utils.SubmissionAllowedMimeTypes
+	}
+	for _, m := range strings.Split(mimeTypes, ",") {
+		if m = strings.TrimSpace(m); m != "" {
+			allowedMimeTypes = append(allowedMimeTypes, m)
+		}
+	}
+
+	return maxFiles, maxFileSizeMB, allowedMimeTypes
+}
+
+func isSubmissionFileType(fileType string) bool {
+	switch fileType {
+	case utils.SubmissionFileTechnicalProposal, utils.SubmissionFilePriceProposal, utils.SubmissionFileCompanyProfile, utils.SubmissionFilePhoto, utils.SubmissionFileDocument:
+		return true
+	}
+	return false
+}
+
+// validateSubmissionFiles checks every upload against the event's limits before any of them is stored.
+// It returns the detected MIME type of each upload, in the same order.
+func validateSubmissionFiles(event domainevents.Event, uploads []dto.SubmissionFileUpload, existingCount int) ([]string, error) {
+	maxFiles, maxFileSizeMB, allowedMimeTypes := submissionFileRules(event)
+
+	if existingCount+len(uploads) > maxFiles {
+		return nil, fmt.Errorf("maximum %d files allowed per submission", maxFiles)
+	}
+
+	mimeTypes := make([]string, len(uploads))
+	for i, u := range uploads {
+		if u.File == nil {
+			return nil, fmt.Errorf("invalid file at position %d", i+1)
+		}
+		if !isSubmissionFileType(u.FileType) {
+			return nil, fmt.Errorf("invalid file_type %q for file %s", u.FileType, u.File.Filename)
+		}
+		if len(u.Caption) > 100 {
+			return nil, fmt.Errorf("caption for file %s must be at most 100 characters", u.File.Filename)
+		}
+		if err := utils.ValidateFileSize(u.File, maxFileSizeMB); err != nil {
+			return nil, err
+		}
+
+		mimeType, err := utils.ValidateFileMimeType(u.File, allowedMimeTypes)
+		if err != nil {
+			return nil, err
+		}
+		mimeTypes[i] = mimeType
+	}
+
+	return mimeTypes, nil
+}
+
+// uploadSubmissionFiles pushes every file to storage before anything is written to the database.
+// If one upload fails, the files already uploaded are removed so nothing is left behind.
+// Files of sealed-bid events are encrypted with the event key before they leave the server.
+func (s *ServiceEvent) uploadSubmissionFiles(ctx context.Context, event domainevents.Event, userId string, uploads []dto.SubmissionFileUpload, mimeTypes []string, orderOffset int) ([]domainevents.EventSubmissionFile, error) {
+	// Requested order decides the position; ties keep the form order
+	order := make([]int, len(uploads))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return uploads[order[a]].FileOrder < uploads[order[b]].FileOrder
+	})
+
+	now := time.Now()
+	files := make([]domainevents.EventSubmissionFile, 0, len(uploads))
+	for pos, i := range order {
+		u := uploads[i]
+
+		fileUrl, err := s.uploadSubmissionFile(ctx, event, u.File)
+		if err != nil {
+			s.cleanupSubmissionFiles(ctx, files)
+			return nil, err
+		}
+
+		files = append(files, domainevents.EventSubmissionFile{
+			ID:          utils.CreateUUID(),
+			FileType:    u.FileType,
+			FileUrl:     fileUrl,
+			FileName:    u.File.Filename,
+			MimeType:    mimeTypes[i],
+			FileSize:    u.File.Size,
+			Caption:     u.Caption,
+			FileOrder:   orderOffset + pos,
+			IsEncrypted: event.SealedBids,
+			CreatedAt:   now,
+			CreatedBy:   userId,
+		})
+	}
+
+	return files, nil
+}
+
+func (s *ServiceEvent) uploadSubmissionFile(ctx context.Context, event domainevents.Event, fileHeader *multipart.FileHeader) (string, error) {
+	file, err := fileHeader.Open()
+	if err != nil {
+		return "", fmt.Errorf("failed to open file %s: %w", fileHeader.Filename, err)
+	}
+	defer file.Close()
+
+	if !event.SealedBids {
+		// Upload to storage provider (MinIO or R2)
+		fileUrl, err := s.StorageProvider.UploadFile(ctx, file, fileHeader, "event-submission-files")
+		if err != nil {
+			return "", fmt.Errorf("failed to upload file %s to storage: %w", fileHeader.Filename, err)
+		}
+		return fileUrl, nil
+	}
+
+	if s.BidCipher == nil {
+		return "", errSealedBidsUnavailable
+	}
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return "", fmt.Errorf("failed to read file %s: %w", fileHeader.Filename, err)
+	}
+
+	sealed, err := s.BidCipher.Encrypt(event.BidKey, data)
+	if err != nil {
+		return "", fmt.Errorf("failed to encrypt file %s: %w", fileHeader.Filename, err)
+	}
+
+	fileUrl, err := s.StorageProvider.UploadFileFromBytes(ctx, sealed, fileHeader.Filename+".sealed", "event-submission-files", "application/octet-stream")
+	if err != nil {
+		return "", fmt.Errorf("failed to upload file %s to storage: %w", fileHeader.Filename, err)
+	}
+	return fileUrl, nil
+}
+
+func (s *ServiceEvent) cleanupSubmissionFiles(ctx context.Context, files []domainevents.EventSubmissionFile) {
+	for _, f := range files {
+		_ = s.StorageProvider.DeleteFile(ctx, f.FileUrl)
+	}
+}
+
+// AmendSubmission replaces the vendor's proposal and, optionally, its files and quotation before
+// the deadline. When files are sent, current files not listed in keepFileIds are detached. Superseded
+// files stay in storage because earlier revisions still reference them.
+func (s *ServiceEvent) AmendSubmission(ctx context.Context, eventId, vendorId string, req dto.SubmitPitchRequest, keepFileIds []string, uploads []dto.SubmissionFileUpload) (domainevents.EventSubmission, error) {
+	event, err := s.EventRepo.GetEventByID(eventId)
+	if err != nil {
+		return domainevents.EventSubmission{}, err
+	}
+
+	if err := ensureSubmissionWindow(event); err != nil {
+		return domainevents.EventSubmission{}, err
+	}
+
+	allowed, err := s.CanVendorAccessEvent(event, vendorId)
+	if err != nil {
+		return domainevents.EventSubmission{}, err
+	}
+	if !allowed {
+		return domainevents.EventSubmission{}, errors.New("access denied: your vendor is not invited to this event")
+	}
+
+	submission, err := s.EventRepo.GetSubmissionByEventAndVendor(eventId, vendorId)
+	if err != nil {
+		return domainevents.EventSubmission{}, err
+	}
+
+	if submission.Status == utils.SubmissionWithdrawn {
+		return domainevents.EventSubmission{}, errors.New("submission has been withdrawn, submit a new pitch instead")
+	}
+
+	if err := s.ensureAddendaAcknowledged(eventId, vendorId); err != nil {
+		return domainevents.EventSubmission{}, err
+	}
+
+	proposalDetails, err := s.sealText(event, req.ProposalDetails)
+	if err != nil {
+		return domainevents.EventSubmission{}, err
+	}
+
+	// Without prices the current quotation is kept
+	var prices []domainevents.EventSubmissionPrice
+	if req.Prices != nil {
+		if prices, err = s.buildSubmissionPrices(event, vendorId, req.Prices); err != nil {
+			return domainevents.EventSubmission{}, err
+		}
+		for i := range prices {
+			prices[i].EventSubmissionId = submission.Id
+		}
+	}
+
+	// Scores and the shortlist given to an earlier revision no longer apply
+	submission.ProposalDetails = proposalDetails
+	submission.RevisionNumber++
+	submission.Score = nil
+	submission.Comments = ""
+	submission.IsShortlisted = false
+	submission.UpdatedAt = time.Now()
+	submission.UpdatedBy = vendorId
+
+	var files []domainevents.EventSubmissionFile
+	if keepFileIds != nil || len(uploads) > 0 {
+		current, err := s.EventRepo.GetSubmissionFilesBySubmissionID(submission.Id)
+		if err != nil {
+			return domainevents.EventSubmission{}, err
+		}
+		currentIds := make(map[string]struct{}, len(current))
+		for _, f := range current {
+			currentIds[f.ID] = struct{}{}
+		}
+		for _, id := range keepFileIds {
+			if _, ok := currentIds[id]; !ok {
+				return domainevents.EventSubmission{}, fmt.Errorf("invalid keep_file_ids: file %s does not belong to this submission", id)
+			}
+		}
+
+		if len(keepFileIds)+len(uploads) == 0 {
+			return domainevents.EventSubmission{}, errors.New("at least one file is required")
+		}
+
+		mimeTypes, err := validateSubmissionFiles(event, uploads, len(keepFileIds))
+		if err != nil {
+			return domainevents.EventSubmission{}, err
+		}
+
+		if files, err = s.uploadSubmissionFiles(ctx, event, vendorId, uploads, mimeTypes, len(keepFileIds)); err != nil {
+			return domainevents.EventSubmission{}, err
+		}
+		for i := range files {
+			files[i].EventSubmissionId = submission.Id
+		}
+	}
+
+	// Uploading can take a while, so the window is checked again under the event lock
+	err = s.UoW.Do(func(repos interfaceuow.Repositories) error {
+		if err := lockSubmissionWindow(repos.Event, eventId, vendorId); err != nil {
+			return err
+		}
+		current, err := repos.Event.GetSubmissionByID(submission.Id)
+		if err != nil {
+			return err
+		}
+		if current.Status == utils.SubmissionWithdrawn || current.RevisionNumber != submission.RevisionNumber-1 {
+			return errors.New("submission was changed meanwhile, please reload and try again")
+		}
+
+		if keepFileIds == nil && len(uploads) == 0 {
+			if err := repos.Event.UpdateSubmission(submission); err != nil {
+				return err
+			}
+		} else if err := repos.Event.ReplaceSubmissionFiles(submission, keepFileIds, files); err != nil {
+			return err
+		}
+
+		if req.Prices != nil {
+			if err := repos.Event.ReplaceSubmissionPrices(submission.Id, prices); err != nil {
+				return err
+			}
+		}
+
+		return s.recordRevision(repos.Event, submission, utils.RevisionAmended, "", vendorId)
+	})
+	if err != nil {
+		s.cleanupSubmissionFiles(ctx, files)
+		return domainevents.EventSubmission{}, err
+	}
+
+	if err := s.loadVendorQuotation(&submission); err != nil {
+		return domainevents.EventSubmission{}, err
+	}
+	s.revealSubmission(event, &submission)
+	return submission, nil
+}
+
+// WithdrawSubmission pulls the vendor's submission out of the event before the deadline.
+// The vendor may submit again while the event is still open.
+func (s *ServiceEvent) WithdrawSubmission(eventId, vendorId string, req dto.WithdrawSubmissionRequest) (domainevents.EventSubmission, error) {
+	event, err := s.EventRepo.GetEventByID(eventId)
+	if err != nil {
+		return domainevents.EventSubmission{}, err
+	}
+
+	if err := ensureSubmissionWindow(event); err != nil {
+		return domainevents.EventSubmission{}, err
+	}
+
+	allowed, err := s.CanVendorAccessEvent(event, vendorId)
+	if err != nil {
+		return domainevents.EventSubmission{}, err
+	}
+	if !allowed {
+		return domainevents.EventSubmission{}, errors.New("access denied: your vendor is not invited to this event")
+	}
+
+	submission, err := s.EventRepo.GetSubmissionByEventAndVendor(eventId, vendorId)
+	if err != nil {
+		return domainevents.EventSubmission{}, err
+	}
+
+	if submission.Status == utils.SubmissionWithdrawn {
+		return domainevents.EventSubmission{}, errors.New("submission has already been withdrawn")
+	}
+
+	now := time.Now()
+	submission.Status = utils.SubmissionWithdrawn
+	submission.RevisionNumber++
+	submission.WithdrawnAt = &now
+	submission.WithdrawalReason = req.Reason
+	submission.Score = nil
+	submission.Comments = ""
+	submission.IsShortlisted = false
+	submission.UpdatedAt = now
+	submission.UpdatedBy = vendorId
+	err = s.UoW.Do(func(repos interfaceuow.Repositories) error {
+		if err := lockSubmissionWindow(repos.Event, eventId, vendorId); err != nil {
+			return err
+		}
+		current, err := repos.Event.GetSubmissionByID(submission.Id)
+		if err != nil {
+			return err
+		}
+		if current.Status == utils.SubmissionWithdrawn || current.RevisionNumber != submission.RevisionNumber-1 {
+			return errors.New("submission was changed meanwhile, please reload and try again")
+		}
+
+		if err := repos.Event.UpdateSubmission(submission); err != nil {
+			return err
+		}
+
+		// Snapshot the withdrawn files before detaching them from the submission
+		if err := s.recordRevision(repos.Event, submission, utils.RevisionWithdrawn, req.Reason, vendorId); err != nil {
+			return err
+		}
+
+		return repos.Event.DeleteSubmissionFilesBySubmissionID(submission.Id)
+	})
+	if err != nil {
+		return domainevents.EventSubmission{}, err
+	}
+
+	s.revealSubmission(event, &submission)
+	return submission, nil
+}
+
+func (s *ServiceEvent) GetMySubmissionRevisions(eventId, vendorId string) ([]domainevents.EventSubmissionRevision, error) {
+	event, err := s.EventRepo.GetEventByID(eventId)
+	if err != nil {
+		return nil, err
+	}
+
+	submission, err := s.EventRepo.GetSubmissionByEventAndVendor(eventId, vendorId)
+	if err != nil {
+		return nil, err
+	}
+
+	revisions, err := s.EventRepo.GetSubmissionRevisions(submission.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	s.presentRevisions(event, revisions, true)
+	return revisions, nil
+}
+
+func (s *ServiceEvent) GetSubmissionRevisions(submissionId string) ([]domainevents.EventSubmissionRevision, error) {
+	submission, err := s.EventRepo.GetSubmissionByID(submissionId)
+	if err != nil {
+		return nil, err
+	}
+
+	event, err := s.EventRepo.GetEventByID(submission.EventID)
+	if err != nil {
+		return nil, err
+	}
+
+	revisions, err := s.EventRepo.GetSubmissionRevisions(submissionId)
+	if err != nil {
+		return nil, err
+	}
+
+	s.presentRevisions(event, revisions, false)
+	return revisions, nil
+}
+
+func (s *ServiceEvent) GetSubmissionsByEventID(eventId string) ([]domainevents.EventSubmission, error) {
+	submissions, err := s.EventRepo.GetSubmissionsByEventID(eventId)
+	if err != nil {
+		return nil, err
+	}
+
+	s.presentSubmissions(submissions, false, false)
+	return submissions, nil
+}
+
+func (s *ServiceEvent) GetSubmissionsByEventIDPaginated(eventId, userId string, params filter.BaseParams) ([]domainevents.EventSubmission, int64, error) {
+	submissions, total, err := s.EventRepo.GetSubmissionsByEventIDPaginated(eventId, params)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	budget, err := s.canSeeBudget(userId)
+	if err != nil {
+		return nil, 0, err
+	}
+	s.presentSubmissions(submissions, false, budget)
+	if budget {
+		for i := range submissions {
+			submissions[i].PriceFlag = priceFlag(submissions[i].Event, submissions[i].QuotedTotal)
+		}
+	}
+	return submissions, total, nil
+}
+
+func (s *ServiceEvent) GetAllSubmissions(userId string, params filter.BaseParams) ([]map[string]interface{}, int64, error) {
+	ownerId, err := s.ownedEventsScope(userId)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	submissions, total, err := s.EventRepo.GetAllSubmissions(ownerId, params)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	budget, err := s.canSeeBudget(userId)
+	if err != nil {
+		return nil, 0, err
+	}
+	s.presentSubmissions(submissions, false, budget)
+
+	result := make([]map[string]interface{}, len(submissions))
+	for i, sub := range submissions {
+		result[i] = map[string]interface{}{
+			"id":                   sub.Id,
+			"event_id":             sub.EventID,
+			"event":                sub.Event,
+			"vendor_id":            sub.VendorID,
+			"vendor":               sub.Vendor,
+			"proposal_details":     sub.ProposalDetails,
+			"additional_materials": sub.AdditionalMaterials,
+			"score":                sub.Score,
+			"comments":             sub.Comments,
+			"is_shortlisted":       sub.IsShortlisted,
+			"is_winner":            sub.IsWinner,
+			"status":               sub.Status,
+			"revision_number":      sub.RevisionNumber,
+			"sealed":               sub.Sealed,
+			"files":                sub.File,
+			"prices":               sub.Prices,
+			"quoted_total":         sub.QuotedTotal,
+			"created_at":           sub.CreatedAt,
+			"updated_at":           sub.UpdatedAt,
+		}
+	}
+
+	return result, total, nil
+}
+
+func (s *ServiceEvent) GetGroupedSubmissions(userId string, params filter.BaseParams, submissionPage int, submissionLimit int) (*domainevents.GroupedSubmissionsResponse, error) {
+	ownerId, err := s.ownedEventsScope(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	grouped, err := s.EventRepo.GetGroupedSubmissions(ownerId, params, submissionPage, submissionLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	budget, err := s.canSeeBudget(userId)
+	if err != nil {
+		return nil, err
+	}
+	for i := range grouped.EventGroups {
+		group := &grouped.EventGroups[i]
+		for j := range group.Submissions {
+			sub := &group.Submissions[j]
+			s.presentSubmission(group.Event, sub, false, budget)
+			if budget {
+				sub.PriceFlag = priceFlag(group.Event, sub.QuotedTotal)
+			}
+		}
+	}
+
+	return grouped, nil
+}
+
+func (s *ServiceEvent) GetMySubmissions(vendorId string, params filter.BaseParams) ([]domainevents.EventSubmission, int64, error) {
+	submissions, total, err := s.EventRepo.GetSubmissionsByVendorID(vendorId, params)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	s.presentSubmissions(submissions, true, false)
+	return submissions, total, nil
+}
+
+func (s *ServiceEvent) ScoreSubmission(submissionId, userId string, req dto.ScoreSubmissionRequest) (domainevents.EventSubmission, error) {
+	submission, err := s.EventRepo.GetSubmissionByID(submissionId)
+	if err != nil {
+		return domainevents.EventSubmission{}, err
+	}
+
+	if submission.Status == utils.SubmissionWithdrawn {
+		return domainevents.EventSubmission{}, errors.New("withdrawn submission cannot be scored")
+	}
+
+	event, err := s.EventRepo.GetEventByID(submission.EventID)
+	if err != nil {
+		return domainevents.EventSubmission{}, err
+	}
+	if event.Status == utils.EventCancelled {
+		return domainevents.EventSubmission{}, errEventCancelled
+	}
+	if !bidsRevealed(event) {
+		return domainevents.EventSubmission{}, errBidsSealed
+	}
+
+	submission.Score = &req.Score
+	submission.Comments = req.Comments
+	submission.UpdatedAt = time.Now()
+
+	if err := s.EventRepo.UpdateSubmission(submission); err != nil {
+		return domainevents.EventSubmission{}, err
+	}
+
+	s.revealSubmission(event, &submission)
+	budget, err := s.canSeeBudget(userId)
+	if err != nil {
+		return domainevents.EventSubmission{}, err
+	}
+	if budget {
+		submission.PriceFlag = priceFlag(event, submission.QuotedTotal)
+	}
+	return submission, nil
+}
+
+func (s *ServiceEvent) ShortlistSubmission(submissionId, userId string, isShortlisted bool) (domainevents.EventSubmission, error) {
+	submission, err := s.EventRepo.GetSubmissionByID(submissionId)
+	if err != nil {
+		return domainevents.EventSubmission{}, err
+	}
+
+	if submission.Status == utils.SubmissionWithdrawn {
+		return domainevents.EventSubmission{}, errors.New("withdrawn submission cannot be shortlisted")
+	}
+
+	event, err := s.EventRepo.GetEventByID(submission.EventID)
+	if err != nil {
+		return domainevents.EventSubmission{}, err
+	}
+	if event.Status == utils.EventCancelled {
+		return domainevents.EventSubmission{}, errEventCancelled
+	}
+	if !bidsRevealed(event) {
+		return domainevents.EventSubmission{}, errBidsSealed
+	}
+
+	submission.IsShortlisted = isShortlisted
+	submission.UpdatedAt = time.Now()
+
+	if err := s.EventRepo.UpdateSubmission(submission); err != nil {
+		return domainevents.EventSubmission{}, err
+	}
+
+	s.revealSubmission(event, &submission)
+	budget, err := s.canSeeBudget(userId)
+	if err != nil {
+		return domainevents.EventSubmission{}, err
+	}
+	if budget {
+		submission.PriceFlag = priceFlag(event, submission.QuotedTotal)
+	}
+	return submission, nil
+}
+
+// SelectWinner awards the event to one or more submissions. A single submission_id is
+// treated as one award for the whole event at the submission's quoted total.
+func (s *ServiceEvent) SelectWinner(eventId, userId string, req dto.SelectWinnerRequest) (map[string]interface{}, error) {
+	event, err := s.EventRepo.GetEventByID(eventId)
+	if err != nil {
+		return nil, err
+	}
+
+	if event.Status == utils.EventCancelled {
+		return nil, errEventCancelled
+	}
+	if !bidsRevealed(event) {
+		return nil, errBidsSealed
+	}
+	if event.EventType == utils.EventTypeReverseAuction && event.AuctionClosedAt == nil {
+		return nil, errors.New("auction must be closed before a winner is selected")
+	}
+
+	awardReqs := req.Awards
+	single := len(awardReqs) == 0
+	if single && req.SubmissionID != "" {
+		awardReqs = []dto.AwardRequest{{SubmissionID: req.SubmissionID, Justification: req.Justification}}
+	}
+	if len(awardReqs) == 0 {
+		return nil, errors.New("at least one award is required")
+	}
+
+	existing, err := s.EventRepo.GetAwardsByEventID(eventId)
+	if err != nil {
+		return nil, err
+	}
+
+	// Further awards are only possible for lots that have not been awarded yet;
+	// an award for the whole event has to be revoked first
+	lots := map[string]bool{}
+	for _, award := range existing {
+		if award.LotReference == "" {
+			return nil, errors.New("winner has already been selected for this event")
+		}
+		lots[award.LotReference] = true
+	}
+	unlotted := map[string]bool{}
+
+	now := time.Now()
+	submissions := map[string]domainevents.EventSubmission{}
+	awards := make([]domainevents.EventAward, 0, len(awardReqs))
+	for _, ar := range awardReqs {
+		submission, ok := submissions[ar.SubmissionID]
+		if !ok {
+			submission, err = s.EventRepo.GetSubmissionByID(ar.SubmissionID)
+			if err != nil {
+				return nil, err
+			}
+			if submission.EventID != eventId {
+				return nil, errors.New("submission does not belong to this event")
+			}
+			if submission.Status == utils.SubmissionWithdrawn {
+				return nil, errors.New("withdrawn submission cannot be selected as winner")
+			}
+			// The quotation is needed for the award amount and the budget checks
+			if err := s.loadVendorQuotation(&submission); err != nil {
+				return nil, err
+			}
+			s.revealSubmission(event, &submission)
+			submissions[ar.SubmissionID] = submission
+		}
+
+		lot := strings.TrimSpace(ar.LotReference)
+		if lot == "" && len(existing) > 0 {
+			return nil, errors.New("lot_reference is required when the event already has lot awards")
+		}
+		if lot != "" {
+			if lots[lot] {
+				return nil, fmt.Errorf("lot %q is awarded more than once", lot)
+			}
+			lots[lot] = true
+		} else {
+			if unlotted[submission.Id] {
+				return nil, errors.New("a submission can only hold one award without a lot reference")
+			}
+			unlotted[submission.Id] = true
+		}
+
+		amount := decimal.NewFromFloat(ar.AwardedAmount).Round(2)
+		quoted := submission.QuotedTotal
+		if event.EventType == utils.EventTypeReverseAuction {
+			bid, err := s.lowestAuctionBid(eventId, submission.VendorID)
+			if err != nil {
+				return nil, err
+			}
+			quoted = &bid.Amount
+		}
+		if single && quoted != nil {
+			amount = quoted.Round(2)
+		}
+
+		// A quote above the ceiling or abnormally low can still win, but only with a recorded justification
+		flag := priceFlag(event, quoted)
+		justification := strings.TrimSpace(ar.Justification)
+		if flag != "" && justification == "" {
+			return nil, fmt.Errorf("submission %s is flagged as %s, a justification is required to award it", submission.Id, flag)
+		}
+
+		awards = append(awards, domainevents.EventAward{
+			Id:                 utils.CreateUUID(),
+			EventID:            eventId,
+			SubmissionID:       submission.Id,
+			VendorID:           submission.VendorID,
+			LotReference:       lot,
+			AwardedAmount:      amount,
+			Notes:              strings.TrimSpace(ar.Notes),
+			PriceFlag:          flag,
+			PriceJustification: justification,
+			CreatedAt:          now,
+			CreatedBy:          userId,
+			UpdatedAt:          now,
+			UpdatedBy:          userId,
+		})
+	}
+
+	// winner_vendor_id keeps pointing at the first award for single-winner consumers
+	primary := awards[0].VendorID
+	if len(existing) > 0 {
+		primary = existing[0].VendorID
+	}
+	event.WinnerVendorID = &primary
+	event.Status = utils.EventCompleted
+	event.UpdatedAt = now
+	event.UpdatedBy = userId
+
+	history := make([]domainevents.EventHistory, 0, len(awards))
+	for i := range awards {
+		history = append(history, awardHistory(awards[i], utils.HistoryAwardGranted, describeAward("Award granted", awards[i]), awards[i].PriceJustification, userId, now))
+	}
+
+	terms, err := s.EventRepo.GetPaymentTerms(eventId)
+	if err != nil {
+		return nil, err
+	}
+	payments := schedulePayments(event, terms, awards, userId, now)
+
+	err = s.UoW.Do(func(repos interfaceuow.Repositories) error {
+		if err := lockAwards(repos.Event, event, existing); err != nil {
+			return err
+		}
+		if err := repos.Event.SaveAwards(event, nil, awards, history); err != nil {
+			return err
+		}
+		return repos.Payment.CreatePayments(payments)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.notifyWinnerAndLosers(event, awards, len(existing) == 0)
+
+	event, err = s.EventRepo.GetEventByID(eventId)
+	if err != nil {
+		return nil, err
+	}
+	allAwards, err := s.EventRepo.GetAwardsByEventID(eventId)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"event":              event,
+		"awards":             allAwards,
+		"scheduled_payments": payments,
+	}, nil
+}
+
+// RevokeAward cancels an award with a reason, then either awards the next-ranked
+// shortlisted submission or re-opens the event when nothing else stays awarded
+func (s *ServiceEvent) RevokeAward(awardId, userId string, req dto.RevokeAwardRequest) (map[string]interface{}, error) {
+	award, err := s.EventRepo.GetAwardByID(awardId)
+	if err != nil {
+		return nil, err
+	}
+
+	event, err := s.EventRepo.GetEventByID(award.EventID)
+	if err != nil {
+		return nil, err
+	}
+	if event.Status == utils.EventCancelled {
+		return nil, errors.New("awards of a cancelled event cannot be revoked")
+	}
+
+	existing, err := s.EventRepo.GetAwardsByEventID(award.EventID)
+	if err != nil {
+		return nil, err
+	}
+	remaining := make([]domainevents.EventAward, 0, len(existing))
+	for _, a := range existing {
+		if a.Id != award.Id {
+			remaining = append(remaining, a)
+		}
+	}
+
+	fallback := req.Fallback
+	if fallback == "" {
+		fallback = utils.RevokeFallbackRunnerUp
+	}
+
+	now := time.Now()
+	reason := strings.TrimSpace(req.Reason)
+	award.RevokedAt = &now
+	award.RevokedBy = &userId
+	award.RevokeReason = reason
+	award.DeletedBy = userId
+
+	history := []domainevents.EventHistory{
+		awardHistory(award, utils.HistoryAwardRevoked, describeAward("Award revoked", award), reason, userId, now),
+	}
+
+	var replacement *domainevents.EventAward
+	switch fallback {
+	case utils.RevokeFallbackRunnerUp:
+		runnerUp, err := s.findRunnerUp(event, award, existing)
+		if err != nil {
+			return nil, err
+		}
+		if runnerUp == nil {
+			return nil, errors.New("no shortlisted runner-up is available, re-open the event instead")
+		}
+
+		amount := award.AwardedAmount
+		if award.LotReference == "" && runnerUp.QuotedTotal != nil {
+			amount = runnerUp.QuotedTotal.Round(2)
+		}
+		flag := priceFlag(event, runnerUp.QuotedTotal)
+		justification := strings.TrimSpace(req.Justification)
+		if flag != "" && justification == "" {
+			return nil, fmt.Errorf("the runner-up is flagged as %s, a justification is required to award it", flag)
+		}
+		replacement = &domainevents.EventAward{
+			Id:                 utils.CreateUUID(),
+			EventID:            event.Id,
+			SubmissionID:       runnerUp.Id,
+			VendorID:           runnerUp.VendorID,
+			LotReference:       award.LotReference,
+			AwardedAmount:      amount,
+			Notes:              award.Notes,
+			PriceFlag:          flag,
+			PriceJustification: justification,
+			CreatedAt:          now,
+			CreatedBy:          userId,
+			UpdatedAt:          now,
+			UpdatedBy:          userId,
+		}
+		history = append(history, awardHistory(*replacement, utils.HistoryRunnerUp, describeAward("Award passed to the runner-up", *replacement), reason, userId, now))
+
+		remaining = append(remaining, *replacement)
+		event.WinnerVendorID = &remaining[0].VendorID
+	case utils.RevokeFallbackReopen:
+		if len(remaining) > 0 {
+			return nil, errors.New("other awards of this event are still active, award the lot to the runner-up instead")
+		}
+		// Vendors who already saw the sealed prices could undercut them in a new round
+		if event.SealedBids && bidsRevealed(event) {
+			return nil, errors.New("the sealed bids of this event were already opened, award the runner-up or create a new event instead")
+		}
+		if req.EndDate != "" {
+			t, err := time.Parse("2006-01-02", req.EndDate)
+			if err != nil {
+				return nil, errors.New("invalid end_date format, use YYYY-MM-DD")
+			}
+			event.EndDate = &t
+		}
+		if event.EndDate == nil || !event.EndDate.After(now) {
+			return nil, errors.New("invalid end_date: re-opening the event requires a deadline in the future")
+		}
+
+		event.WinnerVendorID = nil
+		event.Status = utils.EventOpen
+		history = append(history, domainevents.EventHistory{
+			Id:          utils.CreateUUID(),
+			EventID:     event.Id,
+			Action:      utils.HistoryEventReopened,
+			Description: fmt.Sprintf("Event re-opened until %s", event.EndDate.Format("2006-01-02")),
+			Reason:      reason,
+			CreatedAt:   now,
+			CreatedBy:   userId,
+		})
+	}
+	event.UpdatedAt = now
+	event.UpdatedBy = userId
+
+	var awards []domainevents.EventAward
+	if replacement != nil {
+		awards = append(awards, *replacement)
+	}
+
+	// Unpaid payments of the revoked award are cancelled and the runner-up gets its own schedule
+	terms, err := s.EventRepo.GetPaymentTerms(event.Id)
+	if err != nil {
+		return nil, err
+	}
+	payments := schedulePayments(event, terms, awards, userId, now)
+
+	err = s.UoW.Do(func(repos interfaceuow.Repositories) error {
+		if err := lockAwards(repos.Event, event, existing); err != nil {
+			return err
+		}
+		if err := repos.Event.SaveAwards(event, []domainevents.EventAward{award}, awards, history); err != nil {
+			return err
+		}
+		if err := repos.Payment.CancelScheduledPayments(award.Id, userId); err != nil {
+			return err
+		}
+		return repos.Payment.CreatePayments(payments)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.notifyAwardRevoked(event, award)
+	if replacement != nil {
+		s.notifyWinnerAndLosers(event, awards, false)
+	} else {
+		s.notifyEventReopened(event, award.VendorID)
+	}
+
+	event, err = s.EventRepo.GetEventByID(event.Id)
+	if err != nil {
+		return nil, err
+	}
+	allAwards, err := s.EventRepo.GetAwardsByEventID(event.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"event":              event,
+		"revoked_award":      award,
+		"runner_up":          replacement,
+		"awards":             allAwards,
+		"scheduled_payments": payments,
+	}, nil
+}
+
+// canSeeBudget reports whether the user may see the confidential budget figures of events:
+// owner estimates, deviations from them and the budget checks on quoted prices
+func (s *ServiceEvent) canSeeBudget(userId string) (bool, error) {
+	permissions, err := s.PermissionRepo.GetUserPermissions(userId)
+	if err != nil {
+		return false, err
+	}
+	for _, p := range permissions {
+		if p.Resource == "event" && p.Action == "manage_budget" {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// lockAwards takes the event row lock for an award change and makes sure neither the event's
+// status nor its active awards changed since they were read for the decision
+func lockAwards(repo interfaceevents.RepoEventInterface, event domainevents.Event, existing []domainevents.EventAward) error {
+	locked, err := repo.LockEventForUpdate(event.Id)
+	if err != nil {
+		return err
+	}
+	current, err := repo.GetAwardsByEventID(event.Id)
+	if err != nil {
+		return err
+	}
+
+	changed := len(current) != len(existing)
+	ids := make(map[string]bool, len(existing))
+	for _, a := range existing {
+		ids[a.Id] = true
+	}
+	for _, a := range current {
+		changed = changed || !ids[a.Id]
+	}
+	if changed || locked.Status == utils.EventCancelled {
+		return errors.New("awards of this event were changed meanwhile, please reload and try again")
+	}
+	return nil
+}
+
+// findRunnerUp picks the best-scored shortlisted submission that holds no award,
+// preferring the lower quoted total on equal scores
+func (s *ServiceEvent) findRunnerUp(event domainevents.Event, revoked domainevents.EventAward, awards []domainevents.EventAward) (*domainevents.EventSubmission, error) {
+	submissions, err := s.EventRepo.GetSubmissionsByEventID(event.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	awarded := map[string]bool{revoked.SubmissionID: true}
+	for _, a := range awards {
+		awarded[a.SubmissionID] = true
+	}
+
+	candidates := make([]domainevents.EventSubmission, 0)
+	for _, sub := range submissions {
+		if !sub.IsShortlisted || sub.Status == utils.SubmissionWithdrawn || awarded[sub.Id] {
+			continue
+		}
+		if sub.Vendor.Status == utils.VendorSuspend {
+			continue
+		}
+		s.revealSubmission(event, &sub)
+		candidates = append(candidates, sub)
+	}
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if (a.Score == nil) != (b.Score == nil) {
+			return a.Score != nil
+		}
+		if a.Score != nil && *a.Score != *b.Score {
+			return *a.Score > *b.Score
+		}
+		if (a.QuotedTotal == nil) != (b.QuotedTotal == nil) {
+			return a.QuotedTotal != nil
+		}
+		if a.QuotedTotal != nil && !a.QuotedTotal.Equal(*b.QuotedTotal) {
+			return a.QuotedTotal.LessThan(*b.QuotedTotal)
+		}
+		return a.CreatedAt.Before(b.CreatedAt)
+	})
+	return &candidates[0], nil
+}
+
+func awardHistory(award domainevents.EventAward, action, description, reason, userId string, at time.Time) domainevents.EventHistory {
+	awardId, submissionId, vendorId := award.Id, award.SubmissionID, award.VendorID
+	return domainevents.EventHistory{
+		Id:           utils.CreateUUID(),
+		EventID:      award.EventID,
+		Action:       action,
+		AwardID:      &awardId,
+		SubmissionID: &submissionId,
+		VendorID:     &vendorId,
+		Description:  description,
+		Reason:       reason,
+		CreatedAt:    at,
+		CreatedBy:    userId,
+	}
+}
+
+func describeAward(prefix string, award domainevents.EventAward) string {
+	description := prefix
+	if award.LotReference != "" {
+		description += fmt.Sprintf(" for lot %q", award.LotReference)
+	}
+	description += fmt.Sprintf(", amount %s", award.AwardedAmount.StringFixed(2))
+	if award.PriceFlag != "" {
+		description += ", price " + strings.ReplaceAll(award.PriceFlag, "_", " ")
+	}
+	return description
+}
+
+func (s *ServiceEvent) GetEventHistory(eventId string) ([]domainevents.EventHistory, error) {
+	if _, err := s.EventRepo.GetEventByID(eventId); err != nil {
+		return nil, err
+	}
+	return s.EventRepo.GetEventHistory(eventId)
+}
+
+func (s *ServiceEvent) notifyAwardRevoked(event domainevents.Event, award domainevents.EventAward) {
+	if s.NotificationSvc == nil {
+		return
+	}
+
+	vendor, err := s.VendorRepo.GetVendorByID(award.VendorID)
+	if err != nil {
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("Failed to load vendor for award revocation notification: %s", err))
+		return
+	}
+	if vendor.UserId == "" {
+		return
+	}
+
+	msg := fmt.Sprintf("Penetapan pemenang vendor Anda pada event \"%s\"", event.Title)
+	if award.LotReference != "" {
+		msg += fmt.Sprintf(" untuk lot \"%s\"", award.LotReference)
+	}
+	_ = s.NotificationSvc.CreateForUser(
+		vendor.UserId,
+		"Penetapan pemenang dibatalkan",
+		fmt.Sprintf("%s dibatalkan. Alasan: %s", msg, award.RevokeReason),
+		utils.NotifEventRevoked,
+		"event",
+		event.Id,
+	)
+}
+
+// notifyEventReopened tells the other participants that the event accepts submissions again
+func (s *ServiceEvent) notifyEventReopened(event domainevents.Event, excludeVendorId string) {
+	if s.NotificationSvc == nil {
+		return
+	}
+
+	submissions, err := s.EventRepo.GetSubmissionsByEventID(event.Id)
+	if err != nil {
+		return
+	}
+	for _, sub := range submissions {
+		if sub.VendorID == excludeVendorId || sub.Status == utils.SubmissionWithdrawn || sub.Vendor.UserId == "" {
+			continue
+		}
+		_ = s.NotificationSvc.CreateForUser(
+			sub.Vendor.UserId,
+			"Event dibuka kembali",
+			fmt.Sprintf("Event \"%s\" dibuka kembali hingga %s.", event.Title, event.EndDate.Format("2006-01-02")),
+			utils.NotifEventReopened,
+			"event",
+			event.Id,
+		)
+	}
+}
+
+// totalAwarded sums the awarded amounts
+func totalAwarded(awards []domainevents.EventAward) decimal.Decimal {
+	total := decimal.Zero
+	for _, award := range awards {
+		total = total.Add(award.AwardedAmount)
+	}
+	return total
+}
+
+func (s *ServiceEvent) GetEventResult(eventId, vendorId string) (map[string]interface{}, error) {
+	event, err := s.EventRepo.GetEventByID(eventId)
+	if err != nil {
+		return nil, err
+	}
+
+	submission, err := s.EventRepo.GetSubmissionByEventAndVendor(eventId, vendorId)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	// Check if vendor has submitted
+	hasSubmitted := submission.Id != "" && submission.Status != utils.SubmissionWithdrawn
+	s.revealSubmission(event, &submission)
+
+	// Event not yet completed
+	if event.Status != utils.EventCompleted {
+		result := map[string]interface{}{
+			"event":         event,
+			"has_submitted": hasSubmitted,
+			"status":        "pending",
+			"message":       "Event is still in progress. Results will be announced after the event is completed.",
+		}
+		return result, nil
+	}
+
+	// Event completed - check if the vendor holds any award
+	awards, err := s.EventRepo.GetAwardsByEventAndVendor(eventId, vendorId)
+	if err != nil {
+		return nil, err
+	}
+
+	if hasSubmitted && len(awards) > 0 {
+		hideAwardPriceReview(awards)
+		result := map[string]interface{}{
+			"event":         event,
+			"is_winner":     true,
+			"status":        "won",
+			"message":       "Congratulations! You have won this pitching event.",
+			"submission":    submission,
+			"awards":        awards,
+			"total_awarded": totalAwarded(awards),
+		}
+		return result, nil
+	}
+
+	// Not winner
+	result := map[string]interface{}{
+		"event":         event,
+		"is_winner":     false,
+		"has_submitted": hasSubmitted,
+		"status":        "not_selected",
+		"message":       "Thank you for your participation. Unfortunately, you were not selected for this event. Keep trying and good luck on the next opportunity!",
+	}
+	return result, nil
+}
+
+func (s *ServiceEvent) GetEventResultForAdmin(eventId, userId string) (map[string]interface{}, error) {
+	event, err := s.EventRepo.GetEventByID(eventId)
+	if err != nil {
+		return nil, err
+	}
+
+	budget, err := s.canSeeBudget(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	submissions, err := s.EventRepo.GetSubmissionsByEventID(eventId)
+	if err != nil {
+		return nil, err
+	}
+	for i := range submissions {
+		s.presentSubmission(event, &submissions[i], false, budget)
+		if budget {
+			submissions[i].PriceFlag = priceFlag(event, submissions[i].QuotedTotal)
+		}
+	}
+
+	awards, err := s.EventRepo.GetAwardsByEventID(eventId)
+	if err != nil {
+		return nil, err
+	}
+	if !budget {
+		hideAwardPriceReview(awards)
+	}
+
+	winners := make([]domainevents.EventSubmission, 0)
+	for _, sub := range submissions {
+		if sub.IsWinner {
+			winners = append(winners, sub)
+		}
+	}
+	var winnerSubmission *domainevents.EventSubmission
+	if len(winners) > 0 {
+		winnerSubmission = &winners[0]
+	}
+
+	result := map[string]interface{}{
+		"event":         event,
+		"submissions":   submissions,
+		"winner":        winnerSubmission,
+		"winners":       winners,
+		"awards":        awards,
+		"total_awarded": totalAwarded(awards),
+	}
+
+	return result, nil
+}
+
+func (s *ServiceEvent) UploadEventFile(ctx context.Context, eventId string, userId string, fileHeader *multipart.FileHeader, req dto.UploadEventFileRequest) (domainevents.EventFile, error) {
+	// Verify event exists
+	_, err := s.EventRepo.GetEventByID(eventId)
+	if err != nil {
+		return domainevents.EventFile{}, errors.New("event not found")
+	}
+
+	// Validate file size
+	maxPhotoSize := utils.GetEnv("MAX_PHOTO_SIZE_EVENT", 5).(int)
+	if err := utils.ValidateFileSize(fileHeader, maxPhotoSize); err != nil {
+		return domainevents.EventFile{}, err
+	}
+
+	// Upload to storage provider (MinIO or R2)
+	fileUrl, err := s.uploadEventFile(ctx, fileHeader)
+	if err != nil {
+		return domainevents.EventFile{}, err
+	}
+
+	now := time.Now()
+	eventFile := domainevents.EventFile{
+		ID:        utils.CreateUUID(),
+		EventId:   eventId,
+		FileType:  req.FileType,
+		FileUrl:   fileUrl,
+		Caption:   req.Caption,
+		CreatedAt: now,
+		CreatedBy: userId,
+	}
+
+	if err := s.EventRepo.CreateEventFile(eventFile); err != nil {
+		// Cleanup uploaded file if database save fails
+		_ = s.StorageProvider.DeleteFile(ctx, fileUrl)
+		return domainevents.EventFile{}, err
+	}
+
+	return eventFile, nil
+}
+
+func (s *ServiceEvent) DeleteEventFile(ctx context.Context, fileId string) error {
+	// Get file record to get the URL for storage deletion
+	eventFile, err := s.EventRepo.GetEventFileByID(fileId)
+	if err != nil {
+		return err
+	}
+
+	// Delete from database first
+	if err = s.EventRepo.DeleteEventFile(fileId); err == nil {
+		// Delete from storage if database deletion succeeds
+		_ = s.StorageProvider.DeleteFile(ctx, eventFile.FileUrl)
+	}
+
+	return err
+}
+
+func (s *ServiceEvent) DeleteSubmissionFile(ctx context.Context, fileId string) error {
+	// Get file record to get the URL for storage deletion
+	submissionFile, err := s.EventRepo.GetSubmissionFileByID(fileId)
+	if err != nil {
+		return err
+	}
+
+	// Delete from database first
+	if err = s.EventRepo.DeleteSubmissionFile(fileId); err == nil {
+		// Delete from storage if database deletion succeeds
+		_ = s.StorageProvider.DeleteFile(ctx, submissionFile.FileUrl)
+	}
+
+	return err
+}
+
+func (s *ServiceEvent) InviteVendors(eventId, userId string, req dto.InviteVendorsRequest) ([]domainevents.EventInvitation, error) {
+	event, err := s.EventRepo.GetEventByID(eventId)
+	if err != nil {
+		return nil, err
+	}
+
+	if event.Status == utils.EventCancelled {
+		return nil, errEventCancelled
+	}
+	if event.ParticipationMode != utils.EventModeInvited {
+		return nil, errors.New("vendors can only be invited to events with invited participation mode")
+	}
+
+	invitations, err := s.buildInvitations(eventId, userId, req.VendorIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.EventRepo.CreateEventInvitations(invitations); err != nil {
+		return nil, err
+	}
+
+	// Vendors invited to an already open event are notified right away
+	if event.Status == utils.EventOpen {
+		vendorIds := make([]string, 0, len(invitations))
+		for _, inv := range invitations {
+			vendorIds = append(vendorIds, inv.VendorID)
+		}
+		s.notifyInvitedVendors(event, vendorIds)
+	}
+
+	return s.EventRepo.GetEventInvitations(eventId)
+}
+
+func (s *ServiceEvent) GetEventInvitations(eventId string) (map[string]interface{}, error) {
+	event, err := s.EventRepo.GetEventByID(eventId)
+	if err != nil {
+		return nil, err
+	}
+
+	invitations, err := s.EventRepo.GetEventInvitations(eventId)
+	if err != nil {
+		return nil, err
+	}
+
+	eligibleVendors, err := s.EventRepo.GetEligibleVendors(eventId)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"participation_mode":  event.ParticipationMode,
+		"eligibility_enabled": event.EligibilityEnabled,
+		"invitations":         invitations,
+		"eligible_vendors":    eligibleVendors,
+	}, nil
+}
+
+func (s *ServiceEvent) RemoveInvitation(eventId, vendorId string) error {
+	if _, err := s.EventRepo.GetEventByID(eventId); err != nil {
+		return err
+	}
+
+	return s.EventRepo.DeleteEventInvitation(eventId, vendorId)
+}
+
+var _ interfaceevents.ServiceEventInterface = (*ServiceEvent)(nil)
+
+var (
+	errSealedBidsUnavailable = errors.New("sealed bids are not available, BID_ENCRYPTION_KEY is not configured")
+	errBidsSealed            = errors.New("access denied: bids are sealed until the submission deadline or an approved bid opening")
+	errNotAuction            = errors.New("event is not a reverse auction")
+	errEventCancelled        = errors.New("event is cancelled")
+)
+
+// bidsRevealed reports whether the content of the event's submissions may be shown to the organiser
+func bidsRevealed(event domainevents.Event) bool {
+	if !event.SealedBids || event.BidsOpenedAt != nil {
+		return true
+	}
+	return event.EndDate != nil && !time.Now().Before(*event.EndDate)
+}
+
+// sealText encrypts a submission field when the event uses sealed bids
+func (s *ServiceEvent) sealText(event domainevents.Event, value string) (string, error) {
+	if !event.SealedBids {
+		return value, nil
+	}
+	if s.BidCipher == nil {
+		return "", errSealedBidsUnavailable
+	}
+	return s.BidCipher.EncryptString(event.BidKey, value)
+}
+
+func (s *ServiceEvent) openText(event domainevents.Event, value string) (string, error) {
+	if !security.IsSealedString(value) {
+		return value, nil
+	}
+	if s.BidCipher == nil {
+		return "", errSealedBidsUnavailable
+	}
+	return s.BidCipher.DecryptString(event.BidKey, value)
+}
+
+// revealSubmission decrypts the submission in place. Content that cannot be decrypted is hidden.
+func (s *ServiceEvent) revealSubmission(event domainevents.Event, sub *domainevents.EventSubmission) {
+	proposalDetails, err := s.openText(event, sub.ProposalDetails)
+	if err == nil {
+		var additionalMaterials string
+		if additionalMaterials, err = s.openText(event, sub.AdditionalMaterials); err == nil {
+			if err = s.revealPrices(event, sub); err == nil {
+				sub.ProposalDetails = proposalDetails
+				sub.AdditionalMaterials = additionalMaterials
+				return
+			}
+		}
+	}
+
+	logger.WriteLog(logger.LogLevelError, fmt.Sprintf("Failed to decrypt submission %s: %s", sub.Id, err))
+	sealSubmission(sub)
+}
+
+// sealSubmission strips everything but the metadata from the submission
+func sealSubmission(sub *domainevents.EventSubmission) {
+	sub.ProposalDetails = ""
+	sub.AdditionalMaterials = ""
+	sub.File = nil
+	sub.Prices = nil
+	sub.QuotedTotal = nil
+	sub.Sealed = true
+}
+
+// presentSubmission prepares a submission for a reader. The owning vendor always sees their
+// own content; everyone else sees only metadata until the bids are revealed. The owner estimates
+// of the priced lines are left out unless budget says the reader may see them.
+func (s *ServiceEvent) presentSubmission(event domainevents.Event, sub *domainevents.EventSubmission, owner, budget bool) {
+	if !owner && !bidsRevealed(event) {
+		sealSubmission(sub)
+		return
+	}
+	s.revealSubmission(event, sub)
+	if owner || !budget {
+		hideOwnerEstimates(sub.Prices)
+	}
+}
+
+// presentSubmissions is presentSubmission for lists where each submission carries its event
+func (s *ServiceEvent) presentSubmissions(subs []domainevents.EventSubmission, owner, budget bool) {
+	for i := range subs {
+		s.presentSubmission(subs[i].Event, &subs[i], owner, budget)
+	}
+}
+
+func (s *ServiceEvent) presentRevisions(event domainevents.Event, revisions []domainevents.EventSubmissionRevision, owner bool) {
+	revealed := owner || bidsRevealed(event)
+	for i := range revisions {
+		rev := &revisions[i]
+		if revealed {
+			proposalDetails, err1 := s.openText(event, rev.ProposalDetails)
+			additionalMaterials, err2 := s.openText(event, rev.AdditionalMaterials)
+			err3 := s.revealRevisionPrices(event, rev.Prices)
+			if err1 == nil && err2 == nil && err3 == nil {
+				rev.ProposalDetails = proposalDetails
+				rev.AdditionalMaterials = additionalMaterials
+				continue
+			}
+		}
+		rev.ProposalDetails = ""
+		rev.AdditionalMaterials = ""
+		rev.File = nil
+		rev.Prices = nil
+	}
+}
+
+// OpenBids records the user's approval to open the sealed bids before the deadline.
+// The bids open once the number of distinct approvers reaches the event's quorum.
+func (s *ServiceEvent) OpenBids(eventId, userId string, req dto.OpenBidsRequest) (map[string]interface{}, error) {
+	event, err := s.EventRepo.GetEventByID(eventId)
+	if err != nil {
+		return nil, err
+	}
+
+	if !event.SealedBids {
+		return nil, errors.New("event does not use sealed bids")
+	}
+	if bidsRevealed(event) {
+		return nil, errors.New("bids are already open")
+	}
+
+	now := time.Now()
+	opening := domainevents.EventBidOpening{
+		Id:        utils.CreateUUID(),
+		EventID:   eventId,
+		UserID:    userId,
+		Note:      req.Note,
+		CreatedAt: now,
+	}
+
+	// The event row lock serializes approvals, so two approvers cannot both miss the quorum
+	var openings []domainevents.EventBidOpening
+	quorumReached := false
+	err = s.UoW.Do(func(repos interfaceuow.Repositories) error {
+		locked, err := repos.Event.LockEventForUpdate(eventId)
+		if err != nil {
+			return err
+		}
+		if bidsRevealed(locked) {
+			return errors.New("bids are already open")
+		}
+
+		if openings, err = repos.Event.GetBidOpenings(eventId); err != nil {
+			return err
+		}
+		for _, o := range openings {
+			if o.UserID == userId {
+				return errors.New("you have already approved opening the bids for this event")
+			}
+		}
+
+		if err := repos.Event.CreateBidOpening(opening); err != nil {
+			return err
+		}
+		openings = append(openings, opening)
+
+		event = locked
+		quorumReached = len(openings) >= event.BidOpeningQuorum
+		if !quorumReached {
+			return nil
+		}
+		event.BidsOpenedAt = &now
+		event.UpdatedAt = now
+		event.UpdatedBy = userId
+		return repos.Event.UpdateEvent(event)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.WriteLog(logger.LogLevelInfo, fmt.Sprintf("Bid opening for event %s approved by user %s (%d/%d)", eventId, userId, len(openings), event.BidOpeningQuorum))
+	if quorumReached {
+		logger.WriteLog(logger.LogLevelInfo, fmt.Sprintf("Sealed bids for event %s opened", eventId))
+	}
+
+	return bidOpeningStatus(event, openings), nil
+}
+
+func (s *ServiceEvent) GetBidOpeningStatus(eventId string) (map[string]interface{}, error) {
+	event, err := s.EventRepo.GetEventByID(eventId)
+	if err != nil {
+		return nil, err
+	}
+
+	openings, err := s.EventRepo.GetBidOpenings(eventId)
+	if err != nil {
+		return nil, err
+	}
+
+	return bidOpeningStatus(event, openings), nil
+}
+
+func bidOpeningStatus(event domainevents.Event, openings []domainevents.EventBidOpening) map[string]interface{} {
+	return map[string]interface{}{
+		"sealed_bids":        event.SealedBids,
+		"revealed":           bidsRevealed(event),
+		"bids_opened_at":     event.BidsOpenedAt,
+		"end_date":           event.EndDate,
+		"bid_opening_quorum": event.BidOpeningQuorum,
+		"approvals":          openings,
+	}
+}
+
+// DownloadSubmissionFile returns the file content, decrypted when it was sealed.
+// A non-empty vendorId restricts the download to that vendor's own submission.
+func (s *ServiceEvent) DownloadSubmissionFile(ctx context.Context, fileId, vendorId string) ([]byte, domainevents.EventSubmissionFile, error) {
+	submissionFile, err := s.EventRepo.GetSubmissionFileByID(fileId)
+	if err != nil {
+		return nil, domainevents.EventSubmissionFile{}, err
+	}
+
+	submission, err := s.EventRepo.GetSubmissionByID(submissionFile.EventSubmissionId)
+	if err != nil {
+		return nil, domainevents.EventSubmissionFile{}, err
+	}
+
+	if vendorId != "" && submission.VendorID != vendorId {
+		return nil, domainevents.EventSubmissionFile{}, gorm.ErrRecordNotFound
+	}
+
+	event, err := s.EventRepo.GetEventByID(submission.EventID)
+	if err != nil {
+		return nil, domainevents.EventSubmissionFile{}, err
+	}
+
+	if vendorId == "" && !bidsRevealed(event) {
+		return nil, domainevents.EventSubmissionFile{}, errBidsSealed
+	}
+
+	reader, err := s.StorageProvider.DownloadFileByURL(ctx, submissionFile.FileUrl)
+	if err != nil {
+		return nil, domainevents.EventSubmissionFile{}, err
+	}
+	defer reader.Close()
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, domainevents.EventSubmissionFile{}, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	if submissionFile.IsEncrypted {
+		if s.BidCipher == nil {
+			return nil, domainevents.EventSubmissionFile{}, errSealedBidsUnavailable
+		}
+		if data, err = s.BidCipher.Decrypt(event.BidKey, data); err != nil {
+			return nil, domainevents.EventSubmissionFile{}, err
+		}
+	}
+
+	return data, submissionFile, nil
+}
+
+// SetBoqItems replaces the event's bill of quantities. Lines are numbered in the order sent.
+// The bill is frozen once a vendor has submitted a quotation against it. Owner estimates are
+// confidential and need event:manage_budget.
+func (s *ServiceEvent) SetBoqItems(eventId, userId string, req dto.SetBoqItemsRequest) ([]domainevents.EventBoqItem, error) {
+	event, err := s.EventRepo.GetEventByID(eventId)
+	if err != nil {
+		return nil, err
+	}
+
+	if event.Status != utils.EventDraft && event.Status != utils.EventOpen {
+		return nil, errors.New("bill of quantities can only be changed while the event is draft or open")
+	}
+
+	count, err := s.EventRepo.CountActiveSubmissionsByEventID(eventId)
+	if err != nil {
+		return nil, err
+	}
+	if count > 0 {
+		return nil, errors.New("bill of quantities cannot be changed after vendors have submitted")
+	}
+
+	budget, err := s.canSeeBudget(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	items := make([]domainevents.EventBoqItem, 0, len(req.Items))
+	for i, r := range req.Items {
+		item := domainevents.EventBoqItem{
+			Id:          utils.CreateUUID(),
+			EventID:     eventId,
+			ItemNo:      i + 1,
+			Description: strings.TrimSpace(r.Description),
+			Unit:        strings.TrimSpace(r.Unit),
+			Quantity:    decimal.NewFromFloat(r.Quantity).Round(3),
+			CreatedAt:   now,
+			CreatedBy:   userId,
+			UpdatedAt:   now,
+			UpdatedBy:   userId,
+		}
+		if r.OwnerEstimate != nil {
+			if !budget {
+				return nil, errors.New("access denied: owner estimates can only be set with the event:manage_budget permission")
+			}
+			estimate := decimal.NewFromFloat(*r.OwnerEstimate).Round(2)
+			item.OwnerEstimate = &estimate
+		}
+		if item.Quantity.IsZero() {
+			return nil, fmt.Errorf("invalid quantity on line %d: must be at least 0.001", item.ItemNo)
+		}
+		items = append(items, item)
+	}
+
+	if err := s.EventRepo.ReplaceBoqItems(eventId, userId, items); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// GetBoqItems returns the event's bill of quantities. A non-empty vendorId returns the vendor's
+// view, for which the event must be visible to the vendor. The owner estimates are left out
+// unless the user may see the event's budget.
+func (s *ServiceEvent) GetBoqItems(eventId, userId, vendorId string) ([]domainevents.EventBoqItem, error) {
+	event, err := s.EventRepo.GetEventByID(eventId)
+	if err != nil {
+		return nil, err
+	}
+
+	if vendorId != "" {
+		if event.Status == utils.EventDraft {
+			return nil, gorm.ErrRecordNotFound
+		}
+		allowed, err := s.CanVendorAccessEvent(event, vendorId)
+		if err != nil {
+			return nil, err
+		}
+		if !allowed {
+			return nil, gorm.ErrRecordNotFound
+		}
+	}
+
+	items, err := s.EventRepo.GetBoqItems(eventId)
+	if err != nil {
+		return nil, err
+	}
+
+	budget := false
+	if vendorId == "" {
+		if budget, err = s.canSeeBudget(userId); err != nil {
+			return nil, err
+		}
+	}
+	if !budget {
+		for i := range items {
+			items[i].OwnerEstimate = nil
+		}
+	}
+
+	return items, nil
+}
+
+// buildSubmissionPrices checks a quotation against the event's bill of quantities: every line
+// must be priced exactly once. Prices are sealed when the event uses sealed bids.
+func (s *ServiceEvent) buildSubmissionPrices(event domainevents.Event, userId string, reqs []dto.SubmissionPriceRequest) ([]domainevents.EventSubmissionPrice, error) {
+	items, err := s.EventRepo.GetBoqItems(event.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(items) == 0 {
+		if len(reqs) > 0 {
+			return nil, errors.New("invalid prices: event has no bill of quantities")
+		}
+		return nil, nil
+	}
+
+	lines := make(map[string]domainevents.EventBoqItem, len(items))
+	for _, item := range items {
+		lines[item.Id] = item
+	}
+
+	now := time.Now()
+	prices := make([]domainevents.EventSubmissionPrice, 0, len(reqs))
+	priced := make(map[string]struct{}, len(reqs))
+	for _, r := range reqs {
+		item, ok := lines[r.BoqItemId]
+		if !ok {
+			return nil, fmt.Errorf("invalid boq_item_id: %s is not on this event's bill of quantities", r.BoqItemId)
+		}
+		if _, ok := priced[r.BoqItemId]; ok {
+			return nil, fmt.Errorf("invalid prices: line %d is priced more than once", item.ItemNo)
+		}
+		priced[r.BoqItemId] = struct{}{}
+
+		unitPrice := decimal.NewFromFloat(r.UnitPrice).Round(2)
+		price := domainevents.EventSubmissionPrice{
+			Id:        utils.CreateUUID(),
+			BoqItemId: r.BoqItemId,
+			CreatedAt: now,
+			CreatedBy: userId,
+			UpdatedAt: now,
+			UpdatedBy: userId,
+		}
+		if event.SealedBids {
+			if price.UnitPriceSealed, err = s.sealText(event, unitPrice.StringFixed(2)); err != nil {
+				return nil, err
+			}
+		} else {
+			price.UnitPrice = &unitPrice
+		}
+		prices = append(prices, price)
+	}
+
+	if len(priced) != len(items) {
+		return nil, fmt.Errorf("invalid prices: all %d bill of quantities lines must be priced", len(items))
+	}
+
+	return prices, nil
+}
+
+// loadVendorQuotation attaches the stored prices, with their lines, to the submission as the
+// vendor may see them
+func (s *ServiceEvent) loadVendorQuotation(sub *domainevents.EventSubmission) error {
+	prices, err := s.EventRepo.GetSubmissionPrices(sub.Id)
+	if err != nil {
+		return err
+	}
+
+	items, err := s.EventRepo.GetBoqItems(sub.EventID)
+	if err != nil {
+		return err
+	}
+
+	lines := make(map[string]*domainevents.EventBoqItem, len(items))
+	for i := range items {
+		lines[items[i].Id] = &items[i]
+	}
+	for i := range prices {
+		prices[i].BoqItem = lines[prices[i].BoqItemId]
+	}
+
+	hideOwnerEstimates(prices)
+	sub.Prices = prices
+	return nil
+}
+
+// GetEventBudget returns the confidential owner estimate and budget ceiling of an event
+func (s *ServiceEvent) GetEventBudget(eventId string) (domainevents.EventBudget, error) {
+	event, err := s.EventRepo.GetEventByID(eventId)
+	if err != nil {
+		return domainevents.EventBudget{}, err
+	}
+	return eventBudget(event), nil
+}
+
+// SetEventBudget updates the owner estimate, the budget ceiling and the abnormally low threshold
+// of an event; fields that are not sent keep their value
+func (s *ServiceEvent) SetEventBudget(eventId, userId string, req dto.SetEventBudgetRequest) (domainevents.EventBudget, error) {
+	event, err := s.EventRepo.GetEventByID(eventId)
+	if err != nil {
+		return domainevents.EventBudget{}, err
+	}
+	if event.Status == utils.EventCancelled {
+		return domainevents.EventBudget{}, errEventCancelled
+	}
+
+	if req.OwnerEstimate != nil {
+		ownerEstimate := decimal.NewFromFloat(*req.OwnerEstimate).Round(2)
+		event.OwnerEstimate = &ownerEstimate
+	}
+	if req.BudgetCeiling != nil {
+		budgetCeiling := decimal.NewFromFloat(*req.BudgetCeiling).Round(2)
+		event.BudgetCeiling = &budgetCeiling
+	}
+	if req.AbnormallyLowPercent != nil {
+		percent := decimal.NewFromFloat(*req.AbnormallyLowPercent).Round(2)
+		event.AbnormallyLowPercent = &percent
+	}
+
+	budget := eventBudget(event)
+	if budget.BudgetCeiling != nil && budget.AbnormallyLowThreshold != nil && budget.BudgetCeiling.LessThan(*budget.AbnormallyLowThreshold) {
+		return domainevents.EventBudget{}, errors.New("budget_ceiling cannot be below the abnormally low threshold")
+	}
+
+	event.UpdatedAt = time.Now()
+	event.UpdatedBy = userId
+	if err := s.EventRepo.UpdateEvent(event); err != nil {
+		return domainevents.EventBudget{}, err
+	}
+
+	return budget, nil
+}
+
+func eventBudget(event domainevents.Event) domainevents.EventBudget {
+	budget := domainevents.EventBudget{
+		EventID:              event.Id,
+		OwnerEstimate:        event.OwnerEstimate,
+		BudgetCeiling:        event.BudgetCeiling,
+		AbnormallyLowPercent: decimal.NewFromInt(int64(utils.AbnormallyLowPercent)),
+	}
+	if event.AbnormallyLowPercent != nil {
+		budget.AbnormallyLowPercent = *event.AbnormallyLowPercent
+	}
+	if event.OwnerEstimate != nil {
+		threshold := event.OwnerEstimate.Mul(budget.AbnormallyLowPercent).Div(decimal.NewFromInt(100)).Round(2)
+		budget.AbnormallyLowThreshold = &threshold
+	}
+	return budget
+}
+
+// priceFlag checks a quoted price against the budget ceiling and the abnormally low threshold
+func priceFlag(event domainevents.Event, quoted *decimal.Decimal) string {
+	if quoted == nil {
+		return ""
+	}
+	budget := eventBudget(event)
+	if budget.BudgetCeiling != nil && quoted.GreaterThan(*budget.BudgetCeiling) {
+		return utils.PriceAboveCeiling
+	}
+	if budget.AbnormallyLowThreshold != nil && quoted.LessThan(*budget.AbnormallyLowThreshold) {
+		return utils.PriceAbnormalLow
+	}
+	return ""
+}
+
+// hideAwardPriceReview strips the internal price review from awards shown to vendors
+func hideAwardPriceReview(awards []domainevents.EventAward) {
+	for i := range awards {
+		awards[i].PriceFlag = ""
+		awards[i].PriceJustification = ""
+	}
+}
+
+func hideOwnerEstimates(prices []domainevents.EventSubmissionPrice) {
+	for i := range prices {
+		if prices[i].BoqItem != nil {
+			prices[i].BoqItem.OwnerEstimate = nil
+		}
+	}
+}
+
+func (s *ServiceEvent) openUnitPrice(event domainevents.Event, value string) (*decimal.Decimal, error) {
+	plain, err := s.openText(event, value)
+	if err != nil {
+		return nil, err
+	}
+
+	price, err := decimal.NewFromString(plain)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sealed unit price: %w", err)
+	}
+	return &price, nil
+}
+
+// revealPrices decrypts sealed unit prices and computes the line totals and the quoted total.
+// Lines that were removed from the bill of quantities carry no total.
+func (s *ServiceEvent) revealPrices(event domainevents.Event, sub *domainevents.EventSubmission) error {
+	var total decimal.Decimal
+	hasTotal := false
+	for i := range sub.Prices {
+		p := &sub.Prices[i]
+		if p.UnitPriceSealed != "" {
+			price, err := s.openUnitPrice(event, p.UnitPriceSealed)
+			if err != nil {
+				return err
+			}
+			p.UnitPrice = price
+		}
+		if p.UnitPrice == nil || p.BoqItem == nil {
+			continue
+		}
+
+		lineTotal := p.UnitPrice.Mul(p.BoqItem.Quantity).Round(2)
+		p.LineTotal = &lineTotal
+		total = total.Add(lineTotal)
+		hasTotal = true
+	}
+
+	if hasTotal {
+		sub.QuotedTotal = &total
+	}
+	return nil
+}
+
+func (s *ServiceEvent) revealRevisionPrices(event domainevents.Event, prices []domainevents.EventSubmissionRevisionPrice) error {
+	for i := range prices {
+		if prices[i].UnitPriceSealed == "" {
+			continue
+		}
+		price, err := s.openUnitPrice(event, prices[i].UnitPriceSealed)
+		if err != nil {
+			return err
+		}
+		prices[i].UnitPrice = price
+	}
+	return nil
+}
+
+// deviationPercent is how far value lies above (positive) or below (negative) the estimate
+func deviationPercent(value, estimate *decimal.Decimal) *decimal.Decimal {
+	if value == nil || estimate == nil || estimate.IsZero() {
+		return nil
+	}
+
+	deviation := value.Sub(*estimate).Div(*estimate).Mul(decimal.NewFromInt(100)).Round(2)
+	return &deviation
+}
+
+// GetQuotationComparison lines up the unit prices of every active submission per bill of
+// quantities line, flagging the lowest price and the deviation from the owner estimate.
+// Vendors are ordered by quoted total, lowest first.
+func (s *ServiceEvent) GetQuotationComparison(eventId, userId string) (domainevents.QuotationComparison, error) {
+	event, err := s.EventRepo.GetEventByID(eventId)
+	if err != nil {
+		return domainevents.QuotationComparison{}, err
+	}
+
+	if !bidsRevealed(event) {
+		return domainevents.QuotationComparison{}, errBidsSealed
+	}
+
+	items, err := s.EventRepo.GetBoqItems(eventId)
+	if err != nil {
+		return domainevents.QuotationComparison{}, err
+	}
+	if len(items) == 0 {
+		return domainevents.QuotationComparison{}, errors.New("bill of quantities not found for this event")
+	}
+
+	submissions, err := s.EventRepo.GetSubmissionsByEventID(eventId)
+	if err != nil {
+		return domainevents.QuotationComparison{}, err
+	}
+
+	active := make([]domainevents.EventSubmission, 0, len(submissions))
+	for _, sub := range submissions {
+		if sub.Status == utils.SubmissionWithdrawn {
+			continue
+		}
+		s.revealSubmission(event, &sub)
+		if sub.Sealed {
+			continue
+		}
+		active = append(active, sub)
+	}
+
+	sort.SliceStable(active, func(i, j int) bool {
+		a, b := active[i].QuotedTotal, active[j].QuotedTotal
+		if a == nil || b == nil {
+			return a != nil
+		}
+		return a.LessThan(*b)
+	})
+
+	comparison := domainevents.QuotationComparison{
+		Event:   event,
+		Vendors: make([]domainevents.QuotationVendor, 0, len(active)),
+		Lines:   make([]domainevents.QuotationLine, 0, len(items)),
+	}
+
+	quotes := make([]map[string]domainevents.EventSubmissionPrice, len(active))
+	for i, sub := range active {
+		quotes[i] = make(map[string]domainevents.EventSubmissionPrice, len(sub.Prices))
+		for _, p := range sub.Prices {
+			quotes[i][p.BoqItemId] = p
+		}
+	}
+
+	estimateTotal := decimal.Zero
+	fullEstimate := true
+	for _, item := range items {
+		line := domainevents.QuotationLine{
+			Item:   item,
+			Quotes: make([]domainevents.QuotationQuote, len(active)),
+		}
+		if item.OwnerEstimate != nil {
+			total := item.OwnerEstimate.Mul(item.Quantity).Round(2)
+			line.EstimateTotal = &total
+			estimateTotal = estimateTotal.Add(total)
+		} else {
+			fullEstimate = false
+		}
+
+		var lowest *decimal.Decimal
+		for i, sub := range active {
+			quote := domainevents.QuotationQuote{SubmissionId: sub.Id}
+			if p, ok := quotes[i][item.Id]; ok && p.UnitPrice != nil {
+				quote.UnitPrice = p.UnitPrice
+				quote.LineTotal = p.LineTotal
+				quote.DeviationPercent = deviationPercent(p.UnitPrice, item.OwnerEstimate)
+				if lowest == nil || p.UnitPrice.LessThan(*lowest) {
+					lowest = p.UnitPrice
+				}
+			}
+			line.Quotes[i] = quote
+		}
+		for i := range line.Quotes {
+			if lowest != nil && line.Quotes[i].UnitPrice != nil && line.Quotes[i].UnitPrice.Equal(*lowest) {
+				line.Quotes[i].IsLowest = true
+			}
+		}
+
+		comparison.Lines = append(comparison.Lines, line)
+	}
+
+	if fullEstimate {
+		comparison.EstimateTotal = &estimateTotal
+	}
+
+	for _, sub := range active {
+		vendorName := sub.Vendor.VendorCode
+		if sub.Vendor.Profile != nil && sub.Vendor.Profile.VendorName != "" {
+			vendorName = sub.Vendor.Profile.VendorName
+		}
+		if vendorName == "" {
+			vendorName = sub.VendorID
+		}
+
+		comparison.Vendors = append(comparison.Vendors, domainevents.QuotationVendor{
+			SubmissionId:     sub.Id,
+			VendorId:         sub.VendorID,
+			VendorName:       vendorName,
+			QuotedTotal:      sub.QuotedTotal,
+			DeviationPercent: deviationPercent(sub.QuotedTotal, comparison.EstimateTotal),
+			PriceFlag:        priceFlag(event, sub.QuotedTotal),
+		})
+	}
+	// Vendors are sorted by total, so every vendor sharing the first total is lowest
+	for i := range comparison.Vendors {
+		first := comparison.Vendors[0].QuotedTotal
+		if first != nil && comparison.Vendors[i].QuotedTotal != nil && comparison.Vendors[i].QuotedTotal.Equal(*first) {
+			comparison.Vendors[i].IsLowest = true
+		}
+	}
+
+	budget, err := s.canSeeBudget(userId)
+	if err != nil {
+		return domainevents.QuotationComparison{}, err
+	}
+	if !budget {
+		hideComparisonBudget(&comparison)
+	}
+	return comparison, nil
+}
+
+// hideComparisonBudget strips the owner estimates and the budget checks from a comparison
+// for users without access to the event budget
+func hideComparisonBudget(comparison *domainevents.QuotationComparison) {
+	comparison.EstimateTotal = nil
+	for i := range comparison.Lines {
+		line := &comparison.Lines[i]
+		line.Item.OwnerEstimate = nil
+		line.EstimateTotal = nil
+		for j := range line.Quotes {
+			line.Quotes[j].DeviationPercent = nil
+		}
+	}
+	for i := range comparison.Vendors {
+		comparison.Vendors[i].DeviationPercent = nil
+		comparison.Vendors[i].PriceFlag = ""
+	}
+}
+
+func (s *ServiceEvent) GenerateQuotationComparisonXLSX(eventId, userId string) ([]byte, string, error) {
+	comparison, err := s.GetQuotationComparison(eventId, userId)
+	if err != nil {
+		return nil, "", err
+	}
+
+	file := excelize.NewFile()
+	defer file.Close()
+
+	const sheetName = "Price Comparison"
+	file.SetSheetName(file.GetSheetName(0), sheetName)
+
+	amountFormat := "#,##0.00"
+	headerStyle, err := file.NewStyle(&excelize.Style{
+		Font:      &excelize.Font{Bold: true},
+		Fill:      excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"D9D9D9"}},
+		Alignment: &excelize.Alignment{WrapText: true, Vertical: "center"},
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	amountStyle, err := file.NewStyle(&excelize.Style{CustomNumFmt: &amountFormat})
+	if err != nil {
+		return nil, "", err
+	}
+	lowestStyle, err := file.NewStyle(&excelize.Style{
+		CustomNumFmt: &amountFormat,
+		Font:         &excelize.Font{Bold: true, Color: "006100"},
+		Fill:         excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"C6EFCE"}},
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	aboveStyle, err := file.NewStyle(&excelize.Style{
+		CustomNumFmt: &amountFormat,
+		Font:         &excelize.Font{Color: "9C0006"},
+		Fill:         excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"FFC7CE"}},
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	belowStyle, err := file.NewStyle(&excelize.Style{
+		CustomNumFmt: &amountFormat,
+		Font:         &excelize.Font{Color: "006100"},
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	cell := func(col, row int) string {
+		name, _ := excelize.CoordinatesToCellName(col, row)
+		return name
+	}
+	setAmount := func(col, row int, value *decimal.Decimal, style int) {
+		if value == nil {
+			return
+		}
+		file.SetCellValue(sheetName, cell(col, row), value.InexactFloat64())
+		file.SetCellStyle(sheetName, cell(col, row), cell(col, row), style)
+	}
+	deviationStyle := func(deviation *decimal.Decimal) int {
+		switch {
+		case deviation == nil || deviation.IsZero():
+			return amountStyle
+		case deviation.IsPositive():
+			return aboveStyle
+		default:
+			return belowStyle
+		}
+	}
+
+	file.SetCellValue(sheetName, "A1", fmt.Sprintf("Price Comparison - %s", comparison.Event.Title))
+	file.SetCellValue(sheetName, "A2", fmt.Sprintf("Generated at %s", time.Now().Format("2006-01-02 15:04:05")))
+
+	// Fixed columns, then unit price, line total and deviation for each vendor
+	const headerRow = 4
+	const vendorCol = 7
+	headers := []string{"No", "Description", "Unit", "Quantity", "Owner Estimate", "Estimate Total"}
+	for i, h := range headers {
+		file.SetCellValue(sheetName, cell(i+1, headerRow), h)
+	}
+	for i, v := range comparison.Vendors {
+		col := vendorCol + i*3
+		file.SetCellValue(sheetName, cell(col, headerRow), fmt.Sprintf("%s\nUnit Price", v.VendorName))
+		file.SetCellValue(sheetName, cell(col+1, headerRow), fmt.Sprintf("%s\nTotal", v.VendorName))
+		file.SetCellValue(sheetName, cell(col+2, headerRow), fmt.Sprintf("%s\nDeviation %%", v.VendorName))
+	}
+	lastCol := vendorCol + len(comparison.Vendors)*3 - 1
+	if lastCol < len(headers) {
+		lastCol = len(headers)
+	}
+	file.SetCellStyle(sheetName, cell(1, headerRow), cell(lastCol, headerRow), headerStyle)
+
+	row := headerRow + 1
+	for _, line := range comparison.Lines {
+		file.SetCellValue(sheetName, cell(1, row), line.Item.ItemNo)
+		file.SetCellValue(sheetName, cell(2, row), line.Item.Description)
+		file.SetCellValue(sheetName, cell(3, row), line.Item.Unit)
+		file.SetCellValue(sheetName, cell(4, row), line.Item.Quantity.InexactFloat64())
+		setAmount(5, row, line.Item.OwnerEstimate, amountStyle)
+		setAmount(6, row, line.EstimateTotal, amountStyle)
+
+		for i, quote := range line.Quotes {
+			col := vendorCol + i*3
+			priceStyle := amountStyle
+			if quote.IsLowest {
+				priceStyle = lowestStyle
+			}
+			setAmount(col, row, quote.UnitPrice, priceStyle)
+			setAmount(col+1, row, quote.LineTotal, amountStyle)
+			setAmount(col+2, row, quote.DeviationPercent, deviationStyle(quote.DeviationPercent))
+		}
+		row++
+	}
+
+	file.SetCellValue(sheetName, cell(2, row), "Total")
+	setAmount(6, row, comparison.EstimateTotal, amountStyle)
+	for i, v := range comparison.Vendors {
+		col := vendorCol + i*3
+		totalStyle := amountStyle
+		if v.IsLowest {
+			totalStyle = lowestStyle
+		}
+		setAmount(col+1, row, v.QuotedTotal, totalStyle)
+		setAmount(col+2, row, v.DeviationPercent, deviationStyle(v.DeviationPercent))
+	}
+	file.SetCellStyle(sheetName, cell(1, row), cell(2, row), headerStyle)
+
+	row += 2
+	file.SetCellValue(sheetName, cell(2, row), "Lowest price")
+	file.SetCellStyle(sheetName, cell(2, row), cell(2, row), lowestStyle)
+	file.SetCellValue(sheetName, cell(2, row+1), "Above owner estimate")
+	file.SetCellStyle(sheetName, cell(2, row+1), cell(2, row+1), aboveStyle)
+
+	file.SetColWidth(sheetName, "A", "A", 6)
+	file.SetColWidth(sheetName, "B", "B", 40)
+	file.SetColWidth(sheetName, "C", "F", 15)
+	if len(comparison.Vendors) > 0 {
+		first, _ := excelize.ColumnNumberToName(vendorCol)
+		last, _ := excelize.ColumnNumberToName(lastCol)
+		file.SetColWidth(sheetName, first, last, 18)
+	}
+	file.SetPanes(sheetName, &excelize.Panes{
+		Freeze:      true,
+		XSplit:      2,
+		YSplit:      headerRow,
+		TopLeftCell: cell(3, headerRow+1),
+		ActivePane:  "bottomRight",
+	})
+
+	buf := bytes.Buffer{}
+	if err := file.Write(&buf); err != nil {
+		return nil, "", err
+	}
+
+	filename := utils.BuildQuotationComparisonFilename(comparison.Event)
+	return buf.Bytes(), filename, nil
+}
+
+// GenerateSubmissionsXLSX exports every submission of the event with its scores and file links,
+// plus a sheet of score statistics. Sealed submissions are listed without content.
+func (s *ServiceEvent) GenerateSubmissionsXLSX(eventId string) ([]byte, string, error) {
+	event, err := s.EventRepo.GetEventByID(eventId)
+	if err != nil {
+		return nil, "", err
+	}
+
+	submissions, err := s.EventRepo.GetSubmissionsByEventID(eventId)
+	if err != nil {
+		return nil, "", err
+	}
+	s.presentSubmissions(submissions, false, false)
+
+	// Highest score first, unscored last
+	sort.SliceStable(submissions, func(i, j int) bool {
+		a, b := submissions[i], submissions[j]
+		if (a.Score == nil) != (b.Score == nil) {
+			return a.Score != nil
+		}
+		if a.Score != nil && *a.Score != *b.Score {
+			return *a.Score > *b.Score
+		}
+		return a.CreatedAt.Before(b.CreatedAt)
+	})
+
+	file := excelize.NewFile()
+	defer file.Close()
+
+	const sheetName = "Submissions"
+	const summarySheet = "Score Summary"
+	file.SetSheetName(file.GetSheetName(0), sheetName)
+	if _, err := file.NewSheet(summarySheet); err != nil {
+		return nil, "", err
+	}
+
+	amountFormat := "#,##0.00"
+	scoreFormat := "0.00"
+	headerStyle, err := file.NewStyle(&excelize.Style{
+		Font:      &excelize.Font{Bold: true},
+		Fill:      excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"D9D9D9"}},
+		Alignment: &excelize.Alignment{WrapText: true, Vertical: "center"},
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	amountStyle, err := file.NewStyle(&excelize.Style{CustomNumFmt: &amountFormat})
+	if err != nil {
+		return nil, "", err
+	}
+	scoreStyle, err := file.NewStyle(&excelize.Style{CustomNumFmt: &scoreFormat})
+	if err != nil {
+		return nil, "", err
+	}
+	wrapStyle, err := file.NewStyle(&excelize.Style{Alignment: &excelize.Alignment{WrapText: true, Vertical: "top"}})
+	if err != nil {
+		return nil, "", err
+	}
+	linkStyle, err := file.NewStyle(&excelize.Style{Font: &excelize.Font{Color: "0563C1", Underline: "single"}})
+	if err != nil {
+		return nil, "", err
+	}
+
+	cell := func(col, row int) string {
+		name, _ := excelize.CoordinatesToCellName(col, row)
+		return name
+	}
+	yesNo := func(v bool) string {
+		if v {
+			return "Yes"
+		}
+		return "No"
+	}
+
+	file.SetCellValue(sheetName, "A1", fmt.Sprintf("Submissions - %s", event.Title))
+	file.SetCellValue(sheetName, "A2", fmt.Sprintf("Generated at %s", time.Now().Format("2006-01-02 15:04:05")))
+
+	// Fixed columns, then one column per attached file
+	const headerRow = 4
+	const fileCol = 13
+	headers := []string{"No", "Vendor Name", "Vendor Code", "Status", "Submitted At", "Revision", "Quoted Total", "Score", "Comments", "Shortlisted", "Winner", "Sealed"}
+	maxFiles := 0
+	for _, sub := range submissions {
+		if len(sub.File) > maxFiles {
+			maxFiles = len(sub.File)
+		}
+	}
+	for i, h := range headers {
+		file.SetCellValue(sheetName, cell(i+1, headerRow), h)
+	}
+	for i := 0; i < maxFiles; i++ {
+		file.SetCellValue(sheetName, cell(fileCol+i, headerRow), fmt.Sprintf("File %d", i+1))
+	}
+	lastCol := fileCol + maxFiles - 1
+	if lastCol < len(headers) {
+		lastCol = len(headers)
+	}
+	file.SetCellStyle(sheetName, cell(1, headerRow), cell(lastCol, headerRow), headerStyle)
+
+	row := headerRow + 1
+	for i, sub := range submissions {
+		vendorName := sub.Vendor.VendorCode
+		if sub.Vendor.Profile != nil && sub.Vendor.Profile.VendorName != "" {
+			vendorName = sub.Vendor.Profile.VendorName
+		}
+
+		file.SetCellValue(sheetName, cell(1, row), i+1)
+		file.SetCellValue(sheetName, cell(2, row), vendorName)
+		file.SetCellValue(sheetName, cell(3, row), sub.Vendor.VendorCode)
+		file.SetCellValue(sheetName, cell(4, row), sub.Status)
+		file.SetCellValue(sheetName, cell(5, row), sub.CreatedAt.Format("2006-01-02 15:04:05"))
+		file.SetCellValue(sheetName, cell(6, row), sub.RevisionNumber)
+		if sub.QuotedTotal != nil {
+			file.SetCellValue(sheetName, cell(7, row), sub.QuotedTotal.InexactFloat64())
+			file.SetCellStyle(sheetName, cell(7, row), cell(7, row), amountStyle)
+		}
+		if sub.Score != nil {
+			file.SetCellValue(sheetName, cell(8, row), *sub.Score)
+			file.SetCellStyle(sheetName, cell(8, row), cell(8, row), scoreStyle)
+		}
+		file.SetCellValue(sheetName, cell(9, row), sub.Comments)
+		file.SetCellStyle(sheetName, cell(9, row), cell(9, row), wrapStyle)
+		file.SetCellValue(sheetName, cell(10, row), yesNo(sub.IsShortlisted))
+		file.SetCellValue(sheetName, cell(11, row), yesNo(sub.IsWinner))
+		file.SetCellValue(sheetName, cell(12, row), yesNo(sub.Sealed))
+
+		for j, f := range sub.File {
+			name := f.FileName
+			if name == "" {
+				name = f.FileType
+			}
+			file.SetCellValue(sheetName, cell(fileCol+j, row), name)
+			if link := submissionFileLink(f); link != "" {
+				file.SetCellHyperLink(sheetName, cell(fileCol+j, row), link, "External")
+				file.SetCellStyle(sheetName, cell(fileCol+j, row), cell(fileCol+j, row), linkStyle)
+			}
+		}
+		row++
+	}
+
+	file.SetColWidth(sheetName, "A", "A", 6)
+	file.SetColWidth(sheetName, "B", "B", 30)
+	file.SetColWidth(sheetName, "C", "H", 15)
+	file.SetColWidth(sheetName, "I", "I", 40)
+	file.SetColWidth(sheetName, "J", "L", 12)
+	if maxFiles > 0 {
+		first, _ := excelize.ColumnNumberToName(fileCol)
+		last, _ := excelize.ColumnNumberToName(lastCol)
+		file.SetColWidth(sheetName, first, last, 25)
+	}
+	file.SetPanes(sheetName, &excelize.Panes{
+		Freeze:      true,
+		XSplit:      2,
+		YSplit:      headerRow,
+		TopLeftCell: cell(3, headerRow+1),
+		ActivePane:  "bottomRight",
+	})
+
+	stats := submissionScoreStats(submissions)
+	summary := [][]interface{}{
+		{"Submissions", stats.Total},
+		{"Active", stats.Active},
+		{"Withdrawn", stats.Withdrawn},
+		{"Scored", stats.Scored},
+		{"Not scored", stats.Active - stats.Scored},
+		{"Shortlisted", stats.Shortlisted},
+		{"Winners", stats.Winners},
+	}
+	file.SetCellValue(summarySheet, "A1", fmt.Sprintf("Score Summary - %s", event.Title))
+	file.SetCellValue(summarySheet, "A3", "Metric")
+	file.SetCellValue(summarySheet, "B3", "Value")
+	file.SetCellStyle(summarySheet, "A3", "B3", headerStyle)
+	row = 4
+	for _, line := range summary {
+		file.SetCellValue(summarySheet, cell(1, row), line[0])
+		file.SetCellValue(summarySheet, cell(2, row), line[1])
+		row++
+	}
+	if stats.Scored > 0 {
+		scores := [][]interface{}{
+			{"Average score", stats.Average},
+			{"Median score", stats.Median},
+			{"Highest score", stats.Highest},
+			{"Lowest score", stats.Lowest},
+			{"Standard deviation", stats.StdDev},
+		}
+		for _, line := range scores {
+			file.SetCellValue(summarySheet, cell(1, row), line[0])
+			file.SetCellValue(summarySheet, cell(2, row), line[1])
+			file.SetCellStyle(summarySheet, cell(2, row), cell(2, row), scoreStyle)
+			row++
+		}
+	}
+	file.SetColWidth(summarySheet, "A", "A", 25)
+	file.SetColWidth(summarySheet, "B", "B", 15)
+
+	buf := bytes.Buffer{}
+	if err := file.Write(&buf); err != nil {
+		return nil, "", err
+	}
+
+	filename := utils.BuildSubmissionExportFilename(event)
+	return buf.Bytes(), filename, nil
+}
+
+// submissionFileLink points to the file in storage, or to the download endpoint for encrypted files.
+// It is empty for an encrypted file when API_BASE_URL is not configured, as a relative link would
+// not resolve from the spreadsheet.
+func submissionFileLink(f domainevents.EventSubmissionFile) string {
+	if !f.IsEncrypted {
+		return f.FileUrl
+	}
+	if utils.ApiBaseURL == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s/api/event/submission/file/%s", strings.TrimRight(utils.ApiBaseURL, "/"), f.ID)
+}
+
+type scoreStats struct {
+	Total, Active, Withdrawn, Scored, Shortlisted, Winners int
+	Average, Median, Highest, Lowest, StdDev               float64
+}
+
+// submissionScoreStats summarizes the scores of the active submissions
+func submissionScoreStats(submissions []domainevents.EventSubmission) scoreStats {
+	stats := scoreStats{Total: len(submissions)}
+	var scores []float64
+	for _, sub := range submissions {
+		if sub.Status == utils.SubmissionWithdrawn {
+			stats.Withdrawn++
+			continue
+		}
+		stats.Active++
+		if sub.IsShortlisted {
+			stats.Shortlisted++
+		}
+		if sub.IsWinner {
+			stats.Winners++
+		}
+		if sub.Score != nil {
+			scores = append(scores, *sub.Score)
+		}
+	}
+
+	stats.Scored = len(scores)
+	if stats.Scored == 0 {
+		return stats
+	}
+
+	sort.Float64s(scores)
+	sum := 0.0
+	for _, score := range scores {
+		sum += score
+	}
+	stats.Average = sum / float64(len(scores))
+	stats.Lowest = scores[0]
+	stats.Highest = scores[len(scores)-1]
+	if mid := len(scores) / 2; len(scores)%2 == 0 {
+		stats.Median = (scores[mid-1] + scores[mid]) / 2
+	} else {
+		stats.Median = scores[mid]
+	}
+	variance := 0.0
+	for _, score := range scores {
+		variance += (score - stats.Average) * (score - stats.Average)
+	}
+	stats.StdDev = math.Sqrt(variance / float64(len(scores)))
+
+	return stats
+}
+
+// PlaceAuctionBid records a vendor's bid in a live reverse auction. A bid must stay under the
+// vendor's previous one by the minimum decrement; a bid inside the extension window moves the end back.
+func (s *ServiceEvent) PlaceAuctionBid(eventId, vendorId, userId string, req dto.PlaceAuctionBidRequest) (domainevents.AuctionStatus, error) {
+	event, err := s.EventRepo.GetEventByID(eventId)
+	if err != nil {
+		return domainevents.AuctionStatus{}, err
+	}
+	if event.EventType != utils.EventTypeReverseAuction {
+		return domainevents.AuctionStatus{}, errNotAuction
+	}
+	allowed, err := s.CanVendorAccessEvent(event, vendorId)
+	if err != nil {
+		return domainevents.AuctionStatus{}, err
+	}
+	if !allowed {
+		return domainevents.AuctionStatus{}, errors.New("vendor is not invited to this auction")
+	}
+
+	amount := decimal.NewFromFloat(req.Amount).Round(2)
+	now := time.Now()
+
+	// The event row lock serializes bids, so the end time and the previous bid cannot change underneath
+	err = s.UoW.Do(func(repos interfaceuow.Repositories) error {
+		event, err := repos.Event.LockEventForUpdate(eventId)
+		if err != nil {
+			return err
+		}
+		if phase := auctionPhase(event, now); phase != utils.AuctionLive {
+			return fmt.Errorf("auction is not live, it is %s", phase)
+		}
+
+		var previous *decimal.Decimal
+		last, err := repos.Event.GetLatestAuctionBid(eventId, vendorId)
+		if err == nil {
+			previous = &last.Amount
+		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		if limit := maxNextBid(event, previous); limit != nil && amount.GreaterThan(*limit) {
+			return fmt.Errorf("bid must not be higher than %s", limit.StringFixed(2))
+		}
+
+		if err := repos.Event.CreateAuctionBid(domainevents.EventAuctionBid{
+			Id:        utils.CreateUUID(),
+			EventID:   eventId,
+			VendorID:  vendorId,
+			Amount:    amount,
+			CreatedAt: now,
+			CreatedBy: userId,
+		}); err != nil {
+			return err
+		}
+
+		if event.AuctionExtensionWindow > 0 {
+			windowStart := event.AuctionEndAt.Add(-time.Duration(event.AuctionExtensionWindow) * time.Minute)
+			extendedEnd := now.Add(time.Duration(event.AuctionExtensionMinutes) * time.Minute)
+			if !now.Before(windowStart) && extendedEnd.After(*event.AuctionEndAt) {
+				return repos.Event.ExtendAuctionEnd(eventId, extendedEnd, userId)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return domainevents.AuctionStatus{}, err
+	}
+
+	s.publishAuction(eventId)
+
+	return s.GetAuctionStatus(eventId, vendorId)
+}
+
+// GetAuctionStatus is the vendor's view of an auction: their own rank and the lowest price, without competitors
+func (s *ServiceEvent) GetAuctionStatus(eventId, vendorId string) (domainevents.AuctionStatus, error) {
+	event, err := s.EventRepo.GetEventByID(eventId)
+	if err != nil {
+		return domainevents.AuctionStatus{}, err
+	}
+	if event.EventType != utils.EventTypeReverseAuction {
+		return domainevents.AuctionStatus{}, errNotAuction
+	}
+	allowed, err := s.CanVendorAccessEvent(event, vendorId)
+	if err != nil {
+		return domainevents.AuctionStatus{}, err
+	}
+	if !allowed {
+		return domainevents.AuctionStatus{}, errors.New("vendor is not invited to this auction")
+	}
+
+	bids, err := s.EventRepo.GetAuctionBids(eventId)
+	if err != nil {
+		return domainevents.AuctionStatus{}, err
+	}
+
+	ranks := rankAuctionBids(bids)
+	status := newAuctionStatus(event, ranks)
+	for _, r := range ranks {
+		if r.VendorID != vendorId {
+			continue
+		}
+		rank, best := r.Rank, r.BestBid
+		status.MyRank = &rank
+		status.MyBestBid = &best
+		break
+	}
+	if status.Phase == utils.AuctionLive {
+		status.MaxNextBid = maxNextBid(event, status.MyBestBid)
+	}
+
+	return status, nil
+}
+
+// GetAuctionLeaderboard is the event owner's view of an auction, with every bidder's rank and name
+func (s *ServiceEvent) GetAuctionLeaderboard(eventId string) (domainevents.AuctionStatus, error) {
+	event, err := s.EventRepo.GetEventByID(eventId)
+	if err != nil {
+		return domainevents.AuctionStatus{}, err
+	}
+	if event.EventType != utils.EventTypeReverseAuction {
+		return domainevents.AuctionStatus{}, errNotAuction
+	}
+
+	bids, err := s.EventRepo.GetAuctionBids(eventId)
+	if err != nil {
+		return domainevents.AuctionStatus{}, err
+	}
+
+	ranks := rankAuctionBids(bids)
+	status := newAuctionStatus(event, ranks)
+	status.Leaderboard = ranks
+	return status, nil
+}
+
+// SubscribeAuction signals on the returned channel whenever the auction changes
+func (s *ServiceEvent) SubscribeAuction(eventId string) (<-chan struct{}, func()) {
+	if s.AuctionBroker == nil {
+		return make(chan struct{}), func() {}
+	}
+	return s.AuctionBroker.Subscribe(eventId)
+}
+
+func (s *ServiceEvent) publishAuction(eventId string) {
+	if s.AuctionBroker != nil {
+		s.AuctionBroker.Publish(eventId)
+	}
+}
+
+// CloseAuction ends an auction after its end time. Each bidder gets a submission carrying
+// their best bid and the lowest bidder is shortlisted and proposed as the winner; the award
+// itself still goes through SelectWinner.
+func (s *ServiceEvent) CloseAuction(eventId, userId string) (map[string]interface{}, error) {
+	event, err := s.EventRepo.GetEventByID(eventId)
+	if err != nil {
+		return nil, err
+	}
+	if event.EventType != utils.EventTypeReverseAuction {
+		return nil, errNotAuction
+	}
+
+	now := time.Now()
+	var ranks []domainevents.AuctionRank
+	var submissions []domainevents.EventSubmission
+
+	// The event row lock keeps bids and a concurrent close out while the leaderboard is taken
+	err = s.UoW.Do(func(repos interfaceuow.Repositories) error {
+		locked, err := repos.Event.LockEventForUpdate(eventId)
+		if err != nil {
+			return err
+		}
+		if locked.Status == utils.EventCancelled {
+			return errEventCancelled
+		}
+		if locked.AuctionClosedAt != nil {
+			return errors.New("auction is already closed")
+		}
+		if locked.AuctionEndAt == nil || now.Before(*locked.AuctionEndAt) {
+			return errors.New("auction is still running")
+		}
+
+		bids, err := repos.Event.GetAuctionBids(eventId)
+		if err != nil {
+			return err
+		}
+		ranks = rankAuctionBids(bids)
+
+		submissions = make([]domainevents.EventSubmission, 0, len(ranks))
+		for _, r := range ranks {
+			submission, err := repos.Event.GetSubmissionByEventAndVendor(eventId, r.VendorID)
+			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+				return err
+			}
+			if err != nil {
+				submission = domainevents.EventSubmission{
+					Id:             utils.CreateUUID(),
+					EventID:        eventId,
+					VendorID:       r.VendorID,
+					RevisionNumber: 1,
+					CreatedAt:      now,
+					CreatedBy:      userId,
+				}
+			} else {
+				submission.RevisionNumber++
+			}
+			submission.ProposalDetails = fmt.Sprintf("Reverse auction best bid %s (rank %d, %d bids)", r.BestBid.StringFixed(2), r.Rank, r.BidCount)
+			submission.Status = utils.SubmissionSubmitted
+			submission.IsShortlisted = r.Rank == 1
+			submission.UpdatedAt = now
+			submission.UpdatedBy = userId
+			submissions = append(submissions, submission)
+		}
+
+		event = locked
+		event.AuctionClosedAt = &now
+		event.Status = utils.EventClosed
+		event.UpdatedAt = now
+		event.UpdatedBy = userId
+		if err := repos.Event.UpdateEvent(event); err != nil {
+			return err
+		}
+
+		for _, submission := range submissions {
+			var err error
+			if submission.RevisionNumber == 1 {
+				err = repos.Event.CreateSubmission(submission)
+			} else {
+				err = repos.Event.UpdateSubmission(submission)
+			}
+			if err != nil {
+				return err
+			}
+			if err := s.recordRevision(repos.Event, submission, utils.RevisionSubmitted, "auction closed", userId); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.publishAuction(eventId)
+
+	event, err = s.EventRepo.GetEventByID(eventId)
+	if err != nil {
+		return nil, err
+	}
+
+	var proposed map[string]interface{}
+	if len(ranks) > 0 {
+		proposed = map[string]interface{}{
+			"submission_id": submissions[0].Id,
+			"vendor_id":     ranks[0].VendorID,
+			"vendor_name":   ranks[0].VendorName,
+			"amount":        ranks[0].BestBid,
+		}
+	}
+
+	return map[string]interface{}{
+		"event":           event,
+		"leaderboard":     ranks,
+		"proposed_winner": proposed,
+	}, nil
+}
+
+// auctionPhase tells where the auction stands at the given time
+func auctionPhase(event domainevents.Event, now time.Time) string {
+	switch {
+	case event.AuctionClosedAt != nil:
+		return utils.AuctionClosed
+	case event.AuctionStartAt == nil || now.Before(*event.AuctionStartAt):
+		return utils.AuctionUpcoming
+	case event.Status == utils.EventOpen && event.AuctionEndAt != nil && now.Before(*event.AuctionEndAt):
+		return utils.AuctionLive
+	default:
+		return utils.AuctionEnded
+	}
+}
+
+// maxNextBid is the highest amount a vendor may bid after their previous bid, or nil without a cap.
+// The first bid is capped by the start price; later bids must drop by the larger of the
+// fixed and the percentage decrement, or by at least 0.01 when neither is set.
+func maxNextBid(event domainevents.Event, previous *decimal.Decimal) *decimal.Decimal {
+	if previous == nil {
+		return event.AuctionStartPrice
+	}
+
+	step := decimal.NewFromFloat(0.01)
+	if event.AuctionMinDecrement != nil && event.AuctionMinDecrement.GreaterThan(step) {
+		step = *event.AuctionMinDecrement
+	}
+	if event.AuctionMinDecrementPercent != nil {
+		percentStep := previous.Mul(*event.AuctionMinDecrementPercent).Div(decimal.NewFromInt(100)).Round(2)
+		if percentStep.GreaterThan(step) {
+			step = percentStep
+		}
+	}
+
+	limit := previous.Sub(step)
+	return &limit
+}
+
+// rankAuctionBids ranks vendors by their lowest bid; on a tie the vendor who reached it first ranks higher
+func rankAuctionBids(bids []domainevents.EventAuctionBid) []domainevents.AuctionRank {
+	best := map[string]*domainevents.AuctionRank{}
+	order := []string{}
+	for _, bid := range bids {
+		r, ok := best[bid.VendorID]
+		if !ok {
+			vendorName := bid.VendorID
+			if bid.Vendor != nil {
+				vendorName = bid.Vendor.VendorCode
+				if bid.Vendor.Profile != nil && bid.Vendor.Profile.VendorName != "" {
+					vendorName = bid.Vendor.Profile.VendorName
+				}
+			}
+			r = &domainevents.AuctionRank{VendorID: bid.VendorID, VendorName: vendorName, BestBid: bid.Amount, BidAt: bid.CreatedAt}
+			best[bid.VendorID] = r
+			order = append(order, bid.VendorID)
+		}
+		r.BidCount++
+		if bid.Amount.LessThan(r.BestBid) {
+			r.BestBid = bid.Amount
+			r.BidAt = bid.CreatedAt
+		}
+	}
+
+	ranks := make([]domainevents.AuctionRank, 0, len(order))
+	for _, vendorId := range order {
+		ranks = append(ranks, *best[vendorId])
+	}
+	sort.SliceStable(ranks, func(i, j int) bool {
+		if !ranks[i].BestBid.Equal(ranks[j].BestBid) {
+			return ranks[i].BestBid.LessThan(ranks[j].BestBid)
+		}
+		return ranks[i].BidAt.Before(ranks[j].BidAt)
+	})
+	for i := range ranks {
+		ranks[i].Rank = i + 1
+	}
+	return ranks
+}
+
+func newAuctionStatus(event domainevents.Event, ranks []domainevents.AuctionRank) domainevents.AuctionStatus {
+	now := time.Now()
+	status := domainevents.AuctionStatus{
+		EventID:     event.Id,
+		Phase:       auctionPhase(event, now),
+		StartAt:     event.AuctionStartAt,
+		EndAt:       event.AuctionEndAt,
+		ServerTime:  now,
+		BidderCount: len(ranks),
+	}
+	if len(ranks) > 0 {
+		lowest := ranks[0].BestBid
+		status.LowestBid = &lowest
+	}
+	return status
+}
+
+// lowestAuctionBid is the vendor's final price; every bid is lower than the one before, so it is the latest
+func (s *ServiceEvent) lowestAuctionBid(eventId, vendorId string) (domainevents.EventAuctionBid, error) {
+	bid, err := s.EventRepo.GetLatestAuctionBid(eventId, vendorId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return domainevents.EventAuctionBid{}, errors.New("vendor has no bid in this auction")
+	}
+	return bid, err
+}
+
+// GetCalendarToken returns the user's calendar feed token, creating one on first use
+func (s *ServiceEvent) GetCalendarToken(userId string) (domainevents.CalendarToken, error) {
+	token, err := s.EventRepo.GetCalendarTokenByUserID(userId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return s.RegenerateCalendarToken(userId)
+	}
+	if err != nil {
+		return domainevents.CalendarToken{}, err
+	}
+
+	token.FeedURL = calendarFeedURL(token.Token)
+	return token, nil
+}
+
+// RegenerateCalendarToken replaces the user's calendar feed token, so the old feed URL stops working
+func (s *ServiceEvent) RegenerateCalendarToken(userId string) (domainevents.CalendarToken, error) {
+	now := time.Now()
+	token, err := s.EventRepo.GetCalendarTokenByUserID(userId)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return domainevents.CalendarToken{}, err
+	}
 	if err != nil {
-		return domainevents.EventSubmission{}, err
+		token = domainevents.CalendarToken{
+			Id:        utils.CreateUUID(),
+			UserID:    userId,
+			CreatedAt: now,
+		}
 	}
 
-	submission.IsShortlisted = isShortlisted
-	submission.UpdatedAt = time.Now()
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return domainevents.CalendarToken{}, err
+	}
+	token.Token = hex.EncodeToString(secret)
+	token.UpdatedAt = now
 
-	if err := s.EventRepo.UpdateSubmission(submission); err != nil {
-		return domainevents.EventSubmission{}, err
+	if err := s.EventRepo.SaveCalendarToken(token); err != nil {
+		return domainevents.CalendarToken{}, err
 	}
 
-	return submission, nil
+	token.FeedURL = calendarFeedURL(token.Token)
+	return token, nil
 }
 
-func (s *ServiceEvent) SelectWinner(eventId, submissionId string) (domainevents.Event, error) {
-	event, err := s.EventRepo.GetEventByID(eventId)
+// GetCalendarFeed renders the iCalendar feed of the token's user. Vendors get the events they can see
+// or have submitted to, staff with event:manage_all every event and other staff the events they created
+// or are on the team of.
+// The feed is built on every request, so changed dates show up on the next calendar refresh.
+func (s *ServiceEvent) GetCalendarFeed(token string) ([]byte, error) {
+	calendarToken, err := s.EventRepo.GetCalendarTokenByToken(token)
 	if err != nil {
-		return domainevents.Event{}, err
+		return nil, err
 	}
+	if calendarToken.User == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+	user := calendarToken.User
 
-	// Check if winner already selected
-	if event.WinnerVendorID != nil && *event.WinnerVendorID != "" {
-		// Check if current winner vendor is suspended
-		currentWinnerVendor, err := s.VendorRepo.GetVendorByID(*event.WinnerVendorID)
+	since := time.Now().AddDate(0, 0, -utils.CalendarFeedPastDays)
+	var events []domainevents.Event
+	switch user.Role {
+	case utils.RoleVendor:
+		vendor, err := s.VendorRepo.GetVendorByUserID(user.Id)
 		if err != nil {
-			return domainevents.Event{}, errors.New("failed to check current winner status")
+			return nil, err
 		}
-		if currentWinnerVendor.Status != utils.VendorSuspend {
-			return domainevents.Event{}, errors.New("winner has already been selected for this event")
+		events, err = s.EventRepo.GetCalendarEventsForVendor(vendor.Id, since)
+		if err != nil {
+			return nil, err
 		}
-		// Current winner is suspended, allow changing winner
-		// Reset previous winner's IsWinner flag
-		submissions, err := s.EventRepo.GetSubmissionsByEventID(eventId)
+	default:
+		ownerId, err := s.ownedEventsScope(user.Id)
 		if err != nil {
-			return domainevents.Event{}, err
+			return nil, err
 		}
-		for _, sub := range submissions {
-			if sub.IsWinner {
-				sub.IsWinner = false
-				sub.UpdatedAt = time.Now()
-				s.EventRepo.UpdateSubmission(sub)
-			}
+		if events, err = s.EventRepo.GetCalendarEvents(ownerId, since); err != nil {
+			return nil, err
 		}
 	}
 
-	submission, err := s.EventRepo.GetSubmissionByID(submissionId)
+	eventIds := make([]string, 0, len(events))
+	for _, event := range events {
+		eventIds = append(eventIds, event.Id)
+	}
+	awards, err := s.EventRepo.GetActiveAwardsByEventIDs(eventIds)
 	if err != nil {
-		return domainevents.Event{}, err
+		return nil, err
+	}
+	awardsByEvent := map[string][]domainevents.EventAward{}
+	for _, award := range awards {
+		awardsByEvent[award.EventID] = append(awardsByEvent[award.EventID], award)
 	}
 
-	if submission.EventID != eventId {
-		return domainevents.Event{}, errors.New("submission does not belong to this event")
+	var entries []ical.Event
+	for _, event := range events {
+		entries = append(entries, calendarEntries(event, awardsByEvent[event.Id])...)
 	}
 
-	submission.IsWinner = true
-	submission.IsShortlisted = true
-	submission.UpdatedAt = time.Now()
-	if err := s.EventRepo.UpdateSubmission(submission); err != nil {
-		return domainevents.Event{}, err
+	return ical.Build("-//Vendor Management System//Event Calendar//EN", "Event deadlines - "+user.Name, entries), nil
+}
+
+// calendarEntries lists the dated milestones of an event. UIDs are derived from the event,
+// so calendar apps update the same entries when dates change.
+func calendarEntries(event domainevents.Event, awards []domainevents.EventAward) []ical.Event {
+	cancelled := event.Status == utils.EventCancelled
+	description := fmt.Sprintf("Status: %s", event.Status)
+	if event.Category != "" {
+		description += "\nCategory: " + event.Category
 	}
 
-	event.WinnerVendorID = &submission.VendorID
-	event.Status = utils.EventCompleted
-	event.UpdatedAt = time.Now()
-	if err := s.EventRepo.UpdateEvent(event); err != nil {
-		return domainevents.Event{}, err
+	var entries []ical.Event
+	if event.StartDate != nil {
+		entries = append(entries, ical.Event{
+			UID:         fmt.Sprintf("event-%s-start@vms", event.Id),
+			Summary:     "Opens: " + event.Title,
+			Description: description,
+			Start:       *event.StartDate,
+			End:         *event.StartDate,
+			AllDay:      true,
+			Cancelled:   cancelled,
+			Modified:    event.UpdatedAt,
+		})
+	}
+	if event.EndDate != nil {
+		entries = append(entries, ical.Event{
+			UID:         fmt.Sprintf("event-%s-end@vms", event.Id),
+			Summary:     "Submission deadline: " + event.Title,
+			Description: description,
+			Start:       *event.EndDate,
+			End:         *event.EndDate,
+			AllDay:      true,
+			Cancelled:   cancelled,
+			Modified:    event.UpdatedAt,
+		})
+	}
+	if closesAt := qaClosesAt(event); closesAt != nil && event.EventType != utils.EventTypeReverseAuction {
+		entries = append(entries, ical.Event{
+			UID:         fmt.Sprintf("event-%s-qa@vms", event.Id),
+			Summary:     "Q&A closes: " + event.Title,
+			Description: description,
+			Start:       *closesAt,
+			End:         *closesAt,
+			Cancelled:   cancelled,
+			Modified:    event.UpdatedAt,
+		})
+	}
+	if event.EventType == utils.EventTypeReverseAuction && event.AuctionStartAt != nil && event.AuctionEndAt != nil {
+		entries = append(entries, ical.Event{
+			UID:         fmt.Sprintf("event-%s-auction@vms", event.Id),
+			Summary:     "Reverse auction: " + event.Title,
+			Description: description + "\nThe end moves later when bids arrive in the last minutes",
+			Start:       *event.AuctionStartAt,
+			End:         *event.AuctionEndAt,
+			Cancelled:   cancelled,
+			Modified:    event.UpdatedAt,
+		})
+	}
+	for _, award := range awards {
+		summary := "Awarded: " + event.Title
+		if award.LotReference != "" {
+			summary += " (" + award.LotReference + ")"
+		}
+		entries = append(entries, ical.Event{
+			UID:         fmt.Sprintf("award-%s@vms", award.Id),
+			Summary:     summary,
+			Description: description,
+			Start:       award.CreatedAt,
+			End:         award.CreatedAt,
+			AllDay:      true,
+			Modified:    award.UpdatedAt,
+		})
 	}
 
-	s.notifyWinnerAndLosers(event, submission)
+	return entries
+}
 
-	return event, nil
+func calendarFeedURL(token string) string {
+	return utils.ApiBaseURL + "/api/calendar/" + token + ".ics"
 }
 
-func (s *ServiceEvent) GetEventResult(eventId, vendorId string) (map[string]interface{}, error) {
+// qaClosesAt is when vendors can no longer ask questions, or nil when the event has no end date
+func qaClosesAt(event domainevents.Event) *time.Time {
+	if event.EndDate == nil {
+		return nil
+	}
+
+	cutoffHours := utils.QACutoffHours
+	if event.QACutoffHours != nil {
+		cutoffHours = *event.QACutoffHours
+	}
+
+	closesAt := event.EndDate.Add(-time.Duration(cutoffHours) * time.Hour)
+	return &closesAt
+}
+
+// AskQuestion posts a vendor's clarification question on an open event before the Q&A cut-off
+func (s *ServiceEvent) AskQuestion(eventId, vendorId string, req dto.AskQuestionRequest) (domainevents.EventQuestion, error) {
 	event, err := s.EventRepo.GetEventByID(eventId)
 	if err != nil {
-		return nil, err
+		return domainevents.EventQuestion{}, err
 	}
 
-	submission, err := s.EventRepo.GetSubmissionByEventAndVendor(eventId, vendorId)
-	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
-		return nil, err
+	if event.Status != utils.EventOpen {
+		return domainevents.EventQuestion{}, errors.New("event is not open for questions")
+	}
+	if closesAt := qaClosesAt(event); closesAt != nil && !time.Now().Before(*closesAt) {
+		return domainevents.EventQuestion{}, fmt.Errorf("Q&A for this event closed at %s", closesAt.Format("2006-01-02 15:04"))
 	}
 
-	// Check if vendor has submitted
-	hasSubmitted := submission.Id != ""
+	allowed, err := s.CanVendorAccessEvent(event, vendorId)
+	if err != nil {
+		return domainevents.EventQuestion{}, err
+	}
+	if !allowed {
+		return domainevents.EventQuestion{}, errors.New("access denied: your vendor is not invited to this event")
+	}
 
-	// Event not yet completed
-	if event.Status != utils.EventCompleted {
-		result := map[string]interface{}{
-			"event":         event,
-			"has_submitted": hasSubmitted,
-			"status":        "pending",
-			"message":       "Event is still in progress. Results will be announced after the event is completed.",
-		}
-		return result, nil
+	now := time.Now()
+	question := domainevents.EventQuestion{
+		Id:         utils.CreateUUID(),
+		EventID:    eventId,
+		VendorID:   vendorId,
+		Question:   strings.TrimSpace(req.Question),
+		Status:     utils.QuestionPending,
+		Visibility: utils.QuestionPrivate,
+		CreatedAt:  now,
+		CreatedBy:  vendorId,
+		UpdatedAt:  now,
+		UpdatedBy:  vendorId,
+	}
+	if err := s.EventRepo.CreateQuestion(question); err != nil {
+		return domainevents.EventQuestion{}, err
 	}
 
-	// Event completed - check if winner
-	isWinner := hasSubmitted && submission.IsWinner
+	question.Mine = true
+	return question, nil
+}
 
-	if isWinner {
-		result := map[string]interface{}{
-			"event":      event,
-			"is_winner":  true,
-			"status":     "won",
-			"message":    "Congratulations! You have won this pitching event.",
-			"submission": submission,
-		}
-		return result, nil
+func (s *ServiceEvent) GetEventQuestions(eventId string, params filter.BaseParams) ([]domainevents.EventQuestion, int64, error) {
+	if _, err := s.EventRepo.GetEventByID(eventId); err != nil {
+		return nil, 0, err
 	}
 
-	// Not winner
-	result := map[string]interface{}{
-		"event":         event,
-		"is_winner":     false,
-		"has_submitted": hasSubmitted,
-		"status":        "not_selected",
-		"message":       "Thank you for your participation. Unfortunately, you were not selected for this event. Keep trying and good luck on the next opportunity!",
-	}
-	return result, nil
+	return s.EventRepo.GetQuestionsByEventID(eventId, params)
 }
 
-func (s *ServiceEvent) GetEventResultForAdmin(eventId string) (map[string]interface{}, error) {
+// GetVendorQuestions returns the vendor's own questions and every published answer. Questions
+// asked by other vendors are anonymized.
+func (s *ServiceEvent) GetVendorQuestions(eventId, vendorId string) (map[string]interface{}, error) {
 	event, err := s.EventRepo.GetEventByID(eventId)
 	if err != nil {
 		return nil, err
 	}
 
-	submissions, err := s.EventRepo.GetSubmissionsByEventID(eventId)
+	if event.Status == utils.EventDraft {
+		return nil, gorm.ErrRecordNotFound
+	}
+	allowed, err := s.CanVendorAccessEvent(event, vendorId)
 	if err != nil {
 		return nil, err
 	}
+	if !allowed {
+		return nil, gorm.ErrRecordNotFound
+	}
 
-	var winnerSubmission *domainevents.EventSubmission
-	for _, sub := range submissions {
-		if sub.IsWinner {
-			winnerSubmission = &sub
-			break
+	questions, err := s.EventRepo.GetVisibleQuestions(eventId, vendorId)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range questions {
+		q := &questions[i]
+		q.Mine = q.VendorID == vendorId
+		if !q.Mine {
+			q.VendorID = ""
+			q.CreatedBy = ""
 		}
+		q.AnsweredBy = ""
+		q.UpdatedBy = ""
 	}
 
-	result := map[string]interface{}{
-		"event":       event,
-		"submissions": submissions,
-		"winner":      winnerSubmission,
+	closesAt := qaClosesAt(event)
+	return map[string]interface{}{
+		"questions":    questions,
+		"qa_closes_at": closesAt,
+		"qa_open":      event.Status == utils.EventOpen && (closesAt == nil || time.Now().Before(*closesAt)),
+	}, nil
+}
+
+// AnswerQuestion answers a question privately to the asker or, with Publish, to every vendor of
+// the event. A published answer cannot be made private again, and questions of a cancelled event
+// are no longer answered.
+func (s *ServiceEvent) AnswerQuestion(questionId, userId string, req dto.AnswerQuestionRequest) (domainevents.EventQuestion, error) {
+	question, err := s.EventRepo.GetQuestionByID(questionId)
+	if err != nil {
+		return domainevents.EventQuestion{}, err
 	}
 
-	return result, nil
+	event, err := s.EventRepo.GetEventByID(question.EventID)
+	if err != nil {
+		return domainevents.EventQuestion{}, err
+	}
+	if event.Status == utils.EventCancelled {
+		return domainevents.EventQuestion{}, errEventCancelled
+	}
+
+	if question.Visibility == utils.QuestionPublic && !req.Publish {
+		return domainevents.EventQuestion{}, errors.New("answer has already been published to all vendors")
+	}
+
+	now := time.Now()
+	wasPublic := question.Visibility == utils.QuestionPublic
+	question.Answer = strings.TrimSpace(req.Answer)
+	question.Status = utils.QuestionAnswered
+	question.AnsweredAt = &now
+	question.AnsweredBy = userId
+	if req.Publish && !wasPublic {
+		question.Visibility = utils.QuestionPublic
+		question.PublishedAt = &now
+	}
+	question.UpdatedAt = now
+	question.UpdatedBy = userId
+
+	if err := s.EventRepo.UpdateQuestion(question); err != nil {
+		return domainevents.EventQuestion{}, err
+	}
+
+	// Editing a published answer only tells the asker, every vendor heard of it when it was published
+	if req.Publish && !wasPublic {
+		s.notifyPublishedAnswer(event, question)
+	} else {
+		s.notifyQuestionAnswered(event, question)
+	}
+
+	return question, nil
 }
 
-func (s *ServiceEvent) UploadEventFile(ctx context.Context, eventId string, userId string, fileHeader *multipart.FileHeader, req dto.UploadEventFileRequest) (domainevents.EventFile, error) {
-	// Verify event exists
-	_, err := s.EventRepo.GetEventByID(eventId)
+func (s *ServiceEvent) notifyQuestionAnswered(event domainevents.Event, question domainevents.EventQuestion) {
+	if s.NotificationSvc == nil {
+		return
+	}
+
+	vendor, err := s.VendorRepo.GetVendorByID(question.VendorID)
 	if err != nil {
-		return domainevents.EventFile{}, errors.New("event not found")
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("Failed to load vendor for answer notification: %s", err))
+		return
 	}
+	if vendor.UserId == "" {
+		return
+	}
+	_ = s.NotificationSvc.CreateForUser(
+		vendor.UserId,
+		"Pertanyaan dijawab",
+		fmt.Sprintf("Pertanyaan Anda pada event \"%s\" telah dijawab.", event.Title),
+		utils.NotifEventAnswer,
+		"event",
+		event.Id,
+	)
+}
 
-	// Validate file size
-	maxPhotoSize := utils.GetEnv("MAX_PHOTO_SIZE_EVENT", 5).(int)
-	if err := utils.ValidateFileSize(fileHeader, maxPhotoSize); err != nil {
-		return domainevents.EventFile{}, err
+// notifyPublishedAnswer tells every vendor of the event that a new clarification is available
+func (s *ServiceEvent) notifyPublishedAnswer(event domainevents.Event, question domainevents.EventQuestion) {
+	if s.NotificationSvc == nil {
+		return
 	}
 
-	// Open file
-	file, err := fileHeader.Open()
+	vendors, err := s.EventRepo.GetEventParticipantVendors(event.Id)
 	if err != nil {
-		return domainevents.EventFile{}, fmt.Errorf("failed to open file %s: %w", fileHeader.Filename, err)
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("Failed to load vendors of event %s for answer notification: %s", event.Id, err))
+		return
 	}
-	defer file.Close()
+	for _, vendor := range vendors {
+		if vendor.UserId == "" {
+			continue
+		}
+		_ = s.NotificationSvc.CreateForUser(
+			vendor.UserId,
+			"Klarifikasi event",
+			fmt.Sprintf("Jawaban klarifikasi baru telah dipublikasikan pada event \"%s\".", event.Title),
+			utils.NotifEventAnswer,
+			"event",
+			event.Id,
+		)
+	}
+	logger.WriteLog(logger.LogLevelInfo, fmt.Sprintf("Answer to question %s on event %s published to %d vendors", question.Id, event.Id, len(vendors)))
+}
 
-	// Upload to storage provider (MinIO or R2)
-	fileUrl, err := s.StorageProvider.UploadFile(ctx, file, fileHeader, "event-files")
+// IssueAddendum publishes the next numbered change to an open event, optionally with new files
+// and a new deadline, and tells every vendor that opened or submitted to the event
+func (s *ServiceEvent) IssueAddendum(ctx context.Context, eventId, userId string, req dto.IssueAddendumRequest, fileHeaders []*multipart.FileHeader) (domainevents.EventAddendum, error) {
+	event, err := s.EventRepo.GetEventByID(eventId)
 	if err != nil {
-		return domainevents.EventFile{}, fmt.Errorf("failed to upload file %s to storage: %w", fileHeader.Filename, err)
+		return domainevents.EventAddendum{}, err
+	}
+
+	if event.Status != utils.EventOpen {
+		return domainevents.EventAddendum{}, errors.New("event is not open, addenda are only issued during the tender")
 	}
 
 	now := time.Now()
-	eventFile := domainevents.EventFile{
-		ID:        utils.CreateUUID(),
-		EventId:   eventId,
-		FileType:  req.FileType,
-		FileUrl:   fileUrl,
-		Caption:   req.Caption,
+	addendum := domainevents.EventAddendum{
+		Id:        utils.CreateUUID(),
+		EventID:   eventId,
+		Summary:   strings.TrimSpace(req.Summary),
 		CreatedAt: now,
 		CreatedBy: userId,
 	}
 
-	if err := s.EventRepo.CreateEventFile(eventFile); err != nil {
-		// Cleanup uploaded file if database save fails
-		_ = s.StorageProvider.DeleteFile(ctx, fileUrl)
-		return domainevents.EventFile{}, err
+	if req.EndDate != "" {
+		t, err := time.Parse("2006-01-02", req.EndDate)
+		if err != nil {
+			return domainevents.EventAddendum{}, errors.New("invalid end_date format, use YYYY-MM-DD")
+		}
+		if err := checkDeadlineExtension(event, t, now); err != nil {
+			return domainevents.EventAddendum{}, err
+		}
+		addendum.NewEndDate = &t
 	}
 
-	return eventFile, nil
+	maxFileSize := utils.GetEnv("MAX_ADDENDUM_FILE_SIZE", 20).(int)
+	for _, fileHeader := range fileHeaders {
+		if err := utils.ValidateFileSize(fileHeader, maxFileSize); err != nil {
+			return domainevents.EventAddendum{}, err
+		}
+	}
+
+	for i, fileHeader := range fileHeaders {
+		fileUrl, err := s.uploadEventFile(ctx, fileHeader)
+		if err != nil {
+			s.cleanupEventFiles(ctx, addendum.File)
+			return domainevents.EventAddendum{}, err
+		}
+
+		caption := ""
+		if i < len(req.Captions) {
+			caption = req.Captions[i]
+		}
+		addendum.File = append(addendum.File, domainevents.EventFile{
+			ID:         utils.CreateUUID(),
+			EventId:    eventId,
+			FileType:   "document",
+			FileUrl:    fileUrl,
+			Caption:    caption,
+			AddendumId: &addendum.Id,
+			CreatedAt:  now,
+			CreatedBy:  userId,
+		})
+	}
+
+	// The event row lock serializes addenda, so two of them cannot take the same number
+	err = s.UoW.Do(func(repos interfaceuow.Repositories) error {
+		locked, err := repos.Event.LockEventForUpdate(eventId)
+		if err != nil {
+			return err
+		}
+		if locked.Status != utils.EventOpen {
+			return errors.New("event is not open, addenda are only issued during the tender")
+		}
+
+		latest, err := repos.Event.GetLatestAddendum(eventId)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		addendum.AddendumNumber = latest.AddendumNumber + 1
+
+		if addendum.NewEndDate != nil {
+			if err := checkDeadlineExtension(locked, *addendum.NewEndDate, now); err != nil {
+				return err
+			}
+			addendum.PreviousEndDate = locked.EndDate
+			locked.EndDate = addendum.NewEndDate
+		}
+		locked.UpdatedAt = now
+		locked.UpdatedBy = userId
+		event = locked
+		return repos.Event.CreateAddendum(addendum, event)
+	})
+	if err != nil {
+		s.cleanupEventFiles(ctx, addendum.File)
+		return domainevents.EventAddendum{}, err
+	}
+
+	logger.WriteLog(logger.LogLevelInfo, fmt.Sprintf("Addendum %d issued for event %s by user %s", addendum.AddendumNumber, eventId, userId))
+	s.notifyAddendum(event, addendum)
+
+	return addendum, nil
 }
 
-func (s *ServiceEvent) DeleteEventFile(ctx context.Context, fileId string) error {
-	// Get file record to get the URL for storage deletion
-	eventFile, err := s.EventRepo.GetEventFileByID(fileId)
+func (s *ServiceEvent) uploadEventFile(ctx context.Context, fileHeader *multipart.FileHeader) (string, error) {
+	file, err := fileHeader.Open()
 	if err != nil {
-		return err
+		return "", fmt.Errorf("failed to open file %s: %w", fileHeader.Filename, err)
 	}
+	defer file.Close()
 
-	// Delete from database first
-	if err = s.EventRepo.DeleteEventFile(fileId); err == nil {
-		// Delete from storage if database deletion succeeds
-		_ = s.StorageProvider.DeleteFile(ctx, eventFile.FileUrl)
+	fileUrl, err := s.StorageProvider.UploadFile(ctx, file, fileHeader, "event-files")
+	if err != nil {
+		return "", fmt.Errorf("failed to upload file %s to storage: %w", fileHeader.Filename, err)
 	}
+	return fileUrl, nil
+}
 
-	return err
+func (s *ServiceEvent) cleanupEventFiles(ctx context.Context, files []domainevents.EventFile) {
+	for _, f := range files {
+		if err := s.StorageProvider.DeleteFile(ctx, f.FileUrl); err != nil {
+			logger.WriteLog(logger.LogLevelError, fmt.Sprintf("Failed to clean up event file %s: %s", f.FileUrl, err))
+		}
+	}
 }
 
-func (s *ServiceEvent) UploadSubmissionFile(ctx context.Context, submissionId string, userId string, fileHeader *multipart.FileHeader, req dto.UploadSubmissionFileRequest) (domainevents.EventSubmissionFile, error) {
-	// Verify submission exists
-	_, err := s.EventRepo.GetSubmissionByID(submissionId)
+func (s *ServiceEvent) notifyAddendum(event domainevents.Event, addendum domainevents.EventAddendum) {
+	if s.NotificationSvc == nil {
+		return
+	}
+
+	vendors, err := s.EventRepo.GetEventViewerVendors(event.Id)
 	if err != nil {
-		return domainevents.EventSubmissionFile{}, errors.New("submission not found")
+		logger.WriteLog(logger.LogLevelError, fmt.Sprintf("Failed to load vendors of event %s for addendum notification: %s", event.Id, err))
+		return
+	}
+
+	message := fmt.Sprintf("Adendum %d telah diterbitkan untuk event \"%s\". Mohon konfirmasi adendum sebelum mengirim submission.", addendum.AddendumNumber, event.Title)
+	if addendum.NewEndDate != nil {
+		message += fmt.Sprintf(" Batas waktu baru: %s.", addendum.NewEndDate.Format("2006-01-02"))
 	}
+	for _, vendor := range vendors {
+		if vendor.UserId == "" {
+			continue
+		}
+		_ = s.NotificationSvc.CreateForUser(vendor.UserId, "Adendum event", message, utils.NotifEventAddendum, "event", event.Id)
+	}
+}
 
-	// Check file limit (max 1 file per submission)
-	count, err := s.EventRepo.CountSubmissionFilesBySubmissionID(submissionId)
+// GetAddenda lists the event's addenda. A non-empty vendorId returns the vendor's view, which
+// shows the vendor's own acknowledgement instead of everyone's.
+func (s *ServiceEvent) GetAddenda(eventId, vendorId string) ([]domainevents.EventAddendum, error) {
+	event, err := s.EventRepo.GetEventByID(eventId)
 	if err != nil {
-		return domainevents.EventSubmissionFile{}, err
+		return nil, err
 	}
 
-	if count >= int64(utils.MaxFileLimit) {
-		return domainevents.EventSubmissionFile{}, fmt.Errorf("maximum %d file allowed per submission", utils.MaxFileLimit)
+	if vendorId != "" {
+		if event.Status == utils.EventDraft {
+			return nil, gorm.ErrRecordNotFound
+		}
+		allowed, err := s.CanVendorAccessEvent(event, vendorId)
+		if err != nil {
+			return nil, err
+		}
+		if !allowed {
+			return nil, gorm.ErrRecordNotFound
+		}
 	}
 
-	// Validate file size
-	maxFileSize := utils.GetEnv("MAX_FILE_SIZE", 20).(int)
-	if err := utils.ValidateFileSize(fileHeader, maxFileSize); err != nil {
-		return domainevents.EventSubmissionFile{}, err
+	addenda, err := s.EventRepo.GetAddendaByEventID(eventId)
+	if err != nil {
+		return nil, err
 	}
 
-	// Open file
-	file, err := fileHeader.Open()
+	if vendorId != "" {
+		for i := range addenda {
+			a := &addenda[i]
+			for _, ack := range a.Acknowledgements {
+				if ack.VendorID == vendorId {
+					acknowledgedAt := ack.AcknowledgedAt
+					a.AcknowledgedAt = &acknowledgedAt
+				}
+			}
+			a.Acknowledgements = nil
+			a.CreatedBy = ""
+		}
+	}
+
+	return addenda, nil
+}
+
+// AcknowledgeAddendum records that the vendor has read the addendum. Acknowledging twice keeps
+// the first timestamp.
+func (s *ServiceEvent) AcknowledgeAddendum(addendumId, vendorId, userId string) (domainevents.EventAddendumAcknowledgement, error) {
+	addendum, err := s.EventRepo.GetAddendumByID(addendumId)
 	if err != nil {
-		return domainevents.EventSubmissionFile{}, fmt.Errorf("failed to open file %s: %w", fileHeader.Filename, err)
+		return domainevents.EventAddendumAcknowledgement{}, err
 	}
-	defer file.Close()
 
-	// Upload to storage provider (MinIO or R2)
-	fileUrl, err := s.StorageProvider.UploadFile(ctx, file, fileHeader, "event-submission-files")
+	event, err := s.EventRepo.GetEventByID(addendum.EventID)
 	if err != nil {
-		return domainevents.EventSubmissionFile{}, fmt.Errorf("failed to upload file %s to storage: %w", fileHeader.Filename, err)
+		return domainevents.EventAddendumAcknowledgement{}, err
 	}
 
-	now := time.Now()
-	submissionFile := domainevents.EventSubmissionFile{
-		ID:                utils.CreateUUID(),
-		EventSubmissionId: submissionId,
-		FileType:          req.FileType,
-		FileUrl:           fileUrl,
-		Caption:           req.Caption,
-		FileOrder:         0, // Will be calculated by repo if needed
-		CreatedAt:         now,
-		CreatedBy:         userId,
-	}
-
-	if err := s.EventRepo.CreateSubmissionFile(submissionFile); err != nil {
-		// Cleanup uploaded file if database save fails
-		_ = s.StorageProvider.DeleteFile(ctx, fileUrl)
-		return domainevents.EventSubmissionFile{}, err
+	allowed, err := s.CanVendorAccessEvent(event, vendorId)
+	if err != nil {
+		return domainevents.EventAddendumAcknowledgement{}, err
+	}
+	if !allowed || event.Status == utils.EventDraft {
+		return domainevents.EventAddendumAcknowledgement{}, gorm.ErrRecordNotFound
 	}
 
-	return submissionFile, nil
+	ack := domainevents.EventAddendumAcknowledgement{
+		Id:             utils.CreateUUID(),
+		AddendumId:     addendumId,
+		VendorID:       vendorId,
+		AcknowledgedAt: time.Now(),
+		AcknowledgedBy: userId,
+	}
+	if err := s.EventRepo.CreateAddendumAcknowledgement(ack); err != nil {
+		return domainevents.EventAddendumAcknowledgement{}, err
+	}
+
+	return s.EventRepo.GetAddendumAcknowledgement(addendumId, vendorId)
 }
 
-func (s *ServiceEvent) DeleteSubmissionFile(ctx context.Context, fileId string) error {
-	// Get file record to get the URL for storage deletion
-	submissionFile, err := s.EventRepo.GetSubmissionFileByID(fileId)
+// RecordEventView remembers that the vendor opened the event so later addenda reach them
+func (s *ServiceEvent) RecordEventView(eventId, vendorId string) error {
+	return s.EventRepo.RecordEventView(eventId, vendorId)
+}
+
+// ensureAddendaAcknowledged blocks submissions until the vendor has acknowledged the latest addendum
+func (s *ServiceEvent) ensureAddendaAcknowledged(eventId, vendorId string) error {
+	latest, err := s.EventRepo.GetLatestAddendum(eventId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
 	if err != nil {
 		return err
 	}
 
-	// Delete from database first
-	if err = s.EventRepo.DeleteSubmissionFile(fileId); err == nil {
-		// Delete from storage if database deletion succeeds
-		_ = s.StorageProvider.DeleteFile(ctx, submissionFile.FileUrl)
+	if _, err := s.EventRepo.GetAddendumAcknowledgement(latest.Id, vendorId); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("addendum %d must be acknowledged before submitting", latest.AddendumNumber)
+		}
+		return err
 	}
-
-	return err
+	return nil
 }
-
-var _ interfaceevents.ServiceEventInterface = (*ServiceEvent)(nil)
@@ -2,37 +2,57 @@ package servicepayments
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"io"
 	"mime/multipart"
+	"strings"
 	"time"
 	"vendor-management-system/pkg/storage"
 
+	domainevents "vendor-management-system/internal/domain/events"
 	domainpayments "vendor-management-system/internal/domain/payments"
 	"vendor-management-system/internal/dto"
+	interfaceevaluations "vendor-management-system/internal/interfaces/evaluations"
+	interfaceevents "vendor-management-system/internal/interfaces/events"
 	interfacepayments "vendor-management-system/internal/interfaces/payments"
+	interfacepermission "vendor-management-system/internal/interfaces/permission"
+	interfaceuow "vendor-management-system/internal/interfaces/uow"
 	interfacevendors "vendor-management-system/internal/interfaces/vendors"
+	"vendor-management-system/pkg/bankstatement"
+	"vendor-management-system/pkg/disbursement"
 	"vendor-management-system/pkg/filter"
 	"vendor-management-system/utils"
 
 	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
 )
 
 type ServicePayment struct {
 	PaymentRepo     interfacepayments.RepoPaymentInterface
 	VendorRepo      interfacevendors.RepoVendorInterface
+	EvaluationRepo  interfaceevaluations.RepoEvaluationInterface
+	EventRepo       interfaceevents.RepoEventInterface
+	PermissionRepo  interfacepermission.RepoPermissionInterface
 	StorageProvider storage.StorageProvider
+	UoW             interfaceuow.UnitOfWorkInterface
 }
 
-func NewPaymentService(paymentRepo interfacepayments.RepoPaymentInterface, vendorRepo interfacevendors.RepoVendorInterface, storageProvider storage.StorageProvider) *ServicePayment {
+func NewPaymentService(paymentRepo interfacepayments.RepoPaymentInterface, vendorRepo interfacevendors.RepoVendorInterface, evaluationRepo interfaceevaluations.RepoEvaluationInterface, eventRepo interfaceevents.RepoEventInterface, permissionRepo interfacepermission.RepoPermissionInterface, storageProvider storage.StorageProvider, uow interfaceuow.UnitOfWorkInterface) *ServicePayment {
 	return &ServicePayment{
 		PaymentRepo:     paymentRepo,
 		VendorRepo:      vendorRepo,
+		EvaluationRepo:  evaluationRepo,
+		EventRepo:       eventRepo,
+		PermissionRepo:  permissionRepo,
 		StorageProvider: storageProvider,
+		UoW:             uow,
 	}
 }
 
-func (s *ServicePayment) CreatePayment(req dto.CreatePaymentRequest) (domainpayments.Payment, error) {
+func (s *ServicePayment) CreatePayment(userId string, req dto.CreatePaymentRequest) (domainpayments.Payment, error) {
 	_, err := s.VendorRepo.GetVendorByID(req.VendorID)
 	if err != nil {
 		return domainpayments.Payment{}, errors.New("vendor not found")
@@ -56,10 +76,14 @@ func (s *ServicePayment) CreatePayment(req dto.CreatePaymentRequest) (domainpaym
 		Status:        "pending",
 		PaymentDate:   paymentDate,
 		Description:   req.Description,
+		ApprovalRound: 1,
 		CreatedAt:     now,
-		CreatedBy:     req.VendorID,
+		CreatedBy:     userId,
 		UpdatedAt:     now,
-		UpdatedBy:     req.VendorID,
+		UpdatedBy:     userId,
+	}
+	if err := s.applyTax(&payment); err != nil {
+		return domainpayments.Payment{}, err
 	}
 
 	if err := s.PaymentRepo.CreatePayment(payment); err != nil {
@@ -70,89 +94,663 @@ func (s *ServicePayment) CreatePayment(req dto.CreatePaymentRequest) (domainpaym
 }
 
 func (s *ServicePayment) GetPaymentByID(id string) (domainpayments.Payment, error) {
-	return s.PaymentRepo.GetPaymentByID(id)
+	payment, err := s.PaymentRepo.GetPaymentByID(id)
+	if err != nil {
+		return domainpayments.Payment{}, err
+	}
+
+	levels, err := s.PaymentRepo.GetApprovalLevels()
+	if err != nil {
+		return domainpayments.Payment{}, err
+	}
+	if payment.Status != utils.PaymentScheduled && payment.Status != utils.PaymentCancelled {
+		payment.ApprovalChain = approvalChain(payment, levels)
+	}
+	withBalance(&payment)
+
+	return payment, nil
 }
 
 func (s *ServicePayment) GetPaymentsByVendorID(vendorId string) ([]domainpayments.Payment, error) {
-	return s.PaymentRepo.GetPaymentsByVendorID(vendorId)
+	payments, err := s.PaymentRepo.GetPaymentsByVendorID(vendorId)
+	if err != nil {
+		return nil, err
+	}
+	for i := range payments {
+		withBalance(&payments[i])
+	}
+	return payments, nil
 }
 
 func (s *ServicePayment) GetAllPayments(params filter.BaseParams) ([]domainpayments.Payment, int64, error) {
-	return s.PaymentRepo.GetAllPayments(params)
+	payments, totalData, err := s.PaymentRepo.GetAllPayments(params)
+	if err != nil {
+		return nil, 0, err
+	}
+	for i := range payments {
+		withBalance(&payments[i])
+	}
+	return payments, totalData, nil
 }
 
-func (s *ServicePayment) UpdatePayment(id string, req dto.UpdatePaymentRequest) (domainpayments.Payment, error) {
-	payment, err := s.PaymentRepo.GetPaymentByID(id)
+// GetMyPayments lists the vendor's own payments without the internal review
+func (s *ServicePayment) GetMyPayments(vendorId string, params filter.BaseParams) ([]domainpayments.Payment, int64, error) {
+	params.Filters["vendor_id"] = vendorId
+	payments, totalData, err := s.GetAllPayments(params)
+	if err != nil {
+		return nil, 0, err
+	}
+	for i := range payments {
+		hideInternalReview(&payments[i])
+	}
+	return payments, totalData, nil
+}
+
+// GetMyPaymentByID returns one of the vendor's own payments without the internal review
+func (s *ServicePayment) GetMyPaymentByID(id, vendorId string) (domainpayments.Payment, error) {
+	payment, err := s.GetPaymentByID(id)
 	if err != nil {
 		return domainpayments.Payment{}, err
 	}
+	if payment.VendorID != vendorId {
+		return domainpayments.Payment{}, errors.New("you don't have access to this payment")
+	}
+	hideInternalReview(&payment)
+	return payment, nil
+}
 
-	if req.InvoiceNumber != "" {
-		payment.InvoiceNumber = req.InvoiceNumber
+// hideInternalReview strips the approvers and the tax override from a payment shown to its vendor
+func hideInternalReview(payment *domainpayments.Payment) {
+	payment.Approvals = nil
+	payment.ApprovalChain = nil
+	payment.TaxOverriddenBy = nil
+	payment.TaxOverrideReason = ""
+}
+
+// withBalance fills the outstanding amount and the balance history from the payment's settlements
+func withBalance(payment *domainpayments.Payment) {
+	total := transferAmount(*payment)
+	outstanding := total.Sub(payment.PaidAmount)
+	payment.OutstandingAmount = &outstanding
+
+	paid := decimal.Zero
+	payment.BalanceHistory = make([]domainpayments.PaymentBalanceEntry, 0, len(payment.Settlements))
+	for _, settlement := range payment.Settlements {
+		paid = paid.Add(settlement.Amount)
+		payment.BalanceHistory = append(payment.BalanceHistory, domainpayments.PaymentBalanceEntry{
+			PaymentSettlement: settlement,
+			PaidToDate:        paid,
+			OutstandingAmount: total.Sub(paid),
+		})
 	}
-	if req.Amount > 0 {
-		payment.Amount = decimal.NewFromFloat(req.Amount)
+}
+
+// UpdatePayment changes the payment on its locked row, so a settlement, approval or disbursement
+// batch made meanwhile is not overwritten
+func (s *ServicePayment) UpdatePayment(id, userId string, req dto.UpdatePaymentRequest) (domainpayments.Payment, error) {
+	var paymentDate *time.Time
+	if req.PaymentDate != "" {
+		t, err := time.Parse("2006-01-02", req.PaymentDate)
+		if err != nil {
+			return domainpayments.Payment{}, errors.New("invalid payment_date format, use YYYY-MM-DD")
+		}
+		paymentDate = &t
 	}
-	if req.Status != "" {
-		payment.Status = req.Status
+
+	err := s.UoW.Do(func(repos interfaceuow.Repositories) error {
+		payment, err := repos.Payment.LockPaymentForUpdate(id)
+		if err != nil {
+			return err
+		}
+		paid := req.Status == utils.PaymentPaid && payment.Status != utils.PaymentPaid
+
+		if req.InvoiceNumber != "" {
+			payment.InvoiceNumber = req.InvoiceNumber
+		}
+		if req.Amount > 0 {
+			amount := decimal.NewFromFloat(req.Amount)
+			if !amount.Equal(payment.Amount) {
+				if payment.Status == utils.PaymentProcessing {
+					return errors.New("the amount of a payment in a disbursement batch cannot be changed")
+				}
+				if payment.PaidAmount.IsPositive() {
+					return errors.New("the amount of a payment with settlements cannot be changed")
+				}
+				// Approvals were given for the old amount
+				if payment.Status == utils.PaymentPending || payment.Status == utils.PaymentApproved {
+					restartApproval(&payment)
+				}
+				// Resending the same amount keeps a tax override
+				payment.Amount = amount
+				if err := s.applyTax(&payment); err != nil {
+					return err
+				}
+			}
+		}
+		if req.Status != "" {
+			if err := s.setStatus(&payment, req.Status); err != nil {
+				return err
+			}
+		}
+		if paymentDate != nil {
+			payment.PaymentDate = paymentDate
+		}
+		if req.Description != "" {
+			payment.Description = req.Description
+		}
+
+		payment.UpdatedAt = time.Now()
+		payment.UpdatedBy = userId
+
+		if err := repos.Payment.UpdatePayment(payment); err != nil {
+			return err
+		}
+		if paid {
+			return settleRemainder(repos, payment, userId)
+		}
+		return nil
+	})
+	if err != nil {
+		return domainpayments.Payment{}, err
 	}
+
+	return s.GetPaymentByID(id)
+}
+
+func (s *ServicePayment) UpdatePaymentStatus(id, userId string, req dto.UpdatePaymentStatusRequest) (domainpayments.Payment, error) {
+	var paymentDate *time.Time
 	if req.PaymentDate != "" {
 		t, err := time.Parse("2006-01-02", req.PaymentDate)
 		if err != nil {
 			return domainpayments.Payment{}, errors.New("invalid payment_date format, use YYYY-MM-DD")
 		}
-		payment.PaymentDate = &t
+		paymentDate = &t
+	}
+
+	err := s.UoW.Do(func(repos interfaceuow.Repositories) error {
+		payment, err := repos.Payment.LockPaymentForUpdate(id)
+		if err != nil {
+			return err
+		}
+		paid := req.Status == utils.PaymentPaid && payment.Status != utils.PaymentPaid
+
+		if err := s.setStatus(&payment, req.Status); err != nil {
+			return err
+		}
+
+		if req.Status == utils.PaymentPaid && payment.PaymentDate == nil {
+			now := time.Now()
+			payment.PaymentDate = &now
+		}
+		if paymentDate != nil {
+			payment.PaymentDate = paymentDate
+		}
+
+		payment.UpdatedAt = time.Now()
+		payment.UpdatedBy = userId
+
+		if err := repos.Payment.UpdatePayment(payment); err != nil {
+			return err
+		}
+		if paid {
+			return settleRemainder(repos, payment, userId)
+		}
+		return nil
+	})
+	if err != nil {
+		return domainpayments.Payment{}, err
+	}
+
+	return s.GetPaymentByID(id)
+}
+
+// setStatus moves the payment to a status set by hand. Only approved, processing or partially paid
+// payments can be paid, which the caller does by settling the outstanding balance, and a partially
+// paid payment cannot be moved otherwise. Only scheduled, pending or rejected payments can be
+// cancelled, and only a cancelled payment can be scheduled again. A rejected, approved, processing
+// or cancelled payment sent back to pending starts a new approval round; a processing payment sent
+// back leaves its disbursement batch, e.g. after a failed transfer.
+func (s *ServicePayment) setStatus(payment *domainpayments.Payment, status string) error {
+	if status == payment.Status {
+		return nil
+	}
+	if err := s.ensurePayable(*payment, status); err != nil {
+		return err
+	}
+	if payment.Status == utils.PaymentPartiallyPaid && status != utils.PaymentPaid {
+		return errors.New("a partially paid payment can only be settled")
+	}
+
+	switch status {
+	case utils.PaymentPaid:
+		if payment.Status != utils.PaymentApproved && payment.Status != utils.PaymentProcessing && payment.Status != utils.PaymentPartiallyPaid {
+			return errors.New("payment must be approved by its whole approval chain before it can be paid")
+		}
+		return nil
+	case utils.PaymentPending:
+		if payment.Status == utils.PaymentPaid {
+			return errors.New("a paid payment cannot be sent back for approval")
+		}
+		if payment.Status == utils.PaymentProcessing {
+			payment.DisbursementBatchID = nil
+		}
+		if payment.Status == utils.PaymentRejected || payment.Status == utils.PaymentApproved || payment.Status == utils.PaymentProcessing || payment.Status == utils.PaymentCancelled {
+			restartApproval(payment)
+		}
+	case utils.PaymentCancelled:
+		if payment.Status != utils.PaymentScheduled && payment.Status != utils.PaymentPending && payment.Status != utils.PaymentRejected {
+			return fmt.Errorf("only scheduled, pending or rejected payments can be cancelled, payment is %s", payment.Status)
+		}
+	case utils.PaymentScheduled:
+		if payment.Status != utils.PaymentCancelled {
+			return fmt.Errorf("a %s payment has entered the approval flow and cannot be scheduled again", payment.Status)
+		}
+		// Approvals given before the cancellation do not carry over
+		payment.ApprovalRound++
+	}
+
+	payment.Status = status
+	return nil
+}
+
+// settleRemainder records a settlement of the outstanding balance on the payment date, for payments
+// marked paid by hand
+func settleRemainder(repos interfaceuow.Repositories, payment domainpayments.Payment, userId string) error {
+	remainder := transferAmount(payment).Sub(payment.PaidAmount)
+	if !remainder.IsPositive() {
+		return errors.New("payment has no outstanding balance to settle")
+	}
+	settlementDate := time.Now()
+	if payment.PaymentDate != nil {
+		settlementDate = *payment.PaymentDate
+	}
+
+	return settle(repos, payment, domainpayments.PaymentSettlement{
+		Id:             utils.CreateUUID(),
+		PaymentID:      payment.Id,
+		Amount:         remainder,
+		SettlementDate: settlementDate,
+		Note:           "outstanding balance settled when the payment was marked paid",
+		CreatedAt:      time.Now(),
+		CreatedBy:      userId,
+	}, userId)
+}
+
+// settle records the settlement and adds it to the payment's paid amount; it fails when the
+// settlement is more than the outstanding balance, e.g. after a concurrent settlement
+func settle(repos interfaceuow.Repositories, payment domainpayments.Payment, settlement domainpayments.PaymentSettlement, userId string) error {
+	if err := repos.Payment.CreatePaymentSettlement(settlement); err != nil {
+		return err
+	}
+	updated, err := repos.Payment.AddPaidAmount(payment.Id, settlement.Amount, transferAmount(payment), settlement.SettlementDate, userId)
+	if err != nil {
+		return err
+	}
+	if updated == 0 {
+		return fmt.Errorf("settlement of %s exceeds the outstanding balance of payment %s", settlement.Amount.StringFixed(2), payment.InvoiceNumber)
+	}
+	return nil
+}
+
+// CreatePaymentSettlement records one transfer towards the payment, with an optional proof file. The
+// payment becomes partially paid, or paid once the settlements cover its transfer amount; paying
+// more than the outstanding balance is rejected.
+func (s *ServicePayment) CreatePaymentSettlement(ctx context.Context, id, userId string, req dto.CreatePaymentSettlementRequest, fileHeader *multipart.FileHeader) (domainpayments.Payment, error) {
+	payment, err := s.PaymentRepo.GetPaymentByID(id)
+	if err != nil {
+		return domainpayments.Payment{}, err
+	}
+	if payment.Status != utils.PaymentApproved && payment.Status != utils.PaymentProcessing && payment.Status != utils.PaymentPartiallyPaid {
+		return domainpayments.Payment{}, fmt.Errorf("only approved, processing or partially paid payments can be settled, payment is %s", payment.Status)
+	}
+
+	settlementDate, err := time.Parse("2006-01-02", req.SettlementDate)
+	if err != nil {
+		return domainpayments.Payment{}, errors.New("invalid settlement_date format, use YYYY-MM-DD")
 	}
-	if req.Description != "" {
-		payment.Description = req.Description
+	amount := decimal.NewFromFloat(req.Amount).Round(2)
+	outstanding := transferAmount(payment).Sub(payment.PaidAmount)
+	if amount.GreaterThan(outstanding) {
+		return domainpayments.Payment{}, fmt.Errorf("settlement of %s exceeds the outstanding balance %s", amount.StringFixed(2), outstanding.StringFixed(2))
+	}
+
+	fileUrl := ""
+	if fileHeader != nil {
+		maxFileSize := utils.GetEnv("MAX_PHOTO_SIZE_PAYMENT", 2).(int)
+		if err := utils.ValidateFileSize(fileHeader, maxFileSize); err != nil {
+			return domainpayments.Payment{}, err
+		}
+		file, err := fileHeader.Open()
+		if err != nil {
+			return domainpayments.Payment{}, fmt.Errorf("failed to open file %s: %w", fileHeader.Filename, err)
+		}
+		defer file.Close()
+
+		fileUrl, err = s.StorageProvider.UploadFile(ctx, file, fileHeader, "payment-files")
+		if err != nil {
+			return domainpayments.Payment{}, fmt.Errorf("failed to upload file %s to storage: %w", fileHeader.Filename, err)
+		}
 	}
 
-	payment.UpdatedAt = time.Now()
+	settlement := domainpayments.PaymentSettlement{
+		Id:             utils.CreateUUID(),
+		PaymentID:      payment.Id,
+		Amount:         amount,
+		SettlementDate: settlementDate,
+		Reference:      req.Reference,
+		FileUrl:        fileUrl,
+		Note:           req.Note,
+		CreatedAt:      time.Now(),
+		CreatedBy:      userId,
+	}
 
-	if err := s.PaymentRepo.UpdatePayment(payment); err != nil {
+	err = s.UoW.Do(func(repos interfaceuow.Repositories) error {
+		return settle(repos, payment, settlement, userId)
+	})
+	if err != nil {
+		if fileUrl != "" {
+			_ = s.StorageProvider.DeleteFile(ctx, fileUrl)
+		}
 		return domainpayments.Payment{}, err
 	}
 
-	return payment, nil
+	return s.GetPaymentByID(id)
+}
+
+// restartApproval discards the approvals of the current round
+func restartApproval(payment *domainpayments.Payment) {
+	payment.ApprovalRound++
+	payment.Status = utils.PaymentPending
+	payment.RejectionReason = ""
 }
 
-func (s *ServicePayment) UpdatePaymentStatus(id string, req dto.UpdatePaymentStatusRequest) (domainpayments.Payment, error) {
+// ensurePayable blocks a scheduled payment from moving to pending or paid before its release trigger is met
+func (s *ServicePayment) ensurePayable(payment domainpayments.Payment, status string) error {
+	if payment.Status != utils.PaymentScheduled || status == utils.PaymentScheduled || status == utils.PaymentCancelled {
+		return nil
+	}
+
+	switch payment.ReleaseTrigger {
+	case utils.PaymentReleaseOnDate:
+		if payment.DueDate != nil && payment.DueDate.After(time.Now()) {
+			return fmt.Errorf("payment is not payable before its due date %s", payment.DueDate.Format("2006-01-02"))
+		}
+	case utils.PaymentTermOnCompletion:
+		if payment.EventID == nil {
+			return nil
+		}
+		evaluation, err := s.EvaluationRepo.GetEvaluationByEventAndVendor(*payment.EventID, payment.VendorID)
+		if err != nil || evaluation.FinalizedAt == nil {
+			return errors.New("payment is not payable until the event evaluation is finalized")
+		}
+	}
+	return nil
+}
+
+// ApprovePayment signs the next level of the payment's approval chain. The approver must hold the
+// level's permission and may sign only one level per round; the last level approves the payment.
+func (s *ServicePayment) ApprovePayment(id, userId string, req dto.ApprovePaymentRequest) (domainpayments.Payment, error) {
 	payment, err := s.PaymentRepo.GetPaymentByID(id)
 	if err != nil {
 		return domainpayments.Payment{}, err
 	}
+	if payment.Status != utils.PaymentPending {
+		return domainpayments.Payment{}, fmt.Errorf("only pending payments can be approved, payment is %s", payment.Status)
+	}
+	read := payment
 
-	payment.Status = req.Status
+	levels, err := s.PaymentRepo.GetApprovalLevels()
+	if err != nil {
+		return domainpayments.Payment{}, err
+	}
+	chain := approvalChain(payment, levels)
 
-	if req.Status == "paid" && payment.PaymentDate == nil {
-		now := time.Now()
-		payment.PaymentDate = &now
+	next := -1
+	for i, step := range chain {
+		if step.Approval == nil {
+			next = i
+			break
+		}
+		if step.Approval.CreatedBy == userId {
+			return domainpayments.Payment{}, fmt.Errorf("access denied: you already approved this payment as %s", step.Name)
+		}
+	}
+	if next < 0 {
+		return domainpayments.Payment{}, errors.New("payment has no approval level left to sign")
 	}
 
-	if req.PaymentDate != "" {
-		t, err := time.Parse("2006-01-02", req.PaymentDate)
+	level := chain[next]
+	action := levelAction(levels, level.Level)
+	allowed, err := s.hasPaymentPermission(userId, action)
+	if err != nil {
+		return domainpayments.Payment{}, err
+	}
+	if !allowed {
+		return domainpayments.Payment{}, fmt.Errorf("access denied: approving as %s requires the payment %s permission", level.Name, action)
+	}
+
+	// Scheduled payments get their tax breakdown before the first approval
+	if payment.TaxBase == nil {
+		if err := s.applyTax(&payment); err != nil {
+			return domainpayments.Payment{}, err
+		}
+	}
+
+	now := time.Now()
+	approval := domainpayments.PaymentApproval{
+		Id:        utils.CreateUUID(),
+		PaymentID: payment.Id,
+		Round:     payment.ApprovalRound,
+		Level:     level.Level,
+		LevelName: level.Name,
+		Decision:  utils.ApprovalApproved,
+		Comment:   req.Comment,
+		CreatedAt: now,
+		CreatedBy: userId,
+	}
+	if next == len(chain)-1 {
+		payment.Status = utils.PaymentApproved
+	}
+	payment.UpdatedAt = now
+	payment.UpdatedBy = userId
+
+	err = s.UoW.Do(func(repos interfaceuow.Repositories) error {
+		if err := lockUnchanged(repos, read); err != nil {
+			return err
+		}
+		if err := repos.Payment.CreatePaymentApproval(approval); err != nil {
+			return err
+		}
+		return repos.Payment.UpdatePayment(payment)
+	})
+	if err != nil {
+		return domainpayments.Payment{}, err
+	}
+
+	return s.GetPaymentByID(id)
+}
+
+// RejectPayment sends the payment back with a reason; any approver of its chain can reject it
+// until it is paid. Sending it back to pending starts a new approval round.
+func (s *ServicePayment) RejectPayment(id, userId string, req dto.RejectPaymentRequest) (domainpayments.Payment, error) {
+	payment, err := s.PaymentRepo.GetPaymentByID(id)
+	if err != nil {
+		return domainpayments.Payment{}, err
+	}
+	if payment.Status != utils.PaymentPending && payment.Status != utils.PaymentApproved {
+		return domainpayments.Payment{}, fmt.Errorf("only pending or approved payments can be rejected, payment is %s", payment.Status)
+	}
+	read := payment
+
+	levels, err := s.PaymentRepo.GetApprovalLevels()
+	if err != nil {
+		return domainpayments.Payment{}, err
+	}
+	chain := approvalChain(payment, levels)
+	if len(chain) == 0 {
+		return domainpayments.Payment{}, errors.New("payment has no approval chain")
+	}
+
+	// The rejection is recorded against the level being signed, or the last one of an approved payment
+	var level *domainpayments.PaymentApprovalStep
+	allowed := false
+	for i := range chain {
+		if level == nil && chain[i].Approval == nil {
+			level = &chain[i]
+		}
+		ok, err := s.hasPaymentPermission(userId, levelAction(levels, chain[i].Level))
 		if err != nil {
-			return domainpayments.Payment{}, errors.New("invalid payment_date format, use YYYY-MM-DD")
+			return domainpayments.Payment{}, err
 		}
-		payment.PaymentDate = &t
+		allowed = allowed || ok
+	}
+	if !allowed {
+		return domainpayments.Payment{}, errors.New("access denied: only an approver of this payment's approval chain can reject it")
+	}
+	if level == nil {
+		level = &chain[len(chain)-1]
 	}
 
-	payment.UpdatedAt = time.Now()
+	now := time.Now()
+	rejection := domainpayments.PaymentApproval{
+		Id:        utils.CreateUUID(),
+		PaymentID: payment.Id,
+		Round:     payment.ApprovalRound,
+		Level:     level.Level,
+		LevelName: level.Name,
+		Decision:  utils.ApprovalRejected,
+		Comment:   req.Reason,
+		CreatedAt: now,
+		CreatedBy: userId,
+	}
+	payment.Status = utils.PaymentRejected
+	payment.RejectionReason = req.Reason
+	payment.UpdatedAt = now
+	payment.UpdatedBy = userId
 
-	if err := s.PaymentRepo.UpdatePayment(payment); err != nil {
+	err = s.UoW.Do(func(repos interfaceuow.Repositories) error {
+		if err := lockUnchanged(repos, read); err != nil {
+			return err
+		}
+		if err := repos.Payment.CreatePaymentApproval(rejection); err != nil {
+			return err
+		}
+		return repos.Payment.UpdatePayment(payment)
+	})
+	if err != nil {
 		return domainpayments.Payment{}, err
 	}
 
-	return payment, nil
+	return s.GetPaymentByID(id)
+}
+
+// lockUnchanged takes the payment row lock for an approval decision and makes sure the payment
+// has not changed since it was read for the decision, e.g. by another approver
+func lockUnchanged(repos interfaceuow.Repositories, read domainpayments.Payment) error {
+	locked, err := repos.Payment.LockPaymentForUpdate(read.Id)
+	if err != nil {
+		return err
+	}
+	if locked.Status != read.Status || locked.ApprovalRound != read.ApprovalRound || !locked.UpdatedAt.Equal(read.UpdatedAt) {
+		return errors.New("payment was changed meanwhile, please reload and try again")
+	}
+	return nil
+}
+
+func (s *ServicePayment) GetApprovalLevels() ([]domainpayments.PaymentApprovalLevel, error) {
+	return s.PaymentRepo.GetApprovalLevels()
+}
+
+// SetApprovalLevels replaces the approval chain. Payments still waiting for approval are checked
+// against the new chain; approvals already given keep counting for the levels they signed.
+func (s *ServicePayment) SetApprovalLevels(userId string, req dto.SetPaymentApprovalLevelsRequest) ([]domainpayments.PaymentApprovalLevel, error) {
+	permissions, err := s.PermissionRepo.GetByResource("payment")
+	if err != nil {
+		return nil, err
+	}
+	actions := make(map[string]bool, len(permissions))
+	for _, p := range permissions {
+		actions[p.Action] = true
+	}
+
+	now := time.Now()
+	levels := make([]domainpayments.PaymentApprovalLevel, 0, len(req.Levels))
+	for i, l := range req.Levels {
+		minAmount := decimal.NewFromFloat(l.MinAmount).Round(2)
+		if i == 0 && !minAmount.IsZero() {
+			return nil, errors.New("the first approval level must start at min_amount 0")
+		}
+		if i > 0 && !minAmount.GreaterThan(levels[i-1].MinAmount) {
+			return nil, errors.New("approval levels must be ordered by increasing min_amount")
+		}
+		if !actions[l.PermissionAction] {
+			return nil, fmt.Errorf("unknown payment permission %s", l.PermissionAction)
+		}
+		levels = append(levels, domainpayments.PaymentApprovalLevel{
+			Id:               utils.CreateUUID(),
+			Level:            i + 1,
+			Name:             l.Name,
+			MinAmount:        minAmount,
+			PermissionAction: l.PermissionAction,
+			CreatedAt:        now,
+			UpdatedAt:        now,
+			UpdatedBy:        &userId,
+		})
+	}
+
+	if err := s.PaymentRepo.ReplaceApprovalLevels(levels); err != nil {
+		return nil, err
+	}
+	return levels, nil
+}
+
+// approvalChain lists the levels the payment's amount requires with the approvals of its current round
+func approvalChain(payment domainpayments.Payment, levels []domainpayments.PaymentApprovalLevel) []domainpayments.PaymentApprovalStep {
+	chain := []domainpayments.PaymentApprovalStep{}
+	for _, level := range levels {
+		if !level.MinAmount.IsZero() && !payment.Amount.GreaterThan(level.MinAmount) {
+			continue
+		}
+		step := domainpayments.PaymentApprovalStep{Level: level.Level, Name: level.Name, MinAmount: level.MinAmount}
+		for i, a := range payment.Approvals {
+			if a.Round == payment.ApprovalRound && a.Level == level.Level && a.Decision == utils.ApprovalApproved {
+				step.Approval = &payment.Approvals[i]
+			}
+		}
+		chain = append(chain, step)
+	}
+	return chain
+}
+
+func levelAction(levels []domainpayments.PaymentApprovalLevel, level int) string {
+	for _, l := range levels {
+		if l.Level == level {
+			return l.PermissionAction
+		}
+	}
+	return ""
+}
+
+func (s *ServicePayment) hasPaymentPermission(userId, action string) (bool, error) {
+	permissions, err := s.PermissionRepo.GetUserPermissions(userId)
+	if err != nil {
+		return false, err
+	}
+	for _, p := range permissions {
+		if p.Resource == "payment" && p.Action == action {
+			return true, nil
+		}
+	}
+	return false, nil
 }
 
-func (s *ServicePayment) DeletePayment(id string) error {
+func (s *ServicePayment) DeletePayment(id, userId string) error {
 	_, err := s.PaymentRepo.GetPaymentByID(id)
 	if err != nil {
 		return err
 	}
 
-	return s.PaymentRepo.DeletePayment(id)
+	return s.PaymentRepo.DeletePayment(id, userId)
 }
 
 func (s *ServicePayment) UploadPaymentFile(ctx context.Context, paymentId string, userId string, fileHeader *multipart.FileHeader, req dto.UploadPaymentFileRequest) (domainpayments.PaymentFile, error) {
@@ -217,4 +815,1073 @@ func (s *ServicePayment) DeletePaymentFile(ctx context.Context, fileId string) e
 	return err
 }
 
+// applyTax computes the payment's tax breakdown from the tax rule of the vendor's type and tax
+// status, discarding any override. Payments from an invoice keep the invoice's DPP and PPN; other
+// amounts are taken to include PPN when the vendor is PKP.
+func (s *ServicePayment) applyTax(payment *domainpayments.Payment) error {
+	vendor, err := s.VendorRepo.GetVendorByID(payment.VendorID)
+	if err != nil {
+		return errors.New("vendor not found")
+	}
+	// Without a profile the vendor is treated as non-PKP without an NPWP
+	profile, _ := s.VendorRepo.GetVendorProfileByVendorID(vendor.Id)
+
+	vendorType := vendor.VendorType
+	if vendorType == "" {
+		vendorType = "company"
+	}
+	taxStatus := utils.TaxStatusNonPKP
+	if strings.EqualFold(strings.TrimSpace(profile.TaxStatus), utils.TaxStatusPKP) {
+		taxStatus = utils.TaxStatusPKP
+	}
+
+	rule, err := s.PaymentRepo.GetTaxRule(vendorType, taxStatus)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("no tax rule is configured for %s vendors with tax status %s", vendorType, taxStatus)
+		}
+		return err
+	}
+
+	hundred := decimal.NewFromInt(100)
+	base, vat := payment.Amount, decimal.Zero
+	fromInvoice := false
+	if payment.InvoiceID != nil {
+		invoice, err := s.PaymentRepo.GetInvoiceByID(*payment.InvoiceID)
+		if err == nil && invoice.Amount.Equal(payment.Amount) {
+			base, vat = invoice.Subtotal, invoice.VatAmount
+			fromInvoice = true
+		}
+	}
+	if !fromInvoice && rule.VatRate.IsPositive() {
+		base = payment.Amount.Mul(hundred).Div(hundred.Add(rule.VatRate)).Round(2)
+		vat = payment.Amount.Sub(base)
+	}
+
+	withholdingRate := rule.WithholdingRate
+	if strings.TrimSpace(profile.NpwpNumber) == "" {
+		withholdingRate = rule.NoNpwpWithholdingRate
+	}
+	if rule.WithholdingType == utils.WithholdingNone {
+		withholdingRate = decimal.Zero
+	}
+
+	setTaxBreakdown(payment, base, vat, rule.VatRate, rule.WithholdingType, withholdingRate)
+	payment.TaxOverriddenBy = nil
+	payment.TaxOverrideReason = ""
+	return nil
+}
+
+// setTaxBreakdown withholds the rate from the tax base and leaves the rest of the gross amount as net
+func setTaxBreakdown(payment *domainpayments.Payment, base, vat, vatRate decimal.Decimal, withholdingType string, withholdingRate decimal.Decimal) {
+	withholding := base.Mul(withholdingRate).Div(decimal.NewFromInt(100)).Round(2)
+	net := payment.Amount.Sub(withholding)
+
+	payment.TaxBase = &base
+	payment.VatRate = &vatRate
+	payment.VatAmount = &vat
+	payment.WithholdingType = withholdingType
+	payment.WithholdingRate = &withholdingRate
+	payment.WithholdingAmount = &withholding
+	payment.NetAmount = &net
+}
+
+// OverridePaymentTax replaces the computed tax breakdown, e.g. for a vendor with a withholding
+// exemption. It is only possible before approval; a pending payment restarts its approval round.
+func (s *ServicePayment) OverridePaymentTax(id, userId string, req dto.OverridePaymentTaxRequest) (domainpayments.Payment, error) {
+	base := decimal.NewFromFloat(req.TaxBase).Round(2)
+	vat := decimal.NewFromFloat(req.VatAmount).Round(2)
+	if base.IsZero() {
+		return domainpayments.Payment{}, errors.New("tax_base must be at least 0.01")
+	}
+	withholdingRate := decimal.NewFromFloat(req.WithholdingRate).Round(2)
+	if req.WithholdingType == utils.WithholdingNone {
+		withholdingRate = decimal.Zero
+	}
+
+	err := s.UoW.Do(func(repos interfaceuow.Repositories) error {
+		payment, err := repos.Payment.LockPaymentForUpdate(id)
+		if err != nil {
+			return err
+		}
+		switch payment.Status {
+		case utils.PaymentScheduled, utils.PaymentRejected:
+		case utils.PaymentPending:
+			restartApproval(&payment)
+		default:
+			return fmt.Errorf("tax can only be overridden before the payment is approved, payment is %s", payment.Status)
+		}
+
+		if !base.Add(vat).Equal(payment.Amount) {
+			return fmt.Errorf("tax_base plus vat_amount must equal the payment amount %s", payment.Amount.StringFixed(2))
+		}
+		vatRate := vat.Mul(decimal.NewFromInt(100)).Div(base).Round(2)
+
+		setTaxBreakdown(&payment, base, vat, vatRate, req.WithholdingType, withholdingRate)
+		payment.TaxOverriddenBy = &userId
+		payment.TaxOverrideReason = req.Reason
+		payment.UpdatedAt = time.Now()
+		payment.UpdatedBy = userId
+		return repos.Payment.UpdatePayment(payment)
+	})
+	if err != nil {
+		return domainpayments.Payment{}, err
+	}
+	return s.GetPaymentByID(id)
+}
+
+func (s *ServicePayment) GetTaxRules() ([]domainpayments.TaxRule, error) {
+	return s.PaymentRepo.GetTaxRules()
+}
+
+// SetTaxRules updates the rules of the given vendor type and tax status combinations; payments
+// keep the breakdown they were computed with
+func (s *ServicePayment) SetTaxRules(userId string, req dto.SetTaxRulesRequest) ([]domainpayments.TaxRule, error) {
+	now := time.Now()
+	seen := make(map[string]bool, len(req.Rules))
+	rules := make([]domainpayments.TaxRule, 0, len(req.Rules))
+	for _, r := range req.Rules {
+		key := r.VendorType + "/" + r.TaxStatus
+		if seen[key] {
+			return nil, fmt.Errorf("tax rule for %s vendors with tax status %s is given twice", r.VendorType, r.TaxStatus)
+		}
+		seen[key] = true
+
+		rules = append(rules, domainpayments.TaxRule{
+			Id:                    utils.CreateUUID(),
+			VendorType:            r.VendorType,
+			TaxStatus:             r.TaxStatus,
+			VatRate:               decimal.NewFromFloat(r.VatRate).Round(2),
+			WithholdingType:       r.WithholdingType,
+			WithholdingRate:       decimal.NewFromFloat(r.WithholdingRate).Round(2),
+			NoNpwpWithholdingRate: decimal.NewFromFloat(r.NoNpwpWithholdingRate).Round(2),
+			CreatedAt:             now,
+			UpdatedAt:             now,
+			UpdatedBy:             &userId,
+		})
+	}
+
+	if err := s.PaymentRepo.SaveTaxRules(rules); err != nil {
+		return nil, err
+	}
+	return s.PaymentRepo.GetTaxRules()
+}
+
+// GetWithholdingSlips lists the withholding slips (bukti potong) of a month, given as YYYY-MM;
+// an empty vendorId lists every vendor
+func (s *ServicePayment) GetWithholdingSlips(vendorId, period string) ([]domainpayments.WithholdingSlip, error) {
+	from, err := time.Parse("2006-01", period)
+	if err != nil {
+		return nil, errors.New("invalid period format, use YYYY-MM")
+	}
+	return s.PaymentRepo.GetWithholdingSlips(vendorId, from, from.AddDate(0, 1, 0))
+}
+
+// CreateDisbursementBatch generates a bulk transfer file of approved payments to the bank accounts of
+// their vendors' profiles, paying the net amount after withholding. The file is stored with its
+// checksum and the payments move to processing, so they cannot be included in another batch.
+func (s *ServicePayment) CreateDisbursementBatch(ctx context.Context, userId string, req dto.CreateDisbursementBatchRequest) (domainpayments.DisbursementBatch, error) {
+	format, ok := disbursement.Get(req.Format)
+	if !ok {
+		return domainpayments.DisbursementBatch{}, fmt.Errorf("unknown disbursement format %s, use one of %s", req.Format, strings.Join(disbursement.Names(), ", "))
+	}
+
+	seen := make(map[string]bool, len(req.PaymentIDs))
+	ids := make([]string, 0, len(req.PaymentIDs))
+	for _, id := range req.PaymentIDs {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	payments, err := s.PaymentRepo.GetPaymentsByIDs(ids)
+	if err != nil {
+		return domainpayments.DisbursementBatch{}, err
+	}
+	found := make(map[string]bool, len(payments))
+	for _, p := range payments {
+		found[p.Id] = true
+	}
+	for _, id := range ids {
+		if !found[id] {
+			return domainpayments.DisbursementBatch{}, fmt.Errorf("payment %s not found", id)
+		}
+	}
+
+	now := time.Now()
+	batchId := utils.CreateUUID()
+	batch := disbursement.Batch{
+		Number:    fmt.Sprintf("DISB-%s-%s", now.Format("20060102"), strings.ToUpper(batchId[:8])),
+		CreatedAt: now,
+	}
+	for _, p := range payments {
+		if p.Status != utils.PaymentApproved || p.DisbursementBatchID != nil {
+			return domainpayments.DisbursementBatch{}, fmt.Errorf("payment %s is %s, only approved payments can be disbursed", p.InvoiceNumber, p.Status)
+		}
+		if p.Vendor == nil || p.Vendor.Profile == nil {
+			return domainpayments.DisbursementBatch{}, fmt.Errorf("vendor of payment %s has no profile", p.InvoiceNumber)
+		}
+		profile := p.Vendor.Profile
+		if strings.TrimSpace(profile.BankName) == "" || strings.TrimSpace(profile.AccountNumber) == "" || strings.TrimSpace(profile.AccountHolderName) == "" {
+			return domainpayments.DisbursementBatch{}, fmt.Errorf("vendor %s has no complete bank account for payment %s", profile.VendorName, p.InvoiceNumber)
+		}
+
+		description := p.Description
+		if description == "" {
+			description = "Payment " + p.InvoiceNumber
+		}
+		batch.Transfers = append(batch.Transfers, disbursement.Transfer{
+			Reference:     p.InvoiceNumber,
+			Beneficiary:   strings.TrimSpace(profile.AccountHolderName),
+			BankName:      strings.TrimSpace(profile.BankName),
+			AccountNumber: strings.TrimSpace(profile.AccountNumber),
+			Amount:        transferAmount(p),
+			Description:   description,
+		})
+	}
+
+	data, err := format.Render(batch)
+	if err != nil {
+		return domainpayments.DisbursementBatch{}, err
+	}
+	sum := sha256.Sum256(data)
+
+	fileName := batch.Number + "." + format.Extension()
+	fileUrl, err := s.StorageProvider.UploadFileFromBytes(ctx, data, fileName, "disbursement-files", format.ContentType())
+	if err != nil {
+		return domainpayments.DisbursementBatch{}, fmt.Errorf("failed to upload disbursement file to storage: %w", err)
+	}
+
+	record := domainpayments.DisbursementBatch{
+		Id:           batchId,
+		BatchNumber:  batch.Number,
+		Format:       format.Name(),
+		FileName:     fileName,
+		FileUrl:      fileUrl,
+		Checksum:     hex.EncodeToString(sum[:]),
+		TotalAmount:  batch.Total(),
+		PaymentCount: len(batch.Transfers),
+		CreatedAt:    now,
+		CreatedBy:    userId,
+	}
+
+	err = s.UoW.Do(func(repos interfaceuow.Repositories) error {
+		if err := repos.Payment.CreateDisbursementBatch(record); err != nil {
+			return err
+		}
+		moved, err := repos.Payment.MarkPaymentsProcessing(ids, batchId, userId)
+		if err != nil {
+			return err
+		}
+		if moved != int64(len(ids)) {
+			return errors.New("some payments were disbursed or changed meanwhile, please retry")
+		}
+		return nil
+	})
+	if err != nil {
+		_ = s.StorageProvider.DeleteFile(ctx, fileUrl)
+		return domainpayments.DisbursementBatch{}, err
+	}
+
+	return s.PaymentRepo.GetDisbursementBatchByID(batchId)
+}
+
+func (s *ServicePayment) GetDisbursementBatchByID(id string) (domainpayments.DisbursementBatch, error) {
+	return s.PaymentRepo.GetDisbursementBatchByID(id)
+}
+
+func (s *ServicePayment) GetAllDisbursementBatches(params filter.BaseParams) ([]domainpayments.DisbursementBatch, int64, error) {
+	return s.PaymentRepo.GetAllDisbursementBatches(params)
+}
+
+// DownloadDisbursementFile returns the batch's bulk transfer file after checking it against the stored checksum
+func (s *ServicePayment) DownloadDisbursementFile(ctx context.Context, id string) ([]byte, domainpayments.DisbursementBatch, error) {
+	batch, err := s.PaymentRepo.GetDisbursementBatchByID(id)
+	if err != nil {
+		return nil, domainpayments.DisbursementBatch{}, err
+	}
+
+	reader, err := s.StorageProvider.DownloadFileByURL(ctx, batch.FileUrl)
+	if err != nil {
+		return nil, domainpayments.DisbursementBatch{}, err
+	}
+	defer reader.Close()
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, domainpayments.DisbursementBatch{}, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	sum := sha256.Sum256(data)
+	if hex.EncodeToString(sum[:]) != batch.Checksum {
+		return nil, domainpayments.DisbursementBatch{}, errors.New("disbursement file does not match its checksum")
+	}
+
+	return data, batch, nil
+}
+
+// transferAmount is what reaches the vendor's account: the net amount after withholding once the tax is computed
+func transferAmount(payment domainpayments.Payment) decimal.Decimal {
+	if payment.NetAmount != nil {
+		return *payment.NetAmount
+	}
+	return payment.Amount
+}
+
+// ImportBankStatement stores a bank statement and reconciles its debits against the outstanding
+// payments. A debit is matched when exactly one payment with the same outstanding balance, dated
+// within the reconciliation window, has its invoice number or its vendor's account number in the
+// entry's text; the debit settles that payment on the statement date. Debits with other candidates go to the review
+// queue with those candidates as suggestions, and debits without any are reported as unmatched.
+func (s *ServicePayment) ImportBankStatement(ctx context.Context, userId string, req dto.ImportBankStatementRequest, fileHeader *multipart.FileHeader) (domainpayments.ReconciliationReport, error) {
+	parser, ok := bankstatement.Get(req.Format)
+	if !ok {
+		return domainpayments.ReconciliationReport{}, fmt.Errorf("unknown bank statement format %s, use one of %s", req.Format, strings.Join(bankstatement.Names(), ", "))
+	}
+
+	maxFileSize := utils.GetEnv("MAX_BANK_STATEMENT_SIZE", 5).(int)
+	if err := utils.ValidateFileSize(fileHeader, maxFileSize); err != nil {
+		return domainpayments.ReconciliationReport{}, err
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		return domainpayments.ReconciliationReport{}, fmt.Errorf("failed to open file %s: %w", fileHeader.Filename, err)
+	}
+	defer file.Close()
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return domainpayments.ReconciliationReport{}, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	sum := sha256.Sum256(data)
+	checksum := hex.EncodeToString(sum[:])
+	exists, err := s.PaymentRepo.BankStatementExists(checksum)
+	if err != nil {
+		return domainpayments.ReconciliationReport{}, err
+	}
+	if exists {
+		return domainpayments.ReconciliationReport{}, errors.New("bank statement already exists")
+	}
+
+	entries, err := parser.Parse(data)
+	if err != nil {
+		return domainpayments.ReconciliationReport{}, err
+	}
+
+	payments, err := s.PaymentRepo.GetOutstandingPayments()
+	if err != nil {
+		return domainpayments.ReconciliationReport{}, err
+	}
+	window := time.Duration(utils.GetEnv("RECONCILIATION_DATE_WINDOW_DAYS", 7).(int)) * 24 * time.Hour
+
+	now := time.Now()
+	statement := domainpayments.BankStatement{
+		Id:        utils.CreateUUID(),
+		FileName:  fileHeader.Filename,
+		Format:    parser.Name(),
+		Checksum:  checksum,
+		LineCount: len(entries),
+		CreatedAt: now,
+		CreatedBy: userId,
+	}
+
+	claimed := make(map[string]bool)
+	var lines []domainpayments.BankStatementLine
+	var suggestions []domainpayments.BankStatementSuggestion
+	var matched []domainpayments.Payment
+	var settlements []domainpayments.PaymentSettlement
+	for i, entry := range entries {
+		entryDate := entry.Date
+		if statement.PeriodFrom == nil || entryDate.Before(*statement.PeriodFrom) {
+			statement.PeriodFrom = &entryDate
+		}
+		if statement.PeriodTo == nil || entryDate.After(*statement.PeriodTo) {
+			statement.PeriodTo = &entryDate
+		}
+
+		line := domainpayments.BankStatementLine{
+			Id:          utils.CreateUUID(),
+			StatementID: statement.Id,
+			LineNo:      i + 1,
+			EntryDate:   entry.Date,
+			Direction:   "credit",
+			Amount:      entry.Amount,
+			Description: entry.Description,
+			Reference:   entry.Reference,
+			Status:      utils.StatementLineIgnored,
+		}
+		if !entry.Debit {
+			lines = append(lines, line)
+			continue
+		}
+		line.Direction = "debit"
+
+		match, candidates := matchStatementEntry(entry, payments, claimed, window)
+		switch {
+		case match != nil:
+			claimed[match.Id] = true
+			line.Status = utils.StatementLineMatched
+			line.PaymentID = &match.Id
+
+			matched = append(matched, *match)
+			settlements = append(settlements, statementSettlement(line, userId, now))
+		case len(candidates) > 0:
+			line.Status = utils.StatementLineReview
+			for _, c := range candidates {
+				suggestions = append(suggestions, domainpayments.BankStatementSuggestion{
+					Id:        utils.CreateUUID(),
+					LineID:    line.Id,
+					PaymentID: c.payment.Id,
+					Reason:    c.reason,
+				})
+			}
+		default:
+			line.Status = utils.StatementLineUnmatched
+		}
+		lines = append(lines, line)
+	}
+
+	contentType := fileHeader.Header.Get("Content-Type")
+	if contentType == "" {
+		contentType = "text/plain"
+	}
+	statement.FileUrl, err = s.StorageProvider.UploadFileFromBytes(ctx, data, fileHeader.Filename, "bank-statements", contentType)
+	if err != nil {
+		return domainpayments.ReconciliationReport{}, fmt.Errorf("failed to upload file %s to storage: %w", fileHeader.Filename, err)
+	}
+
+	err = s.UoW.Do(func(repos interfaceuow.Repositories) error {
+		if err := repos.Payment.CreateBankStatement(statement); err != nil {
+			return err
+		}
+		if err := repos.Payment.CreateBankStatementLines(lines); err != nil {
+			return err
+		}
+		if err := repos.Payment.CreateBankStatementSuggestions(suggestions); err != nil {
+			return err
+		}
+		for i, payment := range matched {
+			if err := settle(repos, payment, settlements[i], userId); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		_ = s.StorageProvider.DeleteFile(ctx, statement.FileUrl)
+		return domainpayments.ReconciliationReport{}, err
+	}
+
+	return s.GetReconciliationReport(statement.Id)
+}
+
+// statementSettlement is the settlement of the payment a debit line is matched to
+func statementSettlement(line domainpayments.BankStatementLine, userId string, now time.Time) domainpayments.PaymentSettlement {
+	return domainpayments.PaymentSettlement{
+		Id:              utils.CreateUUID(),
+		PaymentID:       *line.PaymentID,
+		Amount:          line.Amount,
+		SettlementDate:  line.EntryDate,
+		Reference:       line.Reference,
+		StatementLineID: &line.Id,
+		Note:            line.Description,
+		CreatedAt:       now,
+		CreatedBy:       userId,
+	}
+}
+
+type statementCandidate struct {
+	payment domainpayments.Payment
+	reason  string
+}
+
+// matchStatementEntry returns the payment the debit is confidently for, or else the candidates to
+// review: the payments matching on text, or all those matching on amount and date when none does
+func matchStatementEntry(entry bankstatement.Entry, payments []domainpayments.Payment, claimed map[string]bool, window time.Duration) (*domainpayments.Payment, []statementCandidate) {
+	text := normalizeReference(entry.Description + " " + entry.Reference)
+
+	var candidates, strong []statementCandidate
+	for i, p := range payments {
+		if claimed[p.Id] || !transferAmount(p).Sub(p.PaidAmount).Equal(entry.Amount) || !withinWindow(p, entry.Date, window) {
+			continue
+		}
+
+		candidate := statementCandidate{payment: payments[i], reason: utils.MatchAmountDate}
+		invoice := normalizeReference(p.InvoiceNumber)
+		account := ""
+		if p.Vendor != nil && p.Vendor.Profile != nil {
+			account = normalizeReference(p.Vendor.Profile.AccountNumber)
+		}
+		switch {
+		case len(invoice) >= 3 && strings.Contains(text, invoice):
+			candidate.reason = utils.MatchInvoiceNumber
+		case len(account) >= 5 && strings.Contains(text, account):
+			candidate.reason = utils.MatchVendorAccount
+		}
+
+		candidates = append(candidates, candidate)
+		if candidate.reason != utils.MatchAmountDate {
+			strong = append(strong, candidate)
+		}
+	}
+
+	switch {
+	case len(strong) == 1:
+		return &strong[0].payment, nil
+	case len(strong) > 1:
+		return nil, strong
+	default:
+		return nil, candidates
+	}
+}
+
+// withinWindow reports whether the date is close to when the payment was planned, due, approved or batched
+func withinWindow(payment domainpayments.Payment, date time.Time, window time.Duration) bool {
+	dates := []time.Time{payment.UpdatedAt}
+	if payment.PaymentDate != nil {
+		dates = append(dates, *payment.PaymentDate)
+	}
+	if payment.DueDate != nil {
+		dates = append(dates, *payment.DueDate)
+	}
+
+	day := date.Format("2006-01-02")
+	for _, d := range dates {
+		from := d.Add(-window).Format("2006-01-02")
+		to := d.Add(window).Format("2006-01-02")
+		if day >= from && day <= to {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeReference keeps only the letters and digits of the text, in upper case, so references
+// match however the bank punctuated them
+func normalizeReference(text string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		}
+		return -1
+	}, text)
+}
+
+func (s *ServicePayment) GetAllBankStatements(params filter.BaseParams) ([]domainpayments.BankStatement, int64, error) {
+	return s.PaymentRepo.GetAllBankStatements(params)
+}
+
+// GetReconciliationReport returns the statement with its debit lines split by reconciliation status
+func (s *ServicePayment) GetReconciliationReport(id string) (domainpayments.ReconciliationReport, error) {
+	statement, err := s.PaymentRepo.GetBankStatementByID(id)
+	if err != nil {
+		return domainpayments.ReconciliationReport{}, err
+	}
+
+	report := domainpayments.ReconciliationReport{
+		Matched:   []domainpayments.BankStatementLine{},
+		Review:    []domainpayments.BankStatementLine{},
+		Unmatched: []domainpayments.BankStatementLine{},
+		Dismissed: []domainpayments.BankStatementLine{},
+	}
+	for _, line := range statement.Lines {
+		switch line.Status {
+		case utils.StatementLineMatched:
+			report.Matched = append(report.Matched, line)
+		case utils.StatementLineReview:
+			report.Review = append(report.Review, line)
+		case utils.StatementLineUnmatched:
+			report.Unmatched = append(report.Unmatched, line)
+		case utils.StatementLineDismissed:
+			report.Dismissed = append(report.Dismissed, line)
+		}
+	}
+	statement.Lines = nil
+	report.Statement = statement
+
+	return report, nil
+}
+
+// GetReconciliationQueue lists the debit lines of every statement waiting for review, or the unmatched ones
+func (s *ServicePayment) GetReconciliationQueue(status string) ([]domainpayments.BankStatementLine, error) {
+	if status == "" {
+		status = utils.StatementLineReview
+	}
+	if status != utils.StatementLineReview && status != utils.StatementLineUnmatched {
+		return nil, fmt.Errorf("invalid status %s, use review or unmatched", status)
+	}
+	return s.PaymentRepo.GetBankStatementLinesByStatus(status)
+}
+
+// MatchStatementLine resolves a debit under review or unmatched by hand. The debit settles the payment
+// on the statement date, in full or as an installment, so it cannot exceed the outstanding balance.
+func (s *ServicePayment) MatchStatementLine(id, userId string, req dto.MatchStatementLineRequest) (domainpayments.BankStatementLine, error) {
+	line, err := s.PaymentRepo.GetBankStatementLineByID(id)
+	if err != nil {
+		return domainpayments.BankStatementLine{}, err
+	}
+	if line.Status != utils.StatementLineReview && line.Status != utils.StatementLineUnmatched {
+		return domainpayments.BankStatementLine{}, fmt.Errorf("only lines under review or unmatched can be matched, line is %s", line.Status)
+	}
+
+	payment, err := s.PaymentRepo.GetPaymentByID(req.PaymentID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domainpayments.BankStatementLine{}, errors.New("payment not found")
+		}
+		return domainpayments.BankStatementLine{}, err
+	}
+	if payment.Status != utils.PaymentApproved && payment.Status != utils.PaymentProcessing && payment.Status != utils.PaymentPartiallyPaid {
+		return domainpayments.BankStatementLine{}, fmt.Errorf("only approved, processing or partially paid payments can be reconciled, payment is %s", payment.Status)
+	}
+	if outstanding := transferAmount(payment).Sub(payment.PaidAmount); line.Amount.GreaterThan(outstanding) {
+		return domainpayments.BankStatementLine{}, fmt.Errorf("statement amount %s exceeds the payment's outstanding balance %s", line.Amount.StringFixed(2), outstanding.StringFixed(2))
+	}
+
+	now := time.Now()
+	line.Status = utils.StatementLineMatched
+	line.PaymentID = &payment.Id
+	line.ResolvedAt = &now
+	line.ResolvedBy = &userId
+	line.ResolutionNote = req.Note
+
+	// Moving the line first keeps a concurrent match of the same debit from settling a second payment
+	err = s.UoW.Do(func(repos interfaceuow.Repositories) error {
+		updated, err := repos.Payment.ResolveBankStatementLine(line)
+		if err != nil {
+			return err
+		}
+		if updated == 0 {
+			return errors.New("statement line was resolved meanwhile, please reload and try again")
+		}
+		return settle(repos, payment, statementSettlement(line, userId, now), userId)
+	})
+	if err != nil {
+		return domainpayments.BankStatementLine{}, err
+	}
+
+	return s.PaymentRepo.GetBankStatementLineByID(id)
+}
+
+// DismissStatementLine takes a debit that is not for any payment out of the queue, with the reason
+func (s *ServicePayment) DismissStatementLine(id, userId string, req dto.DismissStatementLineRequest) (domainpayments.BankStatementLine, error) {
+	line, err := s.PaymentRepo.GetBankStatementLineByID(id)
+	if err != nil {
+		return domainpayments.BankStatementLine{}, err
+	}
+	if line.Status != utils.StatementLineReview && line.Status != utils.StatementLineUnmatched {
+		return domainpayments.BankStatementLine{}, fmt.Errorf("only lines under review or unmatched can be dismissed, line is %s", line.Status)
+	}
+
+	now := time.Now()
+	line.Status = utils.StatementLineDismissed
+	line.ResolvedAt = &now
+	line.ResolvedBy = &userId
+	line.ResolutionNote = req.Note
+
+	updated, err := s.PaymentRepo.ResolveBankStatementLine(line)
+	if err != nil {
+		return domainpayments.BankStatementLine{}, err
+	}
+	if updated == 0 {
+		return domainpayments.BankStatementLine{}, errors.New("statement line was resolved meanwhile, please reload and try again")
+	}
+	return s.PaymentRepo.GetBankStatementLineByID(id)
+}
+
+// SubmitInvoice records a vendor's invoice for verification. An invoice linked to an event must be
+// for an active award of the vendor; charging VAT requires the tax invoice (faktur pajak).
+func (s *ServicePayment) SubmitInvoice(ctx context.Context, vendorId, userId string, req dto.SubmitInvoiceRequest, files dto.InvoiceFileUploads) (domainpayments.Invoice, error) {
+	invoiceDate, err := time.Parse("2006-01-02", req.InvoiceDate)
+	if err != nil {
+		return domainpayments.Invoice{}, errors.New("invalid invoice_date format, use YYYY-MM-DD")
+	}
+	var dueDate *time.Time
+	if req.DueDate != "" {
+		t, err := time.Parse("2006-01-02", req.DueDate)
+		if err != nil {
+			return domainpayments.Invoice{}, errors.New("invalid due_date format, use YYYY-MM-DD")
+		}
+		if t.Before(invoiceDate) {
+			return domainpayments.Invoice{}, errors.New("due_date cannot be before invoice_date")
+		}
+		dueDate = &t
+	}
+
+	subtotal := decimal.NewFromFloat(req.Subtotal).Round(2)
+	vatAmount := decimal.NewFromFloat(req.VatAmount).Round(2)
+	if vatAmount.IsPositive() && (req.TaxInvoiceNumber == "" || files.TaxInvoice == nil) {
+		return domainpayments.Invoice{}, errors.New("tax_invoice_number and tax_invoice_file are required when VAT is charged")
+	}
+	if files.Invoice == nil {
+		return domainpayments.Invoice{}, errors.New("invoice_file is required")
+	}
+
+	exists, err := s.PaymentRepo.InvoiceNumberExists(vendorId, req.InvoiceNumber)
+	if err != nil {
+		return domainpayments.Invoice{}, err
+	}
+	if exists {
+		return domainpayments.Invoice{}, fmt.Errorf("invoice number %s already exists", req.InvoiceNumber)
+	}
+
+	award, err := s.invoiceAward(vendorId, req.EventID, req.AwardID)
+	if err != nil {
+		return domainpayments.Invoice{}, err
+	}
+	var eventId, awardId *string
+	if award != nil {
+		eventId, awardId = &award.EventID, &award.Id
+		if _, err := s.awardInvoiceSlot(s.PaymentRepo, *award, "", subtotal.Add(vatAmount)); err != nil {
+			return domainpayments.Invoice{}, err
+		}
+	}
+
+	uploads := []struct {
+		fileType string
+		header   *multipart.FileHeader
+	}{
+		{utils.InvoiceFileInvoice, files.Invoice},
+		{utils.InvoiceFileTaxInvoice, files.TaxInvoice},
+		{utils.InvoiceFileDeliveryProof, files.DeliveryProof},
+	}
+	maxFileSize := utils.GetEnv("MAX_PHOTO_SIZE_PAYMENT", 2).(int)
+	for _, u := range uploads {
+		if u.header == nil {
+			continue
+		}
+		if err := utils.ValidateFileSize(u.header, maxFileSize); err != nil {
+			return domainpayments.Invoice{}, err
+		}
+	}
+
+	now := time.Now()
+	invoice := domainpayments.Invoice{
+		Id:               utils.CreateUUID(),
+		InvoiceNumber:    req.InvoiceNumber,
+		VendorID:         vendorId,
+		EventID:          eventId,
+		AwardID:          awardId,
+		InvoiceDate:      invoiceDate,
+		DueDate:          dueDate,
+		Subtotal:         subtotal,
+		VatAmount:        vatAmount,
+		Amount:           subtotal.Add(vatAmount),
+		TaxInvoiceNumber: req.TaxInvoiceNumber,
+		Description:      req.Description,
+		Status:           utils.InvoiceSubmitted,
+		CreatedAt:        now,
+		CreatedBy:        userId,
+		UpdatedAt:        now,
+		UpdatedBy:        userId,
+	}
+
+	for _, u := range uploads {
+		if u.header == nil {
+			continue
+		}
+		fileUrl, err := s.uploadInvoiceFile(ctx, u.header)
+		if err != nil {
+			s.cleanupInvoiceFiles(ctx, invoice.File)
+			return domainpayments.Invoice{}, err
+		}
+		invoice.File = append(invoice.File, domainpayments.InvoiceFile{
+			ID:        utils.CreateUUID(),
+			InvoiceId: invoice.Id,
+			FileType:  u.fileType,
+			FileUrl:   fileUrl,
+			CreatedAt: now,
+			CreatedBy: userId,
+		})
+	}
+
+	if err := s.PaymentRepo.CreateInvoice(invoice); err != nil {
+		s.cleanupInvoiceFiles(ctx, invoice.File)
+		return domainpayments.Invoice{}, err
+	}
+
+	return invoice, nil
+}
+
+// invoiceAward resolves the award an invoice is for; an event alone is matched to the vendor's award on it
+func (s *ServicePayment) invoiceAward(vendorId, eventId, awardId string) (*domainevents.EventAward, error) {
+	if awardId != "" {
+		award, err := s.EventRepo.GetAwardByID(awardId)
+		if err != nil {
+			return nil, errors.New("award not found")
+		}
+		if award.VendorID != vendorId {
+			return nil, errors.New("you can only invoice awards granted to you")
+		}
+		if award.RevokedAt != nil {
+			return nil, errors.New("award has been revoked")
+		}
+		if eventId != "" && eventId != award.EventID {
+			return nil, errors.New("award does not belong to the given event")
+		}
+		return &award, nil
+	}
+
+	if eventId == "" {
+		return nil, nil
+	}
+
+	awards, err := s.EventRepo.GetActiveAwardsByEventIDs([]string{eventId})
+	if err != nil {
+		return nil, err
+	}
+	var vendorAwards []domainevents.EventAward
+	for _, award := range awards {
+		if award.VendorID == vendorId {
+			vendorAwards = append(vendorAwards, award)
+		}
+	}
+	switch len(vendorAwards) {
+	case 0:
+		return nil, errors.New("you can only invoice events awarded to you")
+	case 1:
+		return &vendorAwards[0], nil
+	default:
+		return nil, errors.New("award_id is required, you hold several awards on this event")
+	}
+}
+
+// awardInvoiceSlot checks an invoice of the amount against the award, so the award is not paid twice.
+// The award's other invoices and the payments made without an invoice must leave room for the amount.
+// While the award still has scheduled payments without an invoice, the invoice must be for one of
+// them, which is returned for the invoice to settle into.
+func (s *ServicePayment) awardInvoiceSlot(repo interfacepayments.RepoPaymentInterface, award domainevents.EventAward, invoiceId string, amount decimal.Decimal) (*domainpayments.Payment, error) {
+	value := award.AwardedAmount
+	if !value.IsPositive() {
+		event, err := s.EventRepo.GetEventByID(award.EventID)
+		if err != nil {
+			return nil, err
+		}
+		if event.ContractValue != nil {
+			value = *event.ContractValue
+		}
+	}
+
+	invoices, err := repo.GetAwardInvoices(award.Id)
+	if err != nil {
+		return nil, err
+	}
+	payments, err := repo.GetAwardPayments(award.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	billed := decimal.Zero
+	for _, invoice := range invoices {
+		if invoice.Id != invoiceId {
+			billed = billed.Add(invoice.Amount)
+		}
+	}
+	var open []domainpayments.Payment
+	for _, payment := range payments {
+		if payment.InvoiceID != nil {
+			continue // billed through its invoice
+		}
+		switch payment.Status {
+		case utils.PaymentScheduled, utils.PaymentPending, utils.PaymentRejected:
+			open = append(open, payment)
+		default:
+			billed = billed.Add(payment.Amount)
+		}
+	}
+
+	if value.IsPositive() && billed.Add(amount).GreaterThan(value) {
+		balance := decimal.Max(value.Sub(billed), decimal.Zero)
+		return nil, fmt.Errorf("invoice amount %s exceeds the uninvoiced balance of the award, %s", amount.StringFixed(2), balance.StringFixed(2))
+	}
+	if len(open) == 0 {
+		return nil, nil
+	}
+	for i := range open {
+		if open[i].Amount.Equal(amount) {
+			return &open[i], nil
+		}
+	}
+	return nil, fmt.Errorf("invoice amount %s does not match any scheduled payment of the award, invoice one scheduled payment at a time", amount.StringFixed(2))
+}
+
+func (s *ServicePayment) uploadInvoiceFile(ctx context.Context, fileHeader *multipart.FileHeader) (string, error) {
+	file, err := fileHeader.Open()
+	if err != nil {
+		return "", fmt.Errorf("failed to open file %s: %w", fileHeader.Filename, err)
+	}
+	defer file.Close()
+
+	fileUrl, err := s.StorageProvider.UploadFile(ctx, file, fileHeader, "invoice-files")
+	if err != nil {
+		return "", fmt.Errorf("failed to upload file %s to storage: %w", fileHeader.Filename, err)
+	}
+	return fileUrl, nil
+}
+
+func (s *ServicePayment) cleanupInvoiceFiles(ctx context.Context, files []domainpayments.InvoiceFile) {
+	for _, f := range files {
+		_ = s.StorageProvider.DeleteFile(ctx, f.FileUrl)
+	}
+}
+
+func (s *ServicePayment) GetInvoiceByID(id string) (domainpayments.Invoice, error) {
+	return s.PaymentRepo.GetInvoiceByID(id)
+}
+
+func (s *ServicePayment) GetAllInvoices(params filter.BaseParams) ([]domainpayments.Invoice, int64, error) {
+	return s.PaymentRepo.GetAllInvoices(params)
+}
+
+func (s *ServicePayment) VerifyInvoice(id, userId string) (domainpayments.Invoice, error) {
+	invoice, err := s.PaymentRepo.GetInvoiceByID(id)
+	if err != nil {
+		return domainpayments.Invoice{}, err
+	}
+	if invoice.Status != utils.InvoiceSubmitted {
+		return domainpayments.Invoice{}, fmt.Errorf("only submitted invoices can be verified, invoice is %s", invoice.Status)
+	}
+
+	updated, err := s.PaymentRepo.MarkInvoiceVerified(id, userId, time.Now())
+	if err != nil {
+		return domainpayments.Invoice{}, err
+	}
+	if updated == 0 {
+		return domainpayments.Invoice{}, errors.New("invoice was changed meanwhile, please reload and try again")
+	}
+
+	return s.PaymentRepo.GetInvoiceByID(id)
+}
+
+// RejectInvoice returns the invoice to the vendor, who can submit it again under the same number
+func (s *ServicePayment) RejectInvoice(id, userId string, req dto.RejectInvoiceRequest) (domainpayments.Invoice, error) {
+	invoice, err := s.PaymentRepo.GetInvoiceByID(id)
+	if err != nil {
+		return domainpayments.Invoice{}, err
+	}
+	if invoice.Status != utils.InvoiceSubmitted && invoice.Status != utils.InvoiceVerified {
+		return domainpayments.Invoice{}, fmt.Errorf("only submitted or verified invoices can be rejected, invoice is %s", invoice.Status)
+	}
+
+	// Only moves an invoice that is still submitted or verified, so a concurrent conversion is not undone
+	updated, err := s.PaymentRepo.MarkInvoiceRejected(id, req.Reason, userId)
+	if err != nil {
+		return domainpayments.Invoice{}, err
+	}
+	if updated == 0 {
+		return domainpayments.Invoice{}, errors.New("invoice was changed meanwhile, please reload and try again")
+	}
+
+	return s.PaymentRepo.GetInvoiceByID(id)
+}
+
+// ConvertInvoice creates the pending payment of a verified invoice, which then goes through the approval chain.
+// An invoice for one of its award's scheduled payments settles into that payment instead.
+func (s *ServicePayment) ConvertInvoice(id, userId string, req dto.ConvertInvoiceRequest) (domainpayments.Payment, error) {
+	invoice, err := s.PaymentRepo.GetInvoiceByID(id)
+	if err != nil {
+		return domainpayments.Payment{}, err
+	}
+	if invoice.Status != utils.InvoiceVerified {
+		return domainpayments.Payment{}, fmt.Errorf("only verified invoices can be converted into a payment, invoice is %s", invoice.Status)
+	}
+
+	var paymentDate *time.Time
+	if req.PaymentDate != "" {
+		t, err := time.Parse("2006-01-02", req.PaymentDate)
+		if err != nil {
+			return domainpayments.Payment{}, errors.New("invalid payment_date format, use YYYY-MM-DD")
+		}
+		paymentDate = &t
+	}
+	description := req.Description
+	if description == "" {
+		description = invoice.Description
+	}
+
+	now := time.Now()
+	payment := domainpayments.Payment{
+		Id:            utils.CreateUUID(),
+		InvoiceNumber: invoice.InvoiceNumber,
+		VendorID:      invoice.VendorID,
+		Amount:        invoice.Amount,
+		Status:        utils.PaymentPending,
+		PaymentDate:   paymentDate,
+		Description:   description,
+		EventID:       invoice.EventID,
+		AwardID:       invoice.AwardID,
+		DueDate:       invoice.DueDate,
+		InvoiceID:     &invoice.Id,
+		ApprovalRound: 1,
+		CreatedAt:     now,
+		CreatedBy:     userId,
+		UpdatedAt:     now,
+		UpdatedBy:     userId,
+	}
+
+	err = s.UoW.Do(func(repos interfaceuow.Repositories) error {
+		var slot *domainpayments.Payment
+		if invoice.AwardID != nil {
+			award, err := repos.Event.GetAwardByID(*invoice.AwardID)
+			if err != nil {
+				return errors.New("award not found")
+			}
+			// Conversions of the award's invoices wait for each other
+			if _, err := repos.Event.LockEventForUpdate(award.EventID); err != nil {
+				return err
+			}
+			if award.RevokedAt != nil {
+				return errors.New("award has been revoked")
+			}
+			if slot, err = s.awardInvoiceSlot(repos.Payment, award, invoice.Id, invoice.Amount); err != nil {
+				return err
+			}
+		}
+
+		if slot != nil {
+			slot.InvoiceNumber = invoice.InvoiceNumber
+			slot.InvoiceID = &invoice.Id
+			if paymentDate != nil {
+				slot.PaymentDate = paymentDate
+			}
+			if req.Description != "" {
+				slot.Description = req.Description
+			}
+			if invoice.DueDate != nil {
+				slot.DueDate = invoice.DueDate
+			}
+			slot.UpdatedAt = now
+			slot.UpdatedBy = userId
+			payment = *slot
+		}
+
+		// The invoice's own subtotal and VAT make the tax breakdown
+		if err := s.applyTax(&payment); err != nil {
+			return err
+		}
+
+		converted, err := repos.Payment.MarkInvoiceConverted(invoice.Id, payment.Id, userId)
+		if err != nil {
+			return err
+		}
+		if converted != 1 {
+			return errors.New("invoice was changed meanwhile, please reload and try again")
+		}
+
+		if slot != nil {
+			return repos.Payment.UpdatePayment(payment)
+		}
+		return repos.Payment.CreatePayment(payment)
+	})
+	if err != nil {
+		return domainpayments.Payment{}, err
+	}
+
+	return s.GetPaymentByID(payment.Id)
+}
+
 var _ interfacepayments.ServicePaymentInterface = (*ServicePayment)(nil)
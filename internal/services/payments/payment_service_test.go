@@ -0,0 +1,139 @@
+package servicepayments
+
+import (
+	"testing"
+	"time"
+
+	domainpayments "vendor-management-system/internal/domain/payments"
+	domainvendors "vendor-management-system/internal/domain/vendors"
+	"vendor-management-system/pkg/bankstatement"
+	"vendor-management-system/utils"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestMatchStatementEntry(t *testing.T) {
+	day := time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)
+	window := 3 * 24 * time.Hour
+	net := decimal.NewFromInt(980000)
+
+	payment := func(id, invoiceNumber, account string, amount int64) domainpayments.Payment {
+		p := domainpayments.Payment{Id: id, InvoiceNumber: invoiceNumber, Amount: decimal.NewFromInt(amount), UpdatedAt: day}
+		if account != "" {
+			p.Vendor = &domainvendors.Vendor{Profile: &domainvendors.VendorProfile{AccountNumber: account}}
+		}
+		return p
+	}
+	withNet := payment("p-net", "INV-NET", "", 1000000)
+	withNet.NetAmount = &net
+	partial := payment("p-partial", "INV-PART", "", 1000000)
+	partial.PaidAmount = decimal.NewFromInt(400000)
+	dueLater := payment("p-due", "INV-DUE", "", 1000000)
+	dueLater.UpdatedAt = day.AddDate(0, 0, -30)
+	dueDate := day.AddDate(0, 0, 2)
+	dueLater.DueDate = &dueDate
+	stale := payment("p-stale", "INV-STALE", "", 1000000)
+	stale.UpdatedAt = day.AddDate(0, 0, -30)
+
+	tests := []struct {
+		name       string
+		entry      bankstatement.Entry
+		payments   []domainpayments.Payment
+		claimed    map[string]bool
+		wantMatch  string
+		wantReview map[string]string
+	}{
+		{
+			name:      "invoice number in the description",
+			entry:     bankstatement.Entry{Date: day, Amount: decimal.NewFromInt(1000000), Description: "TRF inv/001 PT Maju"},
+			payments:  []domainpayments.Payment{payment("p1", "INV-001", "", 1000000), payment("p2", "INV-002", "", 1000000)},
+			wantMatch: "p1",
+		},
+		{
+			name:      "vendor account in the reference",
+			entry:     bankstatement.Entry{Date: day, Amount: decimal.NewFromInt(1000000), Reference: "TO 123-456-789"},
+			payments:  []domainpayments.Payment{payment("p1", "INV-001", "123456789", 1000000), payment("p2", "INV-002", "987654321", 1000000)},
+			wantMatch: "p1",
+		},
+		{
+			name:       "several text matches go to review",
+			entry:      bankstatement.Entry{Date: day, Amount: decimal.NewFromInt(1000000), Description: "INV-001 INV-002"},
+			payments:   []domainpayments.Payment{payment("p1", "INV-001", "", 1000000), payment("p2", "INV-002", "", 1000000), payment("p3", "INV-003", "", 1000000)},
+			wantReview: map[string]string{"p1": utils.MatchInvoiceNumber, "p2": utils.MatchInvoiceNumber},
+		},
+		{
+			name:       "amount and date only go to review",
+			entry:      bankstatement.Entry{Date: day, Amount: decimal.NewFromInt(1000000), Description: "TRANSFER"},
+			payments:   []domainpayments.Payment{payment("p1", "INV-001", "", 1000000), payment("p2", "INV-002", "", 1000000), payment("p3", "INV-003", "", 500000)},
+			wantReview: map[string]string{"p1": utils.MatchAmountDate, "p2": utils.MatchAmountDate},
+		},
+		{
+			name:      "net amount after withholding",
+			entry:     bankstatement.Entry{Date: day, Amount: net, Description: "INV-NET"},
+			payments:  []domainpayments.Payment{withNet},
+			wantMatch: "p-net",
+		},
+		{
+			name:      "outstanding balance of a partially paid payment",
+			entry:     bankstatement.Entry{Date: day, Amount: decimal.NewFromInt(600000), Description: "INV-PART"},
+			payments:  []domainpayments.Payment{partial},
+			wantMatch: "p-partial",
+		},
+		{
+			name:      "due date within the window",
+			entry:     bankstatement.Entry{Date: day, Amount: decimal.NewFromInt(1000000), Description: "INV-DUE"},
+			payments:  []domainpayments.Payment{dueLater},
+			wantMatch: "p-due",
+		},
+		{
+			name:       "outside the window",
+			entry:      bankstatement.Entry{Date: day, Amount: decimal.NewFromInt(1000000), Description: "INV-STALE"},
+			payments:   []domainpayments.Payment{stale},
+			wantReview: map[string]string{},
+		},
+		{
+			name:      "claimed payments are skipped",
+			entry:     bankstatement.Entry{Date: day, Amount: decimal.NewFromInt(1000000), Description: "INV-001 INV-002"},
+			payments:  []domainpayments.Payment{payment("p1", "INV-001", "", 1000000), payment("p2", "INV-002", "", 1000000)},
+			claimed:   map[string]bool{"p1": true},
+			wantMatch: "p2",
+		},
+		{
+			name:       "short invoice numbers do not match on text",
+			entry:      bankstatement.Entry{Date: day, Amount: decimal.NewFromInt(1000000), Description: "PAYMENT 12"},
+			payments:   []domainpayments.Payment{payment("p1", "12", "", 1000000)},
+			wantReview: map[string]string{"p1": utils.MatchAmountDate},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claimed := tt.claimed
+			if claimed == nil {
+				claimed = map[string]bool{}
+			}
+			match, review := matchStatementEntry(tt.entry, tt.payments, claimed, window)
+
+			if tt.wantMatch != "" {
+				if match == nil || match.Id != tt.wantMatch {
+					t.Fatalf("match = %v, want %s", match, tt.wantMatch)
+				}
+				if len(review) != 0 {
+					t.Errorf("review = %v, want none with a match", review)
+				}
+				return
+			}
+			if match != nil {
+				t.Fatalf("match = %s, want none", match.Id)
+			}
+			if len(review) != len(tt.wantReview) {
+				t.Fatalf("got %d candidates, want %d", len(review), len(tt.wantReview))
+			}
+			for _, c := range review {
+				if reason, ok := tt.wantReview[c.payment.Id]; !ok || reason != c.reason {
+					t.Errorf("candidate %s with reason %s, want %v", c.payment.Id, c.reason, tt.wantReview)
+				}
+			}
+		})
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"time"
 	domainvendors "vendor-management-system/internal/domain/vendors"
 	"vendor-management-system/internal/dto"
+	interfaceuow "vendor-management-system/internal/interfaces/uow"
 	interfacevendors "vendor-management-system/internal/interfaces/vendors"
 	"vendor-management-system/pkg/filter"
 	"vendor-management-system/pkg/storage"
@@ -22,6 +23,7 @@ import (
 type ServiceVendor struct {
 	VendorRepo      interfacevendors.RepoVendorInterface
 	StorageProvider storage.StorageProvider
+	UoW             interfaceuow.UnitOfWorkInterface
 }
 
 var allowedVendorProfileFileTypes = map[string]struct{}{
@@ -37,10 +39,11 @@ var allowedVendorProfileFileTypes = map[string]struct{}{
 	"rekening":  {},
 }
 
-func NewVendorService(vendorRepo interfacevendors.RepoVendorInterface, storageProvider storage.StorageProvider) *ServiceVendor {
+func NewVendorService(vendorRepo interfacevendors.RepoVendorInterface, storageProvider storage.StorageProvider, uow interfaceuow.UnitOfWorkInterface) *ServiceVendor {
 	return &ServiceVendor{
 		VendorRepo:      vendorRepo,
 		StorageProvider: storageProvider,
+		UoW:             uow,
 	}
 }
 
@@ -213,154 +216,167 @@ func (s *ServiceVendor) CreateOrUpdateVendorProfile(userId string, req dto.Vendo
 	defaultPurchGroup := utils.GetEnv("DEFAULT_PURCH_GROUP", "H530").(string)
 	defaultRegionOrSO := utils.GetEnv("DEFAULT_REGION_OR_SO", "HSO NTB").(string)
 
-	vendor, err := s.VendorRepo.GetVendorByUserID(userId)
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			// Create vendor if not exists
-			now := time.Now()
-			vendor = domainvendors.Vendor{
-				Id:         utils.CreateUUID(),
-				UserId:     userId,
-				VendorType: req.VendorType,
-				Status:     utils.VendorPending,
-				CreatedAt:  now,
-				CreatedBy:  userId,
-				UpdatedAt:  now,
-				UpdatedBy:  userId,
-			}
-			if err := s.VendorRepo.CreateVendor(vendor); err != nil {
-				return nil, err
+	// The vendor, its profile and the status change are saved together
+	var vendor domainvendors.Vendor
+	var profile domainvendors.VendorProfile
+	err := s.UoW.Do(func(repos interfaceuow.Repositories) error {
+		repo := repos.Vendor
+
+		var err error
+		vendor, err = repo.GetVendorByUserID(userId)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				// Create vendor if not exists
+				now := time.Now()
+				vendor = domainvendors.Vendor{
+					Id:         utils.CreateUUID(),
+					UserId:     userId,
+					VendorType: req.VendorType,
+					Status:     utils.VendorPending,
+					CreatedAt:  now,
+					CreatedBy:  userId,
+					UpdatedAt:  now,
+					UpdatedBy:  userId,
+				}
+				if err := repo.CreateVendor(vendor); err != nil {
+					return err
+				}
+			} else {
+				return err
 			}
 		} else {
-			return nil, err
-		}
-	} else {
-		// Update vendor_type if provided
-		if req.VendorType != "" && vendor.VendorType != req.VendorType {
-			vendor.VendorType = req.VendorType
-			vendor.UpdatedAt = time.Now()
-			vendor.UpdatedBy = userId
-			if err := s.VendorRepo.UpdateVendor(vendor); err != nil {
-				return nil, err
+			// Update vendor_type if provided
+			if req.VendorType != "" && vendor.VendorType != req.VendorType {
+				vendor.VendorType = req.VendorType
+				vendor.UpdatedAt = time.Now()
+				vendor.UpdatedBy = userId
+				if err := repo.UpdateVendor(vendor); err != nil {
+					return err
+				}
 			}
 		}
-	}
-
-	profile, err := s.VendorRepo.GetVendorProfileByVendorID(vendor.Id)
-	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
-		return nil, err
-	}
 
-	now := time.Now()
-	if profile.Id == "" {
-		purchGroup := strings.TrimSpace(req.PurchGroup)
-		if purchGroup == "" {
-			purchGroup = defaultPurchGroup
-		}
-		regionOrSO := strings.TrimSpace(req.RegionOrSo)
-		if regionOrSO == "" {
-			regionOrSO = defaultRegionOrSO
+		profile, err = repo.GetVendorProfileByVendorID(vendor.Id)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
 		}
 
-		profile = domainvendors.VendorProfile{
-			Id:                utils.CreateUUID(),
-			VendorId:          vendor.Id,
-			VendorName:        req.VendorName,
-			Email:             req.Email,
-			Telephone:         utils.NormalizePhoneTo62(req.Telephone),
-			Fax:               req.Fax,
-			Phone:             utils.NormalizePhoneTo62(req.Phone),
-			DistrictId:        req.DistrictId,
-			DistrictName:      req.DistrictName,
-			CityId:            req.CityId,
-			CityName:          req.CityName,
-			ProvinceId:        req.ProvinceId,
-			ProvinceName:      req.ProvinceName,
-			PostalCode:        req.PostalCode,
-			Address:           req.Address,
-			BusinessField:     req.BusinessField,
-			KTPNumber:         req.KtpNumber,
-			KTPName:           req.KtpName,
-			NpwpNumber:        req.NpwpNumber,
-			NpwpName:          req.NpwpName,
-			NpwpAddress:       req.NpwpAddress,
-			TaxStatus:         req.TaxStatus,
-			NibNumber:         req.NibNumber,
-			BankName:          req.BankName,
-			BankBranch:        req.BankBranch,
-			AccountNumber:     req.AccountNumber,
-			AccountHolderName: req.AccountHolderName,
-			TransactionType:   req.TransactionType,
-			PurchGroup:        purchGroup,
-			RegionOrSo:        regionOrSO,
-			ContactPerson:     req.ContactPerson,
-			ContactEmail:      req.ContactEmail,
-			ContactPhone:      req.ContactPhone,
-			CreatedAt:         now,
-			CreatedBy:         userId,
-			UpdatedAt:         now,
-			UpdatedBy:         userId,
-		}
-		if err := s.VendorRepo.CreateVendorProfile(profile); err != nil {
-			return nil, err
-		}
-	} else {
-		profile.VendorName = req.VendorName
-		profile.Email = req.Email
-		profile.Telephone = utils.NormalizePhoneTo62(req.Telephone)
-		profile.Fax = req.Fax
-		profile.Phone = utils.NormalizePhoneTo62(req.Phone)
-		profile.DistrictId = req.DistrictId
-		profile.DistrictName = req.DistrictName
-		profile.CityId = req.CityId
-		profile.CityName = req.CityName
-		profile.ProvinceId = req.ProvinceId
-		profile.ProvinceName = req.ProvinceName
-		profile.PostalCode = req.PostalCode
-		profile.Address = req.Address
-		profile.BusinessField = req.BusinessField
-		profile.KTPNumber = req.KtpNumber
-		profile.KTPName = req.KtpName
-		profile.NpwpNumber = req.NpwpNumber
-		profile.NpwpName = req.NpwpName
-		profile.NpwpAddress = req.NpwpAddress
-		profile.TaxStatus = req.TaxStatus
-		profile.NibNumber = req.NibNumber
-		profile.BankName = req.BankName
-		profile.BankBranch = req.BankBranch
-		profile.AccountNumber = req.AccountNumber
-		profile.AccountHolderName = req.AccountHolderName
-		profile.TransactionType = req.TransactionType
-		if trimmed := strings.TrimSpace(req.PurchGroup); trimmed != "" {
-			profile.PurchGroup = trimmed
-		} else if strings.TrimSpace(profile.PurchGroup) == "" {
-			profile.PurchGroup = defaultPurchGroup
-		}
-		if trimmed := strings.TrimSpace(req.RegionOrSo); trimmed != "" {
-			profile.RegionOrSo = trimmed
-		} else if strings.TrimSpace(profile.RegionOrSo) == "" {
-			profile.RegionOrSo = defaultRegionOrSO
-		}
-		profile.ContactPerson = req.ContactPerson
-		profile.ContactEmail = req.ContactEmail
-		profile.ContactPhone = req.ContactPhone
-		profile.UpdatedAt = now
-		profile.UpdatedBy = userId
-
-		if err := s.VendorRepo.UpdateVendorProfile(profile); err != nil {
-			return nil, err
+		now := time.Now()
+		if profile.Id == "" {
+			purchGroup := strings.TrimSpace(req.PurchGroup)
+			if purchGroup == "" {
+				purchGroup = defaultPurchGroup
+			}
+			regionOrSO := strings.TrimSpace(req.RegionOrSo)
+			if regionOrSO == "" {
+				regionOrSO = defaultRegionOrSO
+			}
+
+			profile = domainvendors.VendorProfile{
+				Id:                utils.CreateUUID(),
+				VendorId:          vendor.Id,
+				VendorName:        req.VendorName,
+				Email:             req.Email,
+				Telephone:         utils.NormalizePhoneTo62(req.Telephone),
+				Fax:               req.Fax,
+				Phone:             utils.NormalizePhoneTo62(req.Phone),
+				DistrictId:        req.DistrictId,
+				DistrictName:      req.DistrictName,
+				CityId:            req.CityId,
+				CityName:          req.CityName,
+				ProvinceId:        req.ProvinceId,
+				ProvinceName:      req.ProvinceName,
+				PostalCode:        req.PostalCode,
+				Address:           req.Address,
+				BusinessField:     req.BusinessField,
+				KTPNumber:         req.KtpNumber,
+				KTPName:           req.KtpName,
+				NpwpNumber:        req.NpwpNumber,
+				NpwpName:          req.NpwpName,
+				NpwpAddress:       req.NpwpAddress,
+				TaxStatus:         req.TaxStatus,
+				NibNumber:         req.NibNumber,
+				BankName:          req.BankName,
+				BankBranch:        req.BankBranch,
+				AccountNumber:     req.AccountNumber,
+				AccountHolderName: req.AccountHolderName,
+				TransactionType:   req.TransactionType,
+				PurchGroup:        purchGroup,
+				RegionOrSo:        regionOrSO,
+				ContactPerson:     req.ContactPerson,
+				ContactEmail:      req.ContactEmail,
+				ContactPhone:      req.ContactPhone,
+				CreatedAt:         now,
+				CreatedBy:         userId,
+				UpdatedAt:         now,
+				UpdatedBy:         userId,
+			}
+			if err := repo.CreateVendorProfile(profile); err != nil {
+				return err
+			}
+		} else {
+			profile.VendorName = req.VendorName
+			profile.Email = req.Email
+			profile.Telephone = utils.NormalizePhoneTo62(req.Telephone)
+			profile.Fax = req.Fax
+			profile.Phone = utils.NormalizePhoneTo62(req.Phone)
+			profile.DistrictId = req.DistrictId
+			profile.DistrictName = req.DistrictName
+			profile.CityId = req.CityId
+			profile.CityName = req.CityName
+			profile.ProvinceId = req.ProvinceId
+			profile.ProvinceName = req.ProvinceName
+			profile.PostalCode = req.PostalCode
+			profile.Address = req.Address
+			profile.BusinessField = req.BusinessField
+			profile.KTPNumber = req.KtpNumber
+			profile.KTPName = req.KtpName
+			profile.NpwpNumber = req.NpwpNumber
+			profile.NpwpName = req.NpwpName
+			profile.NpwpAddress = req.NpwpAddress
+			profile.TaxStatus = req.TaxStatus
+			profile.NibNumber = req.NibNumber
+			profile.BankName = req.BankName
+			profile.BankBranch = req.BankBranch
+			profile.AccountNumber = req.AccountNumber
+			profile.AccountHolderName = req.AccountHolderName
+			profile.TransactionType = req.TransactionType
+			if trimmed := strings.TrimSpace(req.PurchGroup); trimmed != "" {
+				profile.PurchGroup = trimmed
+			} else if strings.TrimSpace(profile.PurchGroup) == "" {
+				profile.PurchGroup = defaultPurchGroup
+			}
+			if trimmed := strings.TrimSpace(req.RegionOrSo); trimmed != "" {
+				profile.RegionOrSo = trimmed
+			} else if strings.TrimSpace(profile.RegionOrSo) == "" {
+				profile.RegionOrSo = defaultRegionOrSO
+			}
+			profile.ContactPerson = req.ContactPerson
+			profile.ContactEmail = req.ContactEmail
+			profile.ContactPhone = req.ContactPhone
+			profile.UpdatedAt = now
+			profile.UpdatedBy = userId
+
+			if err := repo.UpdateVendorProfile(profile); err != nil {
+				return err
+			}
 		}
-	}
 
-	// When a revision vendor updates their profile, move back to review state and clear reject reason
-	if vendor.Status == utils.VendorRevision {
-		vendor.Status = utils.VendorVerify
-		vendor.RejectReason = nil
-		vendor.UpdatedAt = now
-		vendor.UpdatedBy = userId
-		if err := s.VendorRepo.UpdateVendor(vendor); err != nil {
-			return nil, err
+		// When a revision vendor updates their profile, move back to review state and clear reject reason
+		if vendor.Status == utils.VendorRevision {
+			vendor.Status = utils.VendorVerify
+			vendor.RejectReason = nil
+			vendor.UpdatedAt = now
+			vendor.UpdatedBy = userId
+			if err := repo.UpdateVendor(vendor); err != nil {
+				return err
+			}
 		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return map[string]interface{}{
@@ -449,14 +465,16 @@ func (s *ServiceVendor) DeleteVendor(vendorId string) error {
 		return err
 	}
 
-	profile, err := s.VendorRepo.GetVendorProfileByVendorID(vendor.Id)
-	if err == nil && profile.Id != "" {
-		if err := s.VendorRepo.DeleteVendorProfile(profile.Id); err != nil {
-			return err
+	return s.UoW.Do(func(repos interfaceuow.Repositories) error {
+		profile, err := repos.Vendor.GetVendorProfileByVendorID(vendor.Id)
+		if err == nil && profile.Id != "" {
+			if err := repos.Vendor.DeleteVendorProfile(profile.Id); err != nil {
+				return err
+			}
 		}
-	}
 
-	return s.VendorRepo.DeleteVendor(vendorId)
+		return repos.Vendor.DeleteVendor(vendorId)
+	})
 }
 
 func (s *ServiceVendor) UploadVendorProfileFile(ctx context.Context, profileId string, userId string, fileHeader *multipart.FileHeader, req dto.UploadVendorProfileFileRequest) (domainvendors.VendorProfileFile, error) {
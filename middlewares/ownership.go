@@ -0,0 +1,77 @@
+package middlewares
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"vendor-management-system/pkg/logger"
+	"vendor-management-system/pkg/messages"
+	"vendor-management-system/pkg/response"
+	"vendor-management-system/utils"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// OwnerResolver returns the ids of the users that own the resource addressed by the request
+type OwnerResolver func(ctx *gin.Context) ([]string, error)
+
+// OwnershipMiddleware limits a route to the owners of the addressed resource. It runs after
+// PermissionMiddleware; users with the resource's manage_all permission act on every resource.
+func (m *Middleware) OwnershipMiddleware(resource string, owners OwnerResolver) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		logId := utils.GenerateLogId(ctx)
+		logPrefix := fmt.Sprintf("[%s][OwnershipMiddleware]", logId)
+
+		authData := utils.GetAuthData(ctx)
+		userId := utils.InterfaceString(authData["user_id"])
+		userRole := utils.InterfaceString(authData["role"])
+
+		// Superadmin bypasses all ownership checks
+		if userRole == utils.RoleSuperAdmin {
+			ctx.Next()
+			return
+		}
+
+		permissions, err := m.PermissionRepo.GetUserPermissions(userId)
+		if err != nil {
+			logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Failed to get user permissions: %s", logPrefix, err.Error()))
+			res := response.Response(http.StatusInternalServerError, messages.MsgFail, logId, nil)
+			res.Error = "failed to check permissions"
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, res)
+			return
+		}
+		for _, perm := range permissions {
+			if perm.Resource == resource && perm.Action == "manage_all" {
+				ctx.Next()
+				return
+			}
+		}
+
+		ownerIds, err := owners(ctx)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				res := response.Response(http.StatusNotFound, messages.MsgNotFound, logId, nil)
+				res.Error = response.Errors{Code: http.StatusNotFound, Message: resource + " not found"}
+				ctx.AbortWithStatusJSON(http.StatusNotFound, res)
+				return
+			}
+			logger.WriteLog(logger.LogLevelError, fmt.Sprintf("%s; Failed to resolve %s owners: %s", logPrefix, resource, err.Error()))
+			res := response.Response(http.StatusInternalServerError, messages.MsgFail, logId, nil)
+			res.Error = "failed to check ownership"
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, res)
+			return
+		}
+
+		if !slices.Contains(ownerIds, userId) {
+			logger.WriteLog(logger.LogLevelWarn, fmt.Sprintf("%s; FORBIDDEN: user '%s' (%s) does not own %s '%s'; %s %s; ip %s", logPrefix, userId, userRole, resource, ctx.Param("id"), ctx.Request.Method, ctx.FullPath(), ctx.ClientIP()))
+			res := response.Response(http.StatusForbidden, messages.MsgDenied, logId, nil)
+			res.Error = response.Errors{Code: http.StatusForbidden, Message: fmt.Sprintf("only the owner of this %s can do this", resource)}
+			ctx.AbortWithStatusJSON(http.StatusForbidden, res)
+			return
+		}
+
+		ctx.Next()
+	}
+}
@@ -0,0 +1,21 @@
+-- ================================
+-- Remove event invitations
+-- ================================
+DELETE FROM role_permissions
+WHERE permission_id IN (
+    SELECT id FROM permissions WHERE name = 'manage_event_invitations'
+);
+
+DELETE FROM permissions WHERE name = 'manage_event_invitations';
+
+DROP TABLE IF EXISTS event_invitations;
+
+DROP INDEX IF EXISTS idx_events_participation_mode;
+
+ALTER TABLE events
+DROP COLUMN IF EXISTS eligible_min_rating,
+DROP COLUMN IF EXISTS eligible_active_only,
+DROP COLUMN IF EXISTS eligible_province_id,
+DROP COLUMN IF EXISTS eligible_business_field,
+DROP COLUMN IF EXISTS eligibility_enabled,
+DROP COLUMN IF EXISTS participation_mode;
@@ -0,0 +1,67 @@
+-- ================================
+-- Participation mode and eligibility rule on events
+-- ================================
+-- open    : every vendor can see and submit to the event
+-- invited : only vendors on the invitee list or matching the eligibility rule
+ALTER TABLE events
+ADD COLUMN IF NOT EXISTS participation_mode VARCHAR(20) NOT NULL DEFAULT 'open',
+ADD COLUMN IF NOT EXISTS eligibility_enabled BOOLEAN NOT NULL DEFAULT FALSE,
+ADD COLUMN IF NOT EXISTS eligible_business_field VARCHAR(255) NULL,
+ADD COLUMN IF NOT EXISTS eligible_province_id VARCHAR(20) NULL,
+ADD COLUMN IF NOT EXISTS eligible_active_only BOOLEAN NOT NULL DEFAULT TRUE,
+ADD COLUMN IF NOT EXISTS eligible_min_rating DECIMAL(3,2) NULL;
+
+COMMENT ON COLUMN events.participation_mode IS 'open, invited';
+COMMENT ON COLUMN events.eligible_min_rating IS 'Minimum average evaluation rating (1-5) a vendor must have to match the eligibility rule';
+
+CREATE INDEX IF NOT EXISTS idx_events_participation_mode
+    ON events(participation_mode);
+
+
+-- ================================
+-- event_invitations table
+-- ================================
+CREATE TABLE IF NOT EXISTS event_invitations (
+    id VARCHAR(36) PRIMARY KEY,
+    event_id VARCHAR(36) NOT NULL,
+    vendor_id VARCHAR(36) NOT NULL,
+
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_by VARCHAR(36) NOT NULL,
+
+    CONSTRAINT fk_event_invitations_event
+        FOREIGN KEY (event_id)
+        REFERENCES events(id)
+        ON DELETE CASCADE,
+    CONSTRAINT fk_event_invitations_vendor
+        FOREIGN KEY (vendor_id)
+        REFERENCES vendors(id)
+        ON DELETE CASCADE,
+    CONSTRAINT unique_event_invitation UNIQUE (event_id, vendor_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_invitations_event_id
+    ON event_invitations(event_id);
+
+CREATE INDEX IF NOT EXISTS idx_event_invitations_vendor_id
+    ON event_invitations(vendor_id);
+
+
+-- ================================
+-- event:manage_invitations permission
+-- ================================
+INSERT INTO permissions (id, name, display_name, resource, action)
+SELECT gen_random_uuid(), 'manage_event_invitations', 'Manage Event Invitations', 'event', 'manage_invitations'
+WHERE NOT EXISTS (
+    SELECT 1 FROM permissions WHERE name = 'manage_event_invitations'
+);
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r, permissions p
+WHERE r.name IN ('superadmin', 'admin', 'client')
+AND p.name = 'manage_event_invitations'
+AND NOT EXISTS (
+    SELECT 1 FROM role_permissions rp
+    WHERE rp.role_id = r.id AND rp.permission_id = p.id
+);
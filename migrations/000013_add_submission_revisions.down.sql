@@ -0,0 +1,14 @@
+-- ================================
+-- Remove submission revisions
+-- ================================
+DROP TABLE IF EXISTS event_submission_revision_files;
+
+DROP TABLE IF EXISTS event_submission_revisions;
+
+DROP INDEX IF EXISTS idx_event_submissions_status;
+
+ALTER TABLE event_submissions
+DROP COLUMN IF EXISTS withdrawal_reason,
+DROP COLUMN IF EXISTS withdrawn_at,
+DROP COLUMN IF EXISTS revision_number,
+DROP COLUMN IF EXISTS status;
@@ -0,0 +1,87 @@
+-- ================================
+-- Submission lifecycle on event_submissions
+-- ================================
+-- submitted : active submission, visible to scorers
+-- withdrawn : pulled by the vendor before the deadline
+ALTER TABLE event_submissions
+ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'submitted',
+ADD COLUMN IF NOT EXISTS revision_number INT NOT NULL DEFAULT 1,
+ADD COLUMN IF NOT EXISTS withdrawn_at TIMESTAMP NULL,
+ADD COLUMN IF NOT EXISTS withdrawal_reason TEXT NULL;
+
+COMMENT ON COLUMN event_submissions.status IS 'submitted, withdrawn';
+
+CREATE INDEX IF NOT EXISTS idx_event_submissions_status
+    ON event_submissions(status);
+
+
+-- ================================
+-- event_submission_revisions table
+-- ================================
+-- Immutable snapshot written on every submit, amendment and withdrawal
+CREATE TABLE IF NOT EXISTS event_submission_revisions (
+    id VARCHAR(36) PRIMARY KEY,
+    event_submission_id VARCHAR(36) NOT NULL,
+    revision_number INT NOT NULL,
+    action VARCHAR(20) NOT NULL,
+    proposal_details TEXT NULL,
+    additional_materials TEXT NULL,
+    note TEXT NULL,
+
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_by VARCHAR(36) NOT NULL,
+
+    CONSTRAINT fk_event_submission_revisions_submission
+        FOREIGN KEY (event_submission_id)
+        REFERENCES event_submissions(id)
+        ON DELETE CASCADE,
+    CONSTRAINT unique_submission_revision UNIQUE (event_submission_id, revision_number)
+);
+
+COMMENT ON COLUMN event_submission_revisions.action IS 'submitted, amended, withdrawn';
+
+CREATE INDEX IF NOT EXISTS idx_event_submission_revisions_submission_id
+    ON event_submission_revisions(event_submission_id);
+
+
+-- ================================
+-- event_submission_revision_files table
+-- ================================
+CREATE TABLE IF NOT EXISTS event_submission_revision_files (
+    id VARCHAR(36) PRIMARY KEY,
+    revision_id VARCHAR(36) NOT NULL,
+    file_type VARCHAR(50) NULL,
+    file_url TEXT NOT NULL,
+    caption TEXT NULL,
+    file_order INT NOT NULL DEFAULT 0,
+
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_event_submission_revision_files_revision
+        FOREIGN KEY (revision_id)
+        REFERENCES event_submission_revisions(id)
+        ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_submission_revision_files_revision_id
+    ON event_submission_revision_files(revision_id);
+
+
+-- ================================
+-- Backfill revision 1 for existing submissions
+-- ================================
+INSERT INTO event_submission_revisions (id, event_submission_id, revision_number, action, proposal_details, additional_materials, created_at, created_by)
+SELECT gen_random_uuid(), s.id, 1, 'submitted', s.proposal_details, s.additional_materials, s.created_at, s.created_by
+FROM event_submissions s
+WHERE NOT EXISTS (
+    SELECT 1 FROM event_submission_revisions r WHERE r.event_submission_id = s.id
+);
+
+INSERT INTO event_submission_revision_files (id, revision_id, file_type, file_url, caption, file_order, created_at)
+SELECT gen_random_uuid(), r.id, f.file_type, f.file_url, f.caption, f.file_order, f.created_at
+FROM event_submission_files f
+JOIN event_submission_revisions r ON r.event_submission_id = f.event_submission_id AND r.revision_number = 1
+WHERE f.deleted_at IS NULL
+AND NOT EXISTS (
+    SELECT 1 FROM event_submission_revision_files rf WHERE rf.revision_id = r.id
+);
@@ -0,0 +1,17 @@
+-- ================================
+-- Remove submission file rules
+-- ================================
+ALTER TABLE event_submission_revision_files
+DROP COLUMN IF EXISTS file_size,
+DROP COLUMN IF EXISTS mime_type,
+DROP COLUMN IF EXISTS file_name;
+
+ALTER TABLE event_submission_files
+DROP COLUMN IF EXISTS file_size,
+DROP COLUMN IF EXISTS mime_type,
+DROP COLUMN IF EXISTS file_name;
+
+ALTER TABLE events
+DROP COLUMN IF EXISTS submission_allowed_mime_types,
+DROP COLUMN IF EXISTS submission_max_file_size_mb,
+DROP COLUMN IF EXISTS submission_max_files;
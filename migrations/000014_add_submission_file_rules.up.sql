@@ -0,0 +1,26 @@
+-- ================================
+-- Per-event submission file rules
+-- ================================
+-- NULL falls back to MAX_SUBMISSION_FILES, MAX_FILE_SIZE and SUBMISSION_ALLOWED_MIME_TYPES
+ALTER TABLE events
+ADD COLUMN IF NOT EXISTS submission_max_files INT NULL,
+ADD COLUMN IF NOT EXISTS submission_max_file_size_mb INT NULL,
+ADD COLUMN IF NOT EXISTS submission_allowed_mime_types TEXT NULL;
+
+COMMENT ON COLUMN events.submission_allowed_mime_types IS 'Comma separated list of MIME types vendors may attach to a submission';
+
+
+-- ================================
+-- File metadata on submission files and their revisions
+-- ================================
+ALTER TABLE event_submission_files
+ADD COLUMN IF NOT EXISTS file_name VARCHAR(255) NULL,
+ADD COLUMN IF NOT EXISTS mime_type VARCHAR(100) NULL,
+ADD COLUMN IF NOT EXISTS file_size BIGINT NULL;
+
+COMMENT ON COLUMN event_submission_files.file_type IS 'technical_proposal, price_proposal, company_profile, photo, document';
+
+ALTER TABLE event_submission_revision_files
+ADD COLUMN IF NOT EXISTS file_name VARCHAR(255) NULL,
+ADD COLUMN IF NOT EXISTS mime_type VARCHAR(100) NULL,
+ADD COLUMN IF NOT EXISTS file_size BIGINT NULL;
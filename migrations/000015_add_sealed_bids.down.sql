@@ -0,0 +1,23 @@
+-- ================================
+-- Remove sealed bids
+-- ================================
+DELETE FROM role_permissions
+WHERE permission_id IN (
+    SELECT id FROM permissions WHERE name = 'open_event_bids'
+);
+
+DELETE FROM permissions WHERE name = 'open_event_bids';
+
+DROP TABLE IF EXISTS event_bid_openings;
+
+ALTER TABLE event_submission_revision_files
+DROP COLUMN IF EXISTS is_encrypted;
+
+ALTER TABLE event_submission_files
+DROP COLUMN IF EXISTS is_encrypted;
+
+ALTER TABLE events
+DROP COLUMN IF EXISTS bids_opened_at,
+DROP COLUMN IF EXISTS bid_opening_quorum,
+DROP COLUMN IF EXISTS bid_key,
+DROP COLUMN IF EXISTS sealed_bids;
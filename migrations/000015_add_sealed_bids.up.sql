@@ -0,0 +1,68 @@
+-- ================================
+-- Sealed bid settings on events
+-- ================================
+-- bid_key holds the per-event data key, wrapped with BID_ENCRYPTION_KEY
+ALTER TABLE events
+ADD COLUMN IF NOT EXISTS sealed_bids BOOLEAN NOT NULL DEFAULT FALSE,
+ADD COLUMN IF NOT EXISTS bid_key TEXT NULL,
+ADD COLUMN IF NOT EXISTS bid_opening_quorum INT NOT NULL DEFAULT 2,
+ADD COLUMN IF NOT EXISTS bids_opened_at TIMESTAMP NULL;
+
+COMMENT ON COLUMN events.bid_opening_quorum IS 'Number of distinct users that must approve an early bid opening';
+
+
+-- ================================
+-- Encrypted marker on submission files
+-- ================================
+ALTER TABLE event_submission_files
+ADD COLUMN IF NOT EXISTS is_encrypted BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE event_submission_revision_files
+ADD COLUMN IF NOT EXISTS is_encrypted BOOLEAN NOT NULL DEFAULT FALSE;
+
+
+-- ================================
+-- event_bid_openings table
+-- ================================
+-- One row per user approving an early bid opening
+CREATE TABLE IF NOT EXISTS event_bid_openings (
+    id VARCHAR(36) PRIMARY KEY,
+    event_id VARCHAR(36) NOT NULL,
+    user_id UUID NOT NULL,
+    note TEXT NULL,
+
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_event_bid_openings_event
+        FOREIGN KEY (event_id)
+        REFERENCES events(id)
+        ON DELETE CASCADE,
+    CONSTRAINT fk_event_bid_openings_user
+        FOREIGN KEY (user_id)
+        REFERENCES users(id)
+        ON DELETE CASCADE,
+    CONSTRAINT unique_event_bid_opening UNIQUE (event_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_bid_openings_event_id
+    ON event_bid_openings(event_id);
+
+
+-- ================================
+-- event:open_bids permission
+-- ================================
+INSERT INTO permissions (id, name, display_name, resource, action)
+SELECT gen_random_uuid(), 'open_event_bids', 'Open Sealed Bids', 'event', 'open_bids'
+WHERE NOT EXISTS (
+    SELECT 1 FROM permissions WHERE name = 'open_event_bids'
+);
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r, permissions p
+WHERE r.name IN ('superadmin', 'admin', 'client')
+AND p.name = 'open_event_bids'
+AND NOT EXISTS (
+    SELECT 1 FROM role_permissions rp
+    WHERE rp.role_id = r.id AND rp.permission_id = p.id
+);
@@ -0,0 +1,6 @@
+-- ================================
+-- Remove bill of quantities
+-- ================================
+DROP TABLE IF EXISTS event_submission_revision_prices;
+DROP TABLE IF EXISTS event_submission_prices;
+DROP TABLE IF EXISTS event_boq_items;
@@ -0,0 +1,86 @@
+-- ================================
+-- event_boq_items table
+-- ================================
+-- Bill of quantities: the lines every vendor must price for the event
+CREATE TABLE IF NOT EXISTS event_boq_items (
+    id VARCHAR(36) PRIMARY KEY,
+    event_id VARCHAR(36) NOT NULL,
+    item_no INT NOT NULL,
+    description TEXT NOT NULL,
+    unit VARCHAR(50) NOT NULL,
+    quantity DECIMAL(15,3) NOT NULL,
+    owner_estimate DECIMAL(15,2) NULL,
+
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_by VARCHAR(36) NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_by VARCHAR(36) NULL,
+    deleted_at TIMESTAMP NULL,
+    deleted_by VARCHAR(36) NULL,
+
+    CONSTRAINT fk_event_boq_items_event
+        FOREIGN KEY (event_id)
+        REFERENCES events(id)
+        ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_boq_items_event_id
+    ON event_boq_items(event_id);
+CREATE INDEX IF NOT EXISTS idx_event_boq_items_deleted_at
+    ON event_boq_items(deleted_at);
+
+COMMENT ON COLUMN event_boq_items.owner_estimate IS 'Owner estimate per unit, hidden from vendors';
+
+
+-- ================================
+-- event_submission_prices table
+-- ================================
+-- Unit price quoted by a vendor for one bill of quantities line.
+-- unit_price_sealed holds the encrypted price while the event uses sealed bids.
+CREATE TABLE IF NOT EXISTS event_submission_prices (
+    id VARCHAR(36) PRIMARY KEY,
+    event_submission_id VARCHAR(36) NOT NULL,
+    boq_item_id VARCHAR(36) NOT NULL,
+    unit_price DECIMAL(15,2) NULL,
+    unit_price_sealed TEXT NULL,
+
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_by VARCHAR(36) NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_by VARCHAR(36) NULL,
+
+    CONSTRAINT fk_event_submission_prices_submission
+        FOREIGN KEY (event_submission_id)
+        REFERENCES event_submissions(id)
+        ON DELETE CASCADE,
+    CONSTRAINT fk_event_submission_prices_item
+        FOREIGN KEY (boq_item_id)
+        REFERENCES event_boq_items(id)
+        ON DELETE CASCADE,
+    CONSTRAINT unique_event_submission_price UNIQUE (event_submission_id, boq_item_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_submission_prices_submission_id
+    ON event_submission_prices(event_submission_id);
+
+
+-- ================================
+-- event_submission_revision_prices table
+-- ================================
+CREATE TABLE IF NOT EXISTS event_submission_revision_prices (
+    id VARCHAR(36) PRIMARY KEY,
+    revision_id VARCHAR(36) NOT NULL,
+    boq_item_id VARCHAR(36) NOT NULL,
+    unit_price DECIMAL(15,2) NULL,
+    unit_price_sealed TEXT NULL,
+
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_event_submission_revision_prices_revision
+        FOREIGN KEY (revision_id)
+        REFERENCES event_submission_revisions(id)
+        ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_submission_revision_prices_revision_id
+    ON event_submission_revision_prices(revision_id);
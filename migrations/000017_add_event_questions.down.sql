@@ -0,0 +1,14 @@
+-- ================================
+-- Remove event Q&A
+-- ================================
+DELETE FROM role_permissions
+WHERE permission_id IN (
+    SELECT id FROM permissions WHERE name IN ('ask_event_questions', 'answer_event_questions')
+);
+
+DELETE FROM permissions WHERE name IN ('ask_event_questions', 'answer_event_questions');
+
+DROP TABLE IF EXISTS event_questions;
+
+ALTER TABLE events
+DROP COLUMN IF EXISTS qa_cutoff_hours;
@@ -0,0 +1,87 @@
+-- ================================
+-- Q&A cut-off on events
+-- ================================
+-- Hours before end_date after which vendors can no longer ask questions.
+-- NULL falls back to QA_CUTOFF_HOURS.
+ALTER TABLE events
+ADD COLUMN IF NOT EXISTS qa_cutoff_hours INT NULL;
+
+
+-- ================================
+-- event_questions table
+-- ================================
+CREATE TABLE IF NOT EXISTS event_questions (
+    id VARCHAR(36) PRIMARY KEY,
+    event_id VARCHAR(36) NOT NULL,
+    vendor_id VARCHAR(36) NOT NULL,
+    question TEXT NOT NULL,
+    answer TEXT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending', -- pending || answered
+    visibility VARCHAR(20) NOT NULL DEFAULT 'private', -- private || public
+    answered_at TIMESTAMP NULL,
+    answered_by VARCHAR(36) NULL,
+    published_at TIMESTAMP NULL,
+
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_by VARCHAR(36) NOT NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_by VARCHAR(36) NULL,
+    deleted_at TIMESTAMP NULL,
+    deleted_by VARCHAR(36) NULL,
+
+    CONSTRAINT fk_event_questions_event
+        FOREIGN KEY (event_id)
+        REFERENCES events(id)
+        ON DELETE CASCADE,
+    CONSTRAINT fk_event_questions_vendor
+        FOREIGN KEY (vendor_id)
+        REFERENCES vendors(id)
+        ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_questions_event_id
+    ON event_questions(event_id);
+CREATE INDEX IF NOT EXISTS idx_event_questions_vendor_id
+    ON event_questions(vendor_id);
+CREATE INDEX IF NOT EXISTS idx_event_questions_deleted_at
+    ON event_questions(deleted_at);
+
+
+-- ================================
+-- event:ask_question permission for vendors
+-- ================================
+INSERT INTO permissions (id, name, display_name, resource, action)
+SELECT gen_random_uuid(), 'ask_event_questions', 'Ask Event Questions', 'event', 'ask_question'
+WHERE NOT EXISTS (
+    SELECT 1 FROM permissions WHERE name = 'ask_event_questions'
+);
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r, permissions p
+WHERE r.name = 'vendor'
+AND p.name = 'ask_event_questions'
+AND NOT EXISTS (
+    SELECT 1 FROM role_permissions rp
+    WHERE rp.role_id = r.id AND rp.permission_id = p.id
+);
+
+
+-- ================================
+-- event:answer_question permission
+-- ================================
+INSERT INTO permissions (id, name, display_name, resource, action)
+SELECT gen_random_uuid(), 'answer_event_questions', 'Answer Event Questions', 'event', 'answer_question'
+WHERE NOT EXISTS (
+    SELECT 1 FROM permissions WHERE name = 'answer_event_questions'
+);
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r, permissions p
+WHERE r.name IN ('superadmin', 'admin', 'client')
+AND p.name = 'answer_event_questions'
+AND NOT EXISTS (
+    SELECT 1 FROM role_permissions rp
+    WHERE rp.role_id = r.id AND rp.permission_id = p.id
+);
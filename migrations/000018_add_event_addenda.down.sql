@@ -0,0 +1,20 @@
+-- ================================
+-- Remove event addenda
+-- ================================
+DELETE FROM role_permissions
+WHERE permission_id IN (
+    SELECT id FROM permissions WHERE name = 'issue_event_addendum'
+);
+
+DELETE FROM permissions WHERE name = 'issue_event_addendum';
+
+DROP TABLE IF EXISTS event_vendor_views;
+DROP TABLE IF EXISTS event_addendum_acknowledgements;
+
+ALTER TABLE event_files
+DROP CONSTRAINT IF EXISTS fk_event_files_addendum;
+
+ALTER TABLE event_files
+DROP COLUMN IF EXISTS addendum_id;
+
+DROP TABLE IF EXISTS event_addenda;
@@ -0,0 +1,109 @@
+-- ================================
+-- event_addenda table
+-- ================================
+-- Numbered scope changes issued while an event is open
+CREATE TABLE IF NOT EXISTS event_addenda (
+    id VARCHAR(36) PRIMARY KEY,
+    event_id VARCHAR(36) NOT NULL,
+    addendum_number INT NOT NULL,
+    summary TEXT NOT NULL,
+    previous_end_date TIMESTAMP NULL,
+    new_end_date TIMESTAMP NULL,
+
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_by VARCHAR(36) NOT NULL,
+
+    CONSTRAINT fk_event_addenda_event
+        FOREIGN KEY (event_id)
+        REFERENCES events(id)
+        ON DELETE CASCADE,
+    CONSTRAINT unique_event_addendum_number UNIQUE (event_id, addendum_number)
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_addenda_event_id
+    ON event_addenda(event_id);
+
+
+-- ================================
+-- Addendum files
+-- ================================
+-- Files issued with an addendum are ordinary event files tagged with the addendum
+ALTER TABLE event_files
+ADD COLUMN IF NOT EXISTS addendum_id VARCHAR(36) NULL;
+
+ALTER TABLE event_files
+DROP CONSTRAINT IF EXISTS fk_event_files_addendum;
+
+ALTER TABLE event_files
+ADD CONSTRAINT fk_event_files_addendum
+    FOREIGN KEY (addendum_id)
+    REFERENCES event_addenda(id)
+    ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_event_files_addendum_id
+    ON event_files(addendum_id);
+
+
+-- ================================
+-- event_addendum_acknowledgements table
+-- ================================
+CREATE TABLE IF NOT EXISTS event_addendum_acknowledgements (
+    id VARCHAR(36) PRIMARY KEY,
+    addendum_id VARCHAR(36) NOT NULL,
+    vendor_id VARCHAR(36) NOT NULL,
+    acknowledged_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    acknowledged_by VARCHAR(36) NOT NULL,
+
+    CONSTRAINT fk_event_addendum_acknowledgements_addendum
+        FOREIGN KEY (addendum_id)
+        REFERENCES event_addenda(id)
+        ON DELETE CASCADE,
+    CONSTRAINT fk_event_addendum_acknowledgements_vendor
+        FOREIGN KEY (vendor_id)
+        REFERENCES vendors(id)
+        ON DELETE CASCADE,
+    CONSTRAINT unique_event_addendum_acknowledgement UNIQUE (addendum_id, vendor_id)
+);
+
+
+-- ================================
+-- event_vendor_views table
+-- ================================
+-- Vendors that opened the event, so they can be told about later addenda
+CREATE TABLE IF NOT EXISTS event_vendor_views (
+    id VARCHAR(36) PRIMARY KEY,
+    event_id VARCHAR(36) NOT NULL,
+    vendor_id VARCHAR(36) NOT NULL,
+    first_viewed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_viewed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_event_vendor_views_event
+        FOREIGN KEY (event_id)
+        REFERENCES events(id)
+        ON DELETE CASCADE,
+    CONSTRAINT fk_event_vendor_views_vendor
+        FOREIGN KEY (vendor_id)
+        REFERENCES vendors(id)
+        ON DELETE CASCADE,
+    CONSTRAINT unique_event_vendor_view UNIQUE (event_id, vendor_id)
+);
+
+
+-- ================================
+-- event:issue_addendum permission
+-- ================================
+INSERT INTO permissions (id, name, display_name, resource, action)
+SELECT gen_random_uuid(), 'issue_event_addendum', 'Issue Event Addendum', 'event', 'issue_addendum'
+WHERE NOT EXISTS (
+    SELECT 1 FROM permissions WHERE name = 'issue_event_addendum'
+);
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r, permissions p
+WHERE r.name IN ('superadmin', 'admin', 'client')
+AND p.name = 'issue_event_addendum'
+AND NOT EXISTS (
+    SELECT 1 FROM role_permissions rp
+    WHERE rp.role_id = r.id AND rp.permission_id = p.id
+);
@@ -0,0 +1,4 @@
+-- ================================
+-- Drop event_awards table
+-- ================================
+DROP TABLE IF EXISTS event_awards;
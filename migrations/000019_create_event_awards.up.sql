@@ -0,0 +1,61 @@
+-- ================================
+-- event_awards table
+-- ================================
+-- An event can be awarded to several submissions, split by lot or line
+CREATE TABLE IF NOT EXISTS event_awards (
+    id VARCHAR(36) PRIMARY KEY,
+    event_id VARCHAR(36) NOT NULL,
+    submission_id VARCHAR(36) NOT NULL,
+    vendor_id VARCHAR(36) NOT NULL,
+    lot_reference VARCHAR(255) NOT NULL DEFAULT '',
+    awarded_amount DECIMAL(15,2) NOT NULL DEFAULT 0,
+    notes TEXT,
+
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_by VARCHAR(36) NOT NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_by VARCHAR(36) NOT NULL,
+    deleted_at TIMESTAMP NULL,
+    deleted_by VARCHAR(36) NULL,
+
+    CONSTRAINT fk_event_awards_event
+        FOREIGN KEY (event_id)
+        REFERENCES events(id)
+        ON DELETE CASCADE,
+    CONSTRAINT fk_event_awards_submission
+        FOREIGN KEY (submission_id)
+        REFERENCES event_submissions(id)
+        ON DELETE CASCADE,
+    CONSTRAINT fk_event_awards_vendor
+        FOREIGN KEY (vendor_id)
+        REFERENCES vendors(id)
+        ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_awards_event_id
+    ON event_awards(event_id);
+
+CREATE INDEX IF NOT EXISTS idx_event_awards_vendor_id
+    ON event_awards(vendor_id);
+
+CREATE INDEX IF NOT EXISTS idx_event_awards_deleted_at
+    ON event_awards(deleted_at);
+
+-- A named lot can only be awarded once per event
+CREATE UNIQUE INDEX IF NOT EXISTS unique_event_award_lot
+    ON event_awards(event_id, lot_reference)
+    WHERE lot_reference <> '' AND deleted_at IS NULL;
+
+
+-- ================================
+-- Backfill existing single winners
+-- ================================
+INSERT INTO event_awards (id, event_id, submission_id, vendor_id, lot_reference, awarded_amount, notes, created_at, created_by, updated_at, updated_by)
+SELECT gen_random_uuid()::text, s.event_id, s.id, s.vendor_id, '', 0, 'Migrated from single winner selection',
+       s.updated_at, COALESCE(NULLIF(s.updated_by, ''), s.created_by), s.updated_at, COALESCE(NULLIF(s.updated_by, ''), s.created_by)
+FROM event_submissions s
+WHERE s.is_winner = TRUE
+AND s.deleted_at IS NULL
+AND NOT EXISTS (
+    SELECT 1 FROM event_awards a WHERE a.submission_id = s.id
+);
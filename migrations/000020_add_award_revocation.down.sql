@@ -0,0 +1,16 @@
+-- ================================
+-- Remove award revocation
+-- ================================
+DELETE FROM role_permissions
+WHERE permission_id IN (
+    SELECT id FROM permissions WHERE name = 'revoke_event_award'
+);
+
+DELETE FROM permissions WHERE name = 'revoke_event_award';
+
+DROP TABLE IF EXISTS event_histories;
+
+ALTER TABLE event_awards
+DROP COLUMN IF EXISTS revoke_reason,
+DROP COLUMN IF EXISTS revoked_by,
+DROP COLUMN IF EXISTS revoked_at;
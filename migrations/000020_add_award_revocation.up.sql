@@ -0,0 +1,54 @@
+-- ================================
+-- Award revocation
+-- ================================
+ALTER TABLE event_awards
+ADD COLUMN IF NOT EXISTS revoked_at TIMESTAMP NULL,
+ADD COLUMN IF NOT EXISTS revoked_by VARCHAR(36) NULL,
+ADD COLUMN IF NOT EXISTS revoke_reason TEXT NULL;
+
+
+-- ================================
+-- event_histories table
+-- ================================
+-- Audit trail of award decisions and status changes on an event
+CREATE TABLE IF NOT EXISTS event_histories (
+    id VARCHAR(36) PRIMARY KEY,
+    event_id VARCHAR(36) NOT NULL,
+    action VARCHAR(50) NOT NULL,
+    award_id VARCHAR(36) NULL,
+    submission_id VARCHAR(36) NULL,
+    vendor_id VARCHAR(36) NULL,
+    description TEXT NOT NULL,
+    reason TEXT NULL,
+
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_by VARCHAR(36) NOT NULL,
+
+    CONSTRAINT fk_event_histories_event
+        FOREIGN KEY (event_id)
+        REFERENCES events(id)
+        ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_histories_event_id
+    ON event_histories(event_id);
+
+
+-- ================================
+-- event:revoke_award permission
+-- ================================
+INSERT INTO permissions (id, name, display_name, resource, action)
+SELECT gen_random_uuid(), 'revoke_event_award', 'Revoke Event Award', 'event', 'revoke_award'
+WHERE NOT EXISTS (
+    SELECT 1 FROM permissions WHERE name = 'revoke_event_award'
+);
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r, permissions p
+WHERE r.name IN ('superadmin', 'admin', 'client')
+AND p.name = 'revoke_event_award'
+AND NOT EXISTS (
+    SELECT 1 FROM role_permissions rp
+    WHERE rp.role_id = r.id AND rp.permission_id = p.id
+);
@@ -0,0 +1,33 @@
+-- ================================
+-- Remove payment schedules
+-- ================================
+DELETE FROM role_permissions
+WHERE permission_id IN (
+    SELECT id FROM permissions WHERE name = 'finalize_evaluation'
+);
+
+DELETE FROM permissions WHERE name = 'finalize_evaluation';
+
+ALTER TABLE evaluations
+DROP COLUMN IF EXISTS finalized_by,
+DROP COLUMN IF EXISTS finalized_at;
+
+-- Enum values cannot be dropped; scheduled payments fall back to pending
+UPDATE payments SET status = 'pending' WHERE status = 'scheduled';
+
+ALTER TABLE payments
+DROP CONSTRAINT IF EXISTS fk_payments_award;
+
+ALTER TABLE payments
+DROP CONSTRAINT IF EXISTS fk_payments_event;
+
+ALTER TABLE payments
+DROP COLUMN IF EXISTS due_date,
+DROP COLUMN IF EXISTS release_trigger,
+DROP COLUMN IF EXISTS award_id,
+DROP COLUMN IF EXISTS event_id;
+
+DROP TABLE IF EXISTS event_payment_terms;
+
+ALTER TABLE events
+DROP COLUMN IF EXISTS contract_value;
@@ -0,0 +1,100 @@
+-- ================================
+-- Event contract value
+-- ================================
+ALTER TABLE events
+ADD COLUMN IF NOT EXISTS contract_value DECIMAL(15,2) NULL;
+
+
+-- ================================
+-- event_payment_terms table
+-- ================================
+-- Payment term template of an event, e.g. 30% on award and 70% on completion
+CREATE TABLE IF NOT EXISTS event_payment_terms (
+    id VARCHAR(36) PRIMARY KEY,
+    event_id VARCHAR(36) NOT NULL,
+    sequence INT NOT NULL,
+    label VARCHAR(100) NOT NULL,
+    percentage DECIMAL(5,2) NOT NULL,
+    release_trigger VARCHAR(20) NOT NULL,
+    installments INT NOT NULL DEFAULT 1,
+
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_by VARCHAR(36) NOT NULL,
+
+    CONSTRAINT fk_event_payment_terms_event
+        FOREIGN KEY (event_id)
+        REFERENCES events(id)
+        ON DELETE CASCADE
+);
+
+COMMENT ON COLUMN event_payment_terms.release_trigger IS 'on_award, on_completion, monthly';
+
+CREATE INDEX IF NOT EXISTS idx_event_payment_terms_event_id
+    ON event_payment_terms(event_id);
+
+
+-- ================================
+-- Scheduled payments
+-- ================================
+ALTER TYPE payment_status ADD VALUE IF NOT EXISTS 'scheduled';
+
+ALTER TABLE payments
+ADD COLUMN IF NOT EXISTS event_id VARCHAR(36) NULL,
+ADD COLUMN IF NOT EXISTS award_id VARCHAR(36) NULL,
+ADD COLUMN IF NOT EXISTS release_trigger VARCHAR(20) NULL,
+ADD COLUMN IF NOT EXISTS due_date TIMESTAMP NULL;
+
+ALTER TABLE payments
+DROP CONSTRAINT IF EXISTS fk_payments_event;
+
+ALTER TABLE payments
+ADD CONSTRAINT fk_payments_event
+    FOREIGN KEY (event_id)
+    REFERENCES events(id)
+    ON DELETE SET NULL;
+
+ALTER TABLE payments
+DROP CONSTRAINT IF EXISTS fk_payments_award;
+
+ALTER TABLE payments
+ADD CONSTRAINT fk_payments_award
+    FOREIGN KEY (award_id)
+    REFERENCES event_awards(id)
+    ON DELETE SET NULL;
+
+COMMENT ON COLUMN payments.status IS 'scheduled, pending, paid, cancelled';
+COMMENT ON COLUMN payments.release_trigger IS 'on_award, on_completion, on_date';
+
+CREATE INDEX IF NOT EXISTS idx_payments_event_id
+    ON payments(event_id);
+
+CREATE INDEX IF NOT EXISTS idx_payments_award_id
+    ON payments(award_id);
+
+
+-- ================================
+-- Evaluation finalization
+-- ================================
+ALTER TABLE evaluations
+ADD COLUMN IF NOT EXISTS finalized_at TIMESTAMP NULL,
+ADD COLUMN IF NOT EXISTS finalized_by VARCHAR(36) NULL;
+
+
+-- ================================
+-- evaluation:finalize permission
+-- ================================
+INSERT INTO permissions (id, name, display_name, resource, action)
+SELECT gen_random_uuid(), 'finalize_evaluation', 'Finalize Evaluation', 'evaluation', 'finalize'
+WHERE NOT EXISTS (
+    SELECT 1 FROM permissions WHERE name = 'finalize_evaluation'
+);
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r, permissions p
+WHERE r.name IN ('superadmin', 'admin', 'client')
+AND p.name = 'finalize_evaluation'
+AND NOT EXISTS (
+    SELECT 1 FROM role_permissions rp
+    WHERE rp.role_id = r.id AND rp.permission_id = p.id
+);
@@ -0,0 +1,12 @@
+-- ================================
+-- Remove event templates
+-- ================================
+DELETE FROM role_permissions
+WHERE permission_id IN (
+    SELECT id FROM permissions WHERE name = 'manage_event_template'
+);
+
+DELETE FROM permissions WHERE name = 'manage_event_template';
+
+DROP TABLE IF EXISTS event_template_files;
+DROP TABLE IF EXISTS event_templates;
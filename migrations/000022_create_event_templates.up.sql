@@ -0,0 +1,88 @@
+-- ================================
+-- event_templates table
+-- ================================
+-- Saved event settings that new events can be created from
+CREATE TABLE IF NOT EXISTS event_templates (
+    id VARCHAR(36) PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    title_pattern VARCHAR(100) NOT NULL,
+    description TEXT NULL,
+    category VARCHAR(100) NULL,
+    source_event_id VARCHAR(36) NULL,
+
+    participation_mode VARCHAR(20) NOT NULL DEFAULT 'open',
+    eligibility_enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    eligible_business_field VARCHAR(255) NULL,
+    eligible_province_id VARCHAR(20) NULL,
+    eligible_active_only BOOLEAN NOT NULL DEFAULT TRUE,
+    eligible_min_rating DECIMAL(3,2) NULL,
+
+    submission_max_files INT NULL,
+    submission_max_file_size_mb INT NULL,
+    submission_allowed_mime_types TEXT NULL,
+
+    sealed_bids BOOLEAN NOT NULL DEFAULT FALSE,
+    bid_opening_quorum INT NOT NULL DEFAULT 2,
+    qa_cutoff_hours INT NULL,
+
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_by VARCHAR(36) NOT NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_by VARCHAR(36) NOT NULL,
+    deleted_at TIMESTAMP NULL,
+    deleted_by VARCHAR(36) NULL,
+
+    CONSTRAINT fk_event_templates_source_event
+        FOREIGN KEY (source_event_id)
+        REFERENCES events(id)
+        ON DELETE SET NULL
+);
+
+COMMENT ON COLUMN event_templates.title_pattern IS 'supports {year}, {quarter} and {month}, filled from the start date';
+
+CREATE INDEX IF NOT EXISTS idx_event_templates_deleted_at
+    ON event_templates(deleted_at);
+
+
+-- ================================
+-- event_template_files table
+-- ================================
+-- Storage copies of the files attached to a template
+CREATE TABLE IF NOT EXISTS event_template_files (
+    id VARCHAR(36) PRIMARY KEY,
+    template_id VARCHAR(36) NOT NULL,
+    file_type VARCHAR(50) NOT NULL,
+    file_url TEXT NOT NULL,
+    caption TEXT NULL,
+
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_by VARCHAR(36) NOT NULL,
+
+    CONSTRAINT fk_event_template_files_template
+        FOREIGN KEY (template_id)
+        REFERENCES event_templates(id)
+        ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_template_files_template_id
+    ON event_template_files(template_id);
+
+
+-- ================================
+-- event:manage_template permission
+-- ================================
+INSERT INTO permissions (id, name, display_name, resource, action)
+SELECT gen_random_uuid(), 'manage_event_template', 'Manage Event Templates', 'event', 'manage_template'
+WHERE NOT EXISTS (
+    SELECT 1 FROM permissions WHERE name = 'manage_event_template'
+);
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r, permissions p
+WHERE r.name IN ('superadmin', 'admin', 'client')
+AND p.name = 'manage_event_template'
+AND NOT EXISTS (
+    SELECT 1 FROM role_permissions rp
+    WHERE rp.role_id = r.id AND rp.permission_id = p.id
+);
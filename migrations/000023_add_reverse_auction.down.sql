@@ -0,0 +1,22 @@
+-- ================================
+-- Remove reverse auctions
+-- ================================
+DELETE FROM role_permissions
+WHERE permission_id IN (
+    SELECT id FROM permissions WHERE name IN ('place_auction_bid', 'close_event_auction')
+);
+
+DELETE FROM permissions WHERE name IN ('place_auction_bid', 'close_event_auction');
+
+DROP TABLE IF EXISTS event_auction_bids;
+
+ALTER TABLE events
+DROP COLUMN IF EXISTS auction_closed_at,
+DROP COLUMN IF EXISTS auction_extension_minutes,
+DROP COLUMN IF EXISTS auction_extension_window_minutes,
+DROP COLUMN IF EXISTS auction_min_decrement_percent,
+DROP COLUMN IF EXISTS auction_min_decrement,
+DROP COLUMN IF EXISTS auction_start_price,
+DROP COLUMN IF EXISTS auction_end_at,
+DROP COLUMN IF EXISTS auction_start_at,
+DROP COLUMN IF EXISTS event_type;
@@ -0,0 +1,85 @@
+-- ================================
+-- Reverse auction settings on events
+-- ================================
+-- event_type tender is the regular proposal flow; reverse_auction takes live descending bids
+ALTER TABLE events
+ADD COLUMN IF NOT EXISTS event_type VARCHAR(20) NOT NULL DEFAULT 'tender',
+ADD COLUMN IF NOT EXISTS auction_start_at TIMESTAMP NULL,
+ADD COLUMN IF NOT EXISTS auction_end_at TIMESTAMP NULL,
+ADD COLUMN IF NOT EXISTS auction_start_price DECIMAL(15,2) NULL,
+ADD COLUMN IF NOT EXISTS auction_min_decrement DECIMAL(15,2) NULL,
+ADD COLUMN IF NOT EXISTS auction_min_decrement_percent DECIMAL(5,2) NULL,
+ADD COLUMN IF NOT EXISTS auction_extension_window_minutes INT NOT NULL DEFAULT 0,
+ADD COLUMN IF NOT EXISTS auction_extension_minutes INT NOT NULL DEFAULT 0,
+ADD COLUMN IF NOT EXISTS auction_closed_at TIMESTAMP NULL;
+
+COMMENT ON COLUMN events.event_type IS 'tender, reverse_auction';
+COMMENT ON COLUMN events.auction_end_at IS 'Moves later when a bid arrives inside the extension window (anti-sniping)';
+
+
+-- ================================
+-- event_auction_bids table
+-- ================================
+CREATE TABLE IF NOT EXISTS event_auction_bids (
+    id VARCHAR(36) PRIMARY KEY,
+    event_id VARCHAR(36) NOT NULL,
+    vendor_id VARCHAR(36) NOT NULL,
+    amount DECIMAL(15,2) NOT NULL,
+
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_by VARCHAR(36) NOT NULL,
+
+    CONSTRAINT fk_event_auction_bids_event
+        FOREIGN KEY (event_id)
+        REFERENCES events(id)
+        ON DELETE CASCADE,
+    CONSTRAINT fk_event_auction_bids_vendor
+        FOREIGN KEY (vendor_id)
+        REFERENCES vendors(id)
+        ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_auction_bids_event_vendor
+    ON event_auction_bids(event_id, vendor_id);
+CREATE INDEX IF NOT EXISTS idx_event_auction_bids_event_amount
+    ON event_auction_bids(event_id, amount);
+
+
+-- ================================
+-- event:bid permission for vendors
+-- ================================
+INSERT INTO permissions (id, name, display_name, resource, action)
+SELECT gen_random_uuid(), 'place_auction_bid', 'Place Auction Bid', 'event', 'bid'
+WHERE NOT EXISTS (
+    SELECT 1 FROM permissions WHERE name = 'place_auction_bid'
+);
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r, permissions p
+WHERE r.name = 'vendor'
+AND p.name = 'place_auction_bid'
+AND NOT EXISTS (
+    SELECT 1 FROM role_permissions rp
+    WHERE rp.role_id = r.id AND rp.permission_id = p.id
+);
+
+
+-- ================================
+-- event:close_auction permission
+-- ================================
+INSERT INTO permissions (id, name, display_name, resource, action)
+SELECT gen_random_uuid(), 'close_event_auction', 'Close Event Auction', 'event', 'close_auction'
+WHERE NOT EXISTS (
+    SELECT 1 FROM permissions WHERE name = 'close_event_auction'
+);
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r, permissions p
+WHERE r.name IN ('superadmin', 'admin', 'client')
+AND p.name = 'close_event_auction'
+AND NOT EXISTS (
+    SELECT 1 FROM role_permissions rp
+    WHERE rp.role_id = r.id AND rp.permission_id = p.id
+);
@@ -0,0 +1,11 @@
+-- ================================
+-- Remove calendar tokens
+-- ================================
+DELETE FROM role_permissions
+WHERE permission_id IN (
+    SELECT id FROM permissions WHERE name = 'manage_calendar_feed'
+);
+
+DELETE FROM permissions WHERE name = 'manage_calendar_feed';
+
+DROP TABLE IF EXISTS calendar_tokens;
@@ -0,0 +1,44 @@
+-- ================================
+-- calendar_tokens table
+-- ================================
+-- Secret token per user for the iCalendar feed of event deadlines;
+-- calendar apps cannot send a bearer token, so the token in the URL authenticates the feed
+CREATE TABLE IF NOT EXISTS calendar_tokens (
+    id VARCHAR(36) PRIMARY KEY,
+    user_id UUID NOT NULL,
+    token VARCHAR(64) NOT NULL,
+
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_calendar_tokens_user
+        FOREIGN KEY (user_id)
+        REFERENCES users(id)
+        ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_calendar_tokens_user_id
+    ON calendar_tokens(user_id);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_calendar_tokens_token
+    ON calendar_tokens(token);
+
+
+-- ================================
+-- calendar:manage permission
+-- ================================
+INSERT INTO permissions (id, name, display_name, resource, action)
+SELECT gen_random_uuid(), 'manage_calendar_feed', 'Manage Calendar Feed', 'calendar', 'manage'
+WHERE NOT EXISTS (
+    SELECT 1 FROM permissions WHERE name = 'manage_calendar_feed'
+);
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r, permissions p
+WHERE r.name IN ('superadmin', 'admin', 'client', 'vendor')
+AND p.name = 'manage_calendar_feed'
+AND NOT EXISTS (
+    SELECT 1 FROM role_permissions rp
+    WHERE rp.role_id = r.id AND rp.permission_id = p.id
+);
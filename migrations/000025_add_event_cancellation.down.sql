@@ -0,0 +1,15 @@
+-- ================================
+-- Remove event cancellation
+-- ================================
+DELETE FROM role_permissions
+WHERE permission_id IN (
+    SELECT id FROM permissions WHERE name = 'cancel_event'
+);
+
+DELETE FROM permissions WHERE name = 'cancel_event';
+
+ALTER TABLE events
+DROP COLUMN IF EXISTS retender_event_id,
+DROP COLUMN IF EXISTS cancel_reason,
+DROP COLUMN IF EXISTS cancelled_by,
+DROP COLUMN IF EXISTS cancelled_at;
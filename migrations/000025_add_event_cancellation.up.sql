@@ -0,0 +1,30 @@
+-- ================================
+-- Event cancellation
+-- ================================
+ALTER TABLE events
+ADD COLUMN IF NOT EXISTS cancelled_at TIMESTAMP NULL,
+ADD COLUMN IF NOT EXISTS cancelled_by VARCHAR(36) NULL,
+ADD COLUMN IF NOT EXISTS cancel_reason TEXT NULL,
+ADD COLUMN IF NOT EXISTS retender_event_id VARCHAR(36) NULL;
+
+COMMENT ON COLUMN events.retender_event_id IS 'draft cloned from this event when it was cancelled for a re-tender';
+
+
+-- ================================
+-- event:cancel permission
+-- ================================
+INSERT INTO permissions (id, name, display_name, resource, action)
+SELECT gen_random_uuid(), 'cancel_event', 'Cancel Event', 'event', 'cancel'
+WHERE NOT EXISTS (
+    SELECT 1 FROM permissions WHERE name = 'cancel_event'
+);
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r, permissions p
+WHERE r.name IN ('superadmin', 'admin', 'client')
+AND p.name = 'cancel_event'
+AND NOT EXISTS (
+    SELECT 1 FROM role_permissions rp
+    WHERE rp.role_id = r.id AND rp.permission_id = p.id
+);
@@ -0,0 +1,18 @@
+-- ================================
+-- Remove owner estimate and budget ceiling
+-- ================================
+DELETE FROM role_permissions
+WHERE permission_id IN (
+    SELECT id FROM permissions WHERE name = 'manage_event_budget'
+);
+
+DELETE FROM permissions WHERE name = 'manage_event_budget';
+
+ALTER TABLE event_awards
+DROP COLUMN IF EXISTS price_justification,
+DROP COLUMN IF EXISTS price_flag;
+
+ALTER TABLE events
+DROP COLUMN IF EXISTS abnormally_low_percent,
+DROP COLUMN IF EXISTS budget_ceiling,
+DROP COLUMN IF EXISTS owner_estimate;
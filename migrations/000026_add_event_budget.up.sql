@@ -0,0 +1,37 @@
+-- ================================
+-- Owner estimate and budget ceiling
+-- ================================
+-- Confidential; only exposed through the event budget endpoints
+ALTER TABLE events
+ADD COLUMN IF NOT EXISTS owner_estimate DECIMAL(15,2) NULL,
+ADD COLUMN IF NOT EXISTS budget_ceiling DECIMAL(15,2) NULL,
+ADD COLUMN IF NOT EXISTS abnormally_low_percent DECIMAL(5,2) NULL;
+
+COMMENT ON COLUMN events.owner_estimate IS 'owner estimate (HPS)';
+COMMENT ON COLUMN events.abnormally_low_percent IS 'quotes below this percentage of the owner estimate are abnormally low, defaults to ABNORMALLY_LOW_PERCENT';
+
+ALTER TABLE event_awards
+ADD COLUMN IF NOT EXISTS price_flag VARCHAR(20) NULL,
+ADD COLUMN IF NOT EXISTS price_justification TEXT NULL;
+
+COMMENT ON COLUMN event_awards.price_flag IS 'above_ceiling, abnormally_low';
+
+
+-- ================================
+-- event:manage_budget permission
+-- ================================
+INSERT INTO permissions (id, name, display_name, resource, action)
+SELECT gen_random_uuid(), 'manage_event_budget', 'Manage Event Budget', 'event', 'manage_budget'
+WHERE NOT EXISTS (
+    SELECT 1 FROM permissions WHERE name = 'manage_event_budget'
+);
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r, permissions p
+WHERE r.name IN ('superadmin', 'admin', 'client')
+AND p.name = 'manage_event_budget'
+AND NOT EXISTS (
+    SELECT 1 FROM role_permissions rp
+    WHERE rp.role_id = r.id AND rp.permission_id = p.id
+);
@@ -0,0 +1,11 @@
+-- ================================
+-- Remove event deadline extensions
+-- ================================
+DELETE FROM role_permissions
+WHERE permission_id IN (
+    SELECT id FROM permissions WHERE name = 'extend_event_deadline'
+);
+
+DELETE FROM permissions WHERE name = 'extend_event_deadline';
+
+DROP TABLE IF EXISTS event_deadline_extensions;
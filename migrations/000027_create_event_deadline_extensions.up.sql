@@ -0,0 +1,42 @@
+-- ================================
+-- event_deadline_extensions table
+-- ================================
+-- Every extension of an event's submission deadline, with the dates before and after
+CREATE TABLE IF NOT EXISTS event_deadline_extensions (
+    id VARCHAR(36) PRIMARY KEY,
+    event_id VARCHAR(36) NOT NULL,
+    previous_end_date TIMESTAMP NULL,
+    new_end_date TIMESTAMP NOT NULL,
+    reason TEXT NOT NULL,
+
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_by VARCHAR(36) NOT NULL,
+
+    CONSTRAINT fk_event_deadline_extensions_event
+        FOREIGN KEY (event_id)
+        REFERENCES events(id)
+        ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_deadline_extensions_event_id
+    ON event_deadline_extensions(event_id);
+
+
+-- ================================
+-- event:extend_deadline permission
+-- ================================
+INSERT INTO permissions (id, name, display_name, resource, action)
+SELECT gen_random_uuid(), 'extend_event_deadline', 'Extend Event Deadline', 'event', 'extend_deadline'
+WHERE NOT EXISTS (
+    SELECT 1 FROM permissions WHERE name = 'extend_event_deadline'
+);
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r, permissions p
+WHERE r.name IN ('superadmin', 'admin', 'client')
+AND p.name = 'extend_event_deadline'
+AND NOT EXISTS (
+    SELECT 1 FROM role_permissions rp
+    WHERE rp.role_id = r.id AND rp.permission_id = p.id
+);
@@ -0,0 +1,11 @@
+-- ================================
+-- Remove resource ownership
+-- ================================
+DELETE FROM role_permissions
+WHERE permission_id IN (
+    SELECT id FROM permissions WHERE name IN ('manage_all_events', 'manage_all_evaluations')
+);
+
+DELETE FROM permissions WHERE name IN ('manage_all_events', 'manage_all_evaluations');
+
+DROP TABLE IF EXISTS event_team_members;
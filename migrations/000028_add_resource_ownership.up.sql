@@ -0,0 +1,52 @@
+-- ================================
+-- event_team_members table
+-- ================================
+-- Users that manage an event together with its creator
+CREATE TABLE IF NOT EXISTS event_team_members (
+    id VARCHAR(36) PRIMARY KEY,
+    event_id VARCHAR(36) NOT NULL,
+    user_id UUID NOT NULL,
+
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_by VARCHAR(36) NOT NULL,
+
+    CONSTRAINT fk_event_team_members_event
+        FOREIGN KEY (event_id)
+        REFERENCES events(id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT fk_event_team_members_user
+        FOREIGN KEY (user_id)
+        REFERENCES users(id)
+        ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_event_team_members_event_user
+    ON event_team_members(event_id, user_id);
+
+
+-- ================================
+-- manage_all permissions
+-- ================================
+-- Let a user act on events and evaluations they do not own
+INSERT INTO permissions (id, name, display_name, resource, action)
+SELECT gen_random_uuid(), 'manage_all_events', 'Manage All Events', 'event', 'manage_all'
+WHERE NOT EXISTS (
+    SELECT 1 FROM permissions WHERE name = 'manage_all_events'
+);
+
+INSERT INTO permissions (id, name, display_name, resource, action)
+SELECT gen_random_uuid(), 'manage_all_evaluations', 'Manage All Evaluations', 'evaluation', 'manage_all'
+WHERE NOT EXISTS (
+    SELECT 1 FROM permissions WHERE name = 'manage_all_evaluations'
+);
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r, permissions p
+WHERE r.name IN ('superadmin', 'admin')
+AND p.name IN ('manage_all_events', 'manage_all_evaluations')
+AND NOT EXISTS (
+    SELECT 1 FROM role_permissions rp
+    WHERE rp.role_id = r.id AND rp.permission_id = p.id
+);
@@ -0,0 +1,32 @@
+-- ================================
+-- Remove payment approvals
+-- ================================
+DELETE FROM role_permissions
+WHERE permission_id IN (
+    SELECT id FROM permissions WHERE name IN (
+        'approve_payment',
+        'approve_payment_finance_staff',
+        'approve_payment_finance_manager',
+        'approve_payment_director',
+        'manage_payment_approval_levels'
+    )
+);
+
+DELETE FROM permissions WHERE name IN (
+    'approve_payment',
+    'approve_payment_finance_staff',
+    'approve_payment_finance_manager',
+    'approve_payment_director',
+    'manage_payment_approval_levels'
+);
+
+DROP TABLE IF EXISTS payment_approvals;
+DROP TABLE IF EXISTS payment_approval_levels;
+
+-- Enum values cannot be dropped; approved payments fall back to pending and rejected ones are cancelled
+UPDATE payments SET status = 'pending' WHERE status = 'approved';
+UPDATE payments SET status = 'cancelled' WHERE status = 'rejected';
+
+ALTER TABLE payments
+DROP COLUMN IF EXISTS rejection_reason,
+DROP COLUMN IF EXISTS approval_round;
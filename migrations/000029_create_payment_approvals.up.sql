@@ -0,0 +1,127 @@
+-- ================================
+-- Payment approval statuses
+-- ================================
+-- pending payments wait for their approval chain; approved ones can be marked paid
+ALTER TYPE payment_status ADD VALUE IF NOT EXISTS 'approved';
+ALTER TYPE payment_status ADD VALUE IF NOT EXISTS 'rejected';
+
+ALTER TABLE payments
+ADD COLUMN IF NOT EXISTS approval_round INT NOT NULL DEFAULT 1,
+ADD COLUMN IF NOT EXISTS rejection_reason TEXT NULL;
+
+COMMENT ON COLUMN payments.status IS 'scheduled, pending, approved, rejected, paid, cancelled';
+COMMENT ON COLUMN payments.approval_round IS 'incremented whenever the approval chain restarts, e.g. after a rejection';
+
+
+-- ================================
+-- payment_approval_levels table
+-- ================================
+-- A level is required for payments whose amount exceeds its min_amount
+CREATE TABLE IF NOT EXISTS payment_approval_levels (
+    id VARCHAR(36) PRIMARY KEY,
+    level INT NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    min_amount DECIMAL(15,2) NOT NULL DEFAULT 0,
+    permission_action VARCHAR(50) NOT NULL,
+
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_by VARCHAR(36) NULL
+);
+
+COMMENT ON COLUMN payment_approval_levels.permission_action IS 'payment permission action an approver of this level must hold';
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_payment_approval_levels_level
+    ON payment_approval_levels(level);
+
+INSERT INTO payment_approval_levels (id, level, name, min_amount, permission_action)
+SELECT gen_random_uuid(), v.level, v.name, v.min_amount, v.permission_action
+FROM (VALUES
+    (1, 'Finance Staff', 0, 'approve_finance_staff'),
+    (2, 'Finance Manager', 10000000, 'approve_finance_manager'),
+    (3, 'Director', 100000000, 'approve_director')
+) AS v(level, name, min_amount, permission_action)
+WHERE NOT EXISTS (SELECT 1 FROM payment_approval_levels);
+
+
+-- ================================
+-- payment_approvals table
+-- ================================
+-- Every approval or rejection of a payment, per approval round
+CREATE TABLE IF NOT EXISTS payment_approvals (
+    id VARCHAR(36) PRIMARY KEY,
+    payment_id VARCHAR(36) NOT NULL,
+    round INT NOT NULL,
+    level INT NOT NULL,
+    level_name VARCHAR(100) NOT NULL,
+    decision VARCHAR(20) NOT NULL,
+    comment TEXT NULL,
+
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_by VARCHAR(36) NOT NULL,
+
+    CONSTRAINT fk_payment_approvals_payment
+        FOREIGN KEY (payment_id)
+        REFERENCES payments(id)
+        ON DELETE CASCADE
+);
+
+COMMENT ON COLUMN payment_approvals.decision IS 'approved, rejected';
+
+CREATE INDEX IF NOT EXISTS idx_payment_approvals_payment_id
+    ON payment_approvals(payment_id);
+
+
+-- ================================
+-- Payment approval permissions
+-- ================================
+-- payment:approve opens the approve and reject endpoints; the level permissions decide which steps a user signs
+INSERT INTO permissions (id, name, display_name, resource, action)
+SELECT gen_random_uuid(), 'approve_payment', 'Approve Payment', 'payment', 'approve'
+WHERE NOT EXISTS (
+    SELECT 1 FROM permissions WHERE name = 'approve_payment'
+);
+
+INSERT INTO permissions (id, name, display_name, resource, action)
+SELECT gen_random_uuid(), 'approve_payment_finance_staff', 'Approve Payment as Finance Staff', 'payment', 'approve_finance_staff'
+WHERE NOT EXISTS (
+    SELECT 1 FROM permissions WHERE name = 'approve_payment_finance_staff'
+);
+
+INSERT INTO permissions (id, name, display_name, resource, action)
+SELECT gen_random_uuid(), 'approve_payment_finance_manager', 'Approve Payment as Finance Manager', 'payment', 'approve_finance_manager'
+WHERE NOT EXISTS (
+    SELECT 1 FROM permissions WHERE name = 'approve_payment_finance_manager'
+);
+
+INSERT INTO permissions (id, name, display_name, resource, action)
+SELECT gen_random_uuid(), 'approve_payment_director', 'Approve Payment as Director', 'payment', 'approve_director'
+WHERE NOT EXISTS (
+    SELECT 1 FROM permissions WHERE name = 'approve_payment_director'
+);
+
+INSERT INTO permissions (id, name, display_name, resource, action)
+SELECT gen_random_uuid(), 'manage_payment_approval_levels', 'Manage Payment Approval Levels', 'payment', 'manage_approval_levels'
+WHERE NOT EXISTS (
+    SELECT 1 FROM permissions WHERE name = 'manage_payment_approval_levels'
+);
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r, permissions p
+WHERE r.name IN ('superadmin', 'admin')
+AND p.name IN ('approve_payment', 'approve_payment_finance_staff', 'approve_payment_finance_manager', 'approve_payment_director')
+AND NOT EXISTS (
+    SELECT 1 FROM role_permissions rp
+    WHERE rp.role_id = r.id AND rp.permission_id = p.id
+);
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r, permissions p
+WHERE r.name = 'superadmin'
+AND p.name = 'manage_payment_approval_levels'
+AND NOT EXISTS (
+    SELECT 1 FROM role_permissions rp
+    WHERE rp.role_id = r.id AND rp.permission_id = p.id
+);
@@ -0,0 +1,15 @@
+-- ================================
+-- Remove vendor invoices
+-- ================================
+DELETE FROM role_permissions
+WHERE permission_id IN (
+    SELECT id FROM permissions WHERE name IN ('submit_invoice', 'view_invoices', 'list_invoices', 'verify_invoice')
+);
+
+DELETE FROM permissions WHERE name IN ('submit_invoice', 'view_invoices', 'list_invoices', 'verify_invoice');
+
+ALTER TABLE payments
+DROP COLUMN IF EXISTS invoice_id;
+
+DROP TABLE IF EXISTS invoice_files;
+DROP TABLE IF EXISTS invoices;
@@ -0,0 +1,148 @@
+-- ================================
+-- invoices table
+-- ================================
+-- Invoices submitted by vendors; verified invoices are converted into payments
+CREATE TABLE IF NOT EXISTS invoices (
+    id VARCHAR(36) PRIMARY KEY,
+    invoice_number VARCHAR(100) NOT NULL,
+    vendor_id VARCHAR(36) NOT NULL,
+    event_id VARCHAR(36) NULL,
+    award_id VARCHAR(36) NULL,
+    invoice_date TIMESTAMP NOT NULL,
+    due_date TIMESTAMP NULL,
+    subtotal DECIMAL(15,2) NOT NULL,
+    vat_amount DECIMAL(15,2) NOT NULL DEFAULT 0,
+    amount DECIMAL(15,2) NOT NULL,
+    tax_invoice_number VARCHAR(50) NULL,
+    description TEXT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'submitted',
+    rejection_reason TEXT NULL,
+    verified_at TIMESTAMP NULL,
+    verified_by VARCHAR(36) NULL,
+    payment_id VARCHAR(36) NULL,
+
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_by VARCHAR(36) NOT NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_by VARCHAR(36) NOT NULL,
+    deleted_at TIMESTAMP NULL,
+    deleted_by VARCHAR(36) NULL,
+
+    CONSTRAINT fk_invoices_vendor
+        FOREIGN KEY (vendor_id)
+        REFERENCES vendors(id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT fk_invoices_event
+        FOREIGN KEY (event_id)
+        REFERENCES events(id)
+        ON DELETE SET NULL,
+
+    CONSTRAINT fk_invoices_award
+        FOREIGN KEY (award_id)
+        REFERENCES event_awards(id)
+        ON DELETE SET NULL,
+
+    CONSTRAINT fk_invoices_payment
+        FOREIGN KEY (payment_id)
+        REFERENCES payments(id)
+        ON DELETE SET NULL
+);
+
+COMMENT ON COLUMN invoices.subtotal IS 'amount before VAT (DPP)';
+COMMENT ON COLUMN invoices.vat_amount IS 'VAT (PPN) charged by the vendor';
+COMMENT ON COLUMN invoices.amount IS 'subtotal + vat_amount';
+COMMENT ON COLUMN invoices.tax_invoice_number IS 'faktur pajak number, required when VAT is charged';
+COMMENT ON COLUMN invoices.status IS 'submitted, verified, rejected, converted';
+
+-- A rejected invoice number can be submitted again
+CREATE UNIQUE INDEX IF NOT EXISTS idx_invoices_vendor_invoice_number
+    ON invoices(vendor_id, invoice_number)
+    WHERE status <> 'rejected' AND deleted_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_invoices_status
+    ON invoices(status);
+
+
+-- ================================
+-- invoice_files table
+-- ================================
+CREATE TABLE IF NOT EXISTS invoice_files (
+    id VARCHAR(36) PRIMARY KEY,
+    invoice_id VARCHAR(36) NOT NULL,
+    file_type VARCHAR(20) NOT NULL,
+    file_url TEXT NOT NULL,
+
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_by VARCHAR(36) NOT NULL,
+    deleted_at TIMESTAMP NULL,
+
+    CONSTRAINT fk_invoice_files_invoice
+        FOREIGN KEY (invoice_id)
+        REFERENCES invoices(id)
+        ON DELETE CASCADE
+);
+
+COMMENT ON COLUMN invoice_files.file_type IS 'invoice, tax_invoice, delivery_proof';
+
+CREATE INDEX IF NOT EXISTS idx_invoice_files_invoice_id
+    ON invoice_files(invoice_id);
+
+
+-- ================================
+-- Payments converted from invoices
+-- ================================
+ALTER TABLE payments
+ADD COLUMN IF NOT EXISTS invoice_id VARCHAR(36) NULL;
+
+CREATE INDEX IF NOT EXISTS idx_payments_invoice_id
+    ON payments(invoice_id);
+
+
+-- ================================
+-- Invoice permissions
+-- ================================
+INSERT INTO permissions (id, name, display_name, resource, action)
+SELECT gen_random_uuid(), 'submit_invoice', 'Submit Invoice', 'invoice', 'submit'
+WHERE NOT EXISTS (
+    SELECT 1 FROM permissions WHERE name = 'submit_invoice'
+);
+
+INSERT INTO permissions (id, name, display_name, resource, action)
+SELECT gen_random_uuid(), 'view_invoices', 'View Invoice Detail', 'invoice', 'view'
+WHERE NOT EXISTS (
+    SELECT 1 FROM permissions WHERE name = 'view_invoices'
+);
+
+INSERT INTO permissions (id, name, display_name, resource, action)
+SELECT gen_random_uuid(), 'list_invoices', 'List Invoices', 'invoice', 'list'
+WHERE NOT EXISTS (
+    SELECT 1 FROM permissions WHERE name = 'list_invoices'
+);
+
+INSERT INTO permissions (id, name, display_name, resource, action)
+SELECT gen_random_uuid(), 'verify_invoice', 'Verify Invoice', 'invoice', 'verify'
+WHERE NOT EXISTS (
+    SELECT 1 FROM permissions WHERE name = 'verify_invoice'
+);
+
+-- Vendors submit and view their own invoices; list is for all invoices
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r, permissions p
+WHERE r.name IN ('superadmin', 'vendor')
+AND p.name IN ('submit_invoice', 'view_invoices')
+AND NOT EXISTS (
+    SELECT 1 FROM role_permissions rp
+    WHERE rp.role_id = r.id AND rp.permission_id = p.id
+);
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r, permissions p
+WHERE r.name IN ('superadmin', 'admin')
+AND p.name IN ('view_invoices', 'list_invoices', 'verify_invoice')
+AND NOT EXISTS (
+    SELECT 1 FROM role_permissions rp
+    WHERE rp.role_id = r.id AND rp.permission_id = p.id
+);
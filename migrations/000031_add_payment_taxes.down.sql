@@ -0,0 +1,24 @@
+-- ================================
+-- Remove payment taxes
+-- ================================
+DELETE FROM role_permissions
+WHERE permission_id IN (
+    SELECT id FROM permissions WHERE name IN ('override_payment_tax', 'manage_tax_rules')
+);
+
+DELETE FROM permissions WHERE name IN ('override_payment_tax', 'manage_tax_rules');
+
+DROP INDEX IF EXISTS idx_payments_withholding;
+
+ALTER TABLE payments
+DROP COLUMN IF EXISTS tax_override_reason,
+DROP COLUMN IF EXISTS tax_overridden_by,
+DROP COLUMN IF EXISTS net_amount,
+DROP COLUMN IF EXISTS withholding_amount,
+DROP COLUMN IF EXISTS withholding_rate,
+DROP COLUMN IF EXISTS withholding_type,
+DROP COLUMN IF EXISTS vat_amount,
+DROP COLUMN IF EXISTS vat_rate,
+DROP COLUMN IF EXISTS tax_base;
+
+DROP TABLE IF EXISTS tax_rules;
@@ -0,0 +1,94 @@
+-- ================================
+-- tax_rules table
+-- ================================
+-- VAT (PPN) and withholding (PPh) rates applied to payments by vendor type and tax status
+CREATE TABLE IF NOT EXISTS tax_rules (
+    id VARCHAR(36) PRIMARY KEY,
+    vendor_type VARCHAR(20) NOT NULL,
+    tax_status VARCHAR(20) NOT NULL,
+    vat_rate DECIMAL(5,2) NOT NULL DEFAULT 0,
+    withholding_type VARCHAR(20) NOT NULL DEFAULT 'none',
+    withholding_rate DECIMAL(5,2) NOT NULL DEFAULT 0,
+    no_npwp_withholding_rate DECIMAL(5,2) NOT NULL DEFAULT 0,
+
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_by VARCHAR(36) NULL
+);
+
+COMMENT ON COLUMN tax_rules.vendor_type IS 'company, individual';
+COMMENT ON COLUMN tax_rules.tax_status IS 'pkp, non_pkp';
+COMMENT ON COLUMN tax_rules.withholding_type IS 'pph23, pph21, none';
+COMMENT ON COLUMN tax_rules.no_npwp_withholding_rate IS 'withholding rate for vendors without an NPWP';
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tax_rules_vendor_type_tax_status
+    ON tax_rules(vendor_type, tax_status);
+
+INSERT INTO tax_rules (id, vendor_type, tax_status, vat_rate, withholding_type, withholding_rate, no_npwp_withholding_rate)
+SELECT gen_random_uuid(), v.vendor_type, v.tax_status, v.vat_rate, v.withholding_type, v.withholding_rate, v.no_npwp_withholding_rate
+FROM (VALUES
+    ('company', 'pkp', 11, 'pph23', 2, 4),
+    ('company', 'non_pkp', 0, 'pph23', 2, 4),
+    ('individual', 'pkp', 11, 'pph21', 2.5, 3),
+    ('individual', 'non_pkp', 0, 'pph21', 2.5, 3)
+) AS v(vendor_type, tax_status, vat_rate, withholding_type, withholding_rate, no_npwp_withholding_rate)
+WHERE NOT EXISTS (SELECT 1 FROM tax_rules);
+
+
+-- ================================
+-- Payment tax breakdown
+-- ================================
+-- amount stays the gross amount; net_amount is what is transferred after withholding
+ALTER TABLE payments
+ADD COLUMN IF NOT EXISTS tax_base DECIMAL(15,2) NULL,
+ADD COLUMN IF NOT EXISTS vat_rate DECIMAL(5,2) NULL,
+ADD COLUMN IF NOT EXISTS vat_amount DECIMAL(15,2) NULL,
+ADD COLUMN IF NOT EXISTS withholding_type VARCHAR(20) NULL,
+ADD COLUMN IF NOT EXISTS withholding_rate DECIMAL(5,2) NULL,
+ADD COLUMN IF NOT EXISTS withholding_amount DECIMAL(15,2) NULL,
+ADD COLUMN IF NOT EXISTS net_amount DECIMAL(15,2) NULL,
+ADD COLUMN IF NOT EXISTS tax_overridden_by VARCHAR(36) NULL,
+ADD COLUMN IF NOT EXISTS tax_override_reason TEXT NULL;
+
+COMMENT ON COLUMN payments.tax_base IS 'DPP';
+COMMENT ON COLUMN payments.withholding_type IS 'pph23, pph21, none';
+
+CREATE INDEX IF NOT EXISTS idx_payments_withholding
+    ON payments(vendor_id, payment_date)
+    WHERE withholding_amount > 0;
+
+
+-- ================================
+-- Payment tax permissions
+-- ================================
+INSERT INTO permissions (id, name, display_name, resource, action)
+SELECT gen_random_uuid(), 'override_payment_tax', 'Override Payment Tax', 'payment', 'override_tax'
+WHERE NOT EXISTS (
+    SELECT 1 FROM permissions WHERE name = 'override_payment_tax'
+);
+
+INSERT INTO permissions (id, name, display_name, resource, action)
+SELECT gen_random_uuid(), 'manage_tax_rules', 'Manage Tax Rules', 'payment', 'manage_tax_rules'
+WHERE NOT EXISTS (
+    SELECT 1 FROM permissions WHERE name = 'manage_tax_rules'
+);
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r, permissions p
+WHERE r.name IN ('superadmin', 'admin')
+AND p.name = 'override_payment_tax'
+AND NOT EXISTS (
+    SELECT 1 FROM role_permissions rp
+    WHERE rp.role_id = r.id AND rp.permission_id = p.id
+);
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r, permissions p
+WHERE r.name = 'superadmin'
+AND p.name = 'manage_tax_rules'
+AND NOT EXISTS (
+    SELECT 1 FROM role_permissions rp
+    WHERE rp.role_id = r.id AND rp.permission_id = p.id
+);
@@ -0,0 +1,21 @@
+-- ================================
+-- Remove disbursement batches
+-- ================================
+DELETE FROM role_permissions
+WHERE permission_id IN (
+    SELECT id FROM permissions WHERE name = 'disburse_payment'
+);
+
+DELETE FROM permissions WHERE name = 'disburse_payment';
+
+-- Enum values cannot be dropped; processing payments fall back to approved
+UPDATE payments SET status = 'approved' WHERE status = 'processing';
+
+DROP INDEX IF EXISTS idx_payments_disbursement_batch_id;
+
+ALTER TABLE payments
+DROP COLUMN IF EXISTS disbursement_batch_id;
+
+DROP TABLE IF EXISTS disbursement_batches;
+
+COMMENT ON COLUMN payments.status IS 'scheduled, pending, approved, rejected, paid, cancelled';
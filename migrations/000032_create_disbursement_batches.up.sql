@@ -0,0 +1,62 @@
+-- ================================
+-- Payment processing status
+-- ================================
+-- processing payments are in a bulk transfer file that was handed to the bank
+ALTER TYPE payment_status ADD VALUE IF NOT EXISTS 'processing';
+
+COMMENT ON COLUMN payments.status IS 'scheduled, pending, approved, rejected, processing, paid, cancelled';
+
+
+-- ================================
+-- disbursement_batches table
+-- ================================
+-- A bulk transfer file generated from approved payments
+CREATE TABLE IF NOT EXISTS disbursement_batches (
+    id VARCHAR(36) PRIMARY KEY,
+    batch_number VARCHAR(50) NOT NULL,
+    format VARCHAR(30) NOT NULL,
+    file_name VARCHAR(255) NOT NULL,
+    file_url TEXT NOT NULL,
+    checksum VARCHAR(64) NOT NULL,
+    total_amount DECIMAL(15,2) NOT NULL,
+    payment_count INT NOT NULL,
+
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_by VARCHAR(36) NOT NULL
+);
+
+COMMENT ON COLUMN disbursement_batches.format IS 'csv, fixed_width';
+COMMENT ON COLUMN disbursement_batches.checksum IS 'hex SHA-256 of the generated file';
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_disbursement_batches_batch_number
+    ON disbursement_batches(batch_number);
+
+
+-- ================================
+-- Payment disbursement batch
+-- ================================
+ALTER TABLE payments
+ADD COLUMN IF NOT EXISTS disbursement_batch_id VARCHAR(36) NULL;
+
+CREATE INDEX IF NOT EXISTS idx_payments_disbursement_batch_id
+    ON payments(disbursement_batch_id);
+
+
+-- ================================
+-- Disbursement permissions
+-- ================================
+INSERT INTO permissions (id, name, display_name, resource, action)
+SELECT gen_random_uuid(), 'disburse_payment', 'Disburse Payment', 'payment', 'disburse'
+WHERE NOT EXISTS (
+    SELECT 1 FROM permissions WHERE name = 'disburse_payment'
+);
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r, permissions p
+WHERE r.name IN ('superadmin', 'admin')
+AND p.name = 'disburse_payment'
+AND NOT EXISTS (
+    SELECT 1 FROM role_permissions rp
+    WHERE rp.role_id = r.id AND rp.permission_id = p.id
+);
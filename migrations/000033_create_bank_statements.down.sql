@@ -0,0 +1,13 @@
+-- ================================
+-- Remove bank statements
+-- ================================
+DELETE FROM role_permissions
+WHERE permission_id IN (
+    SELECT id FROM permissions WHERE name = 'reconcile_payment'
+);
+
+DELETE FROM permissions WHERE name = 'reconcile_payment';
+
+DROP TABLE IF EXISTS bank_statement_suggestions;
+DROP TABLE IF EXISTS bank_statement_lines;
+DROP TABLE IF EXISTS bank_statements;
@@ -0,0 +1,101 @@
+-- ================================
+-- bank_statements table
+-- ================================
+-- An imported bank statement; the checksum keeps the same file from being imported twice
+CREATE TABLE IF NOT EXISTS bank_statements (
+    id VARCHAR(36) PRIMARY KEY,
+    file_name VARCHAR(255) NOT NULL,
+    format VARCHAR(30) NOT NULL,
+    file_url TEXT NOT NULL,
+    checksum VARCHAR(64) NOT NULL,
+    period_from DATE NULL,
+    period_to DATE NULL,
+    line_count INT NOT NULL DEFAULT 0,
+
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_by VARCHAR(36) NOT NULL
+);
+
+COMMENT ON COLUMN bank_statements.format IS 'csv, mt940';
+COMMENT ON COLUMN bank_statements.checksum IS 'hex SHA-256 of the imported file';
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_bank_statements_checksum
+    ON bank_statements(checksum);
+
+
+-- ================================
+-- bank_statement_lines table
+-- ================================
+-- One booked entry of a statement and how it was reconciled
+CREATE TABLE IF NOT EXISTS bank_statement_lines (
+    id VARCHAR(36) PRIMARY KEY,
+    statement_id VARCHAR(36) NOT NULL,
+    line_no INT NOT NULL,
+    entry_date DATE NOT NULL,
+    direction VARCHAR(10) NOT NULL,
+    amount DECIMAL(15,2) NOT NULL,
+    description TEXT NULL,
+    reference VARCHAR(100) NULL,
+    status VARCHAR(20) NOT NULL,
+    payment_id VARCHAR(36) NULL,
+    resolved_at TIMESTAMP NULL,
+    resolved_by VARCHAR(36) NULL,
+    resolution_note TEXT NULL,
+
+    CONSTRAINT fk_bank_statement_lines_statement
+        FOREIGN KEY (statement_id)
+        REFERENCES bank_statements(id)
+        ON DELETE CASCADE
+);
+
+COMMENT ON COLUMN bank_statement_lines.direction IS 'debit, credit';
+COMMENT ON COLUMN bank_statement_lines.status IS 'matched, review, unmatched, dismissed, ignored';
+COMMENT ON COLUMN bank_statement_lines.resolved_by IS 'NULL when the line was matched automatically';
+
+CREATE INDEX IF NOT EXISTS idx_bank_statement_lines_statement_id
+    ON bank_statement_lines(statement_id);
+
+CREATE INDEX IF NOT EXISTS idx_bank_statement_lines_status
+    ON bank_statement_lines(status);
+
+
+-- ================================
+-- bank_statement_suggestions table
+-- ================================
+-- Candidate payments of a line that needs review
+CREATE TABLE IF NOT EXISTS bank_statement_suggestions (
+    id VARCHAR(36) PRIMARY KEY,
+    line_id VARCHAR(36) NOT NULL,
+    payment_id VARCHAR(36) NOT NULL,
+    reason VARCHAR(30) NOT NULL,
+
+    CONSTRAINT fk_bank_statement_suggestions_line
+        FOREIGN KEY (line_id)
+        REFERENCES bank_statement_lines(id)
+        ON DELETE CASCADE
+);
+
+COMMENT ON COLUMN bank_statement_suggestions.reason IS 'invoice_number, vendor_account, amount_date';
+
+CREATE INDEX IF NOT EXISTS idx_bank_statement_suggestions_line_id
+    ON bank_statement_suggestions(line_id);
+
+
+-- ================================
+-- Reconciliation permissions
+-- ================================
+INSERT INTO permissions (id, name, display_name, resource, action)
+SELECT gen_random_uuid(), 'reconcile_payment', 'Reconcile Payment', 'payment', 'reconcile'
+WHERE NOT EXISTS (
+    SELECT 1 FROM permissions WHERE name = 'reconcile_payment'
+);
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r, permissions p
+WHERE r.name IN ('superadmin', 'admin')
+AND p.name = 'reconcile_payment'
+AND NOT EXISTS (
+    SELECT 1 FROM role_permissions rp
+    WHERE rp.role_id = r.id AND rp.permission_id = p.id
+);
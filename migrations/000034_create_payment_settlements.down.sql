@@ -0,0 +1,19 @@
+-- ================================
+-- Remove payment settlements
+-- ================================
+DELETE FROM role_permissions
+WHERE permission_id IN (
+    SELECT id FROM permissions WHERE name = 'settle_payment'
+);
+
+DELETE FROM permissions WHERE name = 'settle_payment';
+
+-- Enum values cannot be dropped; partially paid payments fall back to approved
+UPDATE payments SET status = 'approved' WHERE status = 'partially_paid';
+
+DROP TABLE IF EXISTS payment_settlements;
+
+ALTER TABLE payments
+DROP COLUMN IF EXISTS paid_amount;
+
+COMMENT ON COLUMN payments.status IS 'scheduled, pending, approved, rejected, processing, paid, cancelled';
@@ -0,0 +1,72 @@
+-- ================================
+-- Partially paid status
+-- ================================
+-- partially_paid payments have settlements that do not cover their transfer amount yet
+ALTER TYPE payment_status ADD VALUE IF NOT EXISTS 'partially_paid';
+
+ALTER TABLE payments
+ADD COLUMN IF NOT EXISTS paid_amount DECIMAL(15,2) NOT NULL DEFAULT 0;
+
+COMMENT ON COLUMN payments.status IS 'scheduled, pending, approved, rejected, processing, partially_paid, paid, cancelled';
+COMMENT ON COLUMN payments.paid_amount IS 'sum of the settlements; the transfer amount is net_amount, or amount before the tax is computed';
+
+
+-- ================================
+-- payment_settlements table
+-- ================================
+-- One transfer towards a payment
+CREATE TABLE IF NOT EXISTS payment_settlements (
+    id VARCHAR(36) PRIMARY KEY,
+    payment_id VARCHAR(36) NOT NULL,
+    amount DECIMAL(15,2) NOT NULL,
+    settlement_date DATE NOT NULL,
+    reference VARCHAR(100) NULL,
+    file_url TEXT NULL,
+    statement_line_id VARCHAR(36) NULL,
+    note TEXT NULL,
+
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_by VARCHAR(36) NOT NULL,
+
+    CONSTRAINT fk_payment_settlements_payment
+        FOREIGN KEY (payment_id)
+        REFERENCES payments(id)
+        ON DELETE CASCADE
+);
+
+COMMENT ON COLUMN payment_settlements.statement_line_id IS 'bank statement line the settlement was reconciled from';
+
+CREATE INDEX IF NOT EXISTS idx_payment_settlements_payment_id
+    ON payment_settlements(payment_id);
+
+-- Payments paid before installments were recorded are settled in full on their payment date
+INSERT INTO payment_settlements (id, payment_id, amount, settlement_date, note, created_at, created_by)
+SELECT gen_random_uuid(), p.id, COALESCE(p.net_amount, p.amount), COALESCE(p.payment_date, p.updated_at)::date,
+    'settled in full before installments were recorded', p.updated_at, p.updated_by
+FROM payments p
+WHERE p.status = 'paid'
+AND NOT EXISTS (
+    SELECT 1 FROM payment_settlements ps WHERE ps.payment_id = p.id
+);
+
+UPDATE payments SET paid_amount = COALESCE(net_amount, amount) WHERE status = 'paid';
+
+
+-- ================================
+-- Payment settlement permissions
+-- ================================
+INSERT INTO permissions (id, name, display_name, resource, action)
+SELECT gen_random_uuid(), 'settle_payment', 'Settle Payment', 'payment', 'settle'
+WHERE NOT EXISTS (
+    SELECT 1 FROM permissions WHERE name = 'settle_payment'
+);
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r, permissions p
+WHERE r.name IN ('superadmin', 'admin')
+AND p.name = 'settle_payment'
+AND NOT EXISTS (
+    SELECT 1 FROM role_permissions rp
+    WHERE rp.role_id = r.id AND rp.permission_id = p.id
+);
@@ -0,0 +1,4 @@
+-- ================================
+-- Remove one approval per approval level
+-- ================================
+DROP INDEX IF EXISTS idx_payment_approvals_payment_round_level;
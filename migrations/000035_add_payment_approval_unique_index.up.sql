@@ -0,0 +1,7 @@
+-- ================================
+-- One approval per approval level
+-- ================================
+-- A level of an approval round is signed once, even when two approvers sign it at the same time
+CREATE UNIQUE INDEX IF NOT EXISTS idx_payment_approvals_payment_round_level
+    ON payment_approvals(payment_id, round, level)
+    WHERE decision = 'approved';
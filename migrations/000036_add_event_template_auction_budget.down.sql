@@ -0,0 +1,16 @@
+-- ================================
+-- Remove event type, auction and budget settings from templates
+-- ================================
+ALTER TABLE event_templates
+DROP COLUMN IF EXISTS event_type,
+DROP COLUMN IF EXISTS auction_start_offset_minutes,
+DROP COLUMN IF EXISTS auction_duration_minutes,
+DROP COLUMN IF EXISTS auction_start_price,
+DROP COLUMN IF EXISTS auction_min_decrement,
+DROP COLUMN IF EXISTS auction_min_decrement_percent,
+DROP COLUMN IF EXISTS auction_extension_window_minutes,
+DROP COLUMN IF EXISTS auction_extension_minutes,
+DROP COLUMN IF EXISTS owner_estimate,
+DROP COLUMN IF EXISTS budget_ceiling,
+DROP COLUMN IF EXISTS abnormally_low_percent,
+DROP COLUMN IF EXISTS contract_value;
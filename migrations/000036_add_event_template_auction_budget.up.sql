@@ -0,0 +1,19 @@
+-- ================================
+-- Event type, auction and budget settings on templates
+-- ================================
+-- The auction window is kept relative to the start date, so copies get a window of their own
+ALTER TABLE event_templates
+ADD COLUMN IF NOT EXISTS event_type VARCHAR(20) NOT NULL DEFAULT 'tender',
+ADD COLUMN IF NOT EXISTS auction_start_offset_minutes INT NULL,
+ADD COLUMN IF NOT EXISTS auction_duration_minutes INT NULL,
+ADD COLUMN IF NOT EXISTS auction_start_price DECIMAL(15,2) NULL,
+ADD COLUMN IF NOT EXISTS auction_min_decrement DECIMAL(15,2) NULL,
+ADD COLUMN IF NOT EXISTS auction_min_decrement_percent DECIMAL(5,2) NULL,
+ADD COLUMN IF NOT EXISTS auction_extension_window_minutes INT NOT NULL DEFAULT 0,
+ADD COLUMN IF NOT EXISTS auction_extension_minutes INT NOT NULL DEFAULT 0,
+ADD COLUMN IF NOT EXISTS owner_estimate DECIMAL(15,2) NULL,
+ADD COLUMN IF NOT EXISTS budget_ceiling DECIMAL(15,2) NULL,
+ADD COLUMN IF NOT EXISTS abnormally_low_percent DECIMAL(5,2) NULL,
+ADD COLUMN IF NOT EXISTS contract_value DECIMAL(15,2) NULL;
+
+COMMENT ON COLUMN event_templates.auction_start_offset_minutes IS 'minutes from the start date of a copy to the start of its auction';
@@ -0,0 +1,4 @@
+-- ================================
+-- Remove one submission per vendor and event
+-- ================================
+DROP INDEX IF EXISTS idx_event_submissions_event_vendor;
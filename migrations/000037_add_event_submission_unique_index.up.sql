@@ -0,0 +1,7 @@
+-- ================================
+-- One submission per vendor and event
+-- ================================
+-- Amendments and withdrawals act on the vendor's single submission of an event
+CREATE UNIQUE INDEX IF NOT EXISTS idx_event_submissions_event_vendor
+    ON event_submissions(event_id, vendor_id)
+    WHERE deleted_at IS NULL;
@@ -0,0 +1,324 @@
+package bankstatement
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Entry is one booked line of a bank statement; Amount is always positive
+type Entry struct {
+	Date        time.Time
+	Debit       bool
+	Amount      decimal.Decimal
+	Description string
+	Reference   string
+}
+
+// Parser reads the entries of a bank statement export
+type Parser interface {
+	Name() string
+	Parse(data []byte) ([]Entry, error)
+}
+
+var parsers = map[string]Parser{}
+
+// Register makes a parser available under its name, replacing any parser registered before
+func Register(p Parser) {
+	parsers[p.Name()] = p
+}
+
+func Get(name string) (Parser, bool) {
+	p, ok := parsers[name]
+	return p, ok
+}
+
+// Names lists the registered parsers in alphabetical order
+func Names() []string {
+	names := make([]string, 0, len(parsers))
+	for name := range parsers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func init() {
+	Register(CSV{})
+	Register(MT940{})
+}
+
+var csvColumns = map[string][]string{
+	"date":        {"date", "transaction_date", "value_date", "posting_date", "tanggal"},
+	"description": {"description", "remark", "remarks", "narrative", "keterangan"},
+	"amount":      {"amount", "jumlah", "mutasi"},
+	"debit":       {"debit", "db"},
+	"credit":      {"credit", "cr", "kredit"},
+	"type":        {"type", "dc", "d/c", "db/cr", "direction"},
+	"reference":   {"reference", "ref", "reference_number", "no_ref"},
+}
+
+var csvDateLayouts = []string{"2006-01-02", "02/01/2006", "02-01-2006", "2006/01/02", "02/01/06", "2006-01-02 15:04:05"}
+
+// CSV reads a statement with a header row naming its columns. It needs a date, a description and
+// either debit and credit columns or an amount, whose direction is taken from a type column
+// (D/DB/debit or C/CR/K/credit) or else from its sign.
+type CSV struct{}
+
+func (CSV) Name() string { return "csv" }
+
+func (CSV) Parse(data []byte) ([]Entry, error) {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	firstLine, _, _ := bytes.Cut(data, []byte("\n"))
+
+	r := csv.NewReader(bytes.NewReader(data))
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+	if bytes.Count(firstLine, []byte(";")) > bytes.Count(firstLine, []byte(",")) {
+		r.Comma = ';'
+	}
+
+	records, err := r.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("invalid csv statement: %w", err)
+	}
+	if len(records) < 2 {
+		return nil, errors.New("csv statement has no entries")
+	}
+
+	columns := map[string]int{}
+	for i, name := range records[0] {
+		name = strings.ToLower(strings.TrimSpace(name))
+		for key, aliases := range csvColumns {
+			for _, alias := range aliases {
+				if name == alias {
+					if _, ok := columns[key]; !ok {
+						columns[key] = i
+					}
+				}
+			}
+		}
+	}
+	_, hasAmount := columns["amount"]
+	_, hasDebit := columns["debit"]
+	if _, ok := columns["date"]; !ok {
+		return nil, errors.New("csv statement has no date column")
+	}
+	if _, ok := columns["description"]; !ok {
+		return nil, errors.New("csv statement has no description column")
+	}
+	if !hasAmount && !hasDebit {
+		return nil, errors.New("csv statement needs an amount column or debit and credit columns")
+	}
+
+	field := func(record []string, key string) string {
+		i, ok := columns[key]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	var entries []Entry
+	for n, record := range records[1:] {
+		line := n + 2
+		if strings.TrimSpace(strings.Join(record, "")) == "" {
+			continue
+		}
+
+		date, err := parseDate(field(record, "date"))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		entry := Entry{Date: date, Description: field(record, "description"), Reference: field(record, "reference")}
+
+		if hasAmount {
+			amount, err := parseAmount(field(record, "amount"))
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+			entry.Debit = amount.IsNegative()
+			if kind := strings.ToLower(field(record, "type")); kind != "" {
+				switch kind[0] {
+				case 'd':
+					entry.Debit = true
+				case 'c', 'k':
+					entry.Debit = false
+				default:
+					return nil, fmt.Errorf("line %d: unknown entry type %s", line, kind)
+				}
+			}
+			entry.Amount = amount.Abs()
+		} else {
+			debit, err := parseAmount(field(record, "debit"))
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+			credit, err := parseAmount(field(record, "credit"))
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+			entry.Debit = !debit.IsZero()
+			entry.Amount = debit.Abs()
+			if !entry.Debit {
+				entry.Amount = credit.Abs()
+			}
+		}
+
+		if entry.Amount.IsZero() {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+func parseDate(value string) (time.Time, error) {
+	for _, layout := range csvDateLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q", value)
+}
+
+// parseAmount reads amounts written with either a dot or a comma as decimal separator; the
+// separator that comes last is the decimal one, and a lone separator is only decimal when it is
+// followed by at most two digits
+func parseAmount(value string) (decimal.Decimal, error) {
+	s := strings.NewReplacer(" ", "", "\u00a0", "", "IDR", "", "Rp", "").Replace(value)
+	if s == "" || s == "-" {
+		return decimal.Zero, nil
+	}
+
+	dot, comma := strings.LastIndex(s, "."), strings.LastIndex(s, ",")
+	decimalSep := byte(0)
+	switch {
+	case dot >= 0 && comma >= 0:
+		decimalSep = s[max(dot, comma)]
+	case dot >= 0 && strings.Count(s, ".") == 1 && len(s)-dot-1 <= 2:
+		decimalSep = '.'
+	case comma >= 0 && strings.Count(s, ",") == 1 && len(s)-comma-1 <= 2:
+		decimalSep = ','
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == decimalSep:
+			b.WriteByte('.')
+		case c == '.' || c == ',':
+		default:
+			b.WriteByte(c)
+		}
+	}
+
+	amount, err := decimal.NewFromString(b.String())
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("invalid amount %q", value)
+	}
+	return amount, nil
+}
+
+var (
+	mt940Tag       = regexp.MustCompile(`^:(\d{2}[A-Z]?):`)
+	mt940Statement = regexp.MustCompile(`^(\d{6})(\d{4})?(RC|RD|C|D)[A-Z]?(\d+,\d*)(?:[NSF][A-Z0-9]{3})?([^/]*)(?://(.*))?`)
+)
+
+// MT940 reads SWIFT MT940 customer statements. Each :61: statement line is an entry and the :86:
+// field that follows it is its description; reversals of a credit (RC) are taken as debits.
+type MT940 struct{}
+
+func (MT940) Name() string { return "mt940" }
+
+func (MT940) Parse(data []byte) ([]Entry, error) {
+	var entries []Entry
+	var tag, content string
+
+	flush := func() error {
+		switch tag {
+		case "61":
+			entry, err := parseMT940Line(content)
+			if err != nil {
+				return err
+			}
+			entries = append(entries, entry)
+		case "86":
+			if len(entries) > 0 {
+				last := &entries[len(entries)-1]
+				last.Description = strings.TrimSpace(strings.Join([]string{last.Description, content}, " "))
+			}
+		}
+		tag, content = "", ""
+		return nil
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if m := mt940Tag.FindStringSubmatch(line); m != nil {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+			tag, content = m[1], strings.TrimPrefix(line, m[0])
+			continue
+		}
+		if tag == "" || strings.HasPrefix(line, "-}") || strings.HasPrefix(line, "{") {
+			continue
+		}
+		// :86: continues with more description; what follows :61: are supplementary details
+		if tag == "86" {
+			content += " " + strings.TrimSpace(line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+
+	if len(entries) == 0 {
+		return nil, errors.New("mt940 statement has no :61: entries")
+	}
+	return entries, nil
+}
+
+func parseMT940Line(content string) (Entry, error) {
+	m := mt940Statement.FindStringSubmatch(strings.TrimSpace(content))
+	if m == nil {
+		return Entry{}, fmt.Errorf("invalid mt940 statement line %q", content)
+	}
+
+	date, err := time.Parse("060102", m[1])
+	if err != nil {
+		return Entry{}, fmt.Errorf("invalid mt940 value date %q", m[1])
+	}
+	amount, err := decimal.NewFromString(strings.Replace(m[4], ",", ".", 1))
+	if err != nil {
+		return Entry{}, fmt.Errorf("invalid mt940 amount %q", m[4])
+	}
+
+	reference := strings.TrimSpace(m[5])
+	if reference == "NONREF" {
+		reference = ""
+	}
+	if bankRef := strings.TrimSpace(m[6]); reference == "" && bankRef != "" {
+		reference = bankRef
+	}
+
+	return Entry{
+		Date:      date,
+		Debit:     m[3] == "D" || m[3] == "RC",
+		Amount:    amount,
+		Reference: reference,
+	}, nil
+}
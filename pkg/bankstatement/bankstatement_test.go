@@ -0,0 +1,206 @@
+package bankstatement
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{value: "", want: "0"},
+		{value: "-", want: "0"},
+		{value: "1500000", want: "1500000"},
+		{value: "1500000.50", want: "1500000.5"},
+		{value: "1500000,50", want: "1500000.5"},
+		{value: "1,500,000.50", want: "1500000.5"},
+		{value: "1.500.000,50", want: "1500000.5"},
+		{value: "1.500.000", want: "1500000"},
+		{value: "1,500,000", want: "1500000"},
+		{value: "1.500", want: "1500"},
+		{value: "1,500", want: "1500"},
+		{value: "1.5", want: "1.5"},
+		{value: "12,34", want: "12.34"},
+		{value: "-250.000,00", want: "-250000"},
+		{value: "Rp 1.250.000", want: "1250000"},
+		{value: "IDR 1,250,000.00", want: "1250000"},
+		{value: "abc", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseAmount(tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseAmount(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && !got.Equal(decimal.RequireFromString(tt.want)) {
+			t.Errorf("parseAmount(%q) = %s, want %s", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestCSVParse(t *testing.T) {
+	date := time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		data    string
+		want    []Entry
+		wantErr string
+	}{
+		{
+			name: "signed amount",
+			data: "date,description,amount,reference\n" +
+				"2026-01-05,Transfer INV-001,-1.500.000,REF1\n" +
+				"2026-01-05,Incoming,250000.00,\n",
+			want: []Entry{
+				{Date: date, Debit: true, Amount: decimal.NewFromInt(1500000), Description: "Transfer INV-001", Reference: "REF1"},
+				{Date: date, Debit: false, Amount: decimal.NewFromInt(250000), Description: "Incoming"},
+			},
+		},
+		{
+			name: "type column overrides the sign",
+			data: "tanggal;keterangan;mutasi;db/cr\n" +
+				"05/01/2026;Transfer INV-002;1.250.000,00;DB\n" +
+				"05/01/2026;Bunga;1.000,00;CR\n",
+			want: []Entry{
+				{Date: date, Debit: true, Amount: decimal.NewFromInt(1250000), Description: "Transfer INV-002"},
+				{Date: date, Debit: false, Amount: decimal.NewFromInt(1000), Description: "Bunga"},
+			},
+		},
+		{
+			name: "debit and credit columns, byte order mark and empty rows",
+			data: "\xef\xbb\xbfDate,Remark,Debit,Credit\n" +
+				"2026-01-05,Transfer INV-003,\"1,000,000.00\",\n" +
+				",,,\n" +
+				"2026-01-05,Refund,,\"500.00\"\n" +
+				"2026-01-05,Nothing,0,0\n",
+			want: []Entry{
+				{Date: date, Debit: true, Amount: decimal.NewFromInt(1000000), Description: "Transfer INV-003"},
+				{Date: date, Debit: false, Amount: decimal.NewFromInt(500), Description: "Refund"},
+			},
+		},
+		{
+			name:    "unknown type",
+			data:    "date,description,amount,type\n2026-01-05,Transfer,100,X\n",
+			wantErr: "unknown entry type",
+		},
+		{
+			name:    "invalid date",
+			data:    "date,description,amount\n2026-13-45,Transfer,100\n",
+			wantErr: "line 2: invalid date",
+		},
+		{
+			name:    "no amount column",
+			data:    "date,description,reference\n2026-01-05,Transfer,REF\n",
+			wantErr: "needs an amount column",
+		},
+		{
+			name:    "no entries",
+			data:    "date,description,amount\n",
+			wantErr: "no entries",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CSV{}.Parse([]byte(tt.data))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Parse error = %v, want one containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse: %v", err)
+			}
+			assertEntries(t, got, tt.want)
+		})
+	}
+}
+
+func TestParseMT940Line(t *testing.T) {
+	tests := []struct {
+		content string
+		want    Entry
+		wantErr bool
+	}{
+		{
+			content: "2601050105D1500000,00NTRFINV-001//BANKREF1",
+			want:    Entry{Date: time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC), Debit: true, Amount: decimal.NewFromInt(1500000), Reference: "INV-001"},
+		},
+		{
+			content: "260105C250000,NTRFNONREF//BANKREF2",
+			want:    Entry{Date: time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC), Amount: decimal.NewFromInt(250000), Reference: "BANKREF2"},
+		},
+		{
+			content: "260105RC1000,5NMSCNONREF",
+			want:    Entry{Date: time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC), Debit: true, Amount: decimal.RequireFromString("1000.5")},
+		},
+		{
+			content: "260105RD75,25",
+			want:    Entry{Date: time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC), Amount: decimal.RequireFromString("75.25")},
+		},
+		{content: "260105X100,00NTRF", wantErr: true},
+		{content: "261305D100,00NTRF", wantErr: true},
+		{content: "D100,00", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseMT940Line(tt.content)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseMT940Line(%q) error = %v, wantErr %v", tt.content, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr {
+			assertEntries(t, []Entry{got}, []Entry{tt.want})
+		}
+	}
+}
+
+func TestMT940Parse(t *testing.T) {
+	data := "{1:F01BANKIDJAXXXX0000000000}{4:\r\n" +
+		":20:STMT260105\r\n" +
+		":25:1234567890\r\n" +
+		":60F:C260104IDR10000000,00\r\n" +
+		":61:2601050105D1500000,00NTRFINV-001//BANKREF1\r\n" +
+		"SUPPLEMENTARY DETAILS\r\n" +
+		":86:TRANSFER TO PT MAJU\r\n" +
+		"INV-001 TERM 1\r\n" +
+		":61:260105C250000,00NTRFNONREF\r\n" +
+		":62F:C260105IDR8750000,00\r\n" +
+		"-}\r\n"
+
+	got, err := MT940{}.Parse([]byte(data))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	date := time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)
+	assertEntries(t, got, []Entry{
+		{Date: date, Debit: true, Amount: decimal.NewFromInt(1500000), Description: "TRANSFER TO PT MAJU INV-001 TERM 1", Reference: "INV-001"},
+		{Date: date, Amount: decimal.NewFromInt(250000)},
+	})
+
+	if _, err := (MT940{}).Parse([]byte(":20:STMT\r\n:86:NO ENTRIES\r\n")); err == nil {
+		t.Error("Parse of a statement without :61: entries succeeded")
+	}
+}
+
+func assertEntries(t *testing.T, got, want []Entry) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("got %d entries, want %d: %+v", len(got), len(want), got)
+	}
+	for i := range want {
+		g, w := got[i], want[i]
+		if !g.Date.Equal(w.Date) || g.Debit != w.Debit || !g.Amount.Equal(w.Amount) || g.Description != w.Description || g.Reference != w.Reference {
+			t.Errorf("entry %d = %+v, want %+v", i, g, w)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package broadcast
+
+import "sync"
+
+// Broker fans out change signals to the subscribers of a topic. It only tells subscribers
+// that something changed; each subscriber reloads the state it is allowed to see.
+// Signals stay within this process.
+type Broker struct {
+	mu     sync.Mutex
+	topics map[string]map[chan struct{}]struct{}
+}
+
+func NewBroker() *Broker {
+	return &Broker{topics: make(map[string]map[chan struct{}]struct{})}
+}
+
+// Subscribe returns a channel that receives a signal after each Publish on the topic,
+// and a function that must be called to unsubscribe
+func (b *Broker) Subscribe(topic string) (<-chan struct{}, func()) {
+	ch := make(chan struct{}, 1)
+
+	b.mu.Lock()
+	if b.topics[topic] == nil {
+		b.topics[topic] = make(map[chan struct{}]struct{})
+	}
+	b.topics[topic][ch] = struct{}{}
+	b.mu.Unlock()
+
+	return ch, func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		delete(b.topics[topic], ch)
+		if len(b.topics[topic]) == 0 {
+			delete(b.topics, topic)
+		}
+	}
+}
+
+// Publish signals every subscriber of the topic. Signals are coalesced: a subscriber that
+// has not consumed the previous one yet is not blocked on.
+func (b *Broker) Publish(topic string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for ch := range b.topics[topic] {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
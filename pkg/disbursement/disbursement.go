@@ -0,0 +1,191 @@
+package disbursement
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Transfer is one credit of a bulk transfer file
+type Transfer struct {
+	Reference     string
+	Beneficiary   string
+	BankName      string
+	AccountNumber string
+	Amount        decimal.Decimal
+	Description   string
+}
+
+// Batch is the content of a bulk transfer file
+type Batch struct {
+	Number    string
+	CreatedAt time.Time
+	Transfers []Transfer
+}
+
+// Total is the sum of the transfers of the batch
+func (b Batch) Total() decimal.Decimal {
+	total := decimal.Zero
+	for _, t := range b.Transfers {
+		total = total.Add(t.Amount)
+	}
+	return total
+}
+
+// Format renders a batch as a bulk transfer file for internet banking
+type Format interface {
+	Name() string
+	Extension() string
+	ContentType() string
+	Render(b Batch) ([]byte, error)
+}
+
+var formats = map[string]Format{}
+
+// Register makes a format available under its name, replacing any format registered before
+func Register(f Format) {
+	formats[f.Name()] = f
+}
+
+func Get(name string) (Format, bool) {
+	f, ok := formats[name]
+	return f, ok
+}
+
+// Names lists the registered formats in alphabetical order
+func Names() []string {
+	names := make([]string, 0, len(formats))
+	for name := range formats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func init() {
+	Register(CSV{})
+	Register(FixedWidth{})
+}
+
+// CSV is a generic comma separated layout with a header row, accepted by most bulk upload screens
+type CSV struct{}
+
+func (CSV) Name() string        { return "csv" }
+func (CSV) Extension() string   { return "csv" }
+func (CSV) ContentType() string { return "text/csv" }
+
+func (CSV) Render(b Batch) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	rows := [][]string{{"no", "reference", "beneficiary_name", "bank_name", "account_number", "amount", "currency", "description"}}
+	for i, t := range b.Transfers {
+		rows = append(rows, []string{
+			fmt.Sprint(i + 1),
+			t.Reference,
+			t.Beneficiary,
+			t.BankName,
+			t.AccountNumber,
+			t.Amount.StringFixed(2),
+			"IDR",
+			t.Description,
+		})
+	}
+	if err := w.WriteAll(rows); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// FixedWidth is a fixed-width layout of a header record, one detail record per transfer and a
+// trailer record. Text is left aligned and space padded, amounts are zero padded in cents.
+// Free text that is too long is cut; the batch number, account numbers and references must fit.
+//
+//	H batch number(25) date YYYYMMDD(8) transfer count(6) total(18)
+//	D account number(20) beneficiary(35) bank name(30) amount(15) reference(20) description(35)
+//	T transfer count(6) total(18)
+type FixedWidth struct{}
+
+func (FixedWidth) Name() string        { return "fixed_width" }
+func (FixedWidth) Extension() string   { return "txt" }
+func (FixedWidth) ContentType() string { return "text/plain" }
+
+func (FixedWidth) Render(b Batch) ([]byte, error) {
+	var buf bytes.Buffer
+	count := len(b.Transfers)
+	total, err := cents(b.Total(), 18)
+	if err != nil {
+		return nil, err
+	}
+
+	if count > 999999 {
+		return nil, fmt.Errorf("%d transfers do not fit the fixed-width layout", count)
+	}
+	number, err := field("batch number", b.Number, 25)
+	if err != nil {
+		return nil, err
+	}
+
+	buf.WriteString("H" + number + b.CreatedAt.Format("20060102") + fmt.Sprintf("%06d", count) + total + "\r\n")
+	for _, t := range b.Transfers {
+		amount, err := cents(t.Amount, 15)
+		if err != nil {
+			return nil, fmt.Errorf("transfer %s: %w", t.Reference, err)
+		}
+		account, err := field("account number", t.AccountNumber, 20)
+		if err != nil {
+			return nil, fmt.Errorf("transfer %s: %w", t.Reference, err)
+		}
+		reference, err := field("reference", t.Reference, 20)
+		if err != nil {
+			return nil, err
+		}
+		buf.WriteString("D" + account + text(t.Beneficiary, 35) + text(t.BankName, 30) +
+			amount + reference + text(t.Description, 35) + "\r\n")
+	}
+	buf.WriteString("T" + fmt.Sprintf("%06d", count) + total + "\r\n")
+
+	return buf.Bytes(), nil
+}
+
+// text fits free text to width, cutting what does not fit
+func text(value string, width int) string {
+	clean := printable(value)
+	if len(clean) > width {
+		clean = clean[:width]
+	}
+	return clean + strings.Repeat(" ", width-len(clean))
+}
+
+// field pads an identifier to width; one that does not fit is refused, since a cut account number
+// or reference would send the money elsewhere or make the transfer impossible to match
+func field(name, value string, width int) (string, error) {
+	clean := printable(value)
+	if len(clean) > width {
+		return "", fmt.Errorf("%s %q is longer than the %d characters of the fixed-width layout", name, value, width)
+	}
+	return clean + strings.Repeat(" ", width-len(clean)), nil
+}
+
+// printable replaces characters a bank host cannot read with spaces
+func printable(value string) string {
+	return strings.Map(func(r rune) rune {
+		if r < 0x20 || r > 0x7e {
+			return ' '
+		}
+		return r
+	}, value)
+}
+
+func cents(amount decimal.Decimal, width int) (string, error) {
+	s := amount.Shift(2).Round(0).StringFixed(0)
+	if amount.IsNegative() || len(s) > width {
+		return "", fmt.Errorf("amount %s does not fit the fixed-width layout", amount.StringFixed(2))
+	}
+	return strings.Repeat("0", width-len(s)) + s, nil
+}
@@ -0,0 +1,159 @@
+package disbursement
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestCSVRender(t *testing.T) {
+	batch := Batch{
+		Number:    "DSB-20260105-001",
+		CreatedAt: time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC),
+		Transfers: []Transfer{
+			{Reference: "INV-001", Beneficiary: "PT Maju, Tbk", BankName: "BCA", AccountNumber: "1234567890", Amount: decimal.RequireFromString("1500000.5"), Description: "Term 1"},
+			{Reference: "INV-002", Beneficiary: "CV Jaya", BankName: "Mandiri", AccountNumber: "0987654321", Amount: decimal.RequireFromString("250000"), Description: `Say "hi"`},
+		},
+	}
+
+	got, err := CSV{}.Render(batch)
+	if err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	want := "no,reference,beneficiary_name,bank_name,account_number,amount,currency,description\n" +
+		"1,INV-001,\"PT Maju, Tbk\",BCA,1234567890,1500000.50,IDR,Term 1\n" +
+		"2,INV-002,CV Jaya,Mandiri,0987654321,250000.00,IDR,\"Say \"\"hi\"\"\"\n"
+	if string(got) != want {
+		t.Errorf("Render:\ngot  %q\nwant %q", got, want)
+	}
+}
+
+func TestFixedWidthRender(t *testing.T) {
+	transfer := Transfer{
+		Reference:     "INV-001",
+		Beneficiary:   "PT Maju Bersama",
+		BankName:      "BCA",
+		AccountNumber: "1234567890",
+		Amount:        decimal.RequireFromString("1500000.50"),
+		Description:   "Term 1",
+	}
+
+	tests := []struct {
+		name    string
+		batch   Batch
+		want    []string
+		wantErr string
+	}{
+		{
+			name:  "header, detail and trailer",
+			batch: Batch{Number: "DSB-001", Transfers: []Transfer{transfer}},
+			want: []string{
+				"H" + pad("DSB-001", 25) + "20260105" + "000001" + "000000000150000050",
+				"D" + pad("1234567890", 20) + pad("PT Maju Bersama", 35) + pad("BCA", 30) + "000000150000050" + pad("INV-001", 20) + pad("Term 1", 35),
+				"T" + "000001" + "000000000150000050",
+			},
+		},
+		{
+			name: "free text is cut and cleaned",
+			batch: Batch{Number: "DSB-002", Transfers: []Transfer{{
+				Reference:     "INV-002",
+				Beneficiary:   "PT Nama Yang Sangat Panjang Sekali Untuk Kolom Ini",
+				BankName:      "Bank\tRakyat",
+				AccountNumber: "1234567890",
+				Amount:        decimal.NewFromInt(1),
+				Description:   "Pembayaran termin pertama proyek renovasi gedung",
+			}}},
+			want: []string{
+				"H" + pad("DSB-002", 25) + "20260105" + "000001" + "000000000000000100",
+				"D" + pad("1234567890", 20) + "PT Nama Yang Sangat Panjang Sekali " + pad("Bank Rakyat", 30) + "000000000000100" + pad("INV-002", 20) + "Pembayaran termin pertama proyek re",
+				"T" + "000001" + "000000000000000100",
+			},
+		},
+		{
+			name: "account number too long",
+			batch: Batch{Number: "DSB-003", Transfers: []Transfer{
+				func() Transfer { tr := transfer; tr.AccountNumber = "123456789012345678901"; return tr }(),
+			}},
+			wantErr: "account number",
+		},
+		{
+			name: "reference too long",
+			batch: Batch{Number: "DSB-004", Transfers: []Transfer{
+				func() Transfer { tr := transfer; tr.Reference = "INV-2026-0001-REVISED-2"; return tr }(),
+			}},
+			wantErr: "reference",
+		},
+		{
+			name:    "batch number too long",
+			batch:   Batch{Number: "DSB-20260105-0001-FINANCE-A", Transfers: []Transfer{transfer}},
+			wantErr: "batch number",
+		},
+		{
+			name: "negative amount",
+			batch: Batch{Number: "DSB-005", Transfers: []Transfer{
+				func() Transfer { tr := transfer; tr.Amount = decimal.NewFromInt(-1); return tr }(),
+			}},
+			wantErr: "does not fit",
+		},
+		{
+			name: "amount too large",
+			batch: Batch{Number: "DSB-006", Transfers: []Transfer{
+				func() Transfer { tr := transfer; tr.Amount = decimal.RequireFromString("10000000000000"); return tr }(),
+			}},
+			wantErr: "does not fit",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.batch.CreatedAt = time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+			got, err := FixedWidth{}.Render(tt.batch)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Render error = %v, want one containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Render: %v", err)
+			}
+			want := strings.Join(tt.want, "\r\n") + "\r\n"
+			if string(got) != want {
+				t.Errorf("Render:\ngot  %q\nwant %q", got, want)
+			}
+		})
+	}
+}
+
+func TestCents(t *testing.T) {
+	tests := []struct {
+		amount  string
+		width   int
+		want    string
+		wantErr bool
+	}{
+		{amount: "0", width: 6, want: "000000"},
+		{amount: "12.3", width: 6, want: "001230"},
+		{amount: "12.345", width: 6, want: "001235"},
+		{amount: "9999.99", width: 6, want: "999999"},
+		{amount: "10000", width: 6, wantErr: true},
+		{amount: "-0.01", width: 6, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := cents(decimal.RequireFromString(tt.amount), tt.width)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("cents(%s, %d) error = %v, wantErr %v", tt.amount, tt.width, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("cents(%s, %d) = %q, want %q", tt.amount, tt.width, got, tt.want)
+		}
+	}
+}
+
+func pad(value string, width int) string {
+	return value + strings.Repeat(" ", width-len(value))
+}
@@ -0,0 +1,92 @@
+package ical
+
+import (
+	"bytes"
+	"strings"
+	"time"
+)
+
+const (
+	dateFormat     = "20060102"
+	dateTimeFormat = "20060102T150405Z"
+	maxLineOctets  = 75
+)
+
+// Event is one VEVENT of a calendar. All-day events use the date part of Start and End,
+// where End is the last day the event covers.
+type Event struct {
+	UID         string
+	Summary     string
+	Description string
+	Start       time.Time
+	End         time.Time
+	AllDay      bool
+	Cancelled   bool
+	Modified    time.Time
+}
+
+// Build renders the events as an RFC 5545 calendar
+func Build(prodId, name string, events []Event) []byte {
+	var buf bytes.Buffer
+	now := time.Now().UTC().Format(dateTimeFormat)
+
+	writeLine(&buf, "BEGIN:VCALENDAR")
+	writeLine(&buf, "VERSION:2.0")
+	writeLine(&buf, "PRODID:"+prodId)
+	writeLine(&buf, "CALSCALE:GREGORIAN")
+	writeLine(&buf, "METHOD:PUBLISH")
+	writeLine(&buf, "X-WR-CALNAME:"+escapeText(name))
+
+	for _, e := range events {
+		writeLine(&buf, "BEGIN:VEVENT")
+		writeLine(&buf, "UID:"+e.UID)
+		writeLine(&buf, "DTSTAMP:"+now)
+		if e.AllDay {
+			writeLine(&buf, "DTSTART;VALUE=DATE:"+e.Start.Format(dateFormat))
+			// DTEND of an all-day event is exclusive
+			writeLine(&buf, "DTEND;VALUE=DATE:"+e.End.AddDate(0, 0, 1).Format(dateFormat))
+		} else {
+			writeLine(&buf, "DTSTART:"+e.Start.UTC().Format(dateTimeFormat))
+			writeLine(&buf, "DTEND:"+e.End.UTC().Format(dateTimeFormat))
+		}
+		writeLine(&buf, "SUMMARY:"+escapeText(e.Summary))
+		if e.Description != "" {
+			writeLine(&buf, "DESCRIPTION:"+escapeText(e.Description))
+		}
+		if !e.Modified.IsZero() {
+			writeLine(&buf, "LAST-MODIFIED:"+e.Modified.UTC().Format(dateTimeFormat))
+		}
+		if e.Cancelled {
+			writeLine(&buf, "STATUS:CANCELLED")
+		} else {
+			writeLine(&buf, "STATUS:CONFIRMED")
+		}
+		writeLine(&buf, "TRANSP:TRANSPARENT")
+		writeLine(&buf, "END:VEVENT")
+	}
+
+	writeLine(&buf, "END:VCALENDAR")
+	return buf.Bytes()
+}
+
+func escapeText(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", "").Replace(s)
+}
+
+// writeLine folds content lines longer than 75 octets without splitting a UTF-8 character
+func writeLine(buf *bytes.Buffer, line string) {
+	limit := maxLineOctets
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && line[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		buf.WriteString(line[:cut])
+		buf.WriteString("\r\n ")
+		line = line[cut:]
+		// continuation lines start with the folding space
+		limit = maxLineOctets - 1
+	}
+	buf.WriteString(line)
+	buf.WriteString("\r\n")
+}
@@ -0,0 +1,163 @@
+package security
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// sealedPrefix marks a text value that was encrypted by BidCipher
+const sealedPrefix = "sealed:v1:"
+
+// BidCipher encrypts sealed-bid content with a per-event data key (AES-256-GCM).
+// Data keys are stored wrapped by the master key, so the database alone cannot decrypt bids.
+type BidCipher struct {
+	masterKey []byte
+}
+
+// NewBidCipher builds a cipher from a base64 encoded 32 byte master key.
+// An empty key returns nil without error: sealed bids are simply unavailable.
+func NewBidCipher(masterKey string) (*BidCipher, error) {
+	if masterKey == "" {
+		return nil, nil
+	}
+
+	key, err := base64.StdEncoding.DecodeString(masterKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid bid encryption key: %w", err)
+	}
+	if len(key) != 32 {
+		return nil, fmt.Errorf("invalid bid encryption key: expected 32 bytes, got %d", len(key))
+	}
+
+	return &BidCipher{masterKey: key}, nil
+}
+
+// NewDataKey generates a fresh event key and returns it wrapped by the master key
+func (c *BidCipher) NewDataKey() (string, error) {
+	key := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return "", fmt.Errorf("failed to generate data key: %w", err)
+	}
+
+	wrapped, err := seal(c.masterKey, key)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(wrapped), nil
+}
+
+func (c *BidCipher) unwrap(wrappedKey string) ([]byte, error) {
+	wrapped, err := base64.StdEncoding.DecodeString(wrappedKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid data key: %w", err)
+	}
+
+	return open(c.masterKey, wrapped)
+}
+
+// Encrypt seals data with the event key
+func (c *BidCipher) Encrypt(wrappedKey string, data []byte) ([]byte, error) {
+	key, err := c.unwrap(wrappedKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return seal(key, data)
+}
+
+// Decrypt opens data sealed with the event key
+func (c *BidCipher) Decrypt(wrappedKey string, data []byte) ([]byte, error) {
+	key, err := c.unwrap(wrappedKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return open(key, data)
+}
+
+// EncryptString seals a text value so it can be stored in an ordinary text column
+func (c *BidCipher) EncryptString(wrappedKey, value string) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+
+	sealed, err := c.Encrypt(wrappedKey, []byte(value))
+	if err != nil {
+		return "", err
+	}
+
+	return sealedPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// DecryptString reverses EncryptString. Values that were never sealed are returned unchanged.
+func (c *BidCipher) DecryptString(wrappedKey, value string) (string, error) {
+	if !IsSealedString(value) {
+		return value, nil
+	}
+
+	data, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, sealedPrefix))
+	if err != nil {
+		return "", fmt.Errorf("invalid sealed value: %w", err)
+	}
+
+	plain, err := c.Decrypt(wrappedKey, data)
+	if err != nil {
+		return "", err
+	}
+
+	return string(plain), nil
+}
+
+// IsSealedString reports whether the value was produced by EncryptString
+func IsSealedString(value string) bool {
+	return strings.HasPrefix(value, sealedPrefix)
+}
+
+func seal(key, data []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	return gcm.Seal(nonce, nonce, data, nil), nil
+}
+
+func open(key, data []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) < gcm.NonceSize() {
+		return nil, errors.New("sealed data is too short")
+	}
+
+	nonce, sealed := data[:gcm.NonceSize()], data[gcm.NonceSize():]
+	plain, err := gcm.Open(nil, nonce, sealed, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt sealed data: %w", err)
+	}
+
+	return plain, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+
+	return cipher.NewGCM(block)
+}
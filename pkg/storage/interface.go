@@ -22,6 +22,12 @@ type StorageProvider interface {
 
 	// DownloadFile downloads a file and returns a ReadCloser
 	DownloadFile(ctx context.Context, objectName string) (io.ReadCloser, error)
+
+	// DownloadFileByURL downloads a file using its URL and returns a ReadCloser
+	DownloadFileByURL(ctx context.Context, fileURL string) (io.ReadCloser, error)
+
+	// CopyFile copies an object server-side into folder and returns the URL of the copy
+	CopyFile(ctx context.Context, fileURL string, folder string) (string, error)
 }
 
 // Config holds the configuration for storage providers
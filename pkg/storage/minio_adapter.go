@@ -188,6 +188,41 @@ func (m *MinIOAdapter) ensureBucket(ctx context.Context) {
 	_ = m.client.SetBucketPolicy(ctx, m.bucketName, policy)
 }
 
+func (m *MinIOAdapter) DownloadFileByURL(ctx context.Context, fileURL string) (io.ReadCloser, error) {
+	objectName := m.extractObjectName(fileURL)
+	if objectName == "" {
+		return nil, fmt.Errorf("invalid file URL")
+	}
+
+	return m.DownloadFile(ctx, objectName)
+}
+
+func (m *MinIOAdapter) CopyFile(ctx context.Context, fileURL string, folder string) (string, error) {
+	srcObject := m.extractObjectName(fileURL)
+	if srcObject == "" {
+		return "", fmt.Errorf("invalid file URL")
+	}
+
+	ext := filepath.Ext(srcObject)
+	uniqueFilename := fmt.Sprintf("%s_%s%s", time.Now().Format("20060102_150405"), uuid.New().String()[:8], ext)
+
+	objectName := uniqueFilename
+	if folder != "" {
+		objectName = fmt.Sprintf("%s/%s", strings.Trim(folder, "/"), uniqueFilename)
+	}
+
+	_, err := m.client.CopyObject(ctx,
+		minio.CopyDestOptions{Bucket: m.bucketName, Object: objectName},
+		minio.CopySrcOptions{Bucket: m.bucketName, Object: srcObject},
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to copy file: %w", err)
+	}
+
+	fileURL = fmt.Sprintf("%s/%s/%s", m.baseURL, m.bucketName, objectName)
+	return fileURL, nil
+}
+
 func (m *MinIOAdapter) extractObjectName(fileURL string) string {
 	parts := strings.Split(fileURL, "/")
 	if len(parts) < 2 {
@@ -132,6 +132,41 @@ func (r *R2Adapter) DownloadFile(ctx context.Context, objectName string) (io.Rea
 	return object, nil
 }
 
+func (r *R2Adapter) DownloadFileByURL(ctx context.Context, fileURL string) (io.ReadCloser, error) {
+	objectName := r.extractObjectName(fileURL)
+	if objectName == "" {
+		return nil, fmt.Errorf("invalid file URL")
+	}
+
+	return r.DownloadFile(ctx, objectName)
+}
+
+func (r *R2Adapter) CopyFile(ctx context.Context, fileURL string, folder string) (string, error) {
+	srcObject := r.extractObjectName(fileURL)
+	if srcObject == "" {
+		return "", fmt.Errorf("invalid file URL")
+	}
+
+	ext := filepath.Ext(srcObject)
+	uniqueFilename := fmt.Sprintf("%s_%s%s", time.Now().Format("20060102_150405"), uuid.New().String()[:8], ext)
+
+	objectName := uniqueFilename
+	if folder != "" {
+		objectName = fmt.Sprintf("%s/%s", strings.Trim(folder, "/"), uniqueFilename)
+	}
+
+	_, err := r.client.CopyObject(ctx,
+		minio.CopyDestOptions{Bucket: r.bucketName, Object: objectName},
+		minio.CopySrcOptions{Bucket: r.bucketName, Object: srcObject},
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to copy file in R2: %w", err)
+	}
+
+	fileURL = fmt.Sprintf("%s/%s", strings.TrimRight(r.baseURL, "/"), objectName)
+	return fileURL, nil
+}
+
 func (r *R2Adapter) extractObjectName(fileURL string) string {
 	objectName := strings.TrimPrefix(fileURL, r.baseURL)
 	objectName = strings.TrimPrefix(objectName, "/")
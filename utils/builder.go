@@ -3,6 +3,7 @@ package utils
 import (
 	"fmt"
 	"strings"
+	domainevents "vendor-management-system/internal/domain/events"
 	domainvendors "vendor-management-system/internal/domain/vendors"
 )
 
@@ -15,8 +16,36 @@ func BuildVendorProfileFilename(vendor domainvendors.Vendor, profile domainvendo
 		nameCandidate = vendor.Id
 	}
 
+	safeName := safeFilenamePart(nameCandidate)
+	if safeName == "" {
+		safeName = "vendor"
+	}
+
+	return fmt.Sprintf("vendor_profile_%s.xlsx", safeName)
+}
+
+func BuildQuotationComparisonFilename(event domainevents.Event) string {
+	safeName := safeFilenamePart(strings.TrimSpace(event.Title))
+	if safeName == "" {
+		safeName = event.Id
+	}
+
+	return fmt.Sprintf("price_comparison_%s.xlsx", safeName)
+}
+
+func BuildSubmissionExportFilename(event domainevents.Event) string {
+	safeName := safeFilenamePart(strings.TrimSpace(event.Title))
+	if safeName == "" {
+		safeName = event.Id
+	}
+
+	return fmt.Sprintf("submissions_%s.xlsx", safeName)
+}
+
+// safeFilenamePart lowercases the name and replaces anything but letters, digits, '-' and '_'
+func safeFilenamePart(name string) string {
 	safe := strings.Builder{}
-	for _, r := range nameCandidate {
+	for _, r := range name {
 		switch {
 		case r >= 'a' && r <= 'z':
 			safe.WriteRune(r)
@@ -31,10 +60,5 @@ func BuildVendorProfileFilename(vendor domainvendors.Vendor, profile domainvendo
 		}
 	}
 
-	safeName := strings.Trim(safe.String(), "_")
-	if safeName == "" {
-		safeName = "vendor"
-	}
-
-	return fmt.Sprintf("vendor_profile_%s.xlsx", safeName)
+	return strings.Trim(safe.String(), "_")
 }
@@ -26,6 +26,127 @@ const (
 	EventCancelled = "cancelled"
 )
 
+const (
+	EventModeOpen    = "open"
+	EventModeInvited = "invited"
+)
+
+const (
+	EventTypeTender         = "tender"
+	EventTypeReverseAuction = "reverse_auction"
+)
+
+const (
+	// Phases of a reverse auction as shown to bidders
+	AuctionUpcoming = "upcoming"
+	AuctionLive     = "live"
+	AuctionEnded    = "ended"
+	AuctionClosed   = "closed"
+)
+
+const (
+	SubmissionSubmitted = "submitted"
+	SubmissionWithdrawn = "withdrawn"
+)
+
+const (
+	RevisionSubmitted = "submitted"
+	RevisionAmended   = "amended"
+	RevisionWithdrawn = "withdrawn"
+)
+
+const (
+	QuestionPending  = "pending"
+	QuestionAnswered = "answered"
+)
+
+const (
+	QuestionPrivate = "private"
+	QuestionPublic  = "public"
+)
+
+const (
+	HistoryAwardGranted   = "award_granted"
+	HistoryAwardRevoked   = "award_revoked"
+	HistoryRunnerUp       = "runner_up_awarded"
+	HistoryEventReopened  = "event_reopened"
+	HistoryEventCancelled = "event_cancelled"
+)
+
+const (
+	PriceAboveCeiling = "above_ceiling"
+	PriceAbnormalLow  = "abnormally_low"
+)
+
+const (
+	RevokeFallbackRunnerUp = "runner_up"
+	RevokeFallbackReopen   = "reopen"
+)
+
+const (
+	PaymentScheduled     = "scheduled"
+	PaymentPending       = "pending"
+	PaymentApproved      = "approved"
+	PaymentRejected      = "rejected"
+	PaymentProcessing    = "processing"
+	PaymentPartiallyPaid = "partially_paid"
+	PaymentPaid          = "paid"
+	PaymentCancelled     = "cancelled"
+)
+
+const (
+	ApprovalApproved = "approved"
+	ApprovalRejected = "rejected"
+)
+
+const (
+	InvoiceSubmitted = "submitted"
+	InvoiceVerified  = "verified"
+	InvoiceRejected  = "rejected"
+	InvoiceConverted = "converted"
+)
+
+const (
+	StatementLineMatched   = "matched"
+	StatementLineReview    = "review"
+	StatementLineUnmatched = "unmatched"
+	StatementLineDismissed = "dismissed"
+	StatementLineIgnored   = "ignored"
+)
+
+const (
+	MatchInvoiceNumber = "invoice_number"
+	MatchVendorAccount = "vendor_account"
+	MatchAmountDate    = "amount_date"
+)
+
+const (
+	TaxStatusPKP    = "pkp"
+	TaxStatusNonPKP = "non_pkp"
+)
+
+const (
+	WithholdingPPh23 = "pph23"
+	WithholdingPPh21 = "pph21"
+	WithholdingNone  = "none"
+)
+
+const (
+	InvoiceFileInvoice       = "invoice"
+	InvoiceFileTaxInvoice    = "tax_invoice"
+	InvoiceFileDeliveryProof = "delivery_proof"
+)
+
+const (
+	// Triggers of an event payment term
+	PaymentTermOnAward      = "on_award"
+	PaymentTermOnCompletion = "on_completion"
+	PaymentTermMonthly      = "monthly"
+
+	// Release triggers of a scheduled payment; monthly terms release on their due date
+	PaymentReleaseOnDate = "on_date"
+)
+
 const (
 	VendorPending  = "pending"
 	VendorVerify   = "verify"
@@ -43,10 +164,43 @@ const (
 var (
 	MaxFileLimit  = GetEnv("MAX_FILE_LIMIT", 1).(int)
 	MaxPhotoLimit = GetEnv("MAX_PHOTO_LIMIT", 5).(int)
+
+	// Defaults for events that do not set their own submission file rules
+	MaxSubmissionFiles = GetEnv("MAX_SUBMISSION_FILES", 10).(int)
+	// Hours before end_date that the Q&A closes, for events that do not set their own cut-off
+	QACutoffHours = GetEnv("QA_CUTOFF_HOURS", 24).(int)
+	// Hours after the deadline during which a closed event can still have its deadline extended
+	DeadlineExtensionGraceHours = GetEnv("DEADLINE_EXTENSION_GRACE_HOURS", 72).(int)
+
+	// Public base URL of this API, used to build download links in exported files and calendar feed URLs
+	ApiBaseURL = GetEnv("API_BASE_URL", "").(string)
+	// Quotes below this percentage of the owner estimate are flagged as abnormally low,
+	// for events that do not set their own threshold
+	AbnormallyLowPercent = GetEnv("ABNORMALLY_LOW_PERCENT", 80).(int)
+
+	// Days after their end date that events stay in calendar feeds
+	CalendarFeedPastDays = GetEnv("CALENDAR_FEED_PAST_DAYS", 90).(int)
+
+	SubmissionAllowedMimeTypes = GetEnv("SUBMISSION_ALLOWED_MIME_TYPES", "application/pdf,image/jpeg,image/png,application/msword,application/vnd.openxmlformats-officedocument.wordprocessingml.document,application/vnd.ms-excel,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet").(string)
+)
+
+const (
+	SubmissionFileTechnicalProposal = "technical_proposal"
+	SubmissionFilePriceProposal     = "price_proposal"
+	SubmissionFileCompanyProfile    = "company_profile"
+	SubmissionFilePhoto             = "photo"
+	SubmissionFileDocument          = "document"
 )
 
 const (
-	NotifEventOpen   = "event_open"
-	NotifEventWinner = "event_winner"
-	NotifEventLoser  = "event_not_winner"
+	NotifEventOpen      = "event_open"
+	NotifEventWinner    = "event_winner"
+	NotifEventLoser     = "event_not_winner"
+	NotifEventInvite    = "event_invited"
+	NotifEventAnswer    = "event_answer"
+	NotifEventAddendum  = "event_addendum"
+	NotifEventRevoked   = "event_award_revoked"
+	NotifEventReopened  = "event_reopened"
+	NotifEventCancelled = "event_cancelled"
+	NotifEventExtended  = "event_deadline_extended"
 )
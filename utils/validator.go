@@ -1,12 +1,17 @@
 package utils
 
 import (
+	"archive/zip"
+	"bytes"
 	"errors"
 	"fmt"
+	"io"
+	"mime"
 	"mime/multipart"
 	"net/http"
 	"reflect"
 	"regexp"
+	"strings"
 	"vendor-management-system/pkg/response"
 
 	"github.com/gin-gonic/gin"
@@ -112,3 +117,93 @@ func ValidateFileSize(fileHeader *multipart.FileHeader, maxMB int) error {
 
 	return nil
 }
+
+// DetectFileMimeType sniffs the first bytes of the upload. Office documents are sniffed as
+// zip archives or as unknown binary, so for those the declared Content-Type is only taken once
+// the file's container matches it: an OOXML package for docx, xlsx and pptx, an OLE2 compound
+// file for doc, xls and ppt.
+func DetectFileMimeType(fileHeader *multipart.FileHeader) (string, error) {
+	if fileHeader == nil {
+		return "", fmt.Errorf("invalid file header")
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return "", fmt.Errorf("failed to open file %s: %w", fileHeader.Filename, err)
+	}
+	defer file.Close()
+
+	buf := make([]byte, 512)
+	n, err := file.Read(buf)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", fmt.Errorf("failed to read file %s: %w", fileHeader.Filename, err)
+	}
+
+	detected, _, _ := mime.ParseMediaType(http.DetectContentType(buf[:n]))
+	declared, _, _ := mime.ParseMediaType(fileHeader.Header.Get("Content-Type"))
+	declared = strings.ToLower(declared)
+
+	switch detected {
+	case "application/zip":
+		if folder, ok := ooxmlFolders[declared]; ok && isOOXMLPackage(file, fileHeader.Size, folder) {
+			return declared, nil
+		}
+	case "application/octet-stream":
+		if oleMimeTypes[declared] && bytes.HasPrefix(buf[:n], oleSignature) {
+			return declared, nil
+		}
+	}
+
+	return detected, nil
+}
+
+// ooxmlFolders maps each OOXML type to the folder holding its main part inside the package
+var ooxmlFolders = map[string]string{
+	"application/vnd.openxmlformats-officedocument.wordprocessingml.document":   "word/",
+	"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet":         "xl/",
+	"application/vnd.openxmlformats-officedocument.presentationml.presentation": "ppt/",
+}
+
+// oleMimeTypes are the legacy Office types stored as OLE2 compound files
+var oleMimeTypes = map[string]bool{
+	"application/msword":            true,
+	"application/vnd.ms-excel":      true,
+	"application/vnd.ms-powerpoint": true,
+}
+
+var oleSignature = []byte{0xD0, 0xCF, 0x11, 0xE0, 0xA1, 0xB1, 0x1A, 0xE1}
+
+// isOOXMLPackage reports whether the zip archive is an OOXML package with its main part in folder
+func isOOXMLPackage(file io.ReaderAt, size int64, folder string) bool {
+	archive, err := zip.NewReader(file, size)
+	if err != nil {
+		return false
+	}
+
+	hasContentTypes, hasFolder := false, false
+	for _, f := range archive.File {
+		switch {
+		case f.Name == "[Content_Types].xml":
+			hasContentTypes = true
+		case strings.HasPrefix(f.Name, folder):
+			hasFolder = true
+		}
+	}
+	return hasContentTypes && hasFolder
+}
+
+func ValidateFileMimeType(fileHeader *multipart.FileHeader, allowed []string) (string, error) {
+	mimeType, err := DetectFileMimeType(fileHeader)
+	if err != nil {
+		return "", err
+	}
+
+	for _, a := range allowed {
+		if strings.EqualFold(strings.TrimSpace(a), mimeType) {
+			return mimeType, nil
+		}
+	}
+
+	return "", fmt.Errorf("file %s has type %s which is not allowed (allowed: %s)",
+		fileHeader.Filename, mimeType, strings.Join(allowed, ", "))
+}